@@ -0,0 +1,375 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains definitions of Cluster Autoscaler status objects.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +kubebuilder:storageversions
+// +kubebuilder:resource:scope=Cluster,shortName=castatus
+
+// ClusterAutoscalerStatus exposes the state of a Cluster Autoscaler instance,
+// as seen in its last loop iteration. Status of individual node groups is
+// exposed in separate NodeGroupStatus objects, labeled with the name of the
+// ClusterAutoscalerStatus they belong to.
+// The object is written by Cluster Autoscaler only, there is no spec.
+//
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.autoscalerStatus"
+// +kubebuilder:printcolumn:name="Health",type="string",JSONPath=".status.clusterWide.health.status"
+// +kubebuilder:printcolumn:name="ScaleUp",type="string",JSONPath=".status.clusterWide.scaleUp.status"
+// +kubebuilder:printcolumn:name="ScaleDown",type="string",JSONPath=".status.clusterWide.scaleDown.status"
+// +kubebuilder:printcolumn:name="Updated",type="date",JSONPath=".status.lastUpdateTime"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterAutoscalerStatus struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object metadata. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	//
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Status of the Cluster Autoscaler. CA updates this field in each loop iteration.
+	//
+	// +optional
+	Status ClusterAutoscalerStatusState `json:"status,omitempty"`
+}
+
+// ClusterAutoscalerStatusList is a object for list of ClusterAutoscalerStatus.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterAutoscalerStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	//
+	// +optional
+	metav1.ListMeta `json:"metadata"`
+	// Items, list of ClusterAutoscalerStatus returned from API.
+	//
+	// +optional
+	Items []ClusterAutoscalerStatus `json:"items"`
+}
+
+// ClusterAutoscalerStatusState contains the cluster-wide state of Cluster Autoscaler.
+type ClusterAutoscalerStatusState struct {
+	// AutoscalerStatus is the status of Cluster Autoscaler itself,
+	// either Initializing or Running.
+	//
+	// +optional
+	AutoscalerStatus string `json:"autoscalerStatus,omitempty"`
+	// Message contains extra information about the status.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastUpdateTime is the time of the loop iteration the status comes from.
+	//
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	// ClusterWide contains conditions that apply to the whole cluster.
+	//
+	// +optional
+	ClusterWide ClusterWideState `json:"clusterWide,omitempty"`
+	// LastScaleUpTime is the last time Cluster Autoscaler scaled up any node group.
+	//
+	// +optional
+	LastScaleUpTime *metav1.Time `json:"lastScaleUpTime,omitempty"`
+	// LastScaleDownTime is the last time Cluster Autoscaler started deleting
+	// nodes from any node group.
+	//
+	// +optional
+	LastScaleDownTime *metav1.Time `json:"lastScaleDownTime,omitempty"`
+	// NodeGroups is the number of node groups Cluster Autoscaler works on.
+	// Each of them has a corresponding NodeGroupStatus object.
+	//
+	// +optional
+	NodeGroups int32 `json:"nodeGroups,omitempty"`
+	// UnremovableNodes lists nodes which Cluster Autoscaler considered for
+	// scale-down, but could not remove, along with the reason.
+	// At most 100 nodes are listed.
+	//
+	// +optional
+	// +listType=atomic
+	UnremovableNodes []UnremovableNode `json:"unremovableNodes,omitempty"`
+}
+
+// ClusterWideState contains conditions that apply to the whole cluster.
+type ClusterWideState struct {
+	// Health contains information about health condition of the cluster.
+	//
+	// +optional
+	Health HealthCondition `json:"health,omitempty"`
+	// ScaleUp contains information about scale up condition of the cluster.
+	//
+	// +optional
+	ScaleUp ScaleUpCondition `json:"scaleUp,omitempty"`
+	// ScaleDown contains information about scale down condition of the cluster.
+	//
+	// +optional
+	ScaleDown ScaleDownCondition `json:"scaleDown,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +kubebuilder:storageversions
+// +kubebuilder:resource:scope=Cluster,shortName=ngstatus
+
+// NodeGroupStatus exposes the state of a single node group, as seen by
+// Cluster Autoscaler in its last loop iteration.
+// The object is written by Cluster Autoscaler only, there is no spec.
+//
+// +kubebuilder:printcolumn:name="NodeGroup",type="string",JSONPath=".status.nodeGroup"
+// +kubebuilder:printcolumn:name="Health",type="string",JSONPath=".status.health.status"
+// +kubebuilder:printcolumn:name="Target",type="integer",JSONPath=".status.health.cloudProviderTarget"
+// +kubebuilder:printcolumn:name="ScaleUp",type="string",JSONPath=".status.scaleUp.status"
+// +kubebuilder:printcolumn:name="ScaleDown",type="string",JSONPath=".status.scaleDown.status"
+// +kubebuilder:printcolumn:name="Updated",type="date",JSONPath=".status.lastUpdateTime"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NodeGroupStatus struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object metadata. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	//
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Status of the node group. CA updates this field whenever the status changes.
+	//
+	// +optional
+	Status NodeGroupState `json:"status,omitempty"`
+}
+
+// NodeGroupStatusList is a object for list of NodeGroupStatus.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NodeGroupStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	//
+	// +optional
+	metav1.ListMeta `json:"metadata"`
+	// Items, list of NodeGroupStatus returned from API.
+	//
+	// +optional
+	Items []NodeGroupStatus `json:"items"`
+}
+
+// NodeGroupState contains the state of a single node group.
+type NodeGroupState struct {
+	// NodeGroup is the id of the node group, as reported by the cloud provider.
+	// Ids don't have to be valid object names, so they aren't used as names
+	// of NodeGroupStatus objects.
+	//
+	// +optional
+	NodeGroup string `json:"nodeGroup,omitempty"`
+	// LastUpdateTime is the time of the loop iteration the status comes from.
+	// To limit the number of writes, the object is only updated when the status
+	// changes, so probe times may be stale. The lastUpdateTime of the
+	// ClusterAutoscalerStatus is refreshed in every loop iteration instead.
+	//
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	// Health contains information about health condition of the node group.
+	//
+	// +optional
+	Health NodeGroupHealthCondition `json:"health,omitempty"`
+	// ScaleUp contains information about scale up condition of the node group.
+	//
+	// +optional
+	ScaleUp NodeGroupScaleUpCondition `json:"scaleUp,omitempty"`
+	// ScaleDown contains information about scale down condition of the node group.
+	//
+	// +optional
+	ScaleDown ScaleDownCondition `json:"scaleDown,omitempty"`
+	// LastScaleUpTime is the last time Cluster Autoscaler scaled up the node group.
+	//
+	// +optional
+	LastScaleUpTime *metav1.Time `json:"lastScaleUpTime,omitempty"`
+	// LastScaleDownTime is the last time Cluster Autoscaler started deleting
+	// nodes from the node group.
+	//
+	// +optional
+	LastScaleDownTime *metav1.Time `json:"lastScaleDownTime,omitempty"`
+	// UnremovableNodes lists nodes of the node group which Cluster Autoscaler
+	// considered for scale-down, but could not remove, along with the reason.
+	// At most 100 nodes are listed.
+	//
+	// +optional
+	// +listType=atomic
+	UnremovableNodes []UnremovableNode `json:"unremovableNodes,omitempty"`
+}
+
+// NodeCount contains number of nodes that satisfy different criteria.
+type NodeCount struct {
+	// Registered is the number of nodes registered in Kubernetes.
+	//
+	// +optional
+	Registered int32 `json:"registered"`
+	// Ready is the number of registered nodes which are ready.
+	//
+	// +optional
+	Ready int32 `json:"ready"`
+	// NotStarted is the number of registered nodes which didn't become ready yet.
+	//
+	// +optional
+	NotStarted int32 `json:"notStarted"`
+	// BeingDeleted is the number of nodes that are being currently deleted.
+	//
+	// +optional
+	BeingDeleted int32 `json:"beingDeleted"`
+	// Unready is the number of registered nodes which are not ready.
+	//
+	// +optional
+	Unready int32 `json:"unready"`
+	// ResourceUnready is the number of registered nodes which are not ready
+	// due to a missing resource (e.g. GPU).
+	//
+	// +optional
+	ResourceUnready int32 `json:"resourceUnready"`
+	// Unregistered is the number of nodes which exist in the cloud provider,
+	// but are not registered in Kubernetes.
+	//
+	// +optional
+	Unregistered int32 `json:"unregistered"`
+	// LongUnregistered is the number of nodes which failed to register
+	// in Kubernetes for a long time.
+	//
+	// +optional
+	LongUnregistered int32 `json:"longUnregistered"`
+}
+
+// HealthCondition contains information about health condition of the cluster.
+type HealthCondition struct {
+	// Status of the health, either Healthy or Unhealthy.
+	//
+	// +optional
+	Status string `json:"status,omitempty"`
+	// NodeCounts contains number of nodes that satisfy different criteria.
+	//
+	// +optional
+	NodeCounts NodeCount `json:"nodeCounts,omitempty"`
+	// LastProbeTime is the last time we probed the condition.
+	//
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the time since when the condition was in the given state.
+	//
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// NodeGroupHealthCondition contains information about health condition of a node group.
+type NodeGroupHealthCondition struct {
+	HealthCondition `json:",inline"`
+	// CloudProviderTarget is the target size set by cloud provider.
+	//
+	// +optional
+	CloudProviderTarget int32 `json:"cloudProviderTarget"`
+	// MinSize is the minimum size of the node group.
+	//
+	// +optional
+	MinSize int32 `json:"minSize"`
+	// MaxSize is the maximum size of the node group.
+	//
+	// +optional
+	MaxSize int32 `json:"maxSize"`
+}
+
+// ScaleUpCondition contains information about scale up condition of the cluster.
+type ScaleUpCondition struct {
+	// Status of the scale up, one of Needed, NotNeeded, InProgress, NoActivity or Backoff.
+	//
+	// +optional
+	Status string `json:"status,omitempty"`
+	// LastProbeTime is the last time we probed the condition.
+	//
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the time since when the condition was in the given state.
+	//
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// NodeGroupScaleUpCondition contains information about scale up condition of a node group.
+type NodeGroupScaleUpCondition struct {
+	ScaleUpCondition `json:",inline"`
+	// Backoff is set if the node group is backed off after failed scale-ups.
+	//
+	// +optional
+	Backoff *BackoffInfo `json:"backoff,omitempty"`
+}
+
+// BackoffInfo contains error information that caused the backoff.
+type BackoffInfo struct {
+	// ErrorCode is a specific error code for error condition.
+	//
+	// +optional
+	ErrorCode string `json:"errorCode,omitempty"`
+	// ErrorMessage is human readable description of error condition.
+	//
+	// +optional
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// ScaleDownCondition contains information about scale down condition of a node group or the whole cluster.
+type ScaleDownCondition struct {
+	// Status of the scale down, either CandidatesPresent or NoCandidates.
+	//
+	// +optional
+	Status string `json:"status,omitempty"`
+	// Candidates is the number of scale-down candidates.
+	//
+	// +optional
+	Candidates int32 `json:"candidates,omitempty"`
+	// LastProbeTime is the last time we probed the condition.
+	//
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the time since when the condition was in the given state.
+	//
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// UnremovableNode describes a node that couldn't be removed during scale-down.
+type UnremovableNode struct {
+	// Name of the node.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// NodeGroup is the id of the node group the node belongs to.
+	//
+	// +optional
+	NodeGroup string `json:"nodeGroup,omitempty"`
+	// Reason why the node couldn't be removed.
+	//
+	// +kubebuilder:validation:Required
+	Reason string `json:"reason"`
+	// BlockingPod is the namespaced name of the pod which prevented node removal, if any.
+	//
+	// +optional
+	BlockingPod string `json:"blockingPod,omitempty"`
+	// BlockingPodReason is the reason why the pod prevented node removal.
+	//
+	// +optional
+	BlockingPodReason string `json:"blockingPodReason,omitempty"`
+}
+
+const (
+	// ClusterAutoscalerStatusLabelKey is a label put on NodeGroupStatus objects,
+	// with the name of the ClusterAutoscalerStatus they belong to.
+	ClusterAutoscalerStatusLabelKey = "autoscaling.x-k8s.io/cluster-autoscaler-status"
+)
@@ -0,0 +1,111 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterAutoscalerStatusStateApplyConfiguration represents an declarative configuration of the ClusterAutoscalerStatusState type for use
+// with apply.
+type ClusterAutoscalerStatusStateApplyConfiguration struct {
+	AutoscalerStatus  *string                             `json:"autoscalerStatus,omitempty"`
+	Message           *string                             `json:"message,omitempty"`
+	LastUpdateTime    *v1.Time                            `json:"lastUpdateTime,omitempty"`
+	ClusterWide       *ClusterWideStateApplyConfiguration `json:"clusterWide,omitempty"`
+	LastScaleUpTime   *v1.Time                            `json:"lastScaleUpTime,omitempty"`
+	LastScaleDownTime *v1.Time                            `json:"lastScaleDownTime,omitempty"`
+	NodeGroups        *int32                              `json:"nodeGroups,omitempty"`
+	UnremovableNodes  []UnremovableNodeApplyConfiguration `json:"unremovableNodes,omitempty"`
+}
+
+// ClusterAutoscalerStatusStateApplyConfiguration constructs an declarative configuration of the ClusterAutoscalerStatusState type for use with
+// apply.
+func ClusterAutoscalerStatusState() *ClusterAutoscalerStatusStateApplyConfiguration {
+	return &ClusterAutoscalerStatusStateApplyConfiguration{}
+}
+
+// WithAutoscalerStatus sets the AutoscalerStatus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AutoscalerStatus field is set to the value of the last call.
+func (b *ClusterAutoscalerStatusStateApplyConfiguration) WithAutoscalerStatus(value string) *ClusterAutoscalerStatusStateApplyConfiguration {
+	b.AutoscalerStatus = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ClusterAutoscalerStatusStateApplyConfiguration) WithMessage(value string) *ClusterAutoscalerStatusStateApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithLastUpdateTime sets the LastUpdateTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUpdateTime field is set to the value of the last call.
+func (b *ClusterAutoscalerStatusStateApplyConfiguration) WithLastUpdateTime(value v1.Time) *ClusterAutoscalerStatusStateApplyConfiguration {
+	b.LastUpdateTime = &value
+	return b
+}
+
+// WithClusterWide sets the ClusterWide field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterWide field is set to the value of the last call.
+func (b *ClusterAutoscalerStatusStateApplyConfiguration) WithClusterWide(value *ClusterWideStateApplyConfiguration) *ClusterAutoscalerStatusStateApplyConfiguration {
+	b.ClusterWide = value
+	return b
+}
+
+// WithLastScaleUpTime sets the LastScaleUpTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastScaleUpTime field is set to the value of the last call.
+func (b *ClusterAutoscalerStatusStateApplyConfiguration) WithLastScaleUpTime(value v1.Time) *ClusterAutoscalerStatusStateApplyConfiguration {
+	b.LastScaleUpTime = &value
+	return b
+}
+
+// WithLastScaleDownTime sets the LastScaleDownTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastScaleDownTime field is set to the value of the last call.
+func (b *ClusterAutoscalerStatusStateApplyConfiguration) WithLastScaleDownTime(value v1.Time) *ClusterAutoscalerStatusStateApplyConfiguration {
+	b.LastScaleDownTime = &value
+	return b
+}
+
+// WithNodeGroups sets the NodeGroups field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeGroups field is set to the value of the last call.
+func (b *ClusterAutoscalerStatusStateApplyConfiguration) WithNodeGroups(value int32) *ClusterAutoscalerStatusStateApplyConfiguration {
+	b.NodeGroups = &value
+	return b
+}
+
+// WithUnremovableNodes adds the given value to the UnremovableNodes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UnremovableNodes field.
+func (b *ClusterAutoscalerStatusStateApplyConfiguration) WithUnremovableNodes(values ...*UnremovableNodeApplyConfiguration) *ClusterAutoscalerStatusStateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUnremovableNodes")
+		}
+		b.UnremovableNodes = append(b.UnremovableNodes, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,102 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// NodeCountApplyConfiguration represents an declarative configuration of the NodeCount type for use
+// with apply.
+type NodeCountApplyConfiguration struct {
+	Registered       *int32 `json:"registered,omitempty"`
+	Ready            *int32 `json:"ready,omitempty"`
+	NotStarted       *int32 `json:"notStarted,omitempty"`
+	BeingDeleted     *int32 `json:"beingDeleted,omitempty"`
+	Unready          *int32 `json:"unready,omitempty"`
+	ResourceUnready  *int32 `json:"resourceUnready,omitempty"`
+	Unregistered     *int32 `json:"unregistered,omitempty"`
+	LongUnregistered *int32 `json:"longUnregistered,omitempty"`
+}
+
+// NodeCountApplyConfiguration constructs an declarative configuration of the NodeCount type for use with
+// apply.
+func NodeCount() *NodeCountApplyConfiguration {
+	return &NodeCountApplyConfiguration{}
+}
+
+// WithRegistered sets the Registered field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Registered field is set to the value of the last call.
+func (b *NodeCountApplyConfiguration) WithRegistered(value int32) *NodeCountApplyConfiguration {
+	b.Registered = &value
+	return b
+}
+
+// WithReady sets the Ready field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ready field is set to the value of the last call.
+func (b *NodeCountApplyConfiguration) WithReady(value int32) *NodeCountApplyConfiguration {
+	b.Ready = &value
+	return b
+}
+
+// WithNotStarted sets the NotStarted field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotStarted field is set to the value of the last call.
+func (b *NodeCountApplyConfiguration) WithNotStarted(value int32) *NodeCountApplyConfiguration {
+	b.NotStarted = &value
+	return b
+}
+
+// WithBeingDeleted sets the BeingDeleted field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BeingDeleted field is set to the value of the last call.
+func (b *NodeCountApplyConfiguration) WithBeingDeleted(value int32) *NodeCountApplyConfiguration {
+	b.BeingDeleted = &value
+	return b
+}
+
+// WithUnready sets the Unready field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Unready field is set to the value of the last call.
+func (b *NodeCountApplyConfiguration) WithUnready(value int32) *NodeCountApplyConfiguration {
+	b.Unready = &value
+	return b
+}
+
+// WithResourceUnready sets the ResourceUnready field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceUnready field is set to the value of the last call.
+func (b *NodeCountApplyConfiguration) WithResourceUnready(value int32) *NodeCountApplyConfiguration {
+	b.ResourceUnready = &value
+	return b
+}
+
+// WithUnregistered sets the Unregistered field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Unregistered field is set to the value of the last call.
+func (b *NodeCountApplyConfiguration) WithUnregistered(value int32) *NodeCountApplyConfiguration {
+	b.Unregistered = &value
+	return b
+}
+
+// WithLongUnregistered sets the LongUnregistered field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LongUnregistered field is set to the value of the last call.
+func (b *NodeCountApplyConfiguration) WithLongUnregistered(value int32) *NodeCountApplyConfiguration {
+	b.LongUnregistered = &value
+	return b
+}
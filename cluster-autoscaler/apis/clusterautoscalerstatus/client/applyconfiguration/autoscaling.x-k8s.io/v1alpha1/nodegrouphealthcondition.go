@@ -0,0 +1,94 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeGroupHealthConditionApplyConfiguration represents an declarative configuration of the NodeGroupHealthCondition type for use
+// with apply.
+type NodeGroupHealthConditionApplyConfiguration struct {
+	HealthConditionApplyConfiguration `json:",inline"`
+	CloudProviderTarget               *int32 `json:"cloudProviderTarget,omitempty"`
+	MinSize                           *int32 `json:"minSize,omitempty"`
+	MaxSize                           *int32 `json:"maxSize,omitempty"`
+}
+
+// NodeGroupHealthConditionApplyConfiguration constructs an declarative configuration of the NodeGroupHealthCondition type for use with
+// apply.
+func NodeGroupHealthCondition() *NodeGroupHealthConditionApplyConfiguration {
+	return &NodeGroupHealthConditionApplyConfiguration{}
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *NodeGroupHealthConditionApplyConfiguration) WithStatus(value string) *NodeGroupHealthConditionApplyConfiguration {
+	b.Status = &value
+	return b
+}
+
+// WithNodeCounts sets the NodeCounts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeCounts field is set to the value of the last call.
+func (b *NodeGroupHealthConditionApplyConfiguration) WithNodeCounts(value *NodeCountApplyConfiguration) *NodeGroupHealthConditionApplyConfiguration {
+	b.NodeCounts = value
+	return b
+}
+
+// WithLastProbeTime sets the LastProbeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastProbeTime field is set to the value of the last call.
+func (b *NodeGroupHealthConditionApplyConfiguration) WithLastProbeTime(value v1.Time) *NodeGroupHealthConditionApplyConfiguration {
+	b.LastProbeTime = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *NodeGroupHealthConditionApplyConfiguration) WithLastTransitionTime(value v1.Time) *NodeGroupHealthConditionApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
+
+// WithCloudProviderTarget sets the CloudProviderTarget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CloudProviderTarget field is set to the value of the last call.
+func (b *NodeGroupHealthConditionApplyConfiguration) WithCloudProviderTarget(value int32) *NodeGroupHealthConditionApplyConfiguration {
+	b.CloudProviderTarget = &value
+	return b
+}
+
+// WithMinSize sets the MinSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinSize field is set to the value of the last call.
+func (b *NodeGroupHealthConditionApplyConfiguration) WithMinSize(value int32) *NodeGroupHealthConditionApplyConfiguration {
+	b.MinSize = &value
+	return b
+}
+
+// WithMaxSize sets the MaxSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxSize field is set to the value of the last call.
+func (b *NodeGroupHealthConditionApplyConfiguration) WithMaxSize(value int32) *NodeGroupHealthConditionApplyConfiguration {
+	b.MaxSize = &value
+	return b
+}
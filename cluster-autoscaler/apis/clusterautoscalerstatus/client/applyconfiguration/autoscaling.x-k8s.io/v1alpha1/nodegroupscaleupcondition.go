@@ -0,0 +1,68 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeGroupScaleUpConditionApplyConfiguration represents an declarative configuration of the NodeGroupScaleUpCondition type for use
+// with apply.
+type NodeGroupScaleUpConditionApplyConfiguration struct {
+	ScaleUpConditionApplyConfiguration `json:",inline"`
+	Backoff                            *BackoffInfoApplyConfiguration `json:"backoff,omitempty"`
+}
+
+// NodeGroupScaleUpConditionApplyConfiguration constructs an declarative configuration of the NodeGroupScaleUpCondition type for use with
+// apply.
+func NodeGroupScaleUpCondition() *NodeGroupScaleUpConditionApplyConfiguration {
+	return &NodeGroupScaleUpConditionApplyConfiguration{}
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *NodeGroupScaleUpConditionApplyConfiguration) WithStatus(value string) *NodeGroupScaleUpConditionApplyConfiguration {
+	b.Status = &value
+	return b
+}
+
+// WithLastProbeTime sets the LastProbeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastProbeTime field is set to the value of the last call.
+func (b *NodeGroupScaleUpConditionApplyConfiguration) WithLastProbeTime(value v1.Time) *NodeGroupScaleUpConditionApplyConfiguration {
+	b.LastProbeTime = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *NodeGroupScaleUpConditionApplyConfiguration) WithLastTransitionTime(value v1.Time) *NodeGroupScaleUpConditionApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
+
+// WithBackoff sets the Backoff field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Backoff field is set to the value of the last call.
+func (b *NodeGroupScaleUpConditionApplyConfiguration) WithBackoff(value *BackoffInfoApplyConfiguration) *NodeGroupScaleUpConditionApplyConfiguration {
+	b.Backoff = value
+	return b
+}
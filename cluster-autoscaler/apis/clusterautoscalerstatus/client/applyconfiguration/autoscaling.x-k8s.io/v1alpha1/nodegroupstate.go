@@ -0,0 +1,111 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeGroupStateApplyConfiguration represents an declarative configuration of the NodeGroupState type for use
+// with apply.
+type NodeGroupStateApplyConfiguration struct {
+	NodeGroup         *string                                      `json:"nodeGroup,omitempty"`
+	LastUpdateTime    *v1.Time                                     `json:"lastUpdateTime,omitempty"`
+	Health            *NodeGroupHealthConditionApplyConfiguration  `json:"health,omitempty"`
+	ScaleUp           *NodeGroupScaleUpConditionApplyConfiguration `json:"scaleUp,omitempty"`
+	ScaleDown         *ScaleDownConditionApplyConfiguration        `json:"scaleDown,omitempty"`
+	LastScaleUpTime   *v1.Time                                     `json:"lastScaleUpTime,omitempty"`
+	LastScaleDownTime *v1.Time                                     `json:"lastScaleDownTime,omitempty"`
+	UnremovableNodes  []UnremovableNodeApplyConfiguration          `json:"unremovableNodes,omitempty"`
+}
+
+// NodeGroupStateApplyConfiguration constructs an declarative configuration of the NodeGroupState type for use with
+// apply.
+func NodeGroupState() *NodeGroupStateApplyConfiguration {
+	return &NodeGroupStateApplyConfiguration{}
+}
+
+// WithNodeGroup sets the NodeGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeGroup field is set to the value of the last call.
+func (b *NodeGroupStateApplyConfiguration) WithNodeGroup(value string) *NodeGroupStateApplyConfiguration {
+	b.NodeGroup = &value
+	return b
+}
+
+// WithLastUpdateTime sets the LastUpdateTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUpdateTime field is set to the value of the last call.
+func (b *NodeGroupStateApplyConfiguration) WithLastUpdateTime(value v1.Time) *NodeGroupStateApplyConfiguration {
+	b.LastUpdateTime = &value
+	return b
+}
+
+// WithHealth sets the Health field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Health field is set to the value of the last call.
+func (b *NodeGroupStateApplyConfiguration) WithHealth(value *NodeGroupHealthConditionApplyConfiguration) *NodeGroupStateApplyConfiguration {
+	b.Health = value
+	return b
+}
+
+// WithScaleUp sets the ScaleUp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleUp field is set to the value of the last call.
+func (b *NodeGroupStateApplyConfiguration) WithScaleUp(value *NodeGroupScaleUpConditionApplyConfiguration) *NodeGroupStateApplyConfiguration {
+	b.ScaleUp = value
+	return b
+}
+
+// WithScaleDown sets the ScaleDown field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleDown field is set to the value of the last call.
+func (b *NodeGroupStateApplyConfiguration) WithScaleDown(value *ScaleDownConditionApplyConfiguration) *NodeGroupStateApplyConfiguration {
+	b.ScaleDown = value
+	return b
+}
+
+// WithLastScaleUpTime sets the LastScaleUpTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastScaleUpTime field is set to the value of the last call.
+func (b *NodeGroupStateApplyConfiguration) WithLastScaleUpTime(value v1.Time) *NodeGroupStateApplyConfiguration {
+	b.LastScaleUpTime = &value
+	return b
+}
+
+// WithLastScaleDownTime sets the LastScaleDownTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastScaleDownTime field is set to the value of the last call.
+func (b *NodeGroupStateApplyConfiguration) WithLastScaleDownTime(value v1.Time) *NodeGroupStateApplyConfiguration {
+	b.LastScaleDownTime = &value
+	return b
+}
+
+// WithUnremovableNodes adds the given value to the UnremovableNodes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UnremovableNodes field.
+func (b *NodeGroupStateApplyConfiguration) WithUnremovableNodes(values ...*UnremovableNodeApplyConfiguration) *NodeGroupStateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUnremovableNodes")
+		}
+		b.UnremovableNodes = append(b.UnremovableNodes, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,61 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScaleUpConditionApplyConfiguration represents an declarative configuration of the ScaleUpCondition type for use
+// with apply.
+type ScaleUpConditionApplyConfiguration struct {
+	Status             *string  `json:"status,omitempty"`
+	LastProbeTime      *v1.Time `json:"lastProbeTime,omitempty"`
+	LastTransitionTime *v1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ScaleUpConditionApplyConfiguration constructs an declarative configuration of the ScaleUpCondition type for use with
+// apply.
+func ScaleUpCondition() *ScaleUpConditionApplyConfiguration {
+	return &ScaleUpConditionApplyConfiguration{}
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *ScaleUpConditionApplyConfiguration) WithStatus(value string) *ScaleUpConditionApplyConfiguration {
+	b.Status = &value
+	return b
+}
+
+// WithLastProbeTime sets the LastProbeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastProbeTime field is set to the value of the last call.
+func (b *ScaleUpConditionApplyConfiguration) WithLastProbeTime(value v1.Time) *ScaleUpConditionApplyConfiguration {
+	b.LastProbeTime = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *ScaleUpConditionApplyConfiguration) WithLastTransitionTime(value v1.Time) *ScaleUpConditionApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
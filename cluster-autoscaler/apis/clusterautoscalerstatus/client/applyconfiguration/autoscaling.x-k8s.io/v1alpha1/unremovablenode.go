@@ -0,0 +1,75 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// UnremovableNodeApplyConfiguration represents an declarative configuration of the UnremovableNode type for use
+// with apply.
+type UnremovableNodeApplyConfiguration struct {
+	Name              *string `json:"name,omitempty"`
+	NodeGroup         *string `json:"nodeGroup,omitempty"`
+	Reason            *string `json:"reason,omitempty"`
+	BlockingPod       *string `json:"blockingPod,omitempty"`
+	BlockingPodReason *string `json:"blockingPodReason,omitempty"`
+}
+
+// UnremovableNodeApplyConfiguration constructs an declarative configuration of the UnremovableNode type for use with
+// apply.
+func UnremovableNode() *UnremovableNodeApplyConfiguration {
+	return &UnremovableNodeApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *UnremovableNodeApplyConfiguration) WithName(value string) *UnremovableNodeApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNodeGroup sets the NodeGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeGroup field is set to the value of the last call.
+func (b *UnremovableNodeApplyConfiguration) WithNodeGroup(value string) *UnremovableNodeApplyConfiguration {
+	b.NodeGroup = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *UnremovableNodeApplyConfiguration) WithReason(value string) *UnremovableNodeApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithBlockingPod sets the BlockingPod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BlockingPod field is set to the value of the last call.
+func (b *UnremovableNodeApplyConfiguration) WithBlockingPod(value string) *UnremovableNodeApplyConfiguration {
+	b.BlockingPod = &value
+	return b
+}
+
+// WithBlockingPodReason sets the BlockingPodReason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BlockingPodReason field is set to the value of the last call.
+func (b *UnremovableNodeApplyConfiguration) WithBlockingPodReason(value string) *UnremovableNodeApplyConfiguration {
+	b.BlockingPodReason = &value
+	return b
+}
@@ -0,0 +1,61 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	v1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/applyconfiguration/autoscaling.x-k8s.io/v1alpha1"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=autoscaling.x-k8s.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("BackoffInfo"):
+		return &autoscalingxk8siov1alpha1.BackoffInfoApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterAutoscalerStatus"):
+		return &autoscalingxk8siov1alpha1.ClusterAutoscalerStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterAutoscalerStatusState"):
+		return &autoscalingxk8siov1alpha1.ClusterAutoscalerStatusStateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterWideState"):
+		return &autoscalingxk8siov1alpha1.ClusterWideStateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("HealthCondition"):
+		return &autoscalingxk8siov1alpha1.HealthConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeCount"):
+		return &autoscalingxk8siov1alpha1.NodeCountApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeGroupHealthCondition"):
+		return &autoscalingxk8siov1alpha1.NodeGroupHealthConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeGroupScaleUpCondition"):
+		return &autoscalingxk8siov1alpha1.NodeGroupScaleUpConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeGroupState"):
+		return &autoscalingxk8siov1alpha1.NodeGroupStateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeGroupStatus"):
+		return &autoscalingxk8siov1alpha1.NodeGroupStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScaleDownCondition"):
+		return &autoscalingxk8siov1alpha1.ScaleDownConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScaleUpCondition"):
+		return &autoscalingxk8siov1alpha1.ScaleUpConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UnremovableNode"):
+		return &autoscalingxk8siov1alpha1.UnremovableNodeApplyConfiguration{}
+
+	}
+	return nil
+}
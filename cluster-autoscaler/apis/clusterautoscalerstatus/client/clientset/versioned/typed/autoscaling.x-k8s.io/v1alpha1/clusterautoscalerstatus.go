@@ -0,0 +1,235 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	v1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/applyconfiguration/autoscaling.x-k8s.io/v1alpha1"
+	scheme "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+	consistencydetector "k8s.io/client-go/util/consistencydetector"
+	watchlist "k8s.io/client-go/util/watchlist"
+	"k8s.io/klog/v2"
+)
+
+// ClusterAutoscalerStatusesGetter has a method to return a ClusterAutoscalerStatusInterface.
+// A group's client should implement this interface.
+type ClusterAutoscalerStatusesGetter interface {
+	ClusterAutoscalerStatuses() ClusterAutoscalerStatusInterface
+}
+
+// ClusterAutoscalerStatusInterface has methods to work with ClusterAutoscalerStatus resources.
+type ClusterAutoscalerStatusInterface interface {
+	Create(ctx context.Context, clusterAutoscalerStatus *v1alpha1.ClusterAutoscalerStatus, opts v1.CreateOptions) (*v1alpha1.ClusterAutoscalerStatus, error)
+	Update(ctx context.Context, clusterAutoscalerStatus *v1alpha1.ClusterAutoscalerStatus, opts v1.UpdateOptions) (*v1alpha1.ClusterAutoscalerStatus, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ClusterAutoscalerStatus, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ClusterAutoscalerStatusList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterAutoscalerStatus, err error)
+	Apply(ctx context.Context, clusterAutoscalerStatus *autoscalingxk8siov1alpha1.ClusterAutoscalerStatusApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.ClusterAutoscalerStatus, err error)
+	ClusterAutoscalerStatusExpansion
+}
+
+// clusterAutoscalerStatuses implements ClusterAutoscalerStatusInterface
+type clusterAutoscalerStatuses struct {
+	client rest.Interface
+}
+
+// newClusterAutoscalerStatuses returns a ClusterAutoscalerStatuses
+func newClusterAutoscalerStatuses(c *AutoscalingV1alpha1Client) *clusterAutoscalerStatuses {
+	return &clusterAutoscalerStatuses{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterAutoscalerStatus, and returns the corresponding clusterAutoscalerStatus object, and an error if there is any.
+func (c *clusterAutoscalerStatuses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterAutoscalerStatus, err error) {
+	result = &v1alpha1.ClusterAutoscalerStatus{}
+	err = c.client.Get().
+		Resource("clusterautoscalerstatuses").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterAutoscalerStatuses that match those selectors.
+func (c *clusterAutoscalerStatuses) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ClusterAutoscalerStatusList, error) {
+	if watchListOptions, hasWatchListOptionsPrepared, watchListOptionsErr := watchlist.PrepareWatchListOptionsFromListOptions(opts); watchListOptionsErr != nil {
+		klog.Warningf("Failed preparing watchlist options for clusterautoscalerstatuses, falling back to the standard LIST semantics, err = %v", watchListOptionsErr)
+	} else if hasWatchListOptionsPrepared {
+		result, err := c.watchList(ctx, watchListOptions)
+		if err == nil {
+			consistencydetector.CheckWatchListFromCacheDataConsistencyIfRequested(ctx, "watchlist request for clusterautoscalerstatuses", c.list, opts, result)
+			return result, nil
+		}
+		klog.Warningf("The watchlist request for clusterautoscalerstatuses ended with an error, falling back to the standard LIST semantics, err = %v", err)
+	}
+	result, err := c.list(ctx, opts)
+	if err == nil {
+		consistencydetector.CheckListFromCacheDataConsistencyIfRequested(ctx, "list request for clusterautoscalerstatuses", c.list, opts, result)
+	}
+	return result, err
+}
+
+// list takes label and field selectors, and returns the list of ClusterAutoscalerStatuses that match those selectors.
+func (c *clusterAutoscalerStatuses) list(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterAutoscalerStatusList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ClusterAutoscalerStatusList{}
+	err = c.client.Get().
+		Resource("clusterautoscalerstatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// watchList establishes a watch stream with the server and returns the list of ClusterAutoscalerStatuses
+func (c *clusterAutoscalerStatuses) watchList(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterAutoscalerStatusList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ClusterAutoscalerStatusList{}
+	err = c.client.Get().
+		Resource("clusterautoscalerstatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		WatchList(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterAutoscalerStatuses.
+func (c *clusterAutoscalerStatuses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterautoscalerstatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterAutoscalerStatus and creates it.  Returns the server's representation of the clusterAutoscalerStatus, and an error, if there is any.
+func (c *clusterAutoscalerStatuses) Create(ctx context.Context, clusterAutoscalerStatus *v1alpha1.ClusterAutoscalerStatus, opts v1.CreateOptions) (result *v1alpha1.ClusterAutoscalerStatus, err error) {
+	result = &v1alpha1.ClusterAutoscalerStatus{}
+	err = c.client.Post().
+		Resource("clusterautoscalerstatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterAutoscalerStatus).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterAutoscalerStatus and updates it. Returns the server's representation of the clusterAutoscalerStatus, and an error, if there is any.
+func (c *clusterAutoscalerStatuses) Update(ctx context.Context, clusterAutoscalerStatus *v1alpha1.ClusterAutoscalerStatus, opts v1.UpdateOptions) (result *v1alpha1.ClusterAutoscalerStatus, err error) {
+	result = &v1alpha1.ClusterAutoscalerStatus{}
+	err = c.client.Put().
+		Resource("clusterautoscalerstatuses").
+		Name(clusterAutoscalerStatus.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterAutoscalerStatus).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterAutoscalerStatus and deletes it. Returns an error if one occurs.
+func (c *clusterAutoscalerStatuses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterautoscalerstatuses").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterAutoscalerStatuses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterautoscalerstatuses").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterAutoscalerStatus.
+func (c *clusterAutoscalerStatuses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterAutoscalerStatus, err error) {
+	result = &v1alpha1.ClusterAutoscalerStatus{}
+	err = c.client.Patch(pt).
+		Resource("clusterautoscalerstatuses").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterAutoscalerStatus.
+func (c *clusterAutoscalerStatuses) Apply(ctx context.Context, clusterAutoscalerStatus *autoscalingxk8siov1alpha1.ClusterAutoscalerStatusApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.ClusterAutoscalerStatus, err error) {
+	if clusterAutoscalerStatus == nil {
+		return nil, fmt.Errorf("clusterAutoscalerStatus provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(clusterAutoscalerStatus)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterAutoscalerStatus.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterAutoscalerStatus.Name must be provided to Apply")
+	}
+	result = &v1alpha1.ClusterAutoscalerStatus{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("clusterautoscalerstatuses").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,151 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	v1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/applyconfiguration/autoscaling.x-k8s.io/v1alpha1"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterAutoscalerStatuses implements ClusterAutoscalerStatusInterface
+type FakeClusterAutoscalerStatuses struct {
+	Fake *FakeAutoscalingV1alpha1
+}
+
+var clusterautoscalerstatusesResource = v1alpha1.SchemeGroupVersion.WithResource("clusterautoscalerstatuses")
+
+var clusterautoscalerstatusesKind = v1alpha1.SchemeGroupVersion.WithKind("ClusterAutoscalerStatus")
+
+// Get takes name of the clusterAutoscalerStatus, and returns the corresponding clusterAutoscalerStatus object, and an error if there is any.
+func (c *FakeClusterAutoscalerStatuses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterAutoscalerStatus, err error) {
+	emptyResult := &v1alpha1.ClusterAutoscalerStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterautoscalerstatusesResource, name), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.ClusterAutoscalerStatus), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterAutoscalerStatuses that match those selectors.
+func (c *FakeClusterAutoscalerStatuses) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterAutoscalerStatusList, err error) {
+	emptyResult := &v1alpha1.ClusterAutoscalerStatusList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterautoscalerstatusesResource, clusterautoscalerstatusesKind, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ClusterAutoscalerStatusList{ListMeta: obj.(*v1alpha1.ClusterAutoscalerStatusList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ClusterAutoscalerStatusList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterAutoscalerStatuses.
+func (c *FakeClusterAutoscalerStatuses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterautoscalerstatusesResource, opts))
+}
+
+// Create takes the representation of a clusterAutoscalerStatus and creates it.  Returns the server's representation of the clusterAutoscalerStatus, and an error, if there is any.
+func (c *FakeClusterAutoscalerStatuses) Create(ctx context.Context, clusterAutoscalerStatus *v1alpha1.ClusterAutoscalerStatus, opts v1.CreateOptions) (result *v1alpha1.ClusterAutoscalerStatus, err error) {
+	emptyResult := &v1alpha1.ClusterAutoscalerStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterautoscalerstatusesResource, clusterAutoscalerStatus), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.ClusterAutoscalerStatus), err
+}
+
+// Update takes the representation of a clusterAutoscalerStatus and updates it. Returns the server's representation of the clusterAutoscalerStatus, and an error, if there is any.
+func (c *FakeClusterAutoscalerStatuses) Update(ctx context.Context, clusterAutoscalerStatus *v1alpha1.ClusterAutoscalerStatus, opts v1.UpdateOptions) (result *v1alpha1.ClusterAutoscalerStatus, err error) {
+	emptyResult := &v1alpha1.ClusterAutoscalerStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterautoscalerstatusesResource, clusterAutoscalerStatus), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.ClusterAutoscalerStatus), err
+}
+
+// Delete takes name of the clusterAutoscalerStatus and deletes it. Returns an error if one occurs.
+func (c *FakeClusterAutoscalerStatuses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(clusterautoscalerstatusesResource, name, opts), &v1alpha1.ClusterAutoscalerStatus{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterAutoscalerStatuses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterautoscalerstatusesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterAutoscalerStatusList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterAutoscalerStatus.
+func (c *FakeClusterAutoscalerStatuses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterAutoscalerStatus, err error) {
+	emptyResult := &v1alpha1.ClusterAutoscalerStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterautoscalerstatusesResource, name, pt, data, subresources...), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.ClusterAutoscalerStatus), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied clusterAutoscalerStatus.
+func (c *FakeClusterAutoscalerStatuses) Apply(ctx context.Context, clusterAutoscalerStatus *autoscalingxk8siov1alpha1.ClusterAutoscalerStatusApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.ClusterAutoscalerStatus, err error) {
+	if clusterAutoscalerStatus == nil {
+		return nil, fmt.Errorf("clusterAutoscalerStatus provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(clusterAutoscalerStatus)
+	if err != nil {
+		return nil, err
+	}
+	name := clusterAutoscalerStatus.Name
+	if name == nil {
+		return nil, fmt.Errorf("clusterAutoscalerStatus.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.ClusterAutoscalerStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterautoscalerstatusesResource, *name, types.ApplyPatchType, data), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.ClusterAutoscalerStatus), err
+}
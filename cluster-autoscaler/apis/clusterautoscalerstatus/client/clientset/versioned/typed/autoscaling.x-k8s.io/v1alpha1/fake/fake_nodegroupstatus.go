@@ -0,0 +1,151 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	v1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/applyconfiguration/autoscaling.x-k8s.io/v1alpha1"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeNodeGroupStatuses implements NodeGroupStatusInterface
+type FakeNodeGroupStatuses struct {
+	Fake *FakeAutoscalingV1alpha1
+}
+
+var nodegroupstatusesResource = v1alpha1.SchemeGroupVersion.WithResource("nodegroupstatuses")
+
+var nodegroupstatusesKind = v1alpha1.SchemeGroupVersion.WithKind("NodeGroupStatus")
+
+// Get takes name of the nodeGroupStatus, and returns the corresponding nodeGroupStatus object, and an error if there is any.
+func (c *FakeNodeGroupStatuses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.NodeGroupStatus, err error) {
+	emptyResult := &v1alpha1.NodeGroupStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(nodegroupstatusesResource, name), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.NodeGroupStatus), err
+}
+
+// List takes label and field selectors, and returns the list of NodeGroupStatuses that match those selectors.
+func (c *FakeNodeGroupStatuses) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.NodeGroupStatusList, err error) {
+	emptyResult := &v1alpha1.NodeGroupStatusList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(nodegroupstatusesResource, nodegroupstatusesKind, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.NodeGroupStatusList{ListMeta: obj.(*v1alpha1.NodeGroupStatusList).ListMeta}
+	for _, item := range obj.(*v1alpha1.NodeGroupStatusList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested nodeGroupStatuses.
+func (c *FakeNodeGroupStatuses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(nodegroupstatusesResource, opts))
+}
+
+// Create takes the representation of a nodeGroupStatus and creates it.  Returns the server's representation of the nodeGroupStatus, and an error, if there is any.
+func (c *FakeNodeGroupStatuses) Create(ctx context.Context, nodeGroupStatus *v1alpha1.NodeGroupStatus, opts v1.CreateOptions) (result *v1alpha1.NodeGroupStatus, err error) {
+	emptyResult := &v1alpha1.NodeGroupStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(nodegroupstatusesResource, nodeGroupStatus), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.NodeGroupStatus), err
+}
+
+// Update takes the representation of a nodeGroupStatus and updates it. Returns the server's representation of the nodeGroupStatus, and an error, if there is any.
+func (c *FakeNodeGroupStatuses) Update(ctx context.Context, nodeGroupStatus *v1alpha1.NodeGroupStatus, opts v1.UpdateOptions) (result *v1alpha1.NodeGroupStatus, err error) {
+	emptyResult := &v1alpha1.NodeGroupStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(nodegroupstatusesResource, nodeGroupStatus), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.NodeGroupStatus), err
+}
+
+// Delete takes name of the nodeGroupStatus and deletes it. Returns an error if one occurs.
+func (c *FakeNodeGroupStatuses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(nodegroupstatusesResource, name, opts), &v1alpha1.NodeGroupStatus{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeNodeGroupStatuses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(nodegroupstatusesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.NodeGroupStatusList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched nodeGroupStatus.
+func (c *FakeNodeGroupStatuses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NodeGroupStatus, err error) {
+	emptyResult := &v1alpha1.NodeGroupStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(nodegroupstatusesResource, name, pt, data, subresources...), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.NodeGroupStatus), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied nodeGroupStatus.
+func (c *FakeNodeGroupStatuses) Apply(ctx context.Context, nodeGroupStatus *autoscalingxk8siov1alpha1.NodeGroupStatusApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.NodeGroupStatus, err error) {
+	if nodeGroupStatus == nil {
+		return nil, fmt.Errorf("nodeGroupStatus provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(nodeGroupStatus)
+	if err != nil {
+		return nil, err
+	}
+	name := nodeGroupStatus.Name
+	if name == nil {
+		return nil, fmt.Errorf("nodeGroupStatus.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.NodeGroupStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(nodegroupstatusesResource, *name, types.ApplyPatchType, data), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.NodeGroupStatus), err
+}
@@ -0,0 +1,235 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	v1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/applyconfiguration/autoscaling.x-k8s.io/v1alpha1"
+	scheme "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+	consistencydetector "k8s.io/client-go/util/consistencydetector"
+	watchlist "k8s.io/client-go/util/watchlist"
+	"k8s.io/klog/v2"
+)
+
+// NodeGroupStatusesGetter has a method to return a NodeGroupStatusInterface.
+// A group's client should implement this interface.
+type NodeGroupStatusesGetter interface {
+	NodeGroupStatuses() NodeGroupStatusInterface
+}
+
+// NodeGroupStatusInterface has methods to work with NodeGroupStatus resources.
+type NodeGroupStatusInterface interface {
+	Create(ctx context.Context, nodeGroupStatus *v1alpha1.NodeGroupStatus, opts v1.CreateOptions) (*v1alpha1.NodeGroupStatus, error)
+	Update(ctx context.Context, nodeGroupStatus *v1alpha1.NodeGroupStatus, opts v1.UpdateOptions) (*v1alpha1.NodeGroupStatus, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.NodeGroupStatus, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.NodeGroupStatusList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NodeGroupStatus, err error)
+	Apply(ctx context.Context, nodeGroupStatus *autoscalingxk8siov1alpha1.NodeGroupStatusApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.NodeGroupStatus, err error)
+	NodeGroupStatusExpansion
+}
+
+// nodeGroupStatuses implements NodeGroupStatusInterface
+type nodeGroupStatuses struct {
+	client rest.Interface
+}
+
+// newNodeGroupStatuses returns a NodeGroupStatuses
+func newNodeGroupStatuses(c *AutoscalingV1alpha1Client) *nodeGroupStatuses {
+	return &nodeGroupStatuses{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the nodeGroupStatus, and returns the corresponding nodeGroupStatus object, and an error if there is any.
+func (c *nodeGroupStatuses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.NodeGroupStatus, err error) {
+	result = &v1alpha1.NodeGroupStatus{}
+	err = c.client.Get().
+		Resource("nodegroupstatuses").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of NodeGroupStatuses that match those selectors.
+func (c *nodeGroupStatuses) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.NodeGroupStatusList, error) {
+	if watchListOptions, hasWatchListOptionsPrepared, watchListOptionsErr := watchlist.PrepareWatchListOptionsFromListOptions(opts); watchListOptionsErr != nil {
+		klog.Warningf("Failed preparing watchlist options for nodegroupstatuses, falling back to the standard LIST semantics, err = %v", watchListOptionsErr)
+	} else if hasWatchListOptionsPrepared {
+		result, err := c.watchList(ctx, watchListOptions)
+		if err == nil {
+			consistencydetector.CheckWatchListFromCacheDataConsistencyIfRequested(ctx, "watchlist request for nodegroupstatuses", c.list, opts, result)
+			return result, nil
+		}
+		klog.Warningf("The watchlist request for nodegroupstatuses ended with an error, falling back to the standard LIST semantics, err = %v", err)
+	}
+	result, err := c.list(ctx, opts)
+	if err == nil {
+		consistencydetector.CheckListFromCacheDataConsistencyIfRequested(ctx, "list request for nodegroupstatuses", c.list, opts, result)
+	}
+	return result, err
+}
+
+// list takes label and field selectors, and returns the list of NodeGroupStatuses that match those selectors.
+func (c *nodeGroupStatuses) list(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.NodeGroupStatusList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.NodeGroupStatusList{}
+	err = c.client.Get().
+		Resource("nodegroupstatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// watchList establishes a watch stream with the server and returns the list of NodeGroupStatuses
+func (c *nodeGroupStatuses) watchList(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.NodeGroupStatusList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.NodeGroupStatusList{}
+	err = c.client.Get().
+		Resource("nodegroupstatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		WatchList(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested nodeGroupStatuses.
+func (c *nodeGroupStatuses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("nodegroupstatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a nodeGroupStatus and creates it.  Returns the server's representation of the nodeGroupStatus, and an error, if there is any.
+func (c *nodeGroupStatuses) Create(ctx context.Context, nodeGroupStatus *v1alpha1.NodeGroupStatus, opts v1.CreateOptions) (result *v1alpha1.NodeGroupStatus, err error) {
+	result = &v1alpha1.NodeGroupStatus{}
+	err = c.client.Post().
+		Resource("nodegroupstatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(nodeGroupStatus).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a nodeGroupStatus and updates it. Returns the server's representation of the nodeGroupStatus, and an error, if there is any.
+func (c *nodeGroupStatuses) Update(ctx context.Context, nodeGroupStatus *v1alpha1.NodeGroupStatus, opts v1.UpdateOptions) (result *v1alpha1.NodeGroupStatus, err error) {
+	result = &v1alpha1.NodeGroupStatus{}
+	err = c.client.Put().
+		Resource("nodegroupstatuses").
+		Name(nodeGroupStatus.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(nodeGroupStatus).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the nodeGroupStatus and deletes it. Returns an error if one occurs.
+func (c *nodeGroupStatuses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("nodegroupstatuses").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *nodeGroupStatuses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("nodegroupstatuses").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched nodeGroupStatus.
+func (c *nodeGroupStatuses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NodeGroupStatus, err error) {
+	result = &v1alpha1.NodeGroupStatus{}
+	err = c.client.Patch(pt).
+		Resource("nodegroupstatuses").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied nodeGroupStatus.
+func (c *nodeGroupStatuses) Apply(ctx context.Context, nodeGroupStatus *autoscalingxk8siov1alpha1.NodeGroupStatusApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.NodeGroupStatus, err error) {
+	if nodeGroupStatus == nil {
+		return nil, fmt.Errorf("nodeGroupStatus provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(nodeGroupStatus)
+	if err != nil {
+		return nil, err
+	}
+	name := nodeGroupStatus.Name
+	if name == nil {
+		return nil, fmt.Errorf("nodeGroupStatus.Name must be provided to Apply")
+	}
+	result = &v1alpha1.NodeGroupStatus{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("nodegroupstatuses").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	versioned "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned"
+	internalinterfaces "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/listers/autoscaling.x-k8s.io/v1alpha1"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterAutoscalerStatusInformer provides access to a shared informer and lister for
+// ClusterAutoscalerStatuses.
+type ClusterAutoscalerStatusInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ClusterAutoscalerStatusLister
+}
+
+type clusterAutoscalerStatusInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterAutoscalerStatusInformer constructs a new informer for ClusterAutoscalerStatus type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterAutoscalerStatusInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterAutoscalerStatusInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterAutoscalerStatusInformer constructs a new informer for ClusterAutoscalerStatus type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterAutoscalerStatusInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().ClusterAutoscalerStatuses().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().ClusterAutoscalerStatuses().Watch(context.TODO(), options)
+			},
+		},
+		&autoscalingxk8siov1alpha1.ClusterAutoscalerStatus{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterAutoscalerStatusInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterAutoscalerStatusInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterAutoscalerStatusInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&autoscalingxk8siov1alpha1.ClusterAutoscalerStatus{}, f.defaultInformer)
+}
+
+func (f *clusterAutoscalerStatusInformer) Lister() v1alpha1.ClusterAutoscalerStatusLister {
+	return v1alpha1.NewClusterAutoscalerStatusLister(f.Informer().GetIndexer())
+}
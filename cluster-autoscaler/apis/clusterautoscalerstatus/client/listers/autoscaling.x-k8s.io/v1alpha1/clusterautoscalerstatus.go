@@ -0,0 +1,48 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	v1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterAutoscalerStatusLister helps list ClusterAutoscalerStatuses.
+// All objects returned here must be treated as read-only.
+type ClusterAutoscalerStatusLister interface {
+	// List lists all ClusterAutoscalerStatuses in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ClusterAutoscalerStatus, err error)
+	// Get retrieves the ClusterAutoscalerStatus from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ClusterAutoscalerStatus, error)
+	ClusterAutoscalerStatusListerExpansion
+}
+
+// clusterAutoscalerStatusLister implements the ClusterAutoscalerStatusLister interface.
+type clusterAutoscalerStatusLister struct {
+	listers.ResourceIndexer[*v1alpha1.ClusterAutoscalerStatus]
+}
+
+// NewClusterAutoscalerStatusLister returns a new ClusterAutoscalerStatusLister.
+func NewClusterAutoscalerStatusLister(indexer cache.Indexer) ClusterAutoscalerStatusLister {
+	return &clusterAutoscalerStatusLister{listers.New[*v1alpha1.ClusterAutoscalerStatus](indexer, v1alpha1.Resource("clusterautoscalerstatus"))}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	v1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NodeGroupStatusLister helps list NodeGroupStatuses.
+// All objects returned here must be treated as read-only.
+type NodeGroupStatusLister interface {
+	// List lists all NodeGroupStatuses in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.NodeGroupStatus, err error)
+	// Get retrieves the NodeGroupStatus from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.NodeGroupStatus, error)
+	NodeGroupStatusListerExpansion
+}
+
+// nodeGroupStatusLister implements the NodeGroupStatusLister interface.
+type nodeGroupStatusLister struct {
+	listers.ResourceIndexer[*v1alpha1.NodeGroupStatus]
+}
+
+// NewNodeGroupStatusLister returns a new NodeGroupStatusLister.
+func NewNodeGroupStatusLister(indexer cache.Indexer) NodeGroupStatusLister {
+	return &nodeGroupStatusLister{listers.New[*v1alpha1.NodeGroupStatus](indexer, v1alpha1.Resource("nodegroupstatus"))}
+}
@@ -8,6 +8,7 @@ require (
 	k8s.io/apimachinery v0.31.0-alpha.2
 	k8s.io/client-go v0.31.0-alpha.2
 	k8s.io/code-generator v0.31.0-alpha.2
+	k8s.io/klog/v2 v2.120.1
 	sigs.k8s.io/structured-merge-diff/v4 v4.4.1
 )
 
@@ -52,7 +53,6 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	k8s.io/api v0.31.0-alpha.2 // indirect
 	k8s.io/gengo/v2 v2.0.0-20240228010128-51d4e06bde70 // indirect
-	k8s.io/klog/v2 v2.120.1 // indirect
 	k8s.io/kube-openapi v0.0.0-20240228011516-70dd3763d340 // indirect
 	k8s.io/utils v0.0.0-20230726121419-3b25d923346b // indirect
 	sigs.k8s.io/json v0.0.0-20221116044647-bc3834ca7abd // indirect
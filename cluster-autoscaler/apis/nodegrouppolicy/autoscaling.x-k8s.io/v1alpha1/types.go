@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains definitions of NodeGroupPolicy objects.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:storageversions
+// +kubebuilder:resource:scope=Cluster,shortName=ngpolicy
+// +kubebuilder:subresource:status
+
+// NodeGroupPolicy overrides autoscaling options of the node groups it selects,
+// independently of the cloud provider. Values set in the policy take precedence
+// over both the cloud provider specific configuration (e.g. ASG or VMSS tags)
+// and the global defaults set by flags. If multiple policies select the same
+// node group, each option is taken from the policy with the highest priority
+// which sets it.
+//
+// +kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority"
+// +kubebuilder:printcolumn:name="Valid",type="string",JSONPath=".status.conditions[?(@.type=='Valid')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NodeGroupPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object metadata. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	//
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec contains the node group selector and the overridden options.
+	Spec NodeGroupPolicySpec `json:"spec"`
+	// Status is written by Cluster Autoscaler.
+	//
+	// +optional
+	Status NodeGroupPolicyStatus `json:"status,omitempty"`
+}
+
+// NodeGroupPolicyList is a object for list of NodeGroupPolicy.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NodeGroupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	//
+	// +optional
+	metav1.ListMeta `json:"metadata"`
+	// Items, list of NodeGroupPolicy returned from API.
+	//
+	// +optional
+	Items []NodeGroupPolicy `json:"items"`
+}
+
+// NodeGroupPolicySpec is the specification of a NodeGroupPolicy.
+type NodeGroupPolicySpec struct {
+	// Selector selects node groups the policy applies to.
+	Selector NodeGroupSelector `json:"selector"`
+	// Priority of the policy. If multiple policies select the same node group
+	// and set the same option, the value from the policy with the highest
+	// priority is used. Ties are broken by policy name.
+	//
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// MinSize overrides the minimum size of the node group. It can only
+	// narrow the range supported by the cloud provider: the effective minimum
+	// is never lower than the cloud provider one.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinSize *int32 `json:"minSize,omitempty"`
+	// MaxSize overrides the maximum size of the node group. It can only
+	// narrow the range supported by the cloud provider: the effective maximum
+	// is never higher than the cloud provider one.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxSize *int32 `json:"maxSize,omitempty"`
+	// ScaleDownUtilizationThreshold overrides the utilization below which
+	// nodes are considered for scale down, as a decimal between 0 and 1.
+	//
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	ScaleDownUtilizationThreshold *string `json:"scaleDownUtilizationThreshold,omitempty"`
+	// ScaleDownGpuUtilizationThreshold overrides the GPU utilization below
+	// which GPU nodes are considered for scale down, as a decimal between 0 and 1.
+	//
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	ScaleDownGpuUtilizationThreshold *string `json:"scaleDownGpuUtilizationThreshold,omitempty"`
+	// ScaleDownUnneededTime overrides how long a node should be unneeded
+	// before it is eligible for scale down.
+	//
+	// +optional
+	ScaleDownUnneededTime *metav1.Duration `json:"scaleDownUnneededTime,omitempty"`
+	// ScaleDownUnreadyTime overrides how long an unready node should be
+	// unneeded before it is eligible for scale down.
+	//
+	// +optional
+	ScaleDownUnreadyTime *metav1.Duration `json:"scaleDownUnreadyTime,omitempty"`
+	// MaxNodeProvisionTime overrides how long Cluster Autoscaler waits for
+	// a node to be provisioned.
+	//
+	// +optional
+	MaxNodeProvisionTime *metav1.Duration `json:"maxNodeProvisionTime,omitempty"`
+	// IgnoreDaemonSetsUtilization overrides whether DaemonSet pods are
+	// ignored when computing node utilization for scale down.
+	//
+	// +optional
+	IgnoreDaemonSetsUtilization *bool `json:"ignoreDaemonSetsUtilization,omitempty"`
+	// MaxScaleUpNodesPerMinute overrides the maximum number of nodes added
+	// to the node group within a minute. 0 means no limit.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxScaleUpNodesPerMinute *int32 `json:"maxScaleUpNodesPerMinute,omitempty"`
+	// MaxScaleUpGrowthPercent overrides the maximum number of nodes added
+	// to the node group in one scale-up, as a percentage of its current size.
+	// 0 means no limit.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxScaleUpGrowthPercent *int32 `json:"maxScaleUpGrowthPercent,omitempty"`
+}
+
+// NodeGroupSelector selects node groups. A node group is selected if it
+// matches all the set fields. An empty selector doesn't select anything.
+type NodeGroupSelector struct {
+	// NodeGroupIDRegex is a regular expression matched against the whole node group ID.
+	//
+	// +optional
+	NodeGroupIDRegex string `json:"nodeGroupIdRegex,omitempty"`
+	// TemplateLabels is matched against labels of the node group template node.
+	//
+	// +optional
+	TemplateLabels *metav1.LabelSelector `json:"templateLabels,omitempty"`
+}
+
+// NodeGroupPolicyStatus is the status of a NodeGroupPolicy.
+type NodeGroupPolicyStatus struct {
+	// ObservedGeneration is the generation of the spec the status is based on.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// MatchedNodeGroups lists IDs of node groups selected by the policy.
+	//
+	// +optional
+	MatchedNodeGroups []string `json:"matchedNodeGroups,omitempty"`
+	// Conditions of the policy. The Valid condition tells if the policy
+	// passed validation and is applied.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+const (
+	// PolicyValid is the condition type telling if the policy passed validation.
+	PolicyValid = "Valid"
+	// PolicyValidReason is the reason of the Valid condition of a valid policy.
+	PolicyValidReason = "Valid"
+	// PolicyInvalidReason is the reason of the Valid condition of an invalid policy.
+	PolicyInvalidReason = "Invalid"
+)
@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// NodeGroupSelectorApplyConfiguration represents an declarative configuration of the NodeGroupSelector type for use
+// with apply.
+type NodeGroupSelectorApplyConfiguration struct {
+	NodeGroupIDRegex *string                             `json:"nodeGroupIdRegex,omitempty"`
+	TemplateLabels   *v1.LabelSelectorApplyConfiguration `json:"templateLabels,omitempty"`
+}
+
+// NodeGroupSelectorApplyConfiguration constructs an declarative configuration of the NodeGroupSelector type for use with
+// apply.
+func NodeGroupSelector() *NodeGroupSelectorApplyConfiguration {
+	return &NodeGroupSelectorApplyConfiguration{}
+}
+
+// WithNodeGroupIDRegex sets the NodeGroupIDRegex field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeGroupIDRegex field is set to the value of the last call.
+func (b *NodeGroupSelectorApplyConfiguration) WithNodeGroupIDRegex(value string) *NodeGroupSelectorApplyConfiguration {
+	b.NodeGroupIDRegex = &value
+	return b
+}
+
+// WithTemplateLabels sets the TemplateLabels field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TemplateLabels field is set to the value of the last call.
+func (b *NodeGroupSelectorApplyConfiguration) WithTemplateLabels(value *v1.LabelSelectorApplyConfiguration) *NodeGroupSelectorApplyConfiguration {
+	b.TemplateLabels = value
+	return b
+}
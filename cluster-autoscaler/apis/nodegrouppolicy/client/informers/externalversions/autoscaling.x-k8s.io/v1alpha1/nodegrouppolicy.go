@@ -0,0 +1,89 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	autoscalingxk8siov1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/autoscaling.x-k8s.io/v1alpha1"
+	versioned "k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/client/clientset/versioned"
+	internalinterfaces "k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/client/listers/autoscaling.x-k8s.io/v1alpha1"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NodeGroupPolicyInformer provides access to a shared informer and lister for
+// NodeGroupPolicies.
+type NodeGroupPolicyInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.NodeGroupPolicyLister
+}
+
+type nodeGroupPolicyInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewNodeGroupPolicyInformer constructs a new informer for NodeGroupPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewNodeGroupPolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredNodeGroupPolicyInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredNodeGroupPolicyInformer constructs a new informer for NodeGroupPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredNodeGroupPolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().NodeGroupPolicies().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().NodeGroupPolicies().Watch(context.TODO(), options)
+			},
+		},
+		&autoscalingxk8siov1alpha1.NodeGroupPolicy{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *nodeGroupPolicyInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredNodeGroupPolicyInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *nodeGroupPolicyInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&autoscalingxk8siov1alpha1.NodeGroupPolicy{}, f.defaultInformer)
+}
+
+func (f *nodeGroupPolicyInformer) Lister() v1alpha1.NodeGroupPolicyLister {
+	return v1alpha1.NewNodeGroupPolicyLister(f.Informer().GetIndexer())
+}
@@ -26,6 +26,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
@@ -55,15 +56,20 @@ var (
 type awsCloudProvider struct {
 	awsManager      *AwsManager
 	resourceLimiter *cloudprovider.ResourceLimiter
+	pricingModel    cloudprovider.PricingModel
 }
 
 // BuildAwsCloudProvider builds CloudProvider implementation for AWS.
 func BuildAwsCloudProvider(awsManager *AwsManager, resourceLimiter *cloudprovider.ResourceLimiter) (cloudprovider.CloudProvider, error) {
-	aws := &awsCloudProvider{
+	return buildAwsCloudProvider(awsManager, resourceLimiter, "", nil), nil
+}
+
+func buildAwsCloudProvider(awsManager *AwsManager, resourceLimiter *cloudprovider.ResourceLimiter, region string, spotDiscounts pricing.SpotDiscounts) *awsCloudProvider {
+	return &awsCloudProvider{
 		awsManager:      awsManager,
 		resourceLimiter: resourceLimiter,
+		pricingModel:    newAwsPriceModel(region, spotDiscounts),
 	}
-	return aws, nil
 }
 
 // Cleanup stops the go routine that is handling the current view of the ASGs in the form of a cache
@@ -163,7 +169,7 @@ func (aws *awsCloudProvider) HasInstance(node *apiv1.Node) (bool, error) {
 
 // Pricing returns pricing model for this cloud provider or error if not available.
 func (aws *awsCloudProvider) Pricing() (cloudprovider.PricingModel, errors.AutoscalerError) {
-	return nil, cloudprovider.ErrNotImplemented
+	return aws.pricingModel, nil
 }
 
 // GetAvailableMachineTypes get all machine types that can be requested from the cloud provider.
@@ -460,10 +466,20 @@ func BuildAWS(opts config.AutoscalingOptions, do cloudprovider.NodeGroupDiscover
 		klog.Fatalf("Failed to create AWS Manager: %v", err)
 	}
 
-	provider, err := BuildAwsCloudProvider(manager, rl)
-	if err != nil {
-		klog.Fatalf("Failed to create AWS cloud provider: %v", err)
+	var spotDiscounts pricing.SpotDiscounts
+	if opts.SpotPriceDiscountsFile != "" {
+		spotDiscounts, err = pricing.LoadSpotDiscounts(opts.SpotPriceDiscountsFile)
+		if err != nil {
+			klog.Fatalf("Failed to load spot price discounts: %v", err)
+		}
 	}
+	klog.V(1).Infof("Using static EC2 price list of %d regions", len(InstancePrices))
+	region := ""
+	if sdkProvider.session.Config.Region != nil {
+		region = *sdkProvider.session.Config.Region
+	}
+
+	provider := buildAwsCloudProvider(manager, rl, region, spotDiscounts)
 	RegisterMetrics()
 	return provider
 }
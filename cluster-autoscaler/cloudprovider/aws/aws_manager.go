@@ -15,6 +15,7 @@ limitations under the License.
 */
 
 //go:generate go run ec2_instance_types/gen.go -region $AWS_REGION
+//go:generate go run ec2_instance_prices/gen.go -regions=$AWS_PRICE_REGIONS
 
 package aws
 
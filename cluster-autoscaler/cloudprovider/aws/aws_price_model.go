@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing"
+)
+
+const (
+	eksCapacityTypeLabel       = "eks.amazonaws.com/capacityType"
+	karpenterCapacityTypeLabel = "karpenter.sh/capacity-type"
+	lifecycleLabel             = "node.kubernetes.io/lifecycle"
+)
+
+// newAwsPriceModel creates a price model backed by the static InstancePrices catalog.
+func newAwsPriceModel(region string, spotDiscounts pricing.SpotDiscounts) *pricing.StaticPriceModel {
+	return pricing.NewStaticPriceModel(InstancePrices, region, spotDiscounts, instanceFamily, isSpotNode)
+}
+
+// instanceFamily returns the family of an EC2 instance type, e.g. m5 for m5.large.
+func instanceFamily(instanceType string) string {
+	family, _, _ := strings.Cut(instanceType, ".")
+	return family
+}
+
+// isSpotNode tells if the node is a spot instance, based on labels set by EKS
+// managed node groups, Karpenter or common node group tooling.
+func isSpotNode(node *apiv1.Node) bool {
+	return strings.EqualFold(node.Labels[eksCapacityTypeLabel], "SPOT") ||
+		strings.EqualFold(node.Labels[karpenterCapacityTypeLabel], "spot") ||
+		strings.EqualFold(node.Labels[lifecycleLabel], "spot")
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestAwsPriceModel(t *testing.T) {
+	model := newAwsPriceModel("us-east-1", pricing.SpotDiscounts{"m5": 0.6, "default": 0.5})
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	onDemand := BuildTestNode("n1", 2000, 8*1024*1024*1024)
+	onDemand.Labels = map[string]string{apiv1.LabelInstanceTypeStable: "m5.large"}
+	price, err := model.NodePrice(onDemand, start, end)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.096, price, 1e-9)
+
+	otherRegion := onDemand.DeepCopy()
+	otherRegion.Labels[apiv1.LabelTopologyRegion] = "eu-west-1"
+	price, err = model.NodePrice(otherRegion, start, end)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.107, price, 1e-9)
+
+	for _, label := range []string{eksCapacityTypeLabel, karpenterCapacityTypeLabel, lifecycleLabel} {
+		spot := onDemand.DeepCopy()
+		spot.Labels[label] = "spot"
+		price, err = model.NodePrice(spot, start, end)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.0384, price, 1e-9, label)
+	}
+}
+
+func TestInstanceFamily(t *testing.T) {
+	assert.Equal(t, "m5", instanceFamily("m5.large"))
+	assert.Equal(t, "c6g", instanceFamily("c6g.2xlarge"))
+	assert.Equal(t, "unknown", instanceFamily("unknown"))
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing"
+
+// InstancePrices are on-demand prices of Linux EC2 instances with shared tenancy in USD per hour, by region.
+//
+// The list is a hand-maintained subset of common instance types in a few regions,
+// other instance types are priced by their resources. Run go generate to replace it
+// with the full AWS Price List of these regions, or of the regions in AWS_PRICE_REGIONS.
+var InstancePrices = pricing.Catalog{
+	"eu-west-1": {
+		"c5.2xlarge":  0.384,
+		"c5.large":    0.096,
+		"c5.xlarge":   0.192,
+		"g4dn.xlarge": 0.587,
+		"m5.2xlarge":  0.428,
+		"m5.4xlarge":  0.856,
+		"m5.large":    0.107,
+		"m5.xlarge":   0.214,
+		"m6g.large":   0.086,
+		"m6g.xlarge":  0.172,
+		"r5.2xlarge":  0.564,
+		"r5.large":    0.141,
+		"r5.xlarge":   0.282,
+		"t3.large":    0.0912,
+		"t3.medium":   0.0456,
+		"t3.xlarge":   0.1824,
+	},
+	"us-east-1": {
+		"c5.2xlarge":   0.34,
+		"c5.4xlarge":   0.68,
+		"c5.9xlarge":   1.53,
+		"c5.large":     0.085,
+		"c5.xlarge":    0.17,
+		"c6g.2xlarge":  0.272,
+		"c6g.large":    0.068,
+		"c6g.xlarge":   0.136,
+		"c6i.2xlarge":  0.34,
+		"c6i.4xlarge":  0.68,
+		"c6i.large":    0.085,
+		"c6i.xlarge":   0.17,
+		"g4dn.2xlarge": 0.752,
+		"g4dn.4xlarge": 1.204,
+		"g4dn.xlarge":  0.526,
+		"g5.2xlarge":   1.212,
+		"g5.xlarge":    1.006,
+		"m5.12xlarge":  2.304,
+		"m5.16xlarge":  3.072,
+		"m5.24xlarge":  4.608,
+		"m5.2xlarge":   0.384,
+		"m5.4xlarge":   0.768,
+		"m5.8xlarge":   1.536,
+		"m5.large":     0.096,
+		"m5.xlarge":    0.192,
+		"m6g.2xlarge":  0.308,
+		"m6g.4xlarge":  0.616,
+		"m6g.large":    0.077,
+		"m6g.xlarge":   0.154,
+		"m6i.2xlarge":  0.384,
+		"m6i.4xlarge":  0.768,
+		"m6i.8xlarge":  1.536,
+		"m6i.large":    0.096,
+		"m6i.xlarge":   0.192,
+		"p3.2xlarge":   3.06,
+		"p3.8xlarge":   12.24,
+		"r5.2xlarge":   0.504,
+		"r5.4xlarge":   1.008,
+		"r5.large":     0.126,
+		"r5.xlarge":    0.252,
+		"r6i.2xlarge":  0.504,
+		"r6i.large":    0.126,
+		"r6i.xlarge":   0.252,
+		"t3.2xlarge":   0.3328,
+		"t3.large":     0.0832,
+		"t3.medium":    0.0416,
+		"t3.micro":     0.0104,
+		"t3.small":     0.0208,
+		"t3.xlarge":    0.1664,
+	},
+	"us-west-2": {
+		"c5.2xlarge":   0.34,
+		"c5.4xlarge":   0.68,
+		"c5.9xlarge":   1.53,
+		"c5.large":     0.085,
+		"c5.xlarge":    0.17,
+		"c6g.2xlarge":  0.272,
+		"c6g.large":    0.068,
+		"c6g.xlarge":   0.136,
+		"c6i.2xlarge":  0.34,
+		"c6i.4xlarge":  0.68,
+		"c6i.large":    0.085,
+		"c6i.xlarge":   0.17,
+		"g4dn.2xlarge": 0.752,
+		"g4dn.4xlarge": 1.204,
+		"g4dn.xlarge":  0.526,
+		"g5.2xlarge":   1.212,
+		"g5.xlarge":    1.006,
+		"m5.12xlarge":  2.304,
+		"m5.16xlarge":  3.072,
+		"m5.24xlarge":  4.608,
+		"m5.2xlarge":   0.384,
+		"m5.4xlarge":   0.768,
+		"m5.8xlarge":   1.536,
+		"m5.large":     0.096,
+		"m5.xlarge":    0.192,
+		"m6g.2xlarge":  0.308,
+		"m6g.4xlarge":  0.616,
+		"m6g.large":    0.077,
+		"m6g.xlarge":   0.154,
+		"m6i.2xlarge":  0.384,
+		"m6i.4xlarge":  0.768,
+		"m6i.8xlarge":  1.536,
+		"m6i.large":    0.096,
+		"m6i.xlarge":   0.192,
+		"p3.2xlarge":   3.06,
+		"p3.8xlarge":   12.24,
+		"r5.2xlarge":   0.504,
+		"r5.4xlarge":   1.008,
+		"r5.large":     0.126,
+		"r5.xlarge":    0.252,
+		"r6i.2xlarge":  0.504,
+		"r6i.large":    0.126,
+		"r6i.xlarge":   0.252,
+		"t3.2xlarge":   0.3328,
+		"t3.large":     0.0832,
+		"t3.medium":    0.0416,
+		"t3.micro":     0.0104,
+		"t3.small":     0.0208,
+		"t3.xlarge":    0.1664,
+	},
+}
@@ -0,0 +1,102 @@
+//go:build ignore
+// +build ignore
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing/generator"
+	"k8s.io/klog/v2"
+)
+
+const offerURL = "https://pricing.us-east-1.amazonaws.com/offers/v1.0/aws/AmazonEC2/current/%s/index.json"
+
+// defaultRegions are the regions of the committed price list, used if no regions are given.
+const defaultRegions = "eu-west-1,us-east-1,us-west-2"
+
+// offerFile is the subset of the AWS Price List bulk API offer file used by the generator.
+type offerFile struct {
+	Products map[string]struct {
+		ProductFamily string            `json:"productFamily"`
+		Attributes    map[string]string `json:"attributes"`
+	} `json:"products"`
+	Terms struct {
+		OnDemand map[string]map[string]struct {
+			PriceDimensions map[string]struct {
+				Unit         string            `json:"unit"`
+				PricePerUnit map[string]string `json:"pricePerUnit"`
+			} `json:"priceDimensions"`
+		} `json:"OnDemand"`
+	} `json:"terms"`
+}
+
+// The Price List bulk API is public, no credentials are needed to run the generator.
+func main() {
+	var regions = flag.String("regions", "", "comma separated list of aws regions to generate prices for, "+defaultRegions+" if empty.")
+	flag.Parse()
+	if *regions == "" {
+		*regions = defaultRegions
+	}
+
+	defer klog.Flush()
+
+	catalog, err := generator.FetchCatalog(*regions, fetchPrices)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	description := "on-demand prices of Linux EC2 instances with shared tenancy"
+	if err := generator.WriteCatalog("ec2_instance_prices.go", "aws", description, catalog); err != nil {
+		klog.Fatal(err)
+	}
+}
+
+func fetchPrices(region string) (map[string]float64, error) {
+	var offer offerFile
+	if err := generator.GetJSON(fmt.Sprintf(offerURL, region), &offer); err != nil {
+		return nil, err
+	}
+
+	prices := map[string]float64{}
+	for sku, product := range offer.Products {
+		attrs := product.Attributes
+		if product.ProductFamily != "Compute Instance" ||
+			attrs["operatingSystem"] != "Linux" ||
+			attrs["tenancy"] != "Shared" ||
+			attrs["preInstalledSw"] != "NA" ||
+			attrs["capacitystatus"] != "Used" {
+			continue
+		}
+		for _, term := range offer.Terms.OnDemand[sku] {
+			for _, dimension := range term.PriceDimensions {
+				if dimension.Unit != "Hrs" {
+					continue
+				}
+				price, err := strconv.ParseFloat(dimension.PricePerUnit["USD"], 64)
+				if err != nil || price == 0 {
+					continue
+				}
+				prices[attrs["instanceType"]] = price
+			}
+		}
+	}
+	return prices, nil
+}
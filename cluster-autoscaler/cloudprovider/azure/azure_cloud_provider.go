@@ -24,6 +24,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
@@ -48,16 +49,24 @@ var (
 type AzureCloudProvider struct {
 	azureManager    *AzureManager
 	resourceLimiter *cloudprovider.ResourceLimiter
+	pricingModel    cloudprovider.PricingModel
 }
 
 // BuildAzureCloudProvider creates new AzureCloudProvider
 func BuildAzureCloudProvider(azureManager *AzureManager, resourceLimiter *cloudprovider.ResourceLimiter) (cloudprovider.CloudProvider, error) {
-	azure := &AzureCloudProvider{
+	return buildAzureCloudProvider(azureManager, resourceLimiter, nil), nil
+}
+
+func buildAzureCloudProvider(azureManager *AzureManager, resourceLimiter *cloudprovider.ResourceLimiter, spotDiscounts pricing.SpotDiscounts) *AzureCloudProvider {
+	location := ""
+	if azureManager != nil && azureManager.config != nil {
+		location = azureManager.config.Location
+	}
+	return &AzureCloudProvider{
 		azureManager:    azureManager,
 		resourceLimiter: resourceLimiter,
+		pricingModel:    newAzurePriceModel(location, spotDiscounts),
 	}
-
-	return azure, nil
 }
 
 // Cleanup stops the go routine that is handling the current view of the ASGs in the form of a cache
@@ -128,7 +137,7 @@ func (azure *AzureCloudProvider) HasInstance(*apiv1.Node) (bool, error) {
 
 // Pricing returns pricing model for this cloud provider or error if not available.
 func (azure *AzureCloudProvider) Pricing() (cloudprovider.PricingModel, errors.AutoscalerError) {
-	return nil, cloudprovider.ErrNotImplemented
+	return azure.pricingModel, nil
 }
 
 // GetAvailableMachineTypes get all machine types that can be requested from the cloud provider.
@@ -187,9 +196,13 @@ func BuildAzure(opts config.AutoscalingOptions, do cloudprovider.NodeGroupDiscov
 	if err != nil {
 		klog.Fatalf("Failed to create Azure Manager: %v", err)
 	}
-	provider, err := BuildAzureCloudProvider(manager, rl)
-	if err != nil {
-		klog.Fatalf("Failed to create Azure cloud provider: %v", err)
+	var spotDiscounts pricing.SpotDiscounts
+	if opts.SpotPriceDiscountsFile != "" {
+		spotDiscounts, err = pricing.LoadSpotDiscounts(opts.SpotPriceDiscountsFile)
+		if err != nil {
+			klog.Fatalf("Failed to load spot price discounts: %v", err)
+		}
 	}
-	return provider
+	klog.V(1).Infof("Using static Azure price list of %d regions", len(InstancePrices))
+	return buildAzureCloudProvider(manager, rl, spotDiscounts)
 }
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing"
+
+// InstancePrices are pay-as-you-go prices of Linux virtual machines in USD per hour, by region.
+//
+// The list is a hand-maintained subset of common VM sizes in a few regions, other
+// VM sizes are priced by their resources. Run go generate to replace it with the full
+// Azure Retail Prices list of these regions, or of the regions in AZURE_PRICE_REGIONS.
+var InstancePrices = pricing.Catalog{
+	"eastus": {
+		"Standard_B2ms":        0.0832,
+		"Standard_B2s":         0.0416,
+		"Standard_B4ms":        0.166,
+		"Standard_D16s_v3":     0.768,
+		"Standard_D16s_v5":     0.768,
+		"Standard_D2as_v5":     0.086,
+		"Standard_D2s_v3":      0.096,
+		"Standard_D2s_v5":      0.096,
+		"Standard_D4as_v5":     0.172,
+		"Standard_D4s_v3":      0.192,
+		"Standard_D4s_v5":      0.192,
+		"Standard_D8s_v3":      0.384,
+		"Standard_D8s_v5":      0.384,
+		"Standard_E2s_v3":      0.126,
+		"Standard_E2s_v5":      0.126,
+		"Standard_E4s_v3":      0.252,
+		"Standard_E4s_v5":      0.252,
+		"Standard_E8s_v3":      0.504,
+		"Standard_F2s_v2":      0.0846,
+		"Standard_F4s_v2":      0.169,
+		"Standard_F8s_v2":      0.338,
+		"Standard_NC4as_T4_v3": 0.526,
+		"Standard_NC6s_v3":     3.06,
+	},
+	"westus2": {
+		"Standard_B2ms":        0.0832,
+		"Standard_B2s":         0.0416,
+		"Standard_B4ms":        0.166,
+		"Standard_D16s_v3":     0.768,
+		"Standard_D16s_v5":     0.768,
+		"Standard_D2as_v5":     0.086,
+		"Standard_D2s_v3":      0.096,
+		"Standard_D2s_v5":      0.096,
+		"Standard_D4as_v5":     0.172,
+		"Standard_D4s_v3":      0.192,
+		"Standard_D4s_v5":      0.192,
+		"Standard_D8s_v3":      0.384,
+		"Standard_D8s_v5":      0.384,
+		"Standard_E2s_v3":      0.126,
+		"Standard_E2s_v5":      0.126,
+		"Standard_E4s_v3":      0.252,
+		"Standard_E4s_v5":      0.252,
+		"Standard_E8s_v3":      0.504,
+		"Standard_F2s_v2":      0.0846,
+		"Standard_F4s_v2":      0.169,
+		"Standard_F8s_v2":      0.338,
+		"Standard_NC4as_T4_v3": 0.526,
+		"Standard_NC6s_v3":     3.06,
+	},
+}
@@ -0,0 +1,94 @@
+//go:build ignore
+// +build ignore
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing/generator"
+	"k8s.io/klog/v2"
+)
+
+const retailPricesURL = "https://prices.azure.com/api/retail/prices"
+
+// defaultRegions are the regions of the committed price list, used if no regions are given.
+const defaultRegions = "eastus,westus2"
+
+// retailPricesPage is the subset of the Azure Retail Prices API response used by the generator.
+type retailPricesPage struct {
+	Items []struct {
+		ArmSkuName    string  `json:"armSkuName"`
+		RetailPrice   float64 `json:"retailPrice"`
+		UnitOfMeasure string  `json:"unitOfMeasure"`
+		ProductName   string  `json:"productName"`
+		SkuName       string  `json:"skuName"`
+		CurrencyCode  string  `json:"currencyCode"`
+	} `json:"Items"`
+	NextPageLink string `json:"NextPageLink"`
+}
+
+// The Retail Prices API is public, no credentials are needed to run the generator.
+func main() {
+	var regions = flag.String("regions", "", "comma separated list of azure regions (arm region names) to generate prices for, "+defaultRegions+" if empty.")
+	flag.Parse()
+	if *regions == "" {
+		*regions = defaultRegions
+	}
+
+	defer klog.Flush()
+
+	catalog, err := generator.FetchCatalog(*regions, fetchPrices)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	description := "pay-as-you-go prices of Linux virtual machines"
+	if err := generator.WriteCatalog("azure_instance_prices.go", "azure", description, catalog); err != nil {
+		klog.Fatal(err)
+	}
+}
+
+func fetchPrices(region string) (map[string]float64, error) {
+	filter := fmt.Sprintf("serviceName eq 'Virtual Machines' and priceType eq 'Consumption' and armRegionName eq '%s'", region)
+	next := retailPricesURL + "?$filter=" + url.QueryEscape(filter)
+
+	prices := map[string]float64{}
+	for next != "" {
+		var page retailPricesPage
+		if err := generator.GetJSON(next, &page); err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			if item.CurrencyCode != "USD" ||
+				item.UnitOfMeasure != "1 Hour" ||
+				item.RetailPrice == 0 ||
+				strings.Contains(item.ProductName, "Windows") ||
+				strings.Contains(item.SkuName, "Spot") ||
+				strings.Contains(item.SkuName, "Low Priority") {
+				continue
+			}
+			prices[item.ArmSkuName] = item.RetailPrice
+		}
+		next = page.NextPageLink
+	}
+	return prices, nil
+}
@@ -15,6 +15,7 @@ limitations under the License.
 */
 
 //go:generate go run azure_instance_types/gen.go
+//go:generate go run azure_instance_prices/gen.go -regions=$AZURE_PRICE_REGIONS
 
 package azure
 
@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing"
+)
+
+const (
+	// scaleSetPriorityLabel is set by AKS on nodes of spot node pools.
+	scaleSetPriorityLabel = "kubernetes.azure.com/scalesetpriority"
+	spotPriority          = "spot"
+)
+
+// newAzurePriceModel creates a price model backed by the static InstancePrices catalog.
+func newAzurePriceModel(location string, spotDiscounts pricing.SpotDiscounts) *pricing.StaticPriceModel {
+	return pricing.NewStaticPriceModel(InstancePrices, strings.ToLower(location), spotDiscounts, skuFamily, isSpotNode)
+}
+
+// skuFamily returns the family of a VM size, as listed in InstanceTypes.
+func skuFamily(sku string) string {
+	if instanceType, found := InstanceTypes[sku]; found {
+		return instanceType.SkuFamily
+	}
+	return ""
+}
+
+func isSpotNode(node *apiv1.Node) bool {
+	return strings.EqualFold(node.Labels[scaleSetPriorityLabel], spotPriority)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestAzurePriceModel(t *testing.T) {
+	model := newAzurePriceModel("EastUS", pricing.SpotDiscounts{"default": 0.5})
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	onDemand := BuildTestNode("n1", 2000, 8*1024*1024*1024)
+	onDemand.Labels = map[string]string{apiv1.LabelInstanceTypeStable: "Standard_D2s_v3"}
+	price, err := model.NodePrice(onDemand, start, end)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.096, price, 1e-9)
+
+	spot := onDemand.DeepCopy()
+	spot.Labels[scaleSetPriorityLabel] = spotPriority
+	price, err = model.NodePrice(spot, start, end)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.048, price, 1e-9)
+}
+
+func TestBuildGenericLabelsSpot(t *testing.T) {
+	template := compute.VirtualMachineScaleSet{
+		Location: to.StringPtr("eastus"),
+		Sku:      &compute.Sku{Name: to.StringPtr("Standard_D2s_v3")},
+		VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+			VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{Priority: compute.Spot},
+		},
+	}
+	assert.Equal(t, spotPriority, buildGenericLabels(template, "node")[scaleSetPriorityLabel])
+
+	template.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.Regular
+	assert.NotContains(t, buildGenericLabels(template, "node"), scaleSetPriorityLabel)
+}
@@ -69,6 +69,10 @@ func buildGenericLabels(template compute.VirtualMachineScaleSet, nodeName string
 		result[azureDiskTopologyKey] = ""
 	}
 
+	if isSpot(&template) {
+		result[scaleSetPriorityLabel] = spotPriority
+	}
+
 	result[apiv1.LabelHostname] = nodeName
 	return result
 }
@@ -97,6 +97,9 @@ type GpuConfig struct {
 	Label        string
 	Type         string
 	ResourceName apiv1.ResourceName
+	// PartitionResources are the names of resources the GPUs are exposed as when they are partitioned,
+	// e.g. MIG profiles or time-sliced GPUs. Empty if the GPUs aren't partitioned.
+	PartitionResources []apiv1.ResourceName
 }
 
 // CloudProvider contains configuration info and functions for interacting with
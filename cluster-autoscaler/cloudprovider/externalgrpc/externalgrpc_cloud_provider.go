@@ -38,6 +38,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 	klog "k8s.io/klog/v2"
 	"sigs.k8s.io/yaml"
 )
@@ -51,7 +52,14 @@ type externalGrpcCloudProvider struct {
 	resourceLimiter *cloudprovider.ResourceLimiter
 	client          protos.CloudProviderClient
 	grpcTimeout     time.Duration
+	// ctx is the parent of the gRPC calls, used to propagate the trace to the server.
+	ctx context.Context
 
+	// caches are shared with the copies returned by WithContext.
+	*providerCache
+}
+
+type providerCache struct {
 	mutex                 sync.Mutex
 	nodeGroupForNodeCache map[string]cloudprovider.NodeGroup // used to cache NodeGroupForNode grpc calls. Discarded at each Refresh()
 	nodeGroupsCache       []cloudprovider.NodeGroup          // used to cache NodeGroups grpc calls. Discarded at each Refresh()
@@ -59,6 +67,17 @@ type externalGrpcCloudProvider struct {
 	gpuTypesCache         map[string]struct{}                // used to cache GetAvailableGPUTypes grpc calls
 }
 
+// WithContext returns a copy of the cloud provider issuing gRPC calls with ctx as their parent.
+func (e *externalGrpcCloudProvider) WithContext(ctx context.Context) cloudprovider.CloudProvider {
+	return &externalGrpcCloudProvider{
+		resourceLimiter: e.resourceLimiter,
+		client:          e.client,
+		grpcTimeout:     e.grpcTimeout,
+		ctx:             ctx,
+		providerCache:   e.providerCache,
+	}
+}
+
 // Name returns name of the cloud provider.
 func (e *externalGrpcCloudProvider) Name() string {
 	return cloudprovider.ExternalGrpcProviderName
@@ -74,7 +93,7 @@ func (e *externalGrpcCloudProvider) NodeGroups() []cloudprovider.NodeGroup {
 		return e.nodeGroupsCache
 	}
 	nodeGroups := make([]cloudprovider.NodeGroup, 0)
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := context.WithTimeout(parentContext(e.ctx), e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call NodeGroups")
 	res, err := e.client.NodeGroups(ctx, &protos.NodeGroupsRequest{})
@@ -114,7 +133,7 @@ func (e *externalGrpcCloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudpro
 		return ng, nil
 	}
 	// perform grpc call
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := context.WithTimeout(parentContext(e.ctx), e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call NodeGroupForNode for node %v - %v", node.Name, node.Spec.ProviderID)
 	res, err := e.client.NodeGroupForNode(ctx, &protos.NodeGroupForNodeRequest{
@@ -149,11 +168,12 @@ func (e *externalGrpcCloudProvider) HasInstance(node *apiv1.Node) (bool, error)
 type pricingModel struct {
 	client      protos.CloudProviderClient
 	grpcTimeout time.Duration
+	ctx         context.Context
 }
 
 // NodePrice returns a price of running the given node for a given period of time.
 func (m *pricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), m.grpcTimeout)
+	ctx, cancel := context.WithTimeout(parentContext(m.ctx), m.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call PricingNodePrice for node %v", node.Name)
 	start := metav1.NewTime(startTime)
@@ -177,7 +197,7 @@ func (m *pricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime
 // PodPrice returns a theoretical minimum price of running a pod for a given
 // period of time on a perfectly matching machine.
 func (m *pricingModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), m.grpcTimeout)
+	ctx, cancel := context.WithTimeout(parentContext(m.ctx), m.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call PricingPodPrice for pod %v", pod.Name)
 	start := metav1.NewTime(startTime)
@@ -208,6 +228,7 @@ func (e *externalGrpcCloudProvider) Pricing() (cloudprovider.PricingModel, error
 	return &pricingModel{
 		client:      e.client,
 		grpcTimeout: e.grpcTimeout,
+		ctx:         e.ctx,
 	}, nil
 }
 
@@ -239,7 +260,7 @@ func (e *externalGrpcCloudProvider) GPULabel() string {
 		klog.V(5).Info("Returning cached GPULabel")
 		return *e.gpuLabelCache
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := context.WithTimeout(parentContext(e.ctx), e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call GPULabel")
 	res, err := e.client.GPULabel(ctx, &protos.GPULabelRequest{})
@@ -261,7 +282,7 @@ func (e *externalGrpcCloudProvider) GetAvailableGPUTypes() map[string]struct{} {
 		klog.V(5).Info("Returning cached GetAvailableGPUTypes")
 		return e.gpuTypesCache
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := context.WithTimeout(parentContext(e.ctx), e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call GetAvailableGPUTypes")
 	res, err := e.client.GetAvailableGPUTypes(ctx, &protos.GetAvailableGPUTypesRequest{})
@@ -286,7 +307,7 @@ func (e *externalGrpcCloudProvider) GetNodeGpuConfig(node *apiv1.Node) *cloudpro
 
 // Cleanup cleans up open resources before the cloud provider is destroyed, i.e. go routines etc.
 func (e *externalGrpcCloudProvider) Cleanup() error {
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := context.WithTimeout(parentContext(e.ctx), e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call Cleanup")
 	_, err := e.client.Cleanup(ctx, &protos.CleanupRequest{})
@@ -305,7 +326,7 @@ func (e *externalGrpcCloudProvider) Refresh() error {
 	e.nodeGroupForNodeCache = make(map[string]cloudprovider.NodeGroup)
 	e.nodeGroupsCache = nil
 	e.mutex.Unlock()
-	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
+	ctx, cancel := context.WithTimeout(parentContext(e.ctx), e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call Refresh")
 	_, err := e.client.Refresh(ctx, &protos.RefreshRequest{})
@@ -389,7 +410,7 @@ func newExternalGrpcCloudProviderClient(config []byte) (protos.CloudProviderClie
 		})
 		dialOpt = grpc.WithTransportCredentials(transportCreds)
 	}
-	conn, err := grpc.Dial(yamlConfig.Address, dialOpt)
+	conn, err := grpc.Dial(yamlConfig.Address, dialOpt, tracing.GRPCDialOption())
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to dial server: %v", err)
 	}
@@ -404,11 +425,22 @@ func newExternalGrpcCloudProviderClient(config []byte) (protos.CloudProviderClie
 
 func newExternalGrpcCloudProvider(client protos.CloudProviderClient, grpcTimeout time.Duration, rl *cloudprovider.ResourceLimiter) cloudprovider.CloudProvider {
 	return &externalGrpcCloudProvider{
-		resourceLimiter:       rl,
-		client:                client,
-		grpcTimeout:           grpcTimeout,
-		nodeGroupForNodeCache: make(map[string]cloudprovider.NodeGroup),
+		resourceLimiter: rl,
+		client:          client,
+		grpcTimeout:     grpcTimeout,
+		providerCache: &providerCache{
+			nodeGroupForNodeCache: make(map[string]cloudprovider.NodeGroup),
+		},
+	}
+}
+
+// parentContext returns the parent for a gRPC call, falling back to the background context
+// if the caller didn't bind one.
+func parentContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
 	}
+	return ctx
 }
 
 // externalGrpcNode converts an apiv1.Node to a protos.ExternalGrpcNode.
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package generator contains the code shared by the go generate programs
+// which build static price catalogs from public cloud provider price lists.
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing"
+	klog "k8s.io/klog/v2"
+)
+
+var catalogTemplate = template.Must(template.New("").Parse(`/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by go generate; DO NOT EDIT
+
+package {{ .Package }}
+
+import "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing"
+
+// InstancePrices are {{ .Description }} in USD per hour, by region.
+var InstancePrices = pricing.Catalog{
+{{- range $region, $prices := .Prices }}
+	"{{ $region }}": {
+{{- range $instanceType, $price := $prices }}
+		"{{ $instanceType }}": {{ $price }},
+{{- end }}
+	},
+{{- end }}
+}
+`))
+
+// FetchCatalog fetches the prices of every region in the comma separated list
+// of regions.
+func FetchCatalog(regions string, fetchPrices func(region string) (map[string]float64, error)) (pricing.Catalog, error) {
+	if regions == "" {
+		return nil, fmt.Errorf("at least one region is required to generate prices")
+	}
+	catalog := pricing.Catalog{}
+	for _, region := range strings.Split(regions, ",") {
+		region = strings.TrimSpace(region)
+		prices, err := fetchPrices(region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch prices for region %s: %v", region, err)
+		}
+		klog.Infof("Fetched prices of %d instance types in %s", len(prices), region)
+		catalog[region] = prices
+	}
+	return catalog, nil
+}
+
+// GetJSON decodes the JSON response to a GET request to url into v.
+func GetJSON(url string, v interface{}) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// RenderCatalog returns the formatted source of a file declaring catalog as
+// InstancePrices in the given package. description documents what the prices are.
+func RenderCatalog(packageName, description string, catalog pricing.Catalog) ([]byte, error) {
+	var buf bytes.Buffer
+	err := catalogTemplate.Execute(&buf, struct {
+		Package     string
+		Description string
+		Prices      pricing.Catalog
+	}{
+		Package:     packageName,
+		Description: description,
+		Prices:      catalog,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// WriteCatalog renders catalog with RenderCatalog and writes it to path.
+func WriteCatalog(path, packageName, description string, catalog pricing.Catalog) error {
+	source, err := RenderCatalog(packageName, description, catalog)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, source, 0644)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/pricing"
+)
+
+func TestFetchCatalog(t *testing.T) {
+	fetchPrices := func(region string) (map[string]float64, error) {
+		if region == "broken" {
+			return nil, fmt.Errorf("unavailable")
+		}
+		return map[string]float64{"small-" + region: 0.1}, nil
+	}
+
+	catalog, err := FetchCatalog("a, b", fetchPrices)
+	assert.NoError(t, err)
+	assert.Equal(t, pricing.Catalog{"a": {"small-a": 0.1}, "b": {"small-b": 0.1}}, catalog)
+
+	_, err = FetchCatalog("a,broken", fetchPrices)
+	assert.Error(t, err)
+
+	_, err = FetchCatalog("", fetchPrices)
+	assert.Error(t, err)
+}
+
+func TestRenderCatalog(t *testing.T) {
+	source, err := RenderCatalog("aws", "on-demand prices of test instances", pricing.Catalog{
+		"us-east-1": {"m5.large": 0.096, "c5.large": 0.085},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), "package aws\n")
+	assert.Contains(t, string(source), "// InstancePrices are on-demand prices of test instances in USD per hour, by region.\n")
+	assert.Contains(t, string(source), "\t\"us-east-1\": {\n\t\t\"c5.large\": 0.085,\n\t\t\"m5.large\": 0.096,\n\t},\n")
+}
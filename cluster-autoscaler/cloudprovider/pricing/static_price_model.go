@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pricing implements cloudprovider.PricingModel on top of a static,
+// generated price catalog, for cloud providers without a pricing API that
+// can be queried in the autoscaling loop.
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// DefaultSpotDiscountKey is the key of the discount used for instance types
+	// and families without a more specific entry.
+	DefaultSpotDiscountKey = "default"
+
+	// Prices used for instance types missing in the catalog and for pods.
+	cpuPricePerHour         = 0.033174
+	memoryPricePerHourPerGb = 0.004446
+)
+
+// Catalog maps a region to the on-demand prices of instance types in USD per hour.
+type Catalog map[string]map[string]float64
+
+// SpotDiscounts maps an instance type, an instance family or DefaultSpotDiscountKey
+// to the discount of spot instances relative to the on-demand price, e.g. 0.7
+// means spot instances cost 30% of the on-demand price.
+type SpotDiscounts map[string]float64
+
+// LoadSpotDiscounts reads SpotDiscounts from a YAML or JSON file.
+func LoadSpotDiscounts(path string) (SpotDiscounts, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	discounts := SpotDiscounts{}
+	if err := yaml.Unmarshal(content, &discounts); err != nil {
+		return nil, fmt.Errorf("failed to parse spot discounts file %s: %v", path, err)
+	}
+	for key, discount := range discounts {
+		if discount < 0 || discount >= 1 {
+			return nil, fmt.Errorf("spot discount for %s must be in [0, 1), got %v", key, discount)
+		}
+	}
+	return discounts, nil
+}
+
+// Discount returns the discount of the most specific entry matching the
+// instance type, 0 if there's none.
+func (d SpotDiscounts) Discount(instanceType, instanceFamily string) float64 {
+	for _, key := range []string{instanceType, instanceFamily, DefaultSpotDiscountKey} {
+		if discount, found := d[key]; found && key != "" {
+			return discount
+		}
+	}
+	return 0
+}
+
+// StaticPriceModel implements cloudprovider.PricingModel using a Catalog.
+type StaticPriceModel struct {
+	catalog        Catalog
+	defaultRegion  string
+	spotDiscounts  SpotDiscounts
+	instanceFamily func(instanceType string) string
+	isSpot         func(node *apiv1.Node) bool
+
+	// missingInstanceTypes holds the instance types already reported as
+	// missing in the catalog, so that each is logged only once.
+	missingInstanceTypes sync.Map
+}
+
+// NewStaticPriceModel creates a StaticPriceModel. Nodes without the region label,
+// or in a region missing from the catalog, are priced as if they were in defaultRegion. instanceFamily maps instance types
+// to families for spot discount lookups, isSpot tells if a node is a spot instance.
+func NewStaticPriceModel(catalog Catalog, defaultRegion string, spotDiscounts SpotDiscounts, instanceFamily func(string) string, isSpot func(*apiv1.Node) bool) *StaticPriceModel {
+	return &StaticPriceModel{
+		catalog:        catalog,
+		defaultRegion:  defaultRegion,
+		spotDiscounts:  spotDiscounts,
+		instanceFamily: instanceFamily,
+		isSpot:         isSpot,
+	}
+}
+
+// NodePrice returns a price of running the given node for a given period of time.
+// Instance types missing in the catalog are priced by their cpu and memory capacity.
+// All prices are in USD.
+func (model *StaticPriceModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	instanceType := node.Labels[apiv1.LabelInstanceTypeStable]
+	pricePerHour, found := model.instancePrice(node.Labels[apiv1.LabelTopologyRegion], instanceType)
+	if !found {
+		if _, reported := model.missingInstanceTypes.LoadOrStore(instanceType, true); !reported {
+			klog.Warningf("Pricing information not found for instance type %q; will fallback to default pricing", instanceType)
+		}
+		pricePerHour = resourcePrice(node.Status.Capacity)
+	}
+	if model.isSpot(node) {
+		pricePerHour *= 1 - model.spotDiscounts.Discount(instanceType, model.instanceFamily(instanceType))
+	}
+	return pricePerHour * getHours(startTime, endTime), nil
+}
+
+// PodPrice returns a theoretical minimum price of running a pod for a given
+// period of time on a perfectly matching machine.
+func (model *StaticPriceModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	price := 0.0
+	for _, container := range pod.Spec.Containers {
+		price += resourcePrice(container.Resources.Requests) * getHours(startTime, endTime)
+	}
+	return price, nil
+}
+
+func (model *StaticPriceModel) instancePrice(region, instanceType string) (float64, bool) {
+	if instanceType == "" {
+		return 0, false
+	}
+	for _, r := range []string{region, model.defaultRegion} {
+		if price, found := model.catalog[r][instanceType]; found {
+			return price, true
+		}
+	}
+	return 0, false
+}
+
+func resourcePrice(resources apiv1.ResourceList) float64 {
+	cpu := resources[apiv1.ResourceCPU]
+	mem := resources[apiv1.ResourceMemory]
+	return float64(cpu.MilliValue())/1000.0*cpuPricePerHour + float64(mem.Value())/float64(units.GiB)*memoryPricePerHourPerGb
+}
+
+func getHours(startTime time.Time, endTime time.Time) float64 {
+	minutes := math.Ceil(float64(endTime.Sub(startTime)) / float64(time.Minute))
+	return minutes / 60.0
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
+)
+
+func TestStaticPriceModel(t *testing.T) {
+	catalog := Catalog{
+		"region-a": {"m.large": 0.1, "m.xlarge": 0.2},
+		"region-b": {"m.large": 0.12},
+	}
+	discounts := SpotDiscounts{"m": 0.5, "m.xlarge": 0.75, DefaultSpotDiscountKey: 0.6}
+	family := func(instanceType string) string { return strings.Split(instanceType, ".")[0] }
+	isSpot := func(node *apiv1.Node) bool { return node.Labels["spot"] == "true" }
+	model := NewStaticPriceModel(catalog, "region-a", discounts, family, isSpot)
+
+	now := time.Now()
+	for tn, tc := range map[string]struct {
+		labels map[string]string
+		want   float64
+	}{
+		"catalog price": {
+			labels: map[string]string{apiv1.LabelInstanceTypeStable: "m.large", apiv1.LabelTopologyRegion: "region-b"},
+			want:   0.24,
+		},
+		"unknown region uses default region": {
+			labels: map[string]string{apiv1.LabelInstanceTypeStable: "m.xlarge", apiv1.LabelTopologyRegion: "region-c"},
+			want:   0.4,
+		},
+		"spot discount by family": {
+			labels: map[string]string{apiv1.LabelInstanceTypeStable: "m.large", "spot": "true"},
+			want:   0.1,
+		},
+		"spot discount by instance type": {
+			labels: map[string]string{apiv1.LabelInstanceTypeStable: "m.xlarge", "spot": "true"},
+			want:   0.1,
+		},
+		"unknown instance type is priced by capacity": {
+			labels: map[string]string{apiv1.LabelInstanceTypeStable: "x.large"},
+			want:   2 * (2*cpuPricePerHour + 4*memoryPricePerHourPerGb),
+		},
+		"unknown instance type with spot default discount": {
+			labels: map[string]string{apiv1.LabelInstanceTypeStable: "x.large", "spot": "true"},
+			want:   0.4 * 2 * (2*cpuPricePerHour + 4*memoryPricePerHourPerGb),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			node := BuildTestNode("n1", 2000, 4*units.GiB)
+			node.Labels = tc.labels
+			price, err := model.NodePrice(node, now, now.Add(2*time.Hour))
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.want, price, 1e-9)
+		})
+	}
+
+	pod := BuildTestPod("p1", 1000, units.GiB)
+	price, err := model.PodPrice(pod, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.InDelta(t, cpuPricePerHour+memoryPricePerHourPerGb, price, 1e-9)
+}
+
+func TestLoadSpotDiscounts(t *testing.T) {
+	dir := t.TempDir()
+	for tn, tc := range map[string]struct {
+		content string
+		want    SpotDiscounts
+		wantErr bool
+	}{
+		"yaml": {
+			content: "default: 0.6\nm5: 0.7\nm5.large: 0.65\n",
+			want:    SpotDiscounts{"default": 0.6, "m5": 0.7, "m5.large": 0.65},
+		},
+		"json": {
+			content: `{"Standard_D2s_v3": 0.8}`,
+			want:    SpotDiscounts{"Standard_D2s_v3": 0.8},
+		},
+		"discount out of range": {
+			content: "default: 1.5\n",
+			wantErr: true,
+		},
+		"malformed": {
+			content: "default: [",
+			wantErr: true,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tn, " ", "-"))
+			assert.NoError(t, os.WriteFile(path, []byte(tc.content), 0644))
+			got, err := LoadSpotDiscounts(path)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
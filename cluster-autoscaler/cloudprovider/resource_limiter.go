@@ -18,15 +18,99 @@ package cloudprovider
 
 import (
 	"fmt"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"math"
+	"regexp"
 	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 )
 
+// nodeGroupSetLimitKeyPrefix prefixes keys of node group set limits, distinguishing them from
+// cluster-wide resource names.
+const nodeGroupSetLimitKeyPrefix = "nodegroupset/"
+
 // ResourceLimiter contains limits (max, min) for resources (cores, memory etc.).
 type ResourceLimiter struct {
-	minLimits map[string]int64
-	maxLimits map[string]int64
+	minLimits          map[string]int64
+	maxLimits          map[string]int64
+	nodeGroupSetLimits []NodeGroupSetLimit
+}
+
+// NodeGroupSetLimit contains limits (max, min) for a resource summed over a set of node groups.
+type NodeGroupSetLimit struct {
+	// Name of the set.
+	Name string
+	// Resource is the limited resource, using the same names as cluster-wide limits.
+	Resource string
+	// Min is the lower bound on the resource in the set, 0 means no limit.
+	Min int64
+	// Max is the upper bound on the resource in the set, 0 means no limit.
+	Max int64
+
+	nodeGroupIdRegex *regexp.Regexp
+	labelSelector    labels.Selector
+}
+
+// NewNodeGroupSetLimit creates new NodeGroupSetLimit from its configuration.
+func NewNodeGroupSetLimit(limits config.NodeGroupSetResourceLimits) (NodeGroupSetLimit, error) {
+	if limits.Name == "" || strings.Contains(limits.Name, "/") {
+		return NodeGroupSetLimit{}, fmt.Errorf("node group set name %q must be non-empty and can't contain '/'", limits.Name)
+	}
+	if limits.Resource == "" {
+		return NodeGroupSetLimit{}, fmt.Errorf("resource of node group set %s is empty", limits.Name)
+	}
+	if limits.Min < 0 || limits.Max < 0 || (limits.Max > 0 && limits.Min > limits.Max) {
+		return NodeGroupSetLimit{}, fmt.Errorf("invalid %s limits %d:%d of node group set %s", limits.Resource, limits.Min, limits.Max, limits.Name)
+	}
+	result := NodeGroupSetLimit{
+		Name:     limits.Name,
+		Resource: limits.Resource,
+		Min:      limits.Min,
+		Max:      limits.Max,
+	}
+	if limits.NodeGroupIdRegex != "" {
+		re, err := regexp.Compile("^(?:" + limits.NodeGroupIdRegex + ")$")
+		if err != nil {
+			return NodeGroupSetLimit{}, fmt.Errorf("invalid node group ID regex of node group set %s: %v", limits.Name, err)
+		}
+		result.nodeGroupIdRegex = re
+	}
+	if limits.LabelSelector != "" {
+		selector, err := labels.Parse(limits.LabelSelector)
+		if err != nil {
+			return NodeGroupSetLimit{}, fmt.Errorf("invalid label selector of node group set %s: %v", limits.Name, err)
+		}
+		result.labelSelector = selector
+	}
+	return result, nil
+}
+
+// Matches returns true iff a node group with the given ID and node labels belongs to the set.
+func (l *NodeGroupSetLimit) Matches(nodeGroupId string, nodeLabels map[string]string) bool {
+	if l.nodeGroupIdRegex != nil && !l.nodeGroupIdRegex.MatchString(nodeGroupId) {
+		return false
+	}
+	return l.labelSelector == nil || l.labelSelector.Matches(labels.Set(nodeLabels))
+}
+
+// Key returns the name under which the limit is tracked next to cluster-wide resource limits.
+func (l *NodeGroupSetLimit) Key() string {
+	return nodeGroupSetLimitKeyPrefix + l.Name + "/" + l.Resource
+}
+
+// ParseNodeGroupSetLimitKey returns the set name and resource of a NodeGroupSetLimit key. The last
+// return value is false if the key doesn't belong to a node group set limit.
+func ParseNodeGroupSetLimitKey(key string) (string, string, bool) {
+	if !strings.HasPrefix(key, nodeGroupSetLimitKeyPrefix) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(key, nodeGroupSetLimitKeyPrefix), "/", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
 }
 
 // NewResourceLimiter creates new ResourceLimiter for map. Maps are deep copied.
@@ -41,7 +125,40 @@ func NewResourceLimiter(minLimits map[string]int64, maxLimits map[string]int64)
 	for key, value := range maxLimits {
 		maxLimitsCopy[key] = value
 	}
-	return &ResourceLimiter{minLimitsCopy, maxLimitsCopy}
+	return &ResourceLimiter{minLimits: minLimitsCopy, maxLimits: maxLimitsCopy}
+}
+
+// WithNodeGroupSetLimits returns a copy of the ResourceLimiter also enforcing the given node group set limits.
+func (r *ResourceLimiter) WithNodeGroupSetLimits(limits []NodeGroupSetLimit) *ResourceLimiter {
+	result := NewResourceLimiter(r.minLimits, r.maxLimits)
+	result.nodeGroupSetLimits = append([]NodeGroupSetLimit{}, limits...)
+	return result
+}
+
+// GetNodeGroupSetLimits returns limits of resources in sets of node groups.
+func (r *ResourceLimiter) GetNodeGroupSetLimits() []NodeGroupSetLimit {
+	return r.nodeGroupSetLimits
+}
+
+// GetNodeGroupSetLimitsFor returns limits of node group sets the node group with the given ID and node labels belongs to.
+func (r *ResourceLimiter) GetNodeGroupSetLimitsFor(nodeGroupId string, nodeLabels map[string]string) []NodeGroupSetLimit {
+	var result []NodeGroupSetLimit
+	for _, limit := range r.nodeGroupSetLimits {
+		if limit.Matches(nodeGroupId, nodeLabels) {
+			result = append(result, limit)
+		}
+	}
+	return result
+}
+
+// GetNodeGroupSetLimit returns the node group set limit with the given key.
+func (r *ResourceLimiter) GetNodeGroupSetLimit(key string) (NodeGroupSetLimit, bool) {
+	for _, limit := range r.nodeGroupSetLimits {
+		if limit.Key() == key {
+			return limit, true
+		}
+	}
+	return NodeGroupSetLimit{}, false
 }
 
 // GetMin returns minimal number of resources for a given resource type.
@@ -86,5 +203,8 @@ func (r *ResourceLimiter) String() string {
 	for _, name := range r.GetResources() {
 		resourceDetails = append(resourceDetails, fmt.Sprintf("{%s : %d - %d}", name, r.GetMin(name), r.GetMax(name)))
 	}
+	for _, limit := range r.nodeGroupSetLimits {
+		resourceDetails = append(resourceDetails, fmt.Sprintf("{%s : %d - %d}", limit.Key(), limit.Min, limit.Max))
+	}
 	return strings.Join(resourceDetails, ", ")
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 )
 
 func TestResourceLimiterGetResources(t *testing.T) {
@@ -46,3 +47,71 @@ func TestResourceLimiterHasMaxLimitSet(t *testing.T) {
 	assert.True(t, limiter.HasMaxLimitSet("c"), "expected HasMaxLimitSet to return true for c")
 	assert.False(t, limiter.HasMaxLimitSet("d"), "expected HasMaxLimitSet to return false for d")
 }
+
+func TestNewNodeGroupSetLimit(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		limits  config.NodeGroupSetResourceLimits
+		wantErr bool
+	}{
+		"valid": {
+			limits: config.NodeGroupSetResourceLimits{Name: "a100", NodeGroupIdRegex: ".*-a100-.*", LabelSelector: "team=x", Resource: "nvidia-a100", Max: 512},
+		},
+		"min only": {
+			limits: config.NodeGroupSetResourceLimits{Name: "a100", Resource: ResourceNameCores, Min: 10},
+		},
+		"empty name": {
+			limits:  config.NodeGroupSetResourceLimits{Resource: ResourceNameCores, Max: 10},
+			wantErr: true,
+		},
+		"name with slash": {
+			limits:  config.NodeGroupSetResourceLimits{Name: "a/b", Resource: ResourceNameCores, Max: 10},
+			wantErr: true,
+		},
+		"empty resource": {
+			limits:  config.NodeGroupSetResourceLimits{Name: "a100", Max: 10},
+			wantErr: true,
+		},
+		"min greater than max": {
+			limits:  config.NodeGroupSetResourceLimits{Name: "a100", Resource: ResourceNameCores, Min: 20, Max: 10},
+			wantErr: true,
+		},
+		"invalid regex": {
+			limits:  config.NodeGroupSetResourceLimits{Name: "a100", NodeGroupIdRegex: "(", Resource: ResourceNameCores, Max: 10},
+			wantErr: true,
+		},
+		"invalid label selector": {
+			limits:  config.NodeGroupSetResourceLimits{Name: "a100", LabelSelector: "team in (", Resource: ResourceNameCores, Max: 10},
+			wantErr: true,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			_, err := NewNodeGroupSetLimit(tc.limits)
+			assert.Equal(t, tc.wantErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestNodeGroupSetLimitMatches(t *testing.T) {
+	limit, err := NewNodeGroupSetLimit(config.NodeGroupSetResourceLimits{Name: "a100", NodeGroupIdRegex: "pool-a100-.*", LabelSelector: "team=x", Resource: "nvidia-a100", Max: 512})
+	assert.NoError(t, err)
+	assert.True(t, limit.Matches("pool-a100-1", map[string]string{"team": "x"}))
+	assert.False(t, limit.Matches("pool-a100-1", map[string]string{"team": "y"}))
+	assert.False(t, limit.Matches("other-pool-a100-1", map[string]string{"team": "x"}), "regex should match the whole ID")
+
+	all, err := NewNodeGroupSetLimit(config.NodeGroupSetResourceLimits{Name: "all", Resource: ResourceNameCores, Max: 512})
+	assert.NoError(t, err)
+	assert.True(t, all.Matches("any", nil))
+
+	limiter := NewResourceLimiter(nil, nil).WithNodeGroupSetLimits([]NodeGroupSetLimit{limit, all})
+	assert.Equal(t, []NodeGroupSetLimit{all}, limiter.GetNodeGroupSetLimitsFor("other", nil))
+	found, ok := limiter.GetNodeGroupSetLimit(limit.Key())
+	assert.True(t, ok)
+	assert.Equal(t, limit, found)
+
+	name, resource, ok := ParseNodeGroupSetLimitKey(limit.Key())
+	assert.True(t, ok)
+	assert.Equal(t, "a100", name)
+	assert.Equal(t, "nvidia-a100", resource)
+	_, _, ok = ParseNodeGroupSetLimitKey(ResourceNameCores)
+	assert.False(t, ok)
+}
@@ -201,6 +201,11 @@ func (csr *ClusterStateRegistry) MaxNodeProvisionTime(nodeGroup cloudprovider.No
 	return csr.nodeGroupConfigProcessor.GetMaxNodeProvisionTime(nodeGroup)
 }
 
+// NodeGroupSizeRange returns min and max size that should be used for the given NodeGroup.
+func (csr *ClusterStateRegistry) NodeGroupSizeRange(nodeGroup cloudprovider.NodeGroup) (int, int) {
+	return nodegroupconfig.GetSizeRange(csr.nodeGroupConfigProcessor, nodeGroup)
+}
+
 func (csr *ClusterStateRegistry) registerOrUpdateScaleUpNoLock(nodeGroup cloudprovider.NodeGroup, delta int, currentTime time.Time) {
 	maxNodeProvisionTime, err := csr.MaxNodeProvisionTime(nodeGroup)
 	if err != nil {
@@ -783,8 +788,9 @@ func (csr *ClusterStateRegistry) GetStatus(now time.Time) *api.ClusterAutoscaler
 		nodeGroupLastStatus := nodeGroupsLastStatus[nodeGroup.Id()]
 
 		// Health.
+		minSize, maxSize := csr.NodeGroupSizeRange(nodeGroup)
 		nodeGroupStatus.Health = buildHealthStatusNodeGroup(
-			csr.IsNodeGroupHealthy(nodeGroup.Id()), readiness, acceptable, nodeGroup.MinSize(), nodeGroup.MaxSize(), nodeGroupLastStatus.Health)
+			csr.IsNodeGroupHealthy(nodeGroup.Id()), readiness, acceptable, minSize, maxSize, nodeGroupLastStatus.Health)
 
 		// Scale up.
 		nodeGroupStatus.ScaleUp = csr.buildScaleUpStatusNodeGroup(
@@ -1266,6 +1272,33 @@ func (csr *ClusterStateRegistry) GetScaleUpFailures() map[string][]ScaleUpFailur
 	return result
 }
 
+// RemoveBackoff clears backoff of the given node group, e.g. after the cause of failed scale-ups was fixed.
+func (csr *ClusterStateRegistry) RemoveBackoff(nodeGroup cloudprovider.NodeGroup) {
+	csr.Lock()
+	defer csr.Unlock()
+	csr.backoff.RemoveBackoff(nodeGroup, nil)
+}
+
+// BackoffEntries returns the state of node group backoff, or nil if the backoff
+// implementation doesn't support saving it.
+func (csr *ClusterStateRegistry) BackoffEntries() map[string]backoff.Entry {
+	csr.Lock()
+	defer csr.Unlock()
+	if persistent, ok := csr.backoff.(backoff.Persistent); ok {
+		return persistent.Entries()
+	}
+	return nil
+}
+
+// RestoreBackoffEntries replaces the state of node group backoff with previously saved entries.
+func (csr *ClusterStateRegistry) RestoreBackoffEntries(entries map[string]backoff.Entry) {
+	csr.Lock()
+	defer csr.Unlock()
+	if persistent, ok := csr.backoff.(backoff.Persistent); ok {
+		persistent.RestoreEntries(entries)
+	}
+}
+
 func truncateIfExceedMaxLength(s string, maxLength int) string {
 	if len(s) <= maxLength {
 		return s
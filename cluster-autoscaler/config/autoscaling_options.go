@@ -34,6 +34,22 @@ type GpuLimits struct {
 	Max int64
 }
 
+// NodeGroupSetResourceLimits define lower and upper bound on a resource in a set of node groups
+type NodeGroupSetResourceLimits struct {
+	// Name of the set, used in logs and statuses
+	Name string
+	// Regex matching the whole IDs of node groups in the set, empty matches all node groups
+	NodeGroupIdRegex string
+	// Label selector matching nodes of node groups in the set, empty matches all node groups
+	LabelSelector string
+	// Limited resource: cpu, memory (in bytes) or a GPU type
+	Resource string
+	// Lower bound on the resource in the set
+	Min int64
+	// Upper bound on the resource in the set
+	Max int64
+}
+
 // NodeGroupAutoscalingOptions contain various options to customize how autoscaling of
 // a given NodeGroup works. Different options can be used for each NodeGroup.
 type NodeGroupAutoscalingOptions struct {
@@ -54,6 +70,11 @@ type NodeGroupAutoscalingOptions struct {
 	ZeroOrMaxNodeScaling bool
 	// IgnoreDaemonSetsUtilization sets if daemonsets utilization should be considered during node scale-down
 	IgnoreDaemonSetsUtilization bool
+	// MaxScaleUpNodesPerMinute is the maximum number of nodes added to a node group within a minute. 0 means no limit.
+	MaxScaleUpNodesPerMinute int
+	// MaxScaleUpGrowthPercent is the maximum number of nodes added to a node group in a single scale-up, as a percentage
+	// of its current size. At least one node can always be added. 0 means no limit.
+	MaxScaleUpGrowthPercent int
 }
 
 // GCEOptions contain autoscaling options specific to GCE cloud provider.
@@ -99,6 +120,22 @@ func NewDefaultNodeGroupDifferenceRatios() NodeGroupDifferenceRatios {
 	}
 }
 
+// DrainCostOptions contain options of drain cost aware scale-down, which prefers removing
+// nodes whose pods are cheap to move and waits longer before removing expensive ones.
+type DrainCostOptions struct {
+	// Enabled makes scale-down calculate the cost of moving pods of nodes.
+	Enabled bool
+	// ControllerKindCosts are costs of moving a pod, keyed by the kind of its controller.
+	ControllerKindCosts map[string]float64
+	// SingletonCost is the minimal cost of moving a pod without a controller or with a single replica controller.
+	SingletonCost float64
+	// ExpensiveThreshold is the drain cost of a node at or above which ExpensiveUnneededTime applies.
+	ExpensiveThreshold float64
+	// ExpensiveUnneededTime is how long a node with an expensive drain should be unneeded before it is removed.
+	// It is only used if longer than the unneeded time of the node group.
+	ExpensiveUnneededTime time.Duration
+}
+
 // AutoscalingOptions contain various options to customize how autoscaling works
 type AutoscalingOptions struct {
 	// NodeGroupDefaults are default values for per NodeGroup options.
@@ -118,6 +155,8 @@ type AutoscalingOptions struct {
 	MinMemoryTotal int64
 	// GpuTotal is a list of strings with configuration of min/max limits for different GPUs.
 	GpuTotal []GpuLimits
+	// NodeGroupSetResourceLimits is a list of min/max limits of resources in sets of node groups.
+	NodeGroupSetResourceLimits []NodeGroupSetResourceLimits
 	// NodeGroupAutoDiscovery represents one or more definition(s) of node group auto-discovery
 	NodeGroupAutoDiscovery []string
 	// EstimatorName is the estimator used to estimate the number of needed nodes in scale up.
@@ -192,6 +231,17 @@ type AutoscalingOptions struct {
 	WriteStatusConfigMap bool
 	// StaticConfigMapName
 	StatusConfigMapName string
+	// WriteStatusCRD tells if the status information should be written to ClusterAutoscalerStatus and NodeGroupStatus objects
+	WriteStatusCRD bool
+	// StatusCRDName is the name of the ClusterAutoscalerStatus object
+	StatusCRDName string
+	// PodScaleUpExplanations enables per pod explanations of why a pod didn't trigger a scale-up,
+	// exposed as a pod annotation, a pod condition and in the NotTriggerScaleUp event.
+	PodScaleUpExplanations bool
+	// PodScaleUpExplanationInterval is the minimum time between updates of a single pod's explanation.
+	PodScaleUpExplanationInterval time.Duration
+	// PodScaleUpExplanationQPS is the maximum number of pod explanation updates per second.
+	PodScaleUpExplanationQPS float32
 	// BalanceSimilarNodeGroups enables logic that identifies node groups with similar machines and tries to balance node count between them.
 	BalanceSimilarNodeGroups bool
 	// ConfigNamespace is the namespace cluster-autoscaler is running in and all related configmaps live in
@@ -207,6 +257,13 @@ type AutoscalingOptions struct {
 	// Pods with priority below cutoff are expendable. They can be killed without any consideration during scale down and they don't cause scale-up.
 	// Pods with null priority (PodPriority disabled) are non-expendable.
 	ExpendablePodsPriorityCutoff int
+	// SimulatePreemption enables simulation of preemption of lower priority pods on existing nodes.
+	// Pods which the scheduler will schedule by preempting other pods don't trigger scale-up, while
+	// the preempted pods which don't fit elsewhere do.
+	SimulatePreemption bool
+	// PriorityOrderedScaleUp makes scale-up process pods in decreasing priority order, so that
+	// limits like MaxNodesPerScaleUp are used by the most important pods first.
+	PriorityOrderedScaleUp bool
 	// Regional tells whether the cluster is regional.
 	Regional bool
 	// Pods newer than this will not be considered as unschedulable for scale-up.
@@ -226,6 +283,9 @@ type AutoscalingOptions struct {
 	// status that should be removed when creating a node template for scheduling.
 	// The status taints are expected to appear during node lifetime, after startup.
 	StatusTaints []string
+	// ExtendedResources is a list of resources exposed by device plugins. Nodes are treated as unready
+	// until the resources expected from their node group template become allocatable.
+	ExtendedResources []string
 	// BalancingExtraIgnoredLabels is a list of labels to additionally ignore when comparing if two node groups are similar.
 	// Labels in BasicIgnoredLabels and the cloud provider-specific ignored labels are always ignored.
 	BalancingExtraIgnoredLabels []string
@@ -234,6 +294,9 @@ type AutoscalingOptions struct {
 	BalancingLabels []string
 	// AWSUseStaticInstanceList tells if AWS cloud provider use static instance type list or dynamically fetch from remote APIs.
 	AWSUseStaticInstanceList bool
+	// SpotPriceDiscountsFile is a path to a YAML or JSON file with spot discounts applied on top of the static
+	// price catalogs of AWS and Azure cloud providers.
+	SpotPriceDiscountsFile string
 	// GCEOptions contain autoscaling options specific to GCE cloud provider.
 	GCEOptions GCEOptions
 	// KubeClientOpts specify options for kube client
@@ -259,12 +322,24 @@ type AutoscalingOptions struct {
 	MaxScaleDownParallelism int
 	// MaxDrainParallelism is the maximum number of nodes needing drain, that can be drained and deleted in parallel.
 	MaxDrainParallelism int
+	// MaxDisruptedReplicasPercent is the maximum percentage (rounded up) of replicas of a single controller
+	// that can be disrupted at once by all nodes undergoing scale-down. Value of 0 disables this limit.
+	MaxDisruptedReplicasPercent int
+	// MaxDisruptedReplicas is the maximum number of replicas of a single controller that can be disrupted
+	// at once by all nodes undergoing scale-down. Value of 0 disables this limit.
+	MaxDisruptedReplicas int
+	// DrainCost contains options of drain cost aware scale-down.
+	DrainCost DrainCostOptions
 	// RecordDuplicatedEvents controls whether events should be duplicated within a 5 minute window.
 	RecordDuplicatedEvents bool
 	// MaxNodesPerScaleUp controls how many nodes can be added in a single scale-up.
 	// Note that this is strictly a performance optimization aimed at limiting binpacking time, not a tool to rate-limit
 	// scale-up. There is nothing stopping CA from adding MaxNodesPerScaleUp every loop.
 	MaxNodesPerScaleUp int
+	// MaxScaleUpPlanSteps is the maximum number of steps of a single scale-up. After the best option is
+	// selected, further options are selected for pods it doesn't help, and all of them are executed
+	// together, within MaxNodesPerScaleUp and resource limits. Values below 2 disable multi-step scale-ups.
+	MaxScaleUpPlanSteps int
 	// MaxNodeGroupBinpackingDuration is a maximum time that can be spent binpacking a single NodeGroup. If the threshold
 	// is exceeded binpacking will be cut short and a partial scale-up will be performed.
 	MaxNodeGroupBinpackingDuration time.Duration
@@ -295,6 +370,44 @@ type AutoscalingOptions struct {
 	BypassedSchedulers map[string]bool
 	// ProvisioningRequestEnabled tells if CA processes ProvisioningRequest.
 	ProvisioningRequestEnabled bool
+	// NodeGroupPoliciesEnabled tells if per node group options are overridden by NodeGroupPolicy objects.
+	NodeGroupPoliciesEnabled bool
+	// OptionsOverridesFile is a path to a file with overrides of options which can be changed without restart.
+	OptionsOverridesFile string
+	// OptionsOverridesConfigMapName is the name of a ConfigMap in ConfigNamespace with overrides of options
+	// which can be changed without restart.
+	OptionsOverridesConfigMapName string
+	// WriteStateConfigMap tells if unneeded node timers, backoff and node deletions should be checkpointed
+	// to a ConfigMap and restored after a restart or a leader change.
+	WriteStateConfigMap bool
+	// StateConfigMapName is the name of the ConfigMap in ConfigNamespace holding the checkpoint.
+	StateConfigMapName string
+	// StateCheckpointMaxAge is the maximum age of a checkpoint which is still restored.
+	StateCheckpointMaxAge time.Duration
+	// ShardCount is the number of Cluster Autoscaler instances node groups are split between.
+	ShardCount int
+	// ShardIndex identifies the shard of this instance, it has to be unique among instances.
+	ShardIndex int
+	// ShardName identifies the shard of this instance instead of ShardIndex, it has to be unique
+	// among instances. It's required with ShardNodeGroupSelector.
+	ShardName string
+	// ShardNodeGroupSelector, if set, selects node groups owned by this instance by labels of their
+	// template nodes, instead of hashing node group ids.
+	ShardNodeGroupSelector string
+	// ShardPodClaimTTL is how long a pod that triggered a scale-up in one shard is ignored by other shards.
+	ShardPodClaimTTL time.Duration
+	// MaxNodeAge is the age after which nodes are replaced with new ones. 0 disables rotation of old nodes.
+	MaxNodeAge time.Duration
+	// RotateOutdatedNodes enables replacing nodes created from a previous version of their node group template,
+	// for node groups able to detect it.
+	RotateOutdatedNodes bool
+	// NodeRotationMaintenanceWindows limits starting node rotations to weekly windows, in UTC. Empty means any time.
+	NodeRotationMaintenanceWindows string
+	// MaxNodeRotationsInParallel is the maximum number of nodes rotated at the same time.
+	MaxNodeRotationsInParallel int
+	// DryRun makes Cluster Autoscaler compute scaling decisions without executing them: node groups
+	// aren't resized, created or deleted, and nodes aren't tainted or drained.
+	DryRun bool
 }
 
 // KubeClientOptions specify options for kube client
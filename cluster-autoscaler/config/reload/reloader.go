@@ -0,0 +1,267 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reload
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
+	klog "k8s.io/klog/v2"
+)
+
+// Source provides overrides of autoscaling options, keyed by the name of the
+// command line flag setting the option.
+type Source interface {
+	// Load returns the current overrides. Missing source is equivalent to no overrides.
+	Load() (map[string]string, error)
+}
+
+// Reloader applies overrides read from a Source on top of the options the
+// autoscaler was started with. Only flags listed by ReloadableFlags can be
+// overridden, changing any other option still requires a restart.
+type Reloader struct {
+	source  Source
+	base    config.AutoscalingOptions
+	lastErr string
+	last    map[string]string
+}
+
+// NewReloader creates a Reloader applying overrides from source on top of base options.
+func NewReloader(source Source, base config.AutoscalingOptions) *Reloader {
+	return &Reloader{
+		source: source,
+		base:   base,
+	}
+}
+
+// Poll checks the source for changed overrides. It returns the new options if the overrides
+// changed since the previous call and nil if they didn't. Invalid overrides are reported once,
+// until the source changes again.
+func (r *Reloader) Poll() (*config.AutoscalingOptions, error) {
+	overrides, err := r.source.Load()
+	if err == nil && !equalOverrides(overrides, r.last) {
+		r.last = overrides
+		var options config.AutoscalingOptions
+		if options, err = Apply(r.base, overrides); err == nil {
+			r.lastErr = ""
+			return &options, nil
+		}
+	}
+	if err == nil {
+		r.lastErr = ""
+		return nil, nil
+	}
+	if err.Error() == r.lastErr {
+		klog.V(4).Infof("Options overrides are still invalid: %v", err)
+		return nil, nil
+	}
+	r.lastErr = err.Error()
+	return nil, err
+}
+
+// Apply returns a copy of base options with overrides applied. All overrides are validated,
+// an error is returned if any of them refers to an option which can't be changed at runtime
+// or has an invalid value.
+func Apply(base config.AutoscalingOptions, overrides map[string]string) (config.AutoscalingOptions, error) {
+	options := base
+	// Slices are shared with the base options, copy them before the flag values modify them.
+	options.BalancingExtraIgnoredLabels = append([]string(nil), base.BalancingExtraIgnoredLabels...)
+	options.BalancingLabels = append([]string(nil), base.BalancingLabels...)
+	fs := newFlagSet(&options)
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if fs.Lookup(name) == nil {
+			return base, fmt.Errorf("option %q can't be changed without restarting cluster autoscaler", name)
+		}
+		if err := fs.Set(name, overrides[name]); err != nil {
+			return base, fmt.Errorf("invalid value %q of option %q: %v", overrides[name], name, err)
+		}
+	}
+	if err := validate(options); err != nil {
+		return base, err
+	}
+	return options, nil
+}
+
+// ReloadableFlags returns names of the flags which can be overridden at runtime.
+func ReloadableFlags() []string {
+	var names []string
+	newFlagSet(&config.AutoscalingOptions{}).VisitAll(func(f *flag.Flag) {
+		names = append(names, f.Name)
+	})
+	return names
+}
+
+// ChangedOptions returns names of AutoscalingOptions fields differing between the two options.
+func ChangedOptions(old, new config.AutoscalingOptions) []string {
+	var changed []string
+	oldValue, newValue := reflect.ValueOf(old), reflect.ValueOf(new)
+	for i := 0; i < oldValue.NumField(); i++ {
+		if !reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			changed = append(changed, oldValue.Type().Field(i).Name)
+		}
+	}
+	return changed
+}
+
+// newFlagSet binds reloadable flags to fields of options. Flag names and formats
+// match the ones accepted on the command line.
+func newFlagSet(options *config.AutoscalingOptions) *flag.FlagSet {
+	fs := flag.NewFlagSet("reloadable options", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	defaults := &options.NodeGroupDefaults
+	fs.StringVar(&options.ExpanderNames, "expander", options.ExpanderNames, "")
+	fs.IntVar(&options.MaxNodesTotal, "max-nodes-total", options.MaxNodesTotal, "")
+	fs.Var(&minMaxValue{min: &options.MinCoresTotal, max: &options.MaxCoresTotal, unit: 1}, "cores-total", "")
+	fs.Var(&minMaxValue{min: &options.MinMemoryTotal, max: &options.MaxMemoryTotal, unit: units.GiB}, "memory-total", "")
+	fs.BoolVar(&options.ScaleDownEnabled, "scale-down-enabled", options.ScaleDownEnabled, "")
+	fs.DurationVar(&options.ScaleDownDelayAfterAdd, "scale-down-delay-after-add", options.ScaleDownDelayAfterAdd, "")
+	fs.DurationVar(&options.ScaleDownDelayAfterDelete, "scale-down-delay-after-delete", options.ScaleDownDelayAfterDelete, "")
+	fs.DurationVar(&options.ScaleDownDelayAfterFailure, "scale-down-delay-after-failure", options.ScaleDownDelayAfterFailure, "")
+	fs.DurationVar(&defaults.ScaleDownUnneededTime, "scale-down-unneeded-time", defaults.ScaleDownUnneededTime, "")
+	fs.DurationVar(&defaults.ScaleDownUnreadyTime, "scale-down-unready-time", defaults.ScaleDownUnreadyTime, "")
+	fs.Float64Var(&defaults.ScaleDownUtilizationThreshold, "scale-down-utilization-threshold", defaults.ScaleDownUtilizationThreshold, "")
+	fs.Float64Var(&defaults.ScaleDownGpuUtilizationThreshold, "scale-down-gpu-utilization-threshold", defaults.ScaleDownGpuUtilizationThreshold, "")
+	fs.DurationVar(&defaults.MaxNodeProvisionTime, "max-node-provision-time", defaults.MaxNodeProvisionTime, "")
+	fs.BoolVar(&defaults.IgnoreDaemonSetsUtilization, "ignore-daemonsets-utilization", defaults.IgnoreDaemonSetsUtilization, "")
+	fs.IntVar(&defaults.MaxScaleUpNodesPerMinute, "max-scale-up-nodes-per-minute", defaults.MaxScaleUpNodesPerMinute, "")
+	fs.IntVar(&defaults.MaxScaleUpGrowthPercent, "max-scale-up-growth-percent", defaults.MaxScaleUpGrowthPercent, "")
+	fs.Var((*listValue)(&options.BalancingExtraIgnoredLabels), "balancing-ignore-label", "")
+	fs.Var((*listValue)(&options.BalancingLabels), "balancing-label", "")
+	fs.IntVar(&options.MaxNodesPerScaleUp, "max-nodes-per-scaleup", options.MaxNodesPerScaleUp, "")
+	fs.DurationVar(&options.MaxNodeGroupBinpackingDuration, "max-nodegroup-binpacking-duration", options.MaxNodeGroupBinpackingDuration, "")
+	return fs
+}
+
+func validate(options config.AutoscalingOptions) error {
+	if options.ExpanderNames == "" {
+		return fmt.Errorf("expander can't be empty")
+	}
+	if options.MaxNodesTotal < 0 {
+		return fmt.Errorf("max-nodes-total must be greater or equal to 0")
+	}
+	if options.MaxNodesPerScaleUp <= 0 {
+		return fmt.Errorf("max-nodes-per-scaleup must be greater than 0")
+	}
+	if len(options.BalancingLabels) > 0 && len(options.BalancingExtraIgnoredLabels) > 0 {
+		return fmt.Errorf("balancing-label can't be combined with balancing-ignore-label")
+	}
+	defaults := options.NodeGroupDefaults
+	for name, threshold := range map[string]float64{
+		"scale-down-utilization-threshold":     defaults.ScaleDownUtilizationThreshold,
+		"scale-down-gpu-utilization-threshold": defaults.ScaleDownGpuUtilizationThreshold,
+	} {
+		if threshold < 0 || threshold > 1 {
+			return fmt.Errorf("%s must be between 0 and 1", name)
+		}
+	}
+	for name, duration := range map[string]time.Duration{
+		"scale-down-delay-after-add":        options.ScaleDownDelayAfterAdd,
+		"scale-down-delay-after-delete":     options.ScaleDownDelayAfterDelete,
+		"scale-down-delay-after-failure":    options.ScaleDownDelayAfterFailure,
+		"scale-down-unneeded-time":          defaults.ScaleDownUnneededTime,
+		"scale-down-unready-time":           defaults.ScaleDownUnreadyTime,
+		"max-node-provision-time":           defaults.MaxNodeProvisionTime,
+		"max-nodegroup-binpacking-duration": options.MaxNodeGroupBinpackingDuration,
+	} {
+		if duration < 0 {
+			return fmt.Errorf("%s can't be negative", name)
+		}
+	}
+	for name, limit := range map[string]int{
+		"max-scale-up-nodes-per-minute": defaults.MaxScaleUpNodesPerMinute,
+		"max-scale-up-growth-percent":   defaults.MaxScaleUpGrowthPercent,
+	} {
+		if limit < 0 {
+			return fmt.Errorf("%s can't be negative", name)
+		}
+	}
+	return nil
+}
+
+func equalOverrides(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// minMaxValue parses limits in the <min>:<max> format used by the cores-total and memory-total flags.
+type minMaxValue struct {
+	min, max *int64
+	unit     int64
+}
+
+func (v *minMaxValue) String() string {
+	if v.min == nil || v.max == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v:%v", *v.min/v.unit, *v.max/v.unit)
+}
+
+func (v *minMaxValue) Set(value string) error {
+	tokens := strings.SplitN(value, ":", 2)
+	if len(tokens) != 2 {
+		return fmt.Errorf("expected <min>:<max>")
+	}
+	min, err := strconv.ParseInt(tokens[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("min is not an integer: %v", err)
+	}
+	max, err := strconv.ParseInt(tokens[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("max is not an integer: %v", err)
+	}
+	if min < 0 {
+		return fmt.Errorf("min must be greater or equal to 0")
+	}
+	if max < min {
+		return fmt.Errorf("max must be greater or equal to min")
+	}
+	*v.min, *v.max = min*v.unit, max*v.unit
+	return nil
+}
+
+// listValue parses a comma separated list, replacing the previous value.
+type listValue []string
+
+func (v *listValue) String() string {
+	return strings.Join(*v, ",")
+}
+
+func (v *listValue) Set(value string) error {
+	*v = nil
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*v = append(*v, item)
+		}
+	}
+	return nil
+}
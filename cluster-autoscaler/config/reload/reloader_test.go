@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reload
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
+)
+
+var baseOptions = config.AutoscalingOptions{
+	ExpanderNames:               "random",
+	MaxNodesTotal:               10,
+	MaxCoresTotal:               100,
+	MaxMemoryTotal:              100 * units.GiB,
+	MaxNodesPerScaleUp:          1000,
+	ScaleDownEnabled:            true,
+	BalancingExtraIgnoredLabels: []string{"a"},
+	NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+		ScaleDownUnneededTime:         10 * time.Minute,
+		ScaleDownUtilizationThreshold: 0.5,
+	},
+}
+
+func TestApply(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		overrides map[string]string
+		want      func(*config.AutoscalingOptions)
+		wantErr   bool
+	}{
+		"no overrides": {},
+		"reloadable options": {
+			overrides: map[string]string{
+				"expander":                         "priority,least-waste",
+				"max-nodes-total":                  "20",
+				"memory-total":                     "1:64",
+				"scale-down-enabled":               "false",
+				"scale-down-unneeded-time":         "5m",
+				"scale-down-utilization-threshold": "0.7",
+				"balancing-ignore-label":           "b, c",
+				"max-scale-up-nodes-per-minute":    "10",
+			},
+			want: func(o *config.AutoscalingOptions) {
+				o.ExpanderNames = "priority,least-waste"
+				o.MaxNodesTotal = 20
+				o.MinMemoryTotal = units.GiB
+				o.MaxMemoryTotal = 64 * units.GiB
+				o.ScaleDownEnabled = false
+				o.NodeGroupDefaults.ScaleDownUnneededTime = 5 * time.Minute
+				o.NodeGroupDefaults.ScaleDownUtilizationThreshold = 0.7
+				o.BalancingExtraIgnoredLabels = []string{"b", "c"}
+				o.NodeGroupDefaults.MaxScaleUpNodesPerMinute = 10
+			},
+		},
+		"immutable option": {
+			overrides: map[string]string{"max-nodes-total": "20", "cloud-provider": "aws"},
+			wantErr:   true,
+		},
+		"malformed value": {
+			overrides: map[string]string{"scale-down-delay-after-add": "soon"},
+			wantErr:   true,
+		},
+		"min greater than max": {
+			overrides: map[string]string{"cores-total": "10:5"},
+			wantErr:   true,
+		},
+		"threshold out of range": {
+			overrides: map[string]string{"scale-down-gpu-utilization-threshold": "2"},
+			wantErr:   true,
+		},
+		"negative scale-up rate limit": {
+			overrides: map[string]string{"max-scale-up-growth-percent": "-10"},
+			wantErr:   true,
+		},
+		"conflicting balancing labels": {
+			overrides: map[string]string{"balancing-label": "pool"},
+			wantErr:   true,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			got, err := Apply(baseOptions, tc.overrides)
+			assert.Equal(t, tc.wantErr, err != nil, "unexpected error: %v", err)
+			want := baseOptions
+			want.BalancingExtraIgnoredLabels = []string{"a"}
+			if tc.want != nil {
+				tc.want(&want)
+			}
+			assert.Equal(t, want, got)
+			assert.Equal(t, []string{"a"}, baseOptions.BalancingExtraIgnoredLabels)
+		})
+	}
+}
+
+type fakeSource struct {
+	overrides map[string]string
+	err       error
+}
+
+func (s *fakeSource) Load() (map[string]string, error) {
+	return s.overrides, s.err
+}
+
+func TestReloaderPoll(t *testing.T) {
+	source := &fakeSource{}
+	r := NewReloader(source, baseOptions)
+
+	options, err := r.Poll()
+	assert.NoError(t, err)
+	assert.Nil(t, options, "empty source doesn't change options")
+
+	source.overrides = map[string]string{"max-nodes-total": "20"}
+	options, err = r.Poll()
+	assert.NoError(t, err)
+	if assert.NotNil(t, options) {
+		assert.Equal(t, 20, options.MaxNodesTotal)
+	}
+	options, err = r.Poll()
+	assert.NoError(t, err)
+	assert.Nil(t, options, "unchanged overrides are reported once")
+
+	source.overrides = map[string]string{"max-nodes-total": "-1"}
+	_, err = r.Poll()
+	assert.Error(t, err)
+	options, err = r.Poll()
+	assert.NoError(t, err)
+	assert.Nil(t, options, "invalid overrides are reported once")
+
+	source.err = fmt.Errorf("unavailable")
+	_, err = r.Poll()
+	assert.Error(t, err)
+	_, err = r.Poll()
+	assert.NoError(t, err, "source errors are reported once")
+
+	source.overrides, source.err = nil, nil
+	options, err = r.Poll()
+	assert.NoError(t, err)
+	if assert.NotNil(t, options) {
+		assert.Equal(t, baseOptions.MaxNodesTotal, options.MaxNodesTotal)
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "options.yaml")
+	source := NewFileSource(path)
+
+	overrides, err := source.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, overrides, "missing file means no overrides")
+
+	content := "max-nodes-total: 1000000\nscale-down-utilization-threshold: 0.6\nscale-down-enabled: false\nexpander: least-waste\nbalancing-ignore-label: [a, b]\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	overrides, err = source.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"max-nodes-total":                  "1000000",
+		"scale-down-utilization-threshold": "0.6",
+		"scale-down-enabled":               "false",
+		"expander":                         "least-waste",
+		"balancing-ignore-label":           "a,b",
+	}, overrides)
+
+	assert.NoError(t, os.WriteFile(path, []byte("- not a map"), 0644))
+	_, err = source.Load()
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reload
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	kube_client "k8s.io/client-go/kubernetes"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type fileSource struct {
+	path string
+}
+
+// NewFileSource returns a Source reading overrides from a YAML or JSON file mapping
+// flag names to their values, e.g. a file mounted from a ConfigMap.
+func NewFileSource(path string) Source {
+	return &fileSource{path: path}
+}
+
+func (s *fileSource) Load() (map[string]string, error) {
+	content, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseOverrides(content)
+}
+
+type configMapSource struct {
+	lister    v1lister.ConfigMapNamespaceLister
+	name      string
+	namespace string
+}
+
+// NewConfigMapSource returns a Source reading overrides from the data of a ConfigMap,
+// keyed by flag names. The ConfigMap is watched until stopCh is closed.
+func NewConfigMapSource(kubeClient kube_client.Interface, namespace, name string, stopCh <-chan struct{}) Source {
+	return &configMapSource{
+		lister:    kube_util.NewConfigMapListerForNamespace(kubeClient, stopCh, namespace).ConfigMaps(namespace),
+		name:      name,
+		namespace: namespace,
+	}
+}
+
+func (s *configMapSource) Load() (map[string]string, error) {
+	configMap, err := s.lister.Get(s.name)
+	if kube_errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	return configMap.Data, nil
+}
+
+// parseOverrides accepts scalar values as well as lists, which are joined with commas.
+func parseOverrides(content []byte) (map[string]string, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse options overrides: %v", err)
+	}
+	overrides := make(map[string]string, len(raw))
+	for name, value := range raw {
+		switch v := value.(type) {
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, formatScalar(item))
+			}
+			overrides[name] = strings.Join(items, ",")
+		default:
+			overrides[name] = formatScalar(v)
+		}
+	}
+	return overrides, nil
+}
+
+func formatScalar(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package context
 
 import (
+	ctx "context"
+
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
@@ -61,6 +63,9 @@ type AutoscalingContext struct {
 	RemainingPdbTracker pdb.RemainingPdbTracker
 	// ClusterStateRegistry tracks the health of the node groups and pending scale-ups and scale-downs
 	ClusterStateRegistry *clusterstate.ClusterStateRegistry
+	// TraceContext carries the root span of the current autoscaling loop iteration. It is set at the beginning
+	// of every iteration, so code outliving the iteration needs to capture it.
+	TraceContext ctx.Context
 }
 
 // AutoscalingKubeClients contains all Kubernetes API clients,
@@ -92,7 +97,16 @@ func NewResourceLimiterFromAutoscalingOptions(options config.AutoscalingOptions)
 		minResources[gpuLimits.GpuType] = gpuLimits.Min
 		maxResources[gpuLimits.GpuType] = gpuLimits.Max
 	}
-	return cloudprovider.NewResourceLimiter(minResources, maxResources)
+	var nodeGroupSetLimits []cloudprovider.NodeGroupSetLimit
+	for _, setLimits := range options.NodeGroupSetResourceLimits {
+		limit, err := cloudprovider.NewNodeGroupSetLimit(setLimits)
+		if err != nil {
+			klog.Errorf("Ignoring node group set resource limits: %v", err)
+			continue
+		}
+		nodeGroupSetLimits = append(nodeGroupSetLimits, limit)
+	}
+	return cloudprovider.NewResourceLimiter(minResources, maxResources).WithNodeGroupSetLimits(nodeGroupSetLimits)
 }
 
 // NewAutoscalingContext returns an autoscaling context from all the necessary parameters passed via arguments
@@ -125,7 +139,13 @@ func NewAutoscalingContext(
 // NewAutoscalingKubeClients builds AutoscalingKubeClients out of basic client.
 func NewAutoscalingKubeClients(opts config.AutoscalingOptions, kubeClient kube_client.Interface, informerFactory informers.SharedInformerFactory) *AutoscalingKubeClients {
 	listerRegistry := kube_util.NewListerRegistryWithDefaultListers(informerFactory)
-	kubeEventRecorder := kube_util.CreateEventRecorder(kubeClient, opts.RecordDuplicatedEvents)
+	var kubeEventRecorder kube_record.EventRecorder
+	if opts.DryRun {
+		// Events of a dry-run instance would be indistinguishable from events of the instance actively scaling the cluster.
+		kubeEventRecorder = kube_util.CreateLoggingEventRecorder()
+	} else {
+		kubeEventRecorder = kube_util.CreateEventRecorder(kubeClient, opts.RecordDuplicatedEvents)
+	}
 	logRecorder, err := utils.NewStatusMapRecorder(kubeClient, opts.ConfigNamespace, kubeEventRecorder, opts.WriteStatusConfigMap, opts.StatusConfigMapName)
 	if err != nil {
 		klog.Error("Failed to initialize status configmap, unable to write status events")
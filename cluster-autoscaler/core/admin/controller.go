@@ -0,0 +1,349 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	klog "k8s.io/klog/v2"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// OperationType is a kind of operation requested through the admin API.
+type OperationType string
+
+const (
+	// PauseScaleDown stops scale-down of the whole cluster or of one node group for a duration.
+	PauseScaleDown OperationType = "pause-scale-down"
+	// ResumeScaleDown cancels a previous PauseScaleDown.
+	ResumeScaleDown OperationType = "resume-scale-down"
+	// ScaleUp increases the target size of a node group by a delta, within the same limits as
+	// scale-ups triggered by pending pods.
+	ScaleUp OperationType = "scale-up"
+	// ProtectNode prevents removal of a node for a duration.
+	ProtectNode OperationType = "protect-node"
+	// UnprotectNode cancels a previous ProtectNode.
+	UnprotectNode OperationType = "unprotect-node"
+	// ClearBackoff removes scale-up backoff of a node group.
+	ClearBackoff OperationType = "clear-backoff"
+)
+
+// ErrNotRunning is returned for operations requested before the autoscaling loop
+// ran in this instance, e.g. when it isn't the leader.
+var ErrNotRunning = errors.New("autoscaling loop isn't running in this instance")
+
+// Operation is a runtime control requested through the admin API.
+type Operation struct {
+	Type      OperationType `json:"type"`
+	NodeGroup string        `json:"nodeGroup,omitempty"`
+	Node      string        `json:"node,omitempty"`
+	Delta     int           `json:"delta,omitempty"`
+	Duration  time.Duration `json:"duration,omitempty"`
+}
+
+// String returns a human readable description of the operation.
+func (o Operation) String() string {
+	switch o.Type {
+	case PauseScaleDown:
+		if o.NodeGroup == "" {
+			return fmt.Sprintf("pause scale-down for %v", o.Duration)
+		}
+		return fmt.Sprintf("pause scale-down of node group %s for %v", o.NodeGroup, o.Duration)
+	case ResumeScaleDown:
+		if o.NodeGroup == "" {
+			return "resume scale-down"
+		}
+		return fmt.Sprintf("resume scale-down of node group %s", o.NodeGroup)
+	case ScaleUp:
+		return fmt.Sprintf("scale up node group %s by %d", o.NodeGroup, o.Delta)
+	case ProtectNode:
+		return fmt.Sprintf("protect node %s from scale-down for %v", o.Node, o.Duration)
+	case UnprotectNode:
+		return fmt.Sprintf("unprotect node %s", o.Node)
+	case ClearBackoff:
+		return fmt.Sprintf("clear backoff of node group %s", o.NodeGroup)
+	}
+	return string(o.Type)
+}
+
+// Validate checks if all parameters required by the operation type are set.
+func (o Operation) Validate() error {
+	switch o.Type {
+	case PauseScaleDown:
+		if o.Duration <= 0 {
+			return fmt.Errorf("%s requires a positive duration", o.Type)
+		}
+	case ResumeScaleDown:
+	case ScaleUp:
+		if o.NodeGroup == "" || o.Delta <= 0 {
+			return fmt.Errorf("%s requires a node group and a positive delta", o.Type)
+		}
+	case ProtectNode:
+		if o.Node == "" || o.Duration <= 0 {
+			return fmt.Errorf("%s requires a node and a positive duration", o.Type)
+		}
+	case UnprotectNode:
+		if o.Node == "" {
+			return fmt.Errorf("%s requires a node", o.Type)
+		}
+	case ClearBackoff:
+		if o.NodeGroup == "" {
+			return fmt.Errorf("%s requires a node group", o.Type)
+		}
+	default:
+		return fmt.Errorf("unknown operation %q", o.Type)
+	}
+	return nil
+}
+
+// NodeGroupStatus describes a node group as seen in the last autoscaling loop. MinSize and
+// MaxSize are the effective size range, including overrides of the cloud provider's range.
+type NodeGroupStatus struct {
+	Id         string `json:"id"`
+	MinSize    int    `json:"minSize"`
+	MaxSize    int    `json:"maxSize"`
+	TargetSize int    `json:"targetSize"`
+	Healthy    bool   `json:"healthy"`
+	BackedOff  bool   `json:"backedOff"`
+}
+
+// Status is the state of admin controls, returned by the admin API.
+type Status struct {
+	LastLoopTime                  time.Time            `json:"lastLoopTime"`
+	ScaleDownPausedUntil          *time.Time           `json:"scaleDownPausedUntil,omitempty"`
+	NodeGroupScaleDownPausedUntil map[string]time.Time `json:"nodeGroupScaleDownPausedUntil,omitempty"`
+	ProtectedNodesUntil           map[string]time.Time `json:"protectedNodesUntil,omitempty"`
+	PendingOperations             []Operation          `json:"pendingOperations,omitempty"`
+	NodeGroups                    []NodeGroupStatus    `json:"nodeGroups,omitempty"`
+}
+
+// Controller keeps operations requested through the admin API and applies them
+// in the autoscaling loop, so that they don't race with its decisions. Pauses and
+// protections are only kept in memory, so they are lost when the process restarts
+// or another instance becomes the leader.
+type Controller struct {
+	sync.Mutex
+	pending              []Operation
+	lastLoopTime         time.Time
+	scaleDownPausedUntil time.Time
+	nodeGroupPausedUntil map[string]time.Time
+	protectedNodesUntil  map[string]time.Time
+	nodeGroups           []NodeGroupStatus
+}
+
+// NewController returns a new Controller.
+func NewController() *Controller {
+	return &Controller{
+		nodeGroupPausedUntil: map[string]time.Time{},
+		protectedNodesUntil:  map[string]time.Time{},
+	}
+}
+
+// Enqueue validates the operation and schedules it to be applied in the next autoscaling loop.
+func (c *Controller) Enqueue(op Operation) error {
+	if err := op.Validate(); err != nil {
+		return err
+	}
+	c.Lock()
+	defer c.Unlock()
+	if c.lastLoopTime.IsZero() {
+		return ErrNotRunning
+	}
+	c.pending = append(c.pending, op)
+	return nil
+}
+
+// Status returns the current state of admin controls.
+func (c *Controller) Status() Status {
+	c.Lock()
+	defer c.Unlock()
+	status := Status{
+		LastLoopTime:                  c.lastLoopTime,
+		NodeGroupScaleDownPausedUntil: copyTimes(c.nodeGroupPausedUntil),
+		ProtectedNodesUntil:           copyTimes(c.protectedNodesUntil),
+		PendingOperations:             append([]Operation(nil), c.pending...),
+		NodeGroups:                    append([]NodeGroupStatus(nil), c.nodeGroups...),
+	}
+	if !c.scaleDownPausedUntil.IsZero() {
+		until := c.scaleDownPausedUntil
+		status.ScaleDownPausedUntil = &until
+	}
+	return status
+}
+
+// Apply applies pending operations, records them as events and refreshes node group status.
+// Scale-ups are executed by scaler, which checks them against readyNodes and nodeInfos like
+// any other scale-up; sizeGetter provides the effective size range of node groups.
+func (c *Controller) Apply(autoscalingContext *context.AutoscalingContext, csr *clusterstate.ClusterStateRegistry, scaler scaleup.NodeGroupScaler,
+	sizeGetter nodegroupconfig.NodeGroupSizeGetter, readyNodes []*apiv1.Node, nodeInfos map[string]*schedulerframework.NodeInfo, now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	c.lastLoopTime = now
+	nodeGroups := map[string]cloudprovider.NodeGroup{}
+	for _, ng := range autoscalingContext.CloudProvider.NodeGroups() {
+		nodeGroups[ng.Id()] = ng
+	}
+	for _, op := range c.pending {
+		if err := c.apply(op, nodeGroups, csr, scaler, readyNodes, nodeInfos, now); err != nil {
+			klog.Warningf("Admin operation %q failed: %v", op, err)
+			autoscalingContext.LogRecorder.Eventf(apiv1.EventTypeWarning, "AdminOperationFailed", "Admin operation %q failed: %v", op, err)
+			metrics.RegisterAdminOperation(string(op.Type), metrics.AdminOperationFailed)
+			continue
+		}
+		klog.V(1).Infof("Applied admin operation %q", op)
+		autoscalingContext.LogRecorder.Eventf(apiv1.EventTypeNormal, "AdminOperation", "Applied admin operation %q", op)
+		metrics.RegisterAdminOperation(string(op.Type), metrics.AdminOperationApplied)
+	}
+	c.pending = nil
+	c.removeExpired(now)
+	c.nodeGroups = nodeGroupStatuses(nodeGroups, csr, sizeGetter, now)
+}
+
+func (c *Controller) apply(op Operation, nodeGroups map[string]cloudprovider.NodeGroup, csr *clusterstate.ClusterStateRegistry, scaler scaleup.NodeGroupScaler,
+	readyNodes []*apiv1.Node, nodeInfos map[string]*schedulerframework.NodeInfo, now time.Time) error {
+	var ng cloudprovider.NodeGroup
+	if op.NodeGroup != "" {
+		ng = nodeGroups[op.NodeGroup]
+		if ng == nil {
+			return fmt.Errorf("node group %s not found", op.NodeGroup)
+		}
+	}
+	switch op.Type {
+	case PauseScaleDown:
+		if ng == nil {
+			c.scaleDownPausedUntil = now.Add(op.Duration)
+		} else {
+			c.nodeGroupPausedUntil[ng.Id()] = now.Add(op.Duration)
+		}
+	case ResumeScaleDown:
+		if ng == nil {
+			c.scaleDownPausedUntil = time.Time{}
+		} else {
+			delete(c.nodeGroupPausedUntil, ng.Id())
+		}
+	case ScaleUp:
+		if scaler == nil {
+			return fmt.Errorf("scale-up orchestrator can't scale up individual node groups")
+		}
+		added, err := scaler.ScaleUpNodeGroup(ng, op.Delta, readyNodes, nodeInfos, now)
+		if err != nil {
+			return fmt.Errorf("failed to scale up node group %s: %v", ng.Id(), err)
+		}
+		if added == 0 {
+			return fmt.Errorf("node group %s can't be scaled up: max size, rate limit or resource limits reached", ng.Id())
+		}
+		if added < op.Delta {
+			klog.V(1).Infof("Admin scale-up of node group %s capped to %d nodes", ng.Id(), added)
+		}
+	case ProtectNode:
+		c.protectedNodesUntil[op.Node] = now.Add(op.Duration)
+	case UnprotectNode:
+		delete(c.protectedNodesUntil, op.Node)
+	case ClearBackoff:
+		csr.RemoveBackoff(ng)
+	}
+	return nil
+}
+
+func (c *Controller) removeExpired(now time.Time) {
+	if !c.scaleDownPausedUntil.IsZero() && !now.Before(c.scaleDownPausedUntil) {
+		c.scaleDownPausedUntil = time.Time{}
+	}
+	for id, until := range c.nodeGroupPausedUntil {
+		if !now.Before(until) {
+			delete(c.nodeGroupPausedUntil, id)
+		}
+	}
+	for name, until := range c.protectedNodesUntil {
+		if !now.Before(until) {
+			delete(c.protectedNodesUntil, name)
+		}
+	}
+}
+
+// ScaleDownPaused returns true if scale-down of the whole cluster is paused.
+func (c *Controller) ScaleDownPaused(now time.Time) bool {
+	c.Lock()
+	defer c.Unlock()
+	return now.Before(c.scaleDownPausedUntil)
+}
+
+// FilterScaleDownCandidates removes protected nodes and nodes of node groups with paused scale-down.
+func (c *Controller) FilterScaleDownCandidates(cp cloudprovider.CloudProvider, nodes []*apiv1.Node, now time.Time) []*apiv1.Node {
+	c.Lock()
+	defer c.Unlock()
+	if len(c.nodeGroupPausedUntil) == 0 && len(c.protectedNodesUntil) == 0 {
+		return nodes
+	}
+	filtered := make([]*apiv1.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if until, found := c.protectedNodesUntil[n.Name]; found && now.Before(until) {
+			continue
+		}
+		if len(c.nodeGroupPausedUntil) > 0 {
+			if ng, err := cp.NodeGroupForNode(n); err == nil && ng != nil {
+				if until, found := c.nodeGroupPausedUntil[ng.Id()]; found && now.Before(until) {
+					continue
+				}
+			}
+		}
+		filtered = append(filtered, n)
+	}
+	return filtered
+}
+
+func nodeGroupStatuses(nodeGroups map[string]cloudprovider.NodeGroup, csr *clusterstate.ClusterStateRegistry, sizeGetter nodegroupconfig.NodeGroupSizeGetter, now time.Time) []NodeGroupStatus {
+	result := make([]NodeGroupStatus, 0, len(nodeGroups))
+	for id, ng := range nodeGroups {
+		minSize, maxSize := nodegroupconfig.GetSizeRange(sizeGetter, ng)
+		status := NodeGroupStatus{
+			Id:        id,
+			MinSize:   minSize,
+			MaxSize:   maxSize,
+			Healthy:   csr.IsNodeGroupHealthy(id),
+			BackedOff: csr.BackoffStatusForNodeGroup(ng, now).IsBackedOff,
+		}
+		if size, err := ng.TargetSize(); err == nil {
+			status.TargetSize = size
+		}
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Id < result[j].Id })
+	return result
+}
+
+func copyTimes(times map[string]time.Time) map[string]time.Time {
+	if len(times) == 0 {
+		return nil
+	}
+	result := make(map[string]time.Time, len(times))
+	for k, v := range times {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	clusterstate_utils "k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+	kube_record "k8s.io/client-go/tools/record"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func TestOperationValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		op      Operation
+		wantErr bool
+	}{
+		{name: "pause cluster", op: Operation{Type: PauseScaleDown, Duration: time.Hour}},
+		{name: "pause without duration", op: Operation{Type: PauseScaleDown}, wantErr: true},
+		{name: "resume", op: Operation{Type: ResumeScaleDown}},
+		{name: "scale up", op: Operation{Type: ScaleUp, NodeGroup: "ng1", Delta: 2}},
+		{name: "scale up without delta", op: Operation{Type: ScaleUp, NodeGroup: "ng1"}, wantErr: true},
+		{name: "scale up without node group", op: Operation{Type: ScaleUp, Delta: 2}, wantErr: true},
+		{name: "protect node", op: Operation{Type: ProtectNode, Node: "n1", Duration: 2 * time.Hour}},
+		{name: "protect node without duration", op: Operation{Type: ProtectNode, Node: "n1"}, wantErr: true},
+		{name: "unprotect without node", op: Operation{Type: UnprotectNode}, wantErr: true},
+		{name: "clear backoff without node group", op: Operation{Type: ClearBackoff}, wantErr: true},
+		{name: "unknown", op: Operation{Type: "scale-down"}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.op.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// sizeGetter overrides the size range of some node groups, like NodeGroupPolicies do.
+type sizeGetter map[string][2]int
+
+func (g sizeGetter) GetMinSize(ng cloudprovider.NodeGroup) (int, error) {
+	if sizes, found := g[ng.Id()]; found {
+		return sizes[0], nil
+	}
+	return ng.MinSize(), nil
+}
+
+func (g sizeGetter) GetMaxSize(ng cloudprovider.NodeGroup) (int, error) {
+	if sizes, found := g[ng.Id()]; found {
+		return sizes[1], nil
+	}
+	return ng.MaxSize(), nil
+}
+
+// scalerMock caps scale-ups at the max size returned by sizeGetter, standing in for the orchestrator.
+type scalerMock struct {
+	csr        *clusterstate.ClusterStateRegistry
+	sizeGetter sizeGetter
+}
+
+func (s *scalerMock) ScaleUpNodeGroup(ng cloudprovider.NodeGroup, delta int, _ []*apiv1.Node, _ map[string]*schedulerframework.NodeInfo, now time.Time) (int, errors.AutoscalerError) {
+	size, err := ng.TargetSize()
+	if err != nil {
+		return 0, errors.ToAutoscalerError(errors.CloudProviderError, err)
+	}
+	_, maxSize := nodegroupconfig.GetSizeRange(s.sizeGetter, ng)
+	if size+delta > maxSize {
+		delta = maxSize - size
+	}
+	if delta <= 0 {
+		return 0, nil
+	}
+	if err := ng.IncreaseSize(delta); err != nil {
+		return 0, errors.ToAutoscalerError(errors.CloudProviderError, err)
+	}
+	s.csr.RegisterScaleUp(ng, delta, now)
+	return delta, nil
+}
+
+type controllerTest struct {
+	provider   *testprovider.TestCloudProvider
+	csr        *clusterstate.ClusterStateRegistry
+	context    *context.AutoscalingContext
+	sizeGetter sizeGetter
+	scaler     *scalerMock
+	scaleUps   map[string]int
+}
+
+func newControllerTest(t *testing.T) *controllerTest {
+	ct := &controllerTest{scaleUps: map[string]int{}}
+	ct.provider = testprovider.NewTestCloudProvider(func(id string, delta int) error {
+		ct.scaleUps[id] += delta
+		return nil
+	}, nil)
+	ct.provider.AddNodeGroup("ng1", 0, 10, 2)
+	ct.provider.AddNodeGroup("ng2", 0, 3, 2)
+	ct.provider.AddNode("ng1", BuildTestNode("n1", 1000, 1000))
+	ct.provider.AddNode("ng2", BuildTestNode("n2", 1000, 1000))
+
+	fakeLogRecorder, err := clusterstate_utils.NewStatusMapRecorder(fake.NewSimpleClientset(), "kube-system", kube_record.NewFakeRecorder(5), false, "my-cool-configmap")
+	assert.NoError(t, err)
+	options := config.AutoscalingOptions{}
+	ct.csr = clusterstate.NewClusterStateRegistry(ct.provider, clusterstate.ClusterStateRegistryConfig{MaxTotalUnreadyPercentage: 10, OkTotalUnreadyCount: 1},
+		fakeLogRecorder, backoff.NewIdBasedExponentialBackoff(5*time.Minute, 30*time.Minute, 3*time.Hour), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults))
+	ct.context = &context.AutoscalingContext{
+		AutoscalingOptions: options,
+		CloudProvider:      ct.provider,
+		AutoscalingKubeClients: context.AutoscalingKubeClients{
+			LogRecorder: fakeLogRecorder,
+		},
+	}
+	ct.sizeGetter = sizeGetter{"ng2": {1, 4}}
+	ct.scaler = &scalerMock{csr: ct.csr, sizeGetter: ct.sizeGetter}
+	return ct
+}
+
+func (ct *controllerTest) apply(c *Controller, now time.Time) {
+	c.Apply(ct.context, ct.csr, ct.scaler, ct.sizeGetter, nil, nil, now)
+}
+
+func TestControllerNotRunning(t *testing.T) {
+	c := NewController()
+	assert.Equal(t, ErrNotRunning, c.Enqueue(Operation{Type: ResumeScaleDown}))
+}
+
+func TestControllerScaleUp(t *testing.T) {
+	ct := newControllerTest(t)
+	now := time.Now()
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	SetNodeReadyState(n1, true, now.Add(-time.Hour))
+	SetNodeReadyState(n2, true, now.Add(-time.Hour))
+	assert.NoError(t, ct.csr.UpdateNodes([]*apiv1.Node{n1, n2}, nil, now))
+	c := NewController()
+	ct.apply(c, now)
+
+	assert.NoError(t, c.Enqueue(Operation{Type: ScaleUp, NodeGroup: "ng1", Delta: 3}))
+	assert.NoError(t, c.Enqueue(Operation{Type: ScaleUp, NodeGroup: "ng2", Delta: 3}))
+	assert.NoError(t, c.Enqueue(Operation{Type: ScaleUp, NodeGroup: "missing", Delta: 3}))
+	assert.Len(t, c.Status().PendingOperations, 3)
+	assert.Empty(t, ct.scaleUps)
+
+	ct.apply(c, now)
+	assert.Equal(t, map[string]int{"ng1": 3, "ng2": 2}, ct.scaleUps, "scale-up should be capped at max size")
+	assert.True(t, ct.csr.IsNodeGroupScalingUp("ng1"))
+	status := c.Status()
+	assert.Empty(t, status.PendingOperations)
+	assert.Equal(t, now, status.LastLoopTime)
+	assert.Equal(t, []NodeGroupStatus{
+		{Id: "ng1", MinSize: 0, MaxSize: 10, TargetSize: 5, Healthy: true},
+		{Id: "ng2", MinSize: 1, MaxSize: 4, TargetSize: 4, Healthy: true},
+	}, status.NodeGroups)
+
+	assert.NoError(t, c.Enqueue(Operation{Type: ScaleUp, NodeGroup: "ng2", Delta: 1}))
+	ct.apply(c, now)
+	assert.Equal(t, 2, ct.scaleUps["ng2"], "node group at max size shouldn't be scaled up")
+}
+
+func TestControllerScaleUpWithoutScaler(t *testing.T) {
+	ct := newControllerTest(t)
+	now := time.Now()
+	c := NewController()
+	ct.apply(c, now)
+	assert.NoError(t, c.Enqueue(Operation{Type: ScaleUp, NodeGroup: "ng1", Delta: 1}))
+	c.Apply(ct.context, ct.csr, nil, ct.sizeGetter, nil, nil, now)
+	assert.Empty(t, ct.scaleUps)
+	assert.Empty(t, c.Status().PendingOperations)
+}
+
+func TestControllerClearBackoff(t *testing.T) {
+	ct := newControllerTest(t)
+	now := time.Now()
+	ng := ct.provider.GetNodeGroup("ng1")
+	ct.csr.RegisterFailedScaleUp(ng, "quota", "out of quota", "", "", now)
+	assert.True(t, ct.csr.BackoffStatusForNodeGroup(ng, now).IsBackedOff)
+
+	c := NewController()
+	ct.apply(c, now)
+	assert.True(t, c.Status().NodeGroups[0].BackedOff)
+	assert.NoError(t, c.Enqueue(Operation{Type: ClearBackoff, NodeGroup: "ng1"}))
+	ct.apply(c, now)
+	assert.False(t, ct.csr.BackoffStatusForNodeGroup(ng, now).IsBackedOff)
+	assert.False(t, c.Status().NodeGroups[0].BackedOff)
+}
+
+func TestControllerScaleDownControls(t *testing.T) {
+	ct := newControllerTest(t)
+	now := time.Now()
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n3 := BuildTestNode("n3", 1000, 1000)
+	ct.provider.AddNode("ng1", n3)
+	nodes := []*apiv1.Node{n1, n2, n3}
+
+	c := NewController()
+	ct.apply(c, now)
+	assert.Equal(t, nodes, c.FilterScaleDownCandidates(ct.provider, nodes, now))
+	assert.False(t, c.ScaleDownPaused(now))
+
+	for _, op := range []Operation{
+		{Type: PauseScaleDown, Duration: time.Hour},
+		{Type: PauseScaleDown, NodeGroup: "ng2", Duration: 30 * time.Minute},
+		{Type: ProtectNode, Node: "n3", Duration: 2 * time.Hour},
+	} {
+		assert.NoError(t, c.Enqueue(op))
+	}
+	ct.apply(c, now)
+	assert.True(t, c.ScaleDownPaused(now))
+	assert.Equal(t, []*apiv1.Node{n1}, c.FilterScaleDownCandidates(ct.provider, nodes, now))
+	status := c.Status()
+	assert.Equal(t, now.Add(time.Hour), *status.ScaleDownPausedUntil)
+	assert.Equal(t, map[string]time.Time{"ng2": now.Add(30 * time.Minute)}, status.NodeGroupScaleDownPausedUntil)
+	assert.Equal(t, map[string]time.Time{"n3": now.Add(2 * time.Hour)}, status.ProtectedNodesUntil)
+
+	// Node group pause expires, cluster-wide pause is resumed explicitly.
+	later := now.Add(45 * time.Minute)
+	assert.NoError(t, c.Enqueue(Operation{Type: ResumeScaleDown}))
+	ct.apply(c, later)
+	assert.False(t, c.ScaleDownPaused(later))
+	assert.Equal(t, []*apiv1.Node{n1, n2}, c.FilterScaleDownCandidates(ct.provider, nodes, later))
+	status = c.Status()
+	assert.Nil(t, status.ScaleDownPausedUntil)
+	assert.Nil(t, status.NodeGroupScaleDownPausedUntil)
+
+	assert.NoError(t, c.Enqueue(Operation{Type: UnprotectNode, Node: "n3"}))
+	ct.apply(c, later)
+	assert.Equal(t, nodes, c.FilterScaleDownCandidates(ct.provider, nodes, later))
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	klog "k8s.io/klog/v2"
+)
+
+// PathPrefix is the path under which the admin API is served.
+const PathPrefix = "/admin/"
+
+// NewHandler returns a handler of the admin API, accepting only requests with
+// an "Authorization: Bearer <token>" header. It serves:
+//
+//	GET  /admin/status
+//	POST /admin/pause-scale-down?duration=1h[&nodegroup=<id>]
+//	POST /admin/resume-scale-down[?nodegroup=<id>]
+//	POST /admin/scale-up?nodegroup=<id>&delta=<n>
+//	POST /admin/protect-node?node=<name>&duration=2h
+//	POST /admin/unprotect-node?node=<name>
+//	POST /admin/clear-backoff?nodegroup=<id>
+func NewHandler(controller *Controller, token string) http.Handler {
+	return &handler{controller: controller, token: []byte(token)}
+}
+
+type handler struct {
+	controller *Controller
+	token      []byte
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, PathPrefix)
+	if path == "status" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(h.controller.Status()); err != nil {
+			klog.Errorf("Failed to write admin API status: %v", err)
+		}
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	op, err := parseOperation(OperationType(path), r)
+	if err == nil {
+		err = h.controller.Enqueue(op)
+	}
+	switch {
+	case err == ErrNotRunning:
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		klog.V(1).Infof("Admin operation %q requested by %s", op, r.RemoteAddr)
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, "%s will be applied in the next autoscaling loop\n", op)
+	}
+}
+
+func (h *handler) authorized(r *http.Request) bool {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return found && len(h.token) > 0 && subtle.ConstantTimeCompare([]byte(token), h.token) == 1
+}
+
+func parseOperation(opType OperationType, r *http.Request) (Operation, error) {
+	query := r.URL.Query()
+	op := Operation{
+		Type:      opType,
+		NodeGroup: query.Get("nodegroup"),
+		Node:      query.Get("node"),
+	}
+	if delta := query.Get("delta"); delta != "" {
+		value, err := strconv.Atoi(delta)
+		if err != nil {
+			return op, fmt.Errorf("invalid delta %q: %v", delta, err)
+		}
+		op.Delta = value
+	}
+	if duration := query.Get("duration"); duration != "" {
+		value, err := time.ParseDuration(duration)
+		if err != nil {
+			return op, fmt.Errorf("invalid duration %q: %v", duration, err)
+		}
+		op.Duration = value
+	}
+	return op, nil
+}
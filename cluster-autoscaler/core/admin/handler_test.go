@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	testCases := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		notRunning bool
+		wantCode   int
+		wantOp     *Operation
+	}{
+		{name: "no token", method: http.MethodPost, path: "/admin/resume-scale-down", wantCode: http.StatusUnauthorized},
+		{name: "wrong token", method: http.MethodPost, path: "/admin/resume-scale-down", token: "wrong", wantCode: http.StatusUnauthorized},
+		{name: "pause node group", method: http.MethodPost, path: "/admin/pause-scale-down?nodegroup=ng1&duration=1h", token: "secret",
+			wantCode: http.StatusAccepted, wantOp: &Operation{Type: PauseScaleDown, NodeGroup: "ng1", Duration: time.Hour}},
+		{name: "scale up", method: http.MethodPost, path: "/admin/scale-up?nodegroup=ng1&delta=3", token: "secret",
+			wantCode: http.StatusAccepted, wantOp: &Operation{Type: ScaleUp, NodeGroup: "ng1", Delta: 3}},
+		{name: "protect node", method: http.MethodPost, path: "/admin/protect-node?node=n1&duration=2h", token: "secret",
+			wantCode: http.StatusAccepted, wantOp: &Operation{Type: ProtectNode, Node: "n1", Duration: 2 * time.Hour}},
+		{name: "invalid delta", method: http.MethodPost, path: "/admin/scale-up?nodegroup=ng1&delta=x", token: "secret", wantCode: http.StatusBadRequest},
+		{name: "missing parameter", method: http.MethodPost, path: "/admin/clear-backoff", token: "secret", wantCode: http.StatusBadRequest},
+		{name: "unknown operation", method: http.MethodPost, path: "/admin/delete-everything", token: "secret", wantCode: http.StatusBadRequest},
+		{name: "operation with GET", method: http.MethodGet, path: "/admin/clear-backoff?nodegroup=ng1", token: "secret", wantCode: http.StatusMethodNotAllowed},
+		{name: "not running", method: http.MethodPost, path: "/admin/clear-backoff?nodegroup=ng1", token: "secret", notRunning: true, wantCode: http.StatusServiceUnavailable},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewController()
+			if !tc.notRunning {
+				c.lastLoopTime = time.Now()
+			}
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			NewHandler(c, "secret").ServeHTTP(rec, req)
+			assert.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantOp != nil {
+				assert.Equal(t, []Operation{*tc.wantOp}, c.Status().PendingOperations)
+			} else {
+				assert.Empty(t, c.Status().PendingOperations)
+			}
+		})
+	}
+}
+
+func TestHandlerStatus(t *testing.T) {
+	c := NewController()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c.lastLoopTime = now
+	c.protectedNodesUntil["n1"] = now.Add(2 * time.Hour)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/status", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	NewHandler(c, "secret").ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var status Status
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, now, status.LastLoopTime)
+	assert.Equal(t, map[string]time.Time{"n1": now.Add(2 * time.Hour)}, status.ProtectedNodesUntil)
+}
@@ -23,15 +23,21 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	cloudBuilder "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/builder"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/config/reload"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/admin"
+	"k8s.io/autoscaler/cluster-autoscaler/core/checkpoint"
+	"k8s.io/autoscaler/cluster-autoscaler/core/dryrun"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
+	"k8s.io/autoscaler/cluster-autoscaler/core/sharding"
 	"k8s.io/autoscaler/cluster-autoscaler/debuggingsnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/factory"
 	"k8s.io/autoscaler/cluster-autoscaler/observers/loopstart"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
@@ -61,6 +67,10 @@ type AutoscalerOptions struct {
 	ScaleUpOrchestrator    scaleup.Orchestrator
 	DeleteOptions          options.NodeDeleteOptions
 	DrainabilityRules      rules.Rules
+	OptionsReloader        *reload.Reloader
+	StateStore             checkpoint.Store
+	Shard                  *sharding.Shard
+	AdminController        *admin.Controller
 }
 
 // Autoscaler is the main component of CA which scales up/down node groups according to its configuration
@@ -84,7 +94,7 @@ func NewAutoscaler(opts AutoscalerOptions, informerFactory informers.SharedInfor
 	if err != nil {
 		return nil, errors.ToAutoscalerError(errors.InternalError, err)
 	}
-	return NewStaticAutoscaler(
+	autoscaler := NewStaticAutoscaler(
 		opts.AutoscalingOptions,
 		opts.PredicateChecker,
 		opts.ClusterSnapshot,
@@ -100,7 +110,14 @@ func NewAutoscaler(opts AutoscalerOptions, informerFactory informers.SharedInfor
 		opts.ScaleUpOrchestrator,
 		opts.DeleteOptions,
 		opts.DrainabilityRules,
-	), nil
+	)
+	autoscaler.optionsReloader = opts.OptionsReloader
+	autoscaler.stateStore = opts.StateStore
+	autoscaler.adminController = opts.AdminController
+	if autoscaler.rotator != nil && opts.AdminController != nil {
+		autoscaler.rotator.SetScaleDownBlocker(opts.AdminController)
+	}
+	return autoscaler, nil
 }
 
 // Initialize default options if not provided.
@@ -114,6 +131,9 @@ func initializeDefaultOptions(opts *AutoscalerOptions, informerFactory informers
 	if opts.AutoscalingKubeClients == nil {
 		opts.AutoscalingKubeClients = context.NewAutoscalingKubeClients(opts.AutoscalingOptions, opts.KubeClient, opts.InformerFactory)
 	}
+	if opts.StateStore == nil && opts.WriteStateConfigMap {
+		opts.StateStore = checkpoint.NewConfigMapStore(opts.KubeClient, opts.ConfigNamespace, opts.StateConfigMapName)
+	}
 	if opts.ClusterSnapshot == nil {
 		opts.ClusterSnapshot = clustersnapshot.NewBasicClusterSnapshot()
 	}
@@ -122,28 +142,22 @@ func initializeDefaultOptions(opts *AutoscalerOptions, informerFactory informers
 	}
 	if opts.CloudProvider == nil {
 		opts.CloudProvider = cloudBuilder.NewCloudProvider(opts.AutoscalingOptions, informerFactory)
+		if opts.Shard != nil {
+			opts.CloudProvider = sharding.NewCloudProvider(opts.CloudProvider, opts.Shard)
+		}
+		if opts.DryRun {
+			opts.CloudProvider = dryrun.NewCloudProvider(opts.CloudProvider)
+		}
 	}
 	if opts.ExpanderStrategy == nil {
-		expanderFactory := factory.NewFactory()
-		expanderFactory.RegisterDefaultExpanders(opts.CloudProvider, opts.AutoscalingKubeClients, opts.KubeClient, opts.ConfigNamespace, opts.GRPCExpanderCert, opts.GRPCExpanderURL)
-		expanderStrategy, err := expanderFactory.Build(strings.Split(opts.ExpanderNames, ","))
+		expanderStrategy, err := buildExpanderStrategy(opts.AutoscalingOptions, opts.CloudProvider, opts.AutoscalingKubeClients, opts.KubeClient)
 		if err != nil {
 			return err
 		}
 		opts.ExpanderStrategy = expanderStrategy
 	}
 	if opts.EstimatorBuilder == nil {
-		thresholds := []estimator.Threshold{
-			estimator.NewStaticThreshold(opts.MaxNodesPerScaleUp, opts.MaxNodeGroupBinpackingDuration),
-			estimator.NewSngCapacityThreshold(),
-			estimator.NewClusterCapacityThreshold(),
-		}
-		estimatorBuilder, err := estimator.NewEstimatorBuilder(
-			opts.EstimatorName,
-			estimator.NewThresholdBasedEstimationLimiter(thresholds),
-			estimator.NewDecreasingPodOrderer(),
-			/* EstimationAnalyserFunc */ nil,
-		)
+		estimatorBuilder, err := buildEstimatorBuilder(opts.AutoscalingOptions, opts.Processors.NodeGroupConfigProcessor)
 		if err != nil {
 			return err
 		}
@@ -159,3 +173,32 @@ func initializeDefaultOptions(opts *AutoscalerOptions, informerFactory informers
 
 	return nil
 }
+
+func buildExpanderStrategy(opts config.AutoscalingOptions, cloudProvider cloudprovider.CloudProvider, autoscalingKubeClients *context.AutoscalingKubeClients, kubeClient kube_client.Interface) (expander.Strategy, error) {
+	expanderFactory := factory.NewFactory()
+	expanderFactory.RegisterDefaultExpanders(cloudProvider, autoscalingKubeClients, kubeClient, opts.ConfigNamespace, opts.GRPCExpanderCert, opts.GRPCExpanderURL)
+	expanderStrategy, err := expanderFactory.Build(strings.Split(opts.ExpanderNames, ","))
+	if err != nil {
+		return nil, err
+	}
+	return expanderStrategy, nil
+}
+
+func buildEstimatorBuilder(opts config.AutoscalingOptions, sizeGetter nodegroupconfig.NodeGroupSizeGetter) (estimator.EstimatorBuilder, error) {
+	thresholds := []estimator.Threshold{
+		estimator.NewStaticThreshold(opts.MaxNodesPerScaleUp, opts.MaxNodeGroupBinpackingDuration),
+		estimator.NewSngCapacityThreshold(sizeGetter),
+		estimator.NewClusterCapacityThreshold(),
+		estimator.NewScaleUpRateThreshold(),
+	}
+	var orderer estimator.EstimationPodOrderer = estimator.NewDecreasingPodOrderer()
+	if opts.PriorityOrderedScaleUp {
+		orderer = estimator.NewPriorityPodOrderer(orderer)
+	}
+	return estimator.NewEstimatorBuilder(
+		opts.EstimatorName,
+		estimator.NewThresholdBasedEstimationLimiter(thresholds),
+		orderer,
+		/* EstimationAnalyserFunc */ nil,
+	)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
+)
+
+// Checkpoint is the in-memory state of Cluster Autoscaler which is saved so that it
+// survives restarts and leader changes.
+type Checkpoint struct {
+	// Time at which the checkpoint was saved.
+	Time time.Time `json:"time"`
+	// UnneededSince contains times at which unneeded nodes became unneeded, keyed by node name.
+	UnneededSince map[string]time.Time `json:"unneededSince,omitempty"`
+	// Backoff contains backoff of node groups, keyed by node group key.
+	Backoff map[string]backoff.Entry `json:"backoff,omitempty"`
+	// NodeDeletions contains deletions in progress and their results.
+	NodeDeletions deletiontracker.State `json:"nodeDeletions"`
+}
+
+// EqualState returns true if both checkpoints contain the same state, regardless of the time they were saved.
+func (c *Checkpoint) EqualState(other *Checkpoint) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	a, b := c.withoutTime(), other.withoutTime()
+	return reflect.DeepEqual(a, b)
+}
+
+// withoutTime returns a copy of the checkpoint with zero Time and empty maps replaced with nil,
+// as they are indistinguishable once saved.
+func (c *Checkpoint) withoutTime() Checkpoint {
+	result := *c
+	result.Time = time.Time{}
+	if len(result.UnneededSince) == 0 {
+		result.UnneededSince = nil
+	}
+	if len(result.Backoff) == 0 {
+		result.Backoff = nil
+	}
+	if len(result.NodeDeletions.InProgress) == 0 {
+		result.NodeDeletions.InProgress = nil
+	}
+	if len(result.NodeDeletions.Results) == 0 {
+		result.NodeDeletions.Results = nil
+	}
+	return result
+}
+
+// Store saves and loads checkpoints.
+type Store interface {
+	// Load returns the last saved checkpoint, or nil if nothing was saved.
+	Load() (*Checkpoint, error)
+	// Save replaces the saved checkpoint.
+	Save(checkpoint *Checkpoint) error
+}
+
+// InMemoryStore keeps the checkpoint in memory, it is meant to be used in tests.
+type InMemoryStore struct {
+	sync.Mutex
+	checkpoint *Checkpoint
+	// Saves counts calls to Save.
+	Saves int
+}
+
+// NewInMemoryStore returns an empty InMemoryStore.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{}
+}
+
+// Load returns the last saved checkpoint, or nil if nothing was saved.
+func (s *InMemoryStore) Load() (*Checkpoint, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.checkpoint, nil
+}
+
+// Save replaces the saved checkpoint.
+func (s *InMemoryStore) Save(checkpoint *Checkpoint) error {
+	s.Lock()
+	defer s.Unlock()
+	s.checkpoint = checkpoint
+	s.Saves++
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_client "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// checkpointKey is the key of ConfigMap data under which the checkpoint is stored.
+	checkpointKey = "checkpoint"
+	// configMapCallTimeout bounds calls to the API server, so that a slow API server doesn't block the loop.
+	configMapCallTimeout = 10 * time.Second
+)
+
+type configMapStore struct {
+	kubeClient kube_client.Interface
+	namespace  string
+	name       string
+}
+
+// NewConfigMapStore returns a Store keeping the checkpoint as JSON in a ConfigMap.
+// The ConfigMap is created on first save.
+func NewConfigMapStore(kubeClient kube_client.Interface, namespace, name string) Store {
+	return &configMapStore{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		name:       name,
+	}
+}
+
+func (s *configMapStore) Load() (*Checkpoint, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), configMapCallTimeout)
+	defer cancel()
+	configMap, err := s.kubeClient.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if kube_errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkpoint ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	data, found := configMap.Data[checkpointKey]
+	if !found {
+		return nil, nil
+	}
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal([]byte(data), checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	return checkpoint, nil
+}
+
+func (s *configMapStore) Save(checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), configMapCallTimeout)
+	defer cancel()
+	maps := s.kubeClient.CoreV1().ConfigMaps(s.namespace)
+	configMap, err := maps.Get(ctx, s.name, metav1.GetOptions{})
+	if kube_errors.IsNotFound(err) {
+		configMap = &apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.namespace,
+				Name:      s.name,
+			},
+			Data: map[string]string{checkpointKey: string(data)},
+		}
+		_, err = maps.Create(ctx, configMap, metav1.CreateOptions{})
+	} else if err == nil {
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		configMap.Data[checkpointKey] = string(data)
+		_, err = maps.Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigMapStore(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	checkpoint := &Checkpoint{
+		Time:          now,
+		UnneededSince: map[string]time.Time{"n1": now.Add(-5 * time.Minute)},
+		Backoff: map[string]backoff.Entry{
+			"ng1": {Duration: 10 * time.Minute, BackoffUntil: now.Add(10 * time.Minute), LastFailedExecution: now},
+		},
+		NodeDeletions: deletiontracker.State{
+			InProgress: []string{"n2"},
+			Results:    map[string]deletiontracker.ResultState{"n3": {ResultType: status.NodeDeleteOk}},
+		},
+	}
+	client := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "other"},
+	})
+	store := NewConfigMapStore(client, "kube-system", "cluster-autoscaler-state")
+
+	loaded, err := store.Load()
+	assert.NoError(t, err)
+	assert.Nil(t, loaded, "missing ConfigMap means no checkpoint")
+
+	assert.NoError(t, store.Save(checkpoint))
+	loaded, err = store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, checkpoint, loaded)
+
+	checkpoint.Time = now.Add(time.Minute)
+	checkpoint.UnneededSince = nil
+	assert.NoError(t, store.Save(checkpoint))
+	loaded, err = store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, checkpoint, loaded)
+
+	configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(context.Background(), "cluster-autoscaler-state", metav1.GetOptions{})
+	assert.NoError(t, err)
+	configMap.Data[checkpointKey] = "{"
+	_, err = client.CoreV1().ConfigMaps("kube-system").Update(context.Background(), configMap, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	_, err = store.Load()
+	assert.Error(t, err)
+}
+
+func TestEqualState(t *testing.T) {
+	now := time.Now()
+	a := &Checkpoint{Time: now, UnneededSince: map[string]time.Time{"n1": now}}
+	b := &Checkpoint{Time: now.Add(time.Minute), UnneededSince: map[string]time.Time{"n1": now}}
+	assert.True(t, a.EqualState(b), "time of the checkpoint is ignored")
+	assert.True(t, (&Checkpoint{Backoff: map[string]backoff.Entry{}}).EqualState(&Checkpoint{}), "empty maps are equal to nil")
+	b.UnneededSince["n2"] = now
+	assert.False(t, a.EqualState(b))
+	assert.False(t, a.EqualState(nil))
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/budgets"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+)
+
+// Actuator is a scaledown.Actuator which never taints, drains or deletes nodes. Nodes it
+// is asked to delete are cropped to scale-down budgets like in the real Actuator, recorded,
+// and reported as scaled down.
+type Actuator struct {
+	budgetProcessor *budgets.ScaleDownBudgetProcessor
+}
+
+// NewActuator returns a dry-run Actuator.
+func NewActuator(ctx *context.AutoscalingContext) *Actuator {
+	return &Actuator{budgetProcessor: budgets.NewScaleDownBudgetProcessor(ctx)}
+}
+
+// StartDeletion records deletion of nodes which would be deleted.
+func (a *Actuator) StartDeletion(empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	emptyToDelete, drainToDelete := a.budgetProcessor.CropNodes(a.CheckStatus(), empty, needDrain)
+	var scaledDownNodes []*status.ScaleDownNode
+	for _, bucket := range append(emptyToDelete, drainToDelete...) {
+		recordAction(DeleteNodes, bucket.Group.Id(), -len(bucket.Nodes), bucket.Nodes)
+		for _, node := range bucket.Nodes {
+			scaledDownNodes = append(scaledDownNodes, &status.ScaleDownNode{Node: node, NodeGroup: bucket.Group})
+		}
+	}
+	if len(scaledDownNodes) == 0 {
+		return status.ScaleDownNoNodeDeleted, nil, nil
+	}
+	return status.ScaleDownNodeDeleteStarted, scaledDownNodes, nil
+}
+
+// CheckStatus returns a status with no deletions in progress.
+func (a *Actuator) CheckStatus() scaledown.ActuationStatus {
+	return actuationStatus{}
+}
+
+// ClearResultsNotNewerThan does nothing, as no deletion ever finishes.
+func (a *Actuator) ClearResultsNotNewerThan(time.Time) {
+}
+
+// DeletionResults returns no results, as no deletion ever finishes.
+func (a *Actuator) DeletionResults() (map[string]status.NodeDeleteResult, time.Time) {
+	return map[string]status.NodeDeleteResult{}, time.Time{}
+}
+
+type actuationStatus struct{}
+
+func (actuationStatus) DeletionsInProgress() (empty, drained []string) {
+	return nil, nil
+}
+
+func (actuationStatus) DeletionsCount(string) int {
+	return 0
+}
+
+func (actuationStatus) RecentEvictions() []*apiv1.Pod {
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestActuator(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 3)
+	var nodes []*apiv1.Node
+	for _, name := range []string{"n1", "n2", "n3"} {
+		node := BuildTestNode(name, 1000, 1000)
+		provider.AddNode("ng1", node)
+		nodes = append(nodes, node)
+	}
+	autoscalingContext := &context.AutoscalingContext{
+		AutoscalingOptions: config.AutoscalingOptions{MaxScaleDownParallelism: 2, MaxDrainParallelism: 1},
+		CloudProvider:      NewCloudProvider(provider),
+	}
+	actuator := NewActuator(autoscalingContext)
+
+	result, scaledDown, err := actuator.StartDeletion(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, status.ScaleDownNoNodeDeleted, result)
+	assert.Empty(t, scaledDown)
+
+	result, scaledDown, err = actuator.StartDeletion([]*apiv1.Node{nodes[0]}, []*apiv1.Node{nodes[1], nodes[2]})
+	assert.NoError(t, err)
+	assert.Equal(t, status.ScaleDownNodeDeleteStarted, result)
+	var names []string
+	for _, node := range scaledDown {
+		names = append(names, node.Node.Name)
+	}
+	assert.Equal(t, []string{"n1", "n2"}, names, "deletions should be cropped to scale-down budgets")
+
+	empty, drained := actuator.CheckStatus().DeletionsInProgress()
+	assert.Empty(t, empty)
+	assert.Empty(t, drained)
+	size, sizeErr := provider.GetNodeGroup("ng1").TargetSize()
+	assert.NoError(t, sizeErr)
+	assert.Equal(t, 3, size)
+}
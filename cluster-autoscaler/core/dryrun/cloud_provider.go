@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	klog "k8s.io/klog/v2"
+)
+
+// Actions which would be taken if the autoscaler wasn't running in dry-run mode.
+const (
+	IncreaseSize       = "IncreaseSize"
+	AtomicIncreaseSize = "AtomicIncreaseSize"
+	DecreaseTargetSize = "DecreaseTargetSize"
+	DeleteNodes        = "DeleteNodes"
+	CreateNodeGroup    = "CreateNodeGroup"
+	DeleteNodeGroup    = "DeleteNodeGroup"
+)
+
+// recordAction logs an action which wasn't taken and counts it in metrics.
+func recordAction(action, nodeGroupId string, delta int, nodes []*apiv1.Node) {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	klog.InfoS("Dry run, not executing action", "action", action, "nodeGroup", nodeGroupId, "delta", delta, "nodes", names)
+	metrics.RegisterDryRunAction(action)
+}
+
+// cloudProvider wraps node groups of a cloud provider so that changes of their size
+// are only recorded instead of being executed.
+type cloudProvider struct {
+	cloudprovider.CloudProvider
+}
+
+// NewCloudProvider wraps provider so that no node group is resized, created or deleted.
+func NewCloudProvider(provider cloudprovider.CloudProvider) cloudprovider.CloudProvider {
+	return &cloudProvider{CloudProvider: provider}
+}
+
+// NodeGroups returns all node groups configured for this cloud provider.
+func (p *cloudProvider) NodeGroups() []cloudprovider.NodeGroup {
+	nodeGroups := p.CloudProvider.NodeGroups()
+	result := make([]cloudprovider.NodeGroup, 0, len(nodeGroups))
+	for _, nodeGroup := range nodeGroups {
+		result = append(result, wrap(nodeGroup))
+	}
+	return result
+}
+
+// NodeGroupForNode returns the node group for the given node.
+func (p *cloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudprovider.NodeGroup, error) {
+	nodeGroup, err := p.CloudProvider.NodeGroupForNode(node)
+	if err != nil || nodeGroup == nil {
+		return nodeGroup, err
+	}
+	return wrap(nodeGroup), nil
+}
+
+// NewNodeGroup builds a theoretical node group based on the node definition provided.
+func (p *cloudProvider) NewNodeGroup(machineType string, labels map[string]string, systemLabels map[string]string,
+	taints []apiv1.Taint, extraResources map[string]resource.Quantity) (cloudprovider.NodeGroup, error) {
+	nodeGroup, err := p.CloudProvider.NewNodeGroup(machineType, labels, systemLabels, taints, extraResources)
+	if err != nil || nodeGroup == nil {
+		return nodeGroup, err
+	}
+	return wrap(nodeGroup), nil
+}
+
+func wrap(nodeGroup cloudprovider.NodeGroup) cloudprovider.NodeGroup {
+	if wrapped, ok := nodeGroup.(*nodeGroupWrapper); ok {
+		return wrapped
+	}
+	return &nodeGroupWrapper{NodeGroup: nodeGroup}
+}
+
+// nodeGroupWrapper records changes of a node group instead of executing them.
+type nodeGroupWrapper struct {
+	cloudprovider.NodeGroup
+}
+
+// IncreaseSize records the size increase.
+func (ng *nodeGroupWrapper) IncreaseSize(delta int) error {
+	recordAction(IncreaseSize, ng.Id(), delta, nil)
+	return nil
+}
+
+// AtomicIncreaseSize records the size increase.
+func (ng *nodeGroupWrapper) AtomicIncreaseSize(delta int) error {
+	recordAction(AtomicIncreaseSize, ng.Id(), delta, nil)
+	return nil
+}
+
+// DecreaseTargetSize records the target size decrease.
+func (ng *nodeGroupWrapper) DecreaseTargetSize(delta int) error {
+	recordAction(DecreaseTargetSize, ng.Id(), delta, nil)
+	return nil
+}
+
+// DeleteNodes records the deletion of nodes.
+func (ng *nodeGroupWrapper) DeleteNodes(nodes []*apiv1.Node) error {
+	recordAction(DeleteNodes, ng.Id(), -len(nodes), nodes)
+	return nil
+}
+
+// Create records the creation of the node group and returns the node group itself, so
+// that the following scale-up is recorded too.
+func (ng *nodeGroupWrapper) Create() (cloudprovider.NodeGroup, error) {
+	recordAction(CreateNodeGroup, ng.Id(), 0, nil)
+	return ng, nil
+}
+
+// Delete records the deletion of the node group.
+func (ng *nodeGroupWrapper) Delete() error {
+	recordAction(DeleteNodeGroup, ng.Id(), 0, nil)
+	return nil
+}
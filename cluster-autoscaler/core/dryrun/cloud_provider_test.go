@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestCloudProvider(t *testing.T) {
+	var scaledUp, scaledDown []string
+	provider := testprovider.NewTestCloudProvider(
+		func(id string, delta int) error {
+			scaledUp = append(scaledUp, id)
+			return nil
+		},
+		func(id string, node string) error {
+			scaledDown = append(scaledDown, node)
+			return nil
+		})
+	provider.AddNodeGroup("ng1", 0, 10, 1)
+	node := BuildTestNode("n1", 1000, 1000)
+	provider.AddNode("ng1", node)
+	dryRunProvider := NewCloudProvider(provider)
+
+	nodeGroups := dryRunProvider.NodeGroups()
+	assert.Len(t, nodeGroups, 1)
+	nodeGroup, err := dryRunProvider.NodeGroupForNode(node)
+	assert.NoError(t, err)
+	for _, ng := range append(nodeGroups, nodeGroup) {
+		assert.Equal(t, "ng1", ng.Id())
+		assert.NoError(t, ng.IncreaseSize(2))
+		assert.NoError(t, ng.AtomicIncreaseSize(2))
+		assert.NoError(t, ng.DecreaseTargetSize(-1))
+		assert.NoError(t, ng.DeleteNodes([]*apiv1.Node{node}))
+		assert.NoError(t, ng.Delete())
+		size, err := ng.TargetSize()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, size)
+	}
+	assert.Empty(t, scaledUp)
+	assert.Empty(t, scaledDown)
+	assert.Len(t, provider.NodeGroups(), 1, "node group shouldn't be deleted")
+
+	notAutoscaled, err := dryRunProvider.NodeGroupForNode(BuildTestNode("n2", 1000, 1000))
+	assert.NoError(t, err)
+	assert.Nil(t, notAutoscaled)
+}
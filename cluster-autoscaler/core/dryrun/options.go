@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"fmt"
+
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	klog "k8s.io/klog/v2"
+)
+
+// CheckOptions returns an error if options enable dry-run with features which can't run without
+// writing to objects in the cluster. ProvisioningRequest conditions are updated as part of the scale-up
+// decision, so they can't be skipped without changing the decisions of the dry-run instance.
+func CheckOptions(options config.AutoscalingOptions) error {
+	if options.DryRun && options.ProvisioningRequestEnabled {
+		return fmt.Errorf("--enable-provisioning-requests can't be used with --dry-run, ProvisioningRequest conditions would be updated")
+	}
+	return nil
+}
+
+// DisableWrites turns off options making the autoscaler write to objects in the cluster which
+// may be shared with the instance actively scaling it: the status ConfigMap and CRs, the state
+// ConfigMap and pod conditions explaining missing scale-ups. The state of a dry-run instance is
+// only kept in memory.
+func DisableWrites(options *config.AutoscalingOptions) {
+	disable := func(enabled *bool, what string) {
+		if *enabled {
+			klog.Infof("Dry-run mode, not writing %s", what)
+			*enabled = false
+		}
+	}
+	disable(&options.WriteStatusConfigMap, "the status ConfigMap")
+	disable(&options.WriteStatusCRD, "status CRs")
+	disable(&options.WriteStateConfigMap, "the state ConfigMap")
+	disable(&options.PodScaleUpExplanations, "pod scale-up explanations")
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+)
+
+func TestDisableWrites(t *testing.T) {
+	options := config.AutoscalingOptions{
+		DryRun:                 true,
+		WriteStatusConfigMap:   true,
+		StatusConfigMapName:    "cluster-autoscaler-status",
+		WriteStatusCRD:         true,
+		WriteStateConfigMap:    true,
+		PodScaleUpExplanations: true,
+	}
+	DisableWrites(&options)
+	assert.Equal(t, config.AutoscalingOptions{
+		DryRun:              true,
+		StatusConfigMapName: "cluster-autoscaler-status",
+	}, options)
+}
+
+func TestCheckOptions(t *testing.T) {
+	assert.NoError(t, CheckOptions(config.AutoscalingOptions{DryRun: true}))
+	assert.NoError(t, CheckOptions(config.AutoscalingOptions{ProvisioningRequestEnabled: true}))
+	assert.Error(t, CheckOptions(config.AutoscalingOptions{DryRun: true, ProvisioningRequestEnabled: true}))
+}
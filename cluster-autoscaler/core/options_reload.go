@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	stdcontext "context"
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/config/reload"
+	"k8s.io/autoscaler/cluster-autoscaler/estimator"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
+	klog "k8s.io/klog/v2"
+)
+
+// reloadableOptions are the AutoscalingOptions fields UpdateOptions knows how to apply.
+var reloadableOptions = sets.New(
+	"ExpanderNames",
+	"MaxNodesTotal",
+	"MinCoresTotal",
+	"MaxCoresTotal",
+	"MinMemoryTotal",
+	"MaxMemoryTotal",
+	"ScaleDownEnabled",
+	"ScaleDownDelayAfterAdd",
+	"ScaleDownDelayAfterDelete",
+	"ScaleDownDelayAfterFailure",
+	"NodeGroupDefaults",
+	"BalancingExtraIgnoredLabels",
+	"BalancingLabels",
+	"MaxNodesPerScaleUp",
+	"MaxNodeGroupBinpackingDuration",
+)
+
+// resourceLimiterOverride replaces resource limits of the wrapped cloud provider which were
+// changed in reloaded options. Other limits of the cloud provider are kept.
+type resourceLimiterOverride struct {
+	cloudprovider.CloudProvider
+	minLimits map[string]int64
+	maxLimits map[string]int64
+}
+
+// GetResourceLimiter returns struct containing limits (max, min) for resources (cores, memory etc.).
+func (p *resourceLimiterOverride) GetResourceLimiter() (*cloudprovider.ResourceLimiter, error) {
+	limiter, err := p.CloudProvider.GetResourceLimiter()
+	if err != nil {
+		return nil, err
+	}
+	minLimits := make(map[string]int64)
+	maxLimits := make(map[string]int64)
+	var nodeGroupSetLimits []cloudprovider.NodeGroupSetLimit
+	if limiter != nil {
+		for _, resource := range limiter.GetResources() {
+			if limiter.HasMinLimitSet(resource) {
+				minLimits[resource] = limiter.GetMin(resource)
+			}
+			if limiter.HasMaxLimitSet(resource) {
+				maxLimits[resource] = limiter.GetMax(resource)
+			}
+		}
+		nodeGroupSetLimits = limiter.GetNodeGroupSetLimits()
+	}
+	for resource, value := range p.minLimits {
+		minLimits[resource] = value
+	}
+	for resource, value := range p.maxLimits {
+		maxLimits[resource] = value
+	}
+	return cloudprovider.NewResourceLimiter(minLimits, maxLimits).WithNodeGroupSetLimits(nodeGroupSetLimits), nil
+}
+
+// WithContext returns a copy of the override wrapping the cloud provider making outgoing calls with ctx.
+func (p *resourceLimiterOverride) WithContext(ctx stdcontext.Context) cloudprovider.CloudProvider {
+	return &resourceLimiterOverride{
+		CloudProvider: tracing.Bind(p.CloudProvider, ctx),
+		minLimits:     p.minLimits,
+		maxLimits:     p.maxLimits,
+	}
+}
+
+// overrideResourceLimits returns the cloud provider with resource limits changed in options overridden.
+func overrideResourceLimits(provider cloudprovider.CloudProvider, options config.AutoscalingOptions, changed sets.Set[string]) cloudprovider.CloudProvider {
+	override := &resourceLimiterOverride{
+		CloudProvider: provider,
+		minLimits:     make(map[string]int64),
+		maxLimits:     make(map[string]int64),
+	}
+	if previous, ok := provider.(*resourceLimiterOverride); ok {
+		override.CloudProvider = previous.CloudProvider
+		for resource, value := range previous.minLimits {
+			override.minLimits[resource] = value
+		}
+		for resource, value := range previous.maxLimits {
+			override.maxLimits[resource] = value
+		}
+	}
+	if changed.Has("MinCoresTotal") {
+		override.minLimits[cloudprovider.ResourceNameCores] = options.MinCoresTotal
+	}
+	if changed.Has("MaxCoresTotal") {
+		override.maxLimits[cloudprovider.ResourceNameCores] = options.MaxCoresTotal
+	}
+	if changed.Has("MinMemoryTotal") {
+		override.minLimits[cloudprovider.ResourceNameMemory] = options.MinMemoryTotal
+	}
+	if changed.Has("MaxMemoryTotal") {
+		override.maxLimits[cloudprovider.ResourceNameMemory] = options.MaxMemoryTotal
+	}
+	return override
+}
+
+// reloadOptions applies options changed in the options source since the previous iteration.
+func (a *StaticAutoscaler) reloadOptions() {
+	if a.optionsReloader == nil {
+		return
+	}
+	options, err := a.optionsReloader.Poll()
+	if err == nil && options != nil {
+		err = a.UpdateOptions(*options)
+	}
+	if err != nil {
+		klog.Errorf("Failed to reload autoscaling options: %v", err)
+		a.LogRecorder.Eventf(apiv1.EventTypeWarning, "OptionsReloadRejected", "Reloaded autoscaling options rejected: %v", err)
+		metrics.RegisterOptionsReload(metrics.OptionsReloadRejected)
+	}
+}
+
+// UpdateOptions replaces the autoscaling options between iterations. Components capturing
+// the options at construction time are updated in place or rebuilt, while the state of
+// the autoscaler (unneeded nodes, backoff, cluster state) is preserved. Changes of options
+// which can't be applied at runtime are rejected and no option is changed.
+func (a *StaticAutoscaler) UpdateOptions(options config.AutoscalingOptions) error {
+	changed := reload.ChangedOptions(a.AutoscalingOptions, options)
+	if len(changed) == 0 {
+		return nil
+	}
+	changedSet := sets.New(changed...)
+	if immutable := changedSet.Difference(reloadableOptions); immutable.Len() > 0 {
+		return fmt.Errorf("options %s can't be changed without restarting cluster autoscaler", strings.Join(sets.List(immutable), ", "))
+	}
+
+	// Build all new components before changing anything, so a failure leaves the autoscaler intact.
+	expanderStrategy := a.ExpanderStrategy
+	if changedSet.Has("ExpanderNames") {
+		var err error
+		expanderStrategy, err = buildExpanderStrategy(options, a.CloudProvider, &a.AutoscalingKubeClients, a.ClientSet)
+		if err != nil {
+			return err
+		}
+	}
+	var estimatorBuilder estimator.EstimatorBuilder
+	if changedSet.HasAny("MaxNodesPerScaleUp", "MaxNodeGroupBinpackingDuration") {
+		var err error
+		estimatorBuilder, err = buildEstimatorBuilder(options, a.processors.NodeGroupConfigProcessor)
+		if err != nil {
+			return err
+		}
+	}
+
+	a.AutoscalingOptions = options
+	a.ExpanderStrategy = expanderStrategy
+	if estimatorBuilder != nil {
+		a.scaleUpOrchestrator.Initialize(a.AutoscalingContext, a.processors, a.clusterStateRegistry, estimatorBuilder, a.taintConfig)
+	}
+	if changedSet.HasAny("MinCoresTotal", "MaxCoresTotal", "MinMemoryTotal", "MaxMemoryTotal") {
+		a.CloudProvider = overrideResourceLimits(a.CloudProvider, options, changedSet)
+		metrics.UpdateCPULimitsCores(options.MinCoresTotal, options.MaxCoresTotal)
+		metrics.UpdateMemoryLimitsBytes(options.MinMemoryTotal, options.MaxMemoryTotal)
+	}
+	a.processors.UpdateOptions(options)
+
+	klog.Infof("Reloaded autoscaling options: %s", strings.Join(changed, ", "))
+	a.LogRecorder.Eventf(apiv1.EventTypeNormal, "OptionsReloaded", "Reloaded autoscaling options: %s", strings.Join(changed, ", "))
+	metrics.RegisterOptionsReload(metrics.OptionsReloadApplied)
+	return nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	stdcontext "context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/config/reload"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/orchestrator"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/estimator"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeOptionsSource struct {
+	overrides map[string]string
+}
+
+func (s *fakeOptionsSource) Load() (map[string]string, error) {
+	return s.overrides, nil
+}
+
+func newOptionsReloadTestAutoscaler(t *testing.T, options config.AutoscalingOptions) *StaticAutoscaler {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng", 0, 10, 1)
+	autoscalingContext, err := NewScaleTestAutoscalingContext(options, fake.NewSimpleClientset(), nil, provider, nil, nil)
+	assert.NoError(t, err)
+	expanderStrategy, err := buildExpanderStrategy(options, provider, &autoscalingContext.AutoscalingKubeClients, autoscalingContext.ClientSet)
+	assert.NoError(t, err)
+	autoscalingContext.ExpanderStrategy = expanderStrategy
+	processors := NewTestProcessors(&autoscalingContext)
+	scaleUpOrchestrator := orchestrator.New()
+	scaleUpOrchestrator.Initialize(&autoscalingContext, processors, nil, newEstimatorBuilder(), taints.TaintConfig{})
+	return &StaticAutoscaler{
+		AutoscalingContext:  &autoscalingContext,
+		processors:          processors,
+		scaleUpOrchestrator: scaleUpOrchestrator,
+	}
+}
+
+func TestUpdateOptions(t *testing.T) {
+	options := config.AutoscalingOptions{
+		ExpanderNames:      expander.RandomExpanderName,
+		EstimatorName:      estimator.BinpackingEstimatorName,
+		MaxNodesPerScaleUp: 100,
+		MaxCoresTotal:      100,
+		MaxMemoryTotal:     1000,
+		NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+			ScaleDownUnneededTime: 10 * time.Minute,
+		},
+	}
+	nodeGroup := testprovider.NewTestCloudProvider(nil, nil).BuildNodeGroup("ng", 0, 10, 1, false, "", nil)
+
+	for tn, tc := range map[string]struct {
+		update      func(*config.AutoscalingOptions)
+		wantErr     bool
+		wantChanged bool
+	}{
+		"no change": {
+			update: func(*config.AutoscalingOptions) {},
+		},
+		"reloadable options": {
+			update: func(o *config.AutoscalingOptions) {
+				o.ExpanderNames = expander.LeastWasteExpanderName
+				o.MaxCoresTotal = 50
+				o.MaxNodesPerScaleUp = 10
+				o.NodeGroupDefaults.ScaleDownUnneededTime = 5 * time.Minute
+			},
+			wantChanged: true,
+		},
+		"immutable option": {
+			update: func(o *config.AutoscalingOptions) {
+				o.MaxCoresTotal = 50
+				o.ParallelDrain = true
+			},
+			wantErr: true,
+		},
+		"unknown expander": {
+			update: func(o *config.AutoscalingOptions) {
+				o.ExpanderNames = "unknown"
+				o.MaxCoresTotal = 50
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			a := newOptionsReloadTestAutoscaler(t, options)
+			oldStrategy := a.ExpanderStrategy
+			newOptions := options
+			tc.update(&newOptions)
+
+			err := a.UpdateOptions(newOptions)
+			assert.Equal(t, tc.wantErr, err != nil, "unexpected error: %v", err)
+
+			wantOptions := options
+			if tc.wantChanged {
+				wantOptions = newOptions
+			}
+			assert.Equal(t, wantOptions, a.AutoscalingOptions)
+			assert.Equal(t, wantOptions.ExpanderNames != options.ExpanderNames, a.ExpanderStrategy != oldStrategy)
+			unneededTime, err := a.processors.NodeGroupConfigProcessor.GetScaleDownUnneededTime(nodeGroup)
+			assert.NoError(t, err)
+			assert.Equal(t, wantOptions.NodeGroupDefaults.ScaleDownUnneededTime, unneededTime)
+			resourceLimiter, err := a.CloudProvider.GetResourceLimiter()
+			assert.NoError(t, err)
+			if tc.wantChanged {
+				assert.Equal(t, wantOptions.MaxCoresTotal, resourceLimiter.GetMax(cloudprovider.ResourceNameCores))
+			}
+		})
+	}
+}
+
+type contextualCloudProvider struct {
+	*testprovider.TestCloudProvider
+	ctx stdcontext.Context
+}
+
+func (p *contextualCloudProvider) WithContext(ctx stdcontext.Context) cloudprovider.CloudProvider {
+	return &contextualCloudProvider{TestCloudProvider: p.TestCloudProvider, ctx: ctx}
+}
+
+type testContextKey struct{}
+
+func TestOverrideResourceLimits(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.SetResourceLimiter(cloudprovider.NewResourceLimiter(
+		map[string]int64{cloudprovider.ResourceNameCores: 1},
+		map[string]int64{cloudprovider.ResourceNameCores: 100, cloudprovider.ResourceNameMemory: 1000, "nvidia.com/gpu": 8}))
+
+	// Limits which didn't change are taken from the cloud provider.
+	overridden := overrideResourceLimits(&contextualCloudProvider{TestCloudProvider: provider},
+		config.AutoscalingOptions{MaxCoresTotal: 50}, sets.New("MaxCoresTotal"))
+	limiter, err := overridden.GetResourceLimiter()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), limiter.GetMin(cloudprovider.ResourceNameCores))
+	assert.Equal(t, int64(50), limiter.GetMax(cloudprovider.ResourceNameCores))
+	assert.Equal(t, int64(1000), limiter.GetMax(cloudprovider.ResourceNameMemory))
+	assert.Equal(t, int64(8), limiter.GetMax("nvidia.com/gpu"))
+
+	// Overrides of subsequent reloads are combined.
+	overridden = overrideResourceLimits(overridden, config.AutoscalingOptions{MaxCoresTotal: 50, MaxMemoryTotal: 500}, sets.New("MaxMemoryTotal"))
+	limiter, err = overridden.GetResourceLimiter()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), limiter.GetMax(cloudprovider.ResourceNameCores))
+	assert.Equal(t, int64(500), limiter.GetMax(cloudprovider.ResourceNameMemory))
+
+	// The trace context is still passed to the cloud provider.
+	traceContext := stdcontext.WithValue(stdcontext.Background(), testContextKey{}, "trace")
+	bound := tracing.Bind(overridden, traceContext)
+	override, ok := bound.(*resourceLimiterOverride)
+	if assert.True(t, ok) {
+		contextual, ok := override.CloudProvider.(*contextualCloudProvider)
+		if assert.True(t, ok) {
+			assert.Equal(t, traceContext, contextual.ctx)
+		}
+	}
+	limiter, err = bound.GetResourceLimiter()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500), limiter.GetMax(cloudprovider.ResourceNameMemory))
+}
+
+func TestReloadOptions(t *testing.T) {
+	options := config.AutoscalingOptions{
+		ExpanderNames:      expander.RandomExpanderName,
+		EstimatorName:      estimator.BinpackingEstimatorName,
+		MaxNodesPerScaleUp: 100,
+		MaxNodesTotal:      10,
+	}
+	a := newOptionsReloadTestAutoscaler(t, options)
+	source := &fakeOptionsSource{}
+	a.optionsReloader = reload.NewReloader(source, options)
+
+	source.overrides = map[string]string{"max-nodes-total": "20"}
+	a.reloadOptions()
+	assert.Equal(t, 20, a.MaxNodesTotal)
+
+	// Rejected overrides keep the previously applied options.
+	source.overrides = map[string]string{"max-nodes-total": "30", "parallel-drain": "true"}
+	a.reloadOptions()
+	assert.Equal(t, 20, a.MaxNodesTotal)
+
+	// Removed overrides restore options the autoscaler was started with.
+	source.overrides = nil
+	a.reloadOptions()
+	assert.Equal(t, 10, a.MaxNodesTotal)
+}
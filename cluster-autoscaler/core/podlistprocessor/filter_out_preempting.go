@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podlistprocessor
+
+import (
+	"sort"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	core_utils "k8s.io/autoscaler/cluster-autoscaler/core/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/predicatechecker"
+	caerrors "k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	klog "k8s.io/klog/v2"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+type filterOutPreemptingPodListProcessor struct {
+	predicateChecker predicatechecker.PredicateChecker
+	nodeFilter       func(*schedulerframework.NodeInfo) bool
+}
+
+// preemptionCandidate is a node on which a pod fits after preempting victims.
+type preemptionCandidate struct {
+	nodeName string
+	victims  []*apiv1.Pod
+}
+
+// NewFilterOutPreemptingPodListProcessor creates a PodListProcessor filtering out pods
+// which the scheduler will schedule by preempting lower priority pods on existing nodes.
+func NewFilterOutPreemptingPodListProcessor(predicateChecker predicatechecker.PredicateChecker, nodeFilter func(*schedulerframework.NodeInfo) bool) *filterOutPreemptingPodListProcessor {
+	return &filterOutPreemptingPodListProcessor{
+		predicateChecker: predicateChecker,
+		nodeFilter:       nodeFilter,
+	}
+}
+
+// Process simulates preemption of lower priority pods for unschedulable pods, highest
+// priority first. Pods which fit on an existing node after preemption are filtered out
+// and added to the snapshot, while preempted pods which don't fit elsewhere and aren't
+// expendable are returned instead, as they will need new capacity. Preempted pods without
+// a controller are ignored, as nothing recreates them.
+func (p *filterOutPreemptingPodListProcessor) Process(context *context.AutoscalingContext, unschedulablePods []*apiv1.Pod) ([]*apiv1.Pod, error) {
+	if !context.AutoscalingOptions.SimulatePreemption || len(unschedulablePods) == 0 {
+		return unschedulablePods, nil
+	}
+	candidates := make([]*apiv1.Pod, len(unschedulablePods))
+	copy(candidates, unschedulablePods)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return corev1helpers.PodPriority(candidates[i]) > corev1helpers.PodPriority(candidates[j])
+	})
+
+	var remaining, victims []*apiv1.Pod
+	for _, pod := range candidates {
+		if pod.Spec.PreemptionPolicy != nil && *pod.Spec.PreemptionPolicy == apiv1.PreemptNever {
+			remaining = append(remaining, pod)
+			continue
+		}
+		candidate, err := p.findPreemptionCandidate(context.ClusterSnapshot, pod)
+		if err != nil {
+			return nil, err
+		}
+		if candidate == nil {
+			remaining = append(remaining, pod)
+			continue
+		}
+		if err := p.preempt(context.ClusterSnapshot, pod, candidate); err != nil {
+			return nil, err
+		}
+		klog.V(4).Infof("Pod %s/%s will be scheduled on %s after preempting %d lower priority pods. Ignoring in scale up.", pod.Namespace, pod.Name, candidate.nodeName, len(candidate.victims))
+		victims = append(victims, candidate.victims...)
+	}
+
+	for _, victim := range victims {
+		if core_utils.IsExpendablePod(victim, context.AutoscalingOptions.ExpendablePodsPriorityCutoff) {
+			continue
+		}
+		if metav1.GetControllerOf(victim) == nil {
+			klog.V(4).Infof("Pod %s/%s preempted in simulation has no controller, it won't be recreated", victim.Namespace, victim.Name)
+			continue
+		}
+		pending := pendingCopy(victim)
+		nodeName, err := p.predicateChecker.FitsAnyNodeMatching(context.ClusterSnapshot, pending, p.nodeFilter)
+		if err == nil {
+			if err := context.ClusterSnapshot.AddPod(pending, nodeName); err != nil {
+				return nil, caerrors.ToAutoscalerError(caerrors.InternalError, err)
+			}
+			continue
+		}
+		klog.V(4).Infof("Pod %s/%s preempted in simulation doesn't fit on existing nodes", victim.Namespace, victim.Name)
+		remaining = append(remaining, pending)
+	}
+	return remaining, nil
+}
+
+func (p *filterOutPreemptingPodListProcessor) CleanUp() {
+}
+
+// findPreemptionCandidate finds the node on which the pod fits after preempting pods with
+// the lowest priority, preferring fewer victims. It returns nil if there is no such node.
+func (p *filterOutPreemptingPodListProcessor) findPreemptionCandidate(snapshot clustersnapshot.ClusterSnapshot, pod *apiv1.Pod) (*preemptionCandidate, error) {
+	nodeInfos, err := snapshot.NodeInfos().List()
+	if err != nil {
+		return nil, caerrors.ToAutoscalerError(caerrors.InternalError, err)
+	}
+	var best *preemptionCandidate
+	var bestPriority int32
+	for _, nodeInfo := range nodeInfos {
+		if p.nodeFilter != nil && !p.nodeFilter(nodeInfo) {
+			continue
+		}
+		victims, err := p.selectVictims(snapshot, pod, nodeInfo)
+		if err != nil {
+			return nil, err
+		}
+		if len(victims) == 0 {
+			continue
+		}
+		// Victims are sorted by decreasing priority.
+		priority := corev1helpers.PodPriority(victims[0])
+		if best == nil || priority < bestPriority || (priority == bestPriority && len(victims) < len(best.victims)) {
+			best = &preemptionCandidate{nodeName: nodeInfo.Node().Name, victims: victims}
+			bestPriority = priority
+		}
+	}
+	return best, nil
+}
+
+// selectVictims returns the minimal set of lower priority pods on the node which need to be
+// preempted for the pod to fit, sorted by decreasing priority. Like the scheduler, it removes
+// all lower priority pods and then reprieves as many of them as possible, highest priority first.
+// Unlike the scheduler, it doesn't prefer reprieving pods whose eviction would violate a
+// PodDisruptionBudget, nor nodes with fewer such violations.
+func (p *filterOutPreemptingPodListProcessor) selectVictims(snapshot clustersnapshot.ClusterSnapshot, pod *apiv1.Pod, nodeInfo *schedulerframework.NodeInfo) ([]*apiv1.Pod, error) {
+	nodeName := nodeInfo.Node().Name
+	podPriority := corev1helpers.PodPriority(pod)
+	var potentialVictims []*apiv1.Pod
+	for _, podInfo := range nodeInfo.Pods {
+		if corev1helpers.PodPriority(podInfo.Pod) < podPriority && !pod_util.IsMirrorPod(podInfo.Pod) {
+			potentialVictims = append(potentialVictims, podInfo.Pod)
+		}
+	}
+	if len(potentialVictims) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(potentialVictims, func(i, j int) bool {
+		return corev1helpers.PodPriority(potentialVictims[i]) > corev1helpers.PodPriority(potentialVictims[j])
+	})
+
+	var victims []*apiv1.Pod
+	snapshot.Fork()
+	defer snapshot.Revert()
+	for _, victim := range potentialVictims {
+		if err := snapshot.RemovePod(victim.Namespace, victim.Name, nodeName); err != nil {
+			return nil, caerrors.ToAutoscalerError(caerrors.InternalError, err)
+		}
+	}
+	if p.predicateChecker.CheckPredicates(snapshot, pod, nodeName) != nil {
+		return nil, nil
+	}
+	for _, victim := range potentialVictims {
+		if err := snapshot.AddPod(victim, nodeName); err != nil {
+			return nil, caerrors.ToAutoscalerError(caerrors.InternalError, err)
+		}
+		if p.predicateChecker.CheckPredicates(snapshot, pod, nodeName) == nil {
+			continue
+		}
+		if err := snapshot.RemovePod(victim.Namespace, victim.Name, nodeName); err != nil {
+			return nil, caerrors.ToAutoscalerError(caerrors.InternalError, err)
+		}
+		victims = append(victims, victim)
+	}
+	return victims, nil
+}
+
+// preempt removes the victims from the snapshot and schedules the pod in their place.
+func (p *filterOutPreemptingPodListProcessor) preempt(snapshot clustersnapshot.ClusterSnapshot, pod *apiv1.Pod, candidate *preemptionCandidate) error {
+	for _, victim := range candidate.victims {
+		if err := snapshot.RemovePod(victim.Namespace, victim.Name, candidate.nodeName); err != nil {
+			return caerrors.ToAutoscalerError(caerrors.InternalError, err)
+		}
+	}
+	if err := snapshot.AddPod(pod, candidate.nodeName); err != nil {
+		klog.Errorf("Failed to update snapshot with pod %s/%s preempting lower priority pods: %v", pod.Namespace, pod.Name, err)
+		return caerrors.ToAutoscalerError(caerrors.InternalError, err)
+	}
+	return nil
+}
+
+// pendingCopy returns a copy of a preempted pod as it will be seen once recreated by its controller.
+func pendingCopy(pod *apiv1.Pod) *apiv1.Pod {
+	pending := pod.DeepCopy()
+	pending.Spec.NodeName = ""
+	pending.Status = apiv1.PodStatus{Phase: apiv1.PodPending}
+	return pending
+}
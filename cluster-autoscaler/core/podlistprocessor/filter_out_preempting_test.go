@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podlistprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/predicatechecker"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/scheduling"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestFilterOutPreempting(t *testing.T) {
+	preemptNever := apiv1.PreemptNever
+	testCases := map[string]struct {
+		disabled        bool
+		nodes           []*apiv1.Node
+		scheduledPods   []*apiv1.Pod
+		pods            []*apiv1.Pod
+		wantPods        []string
+		wantPodsOnNodes map[string][]string
+	}{
+		"disabled": {
+			disabled:        true,
+			nodes:           []*apiv1.Node{BuildTestNode("n1", 2000, 1000)},
+			scheduledPods:   []*apiv1.Pod{BuildTestPod("low", 2000, 1, WithNodeName("n1"))},
+			pods:            []*apiv1.Pod{BuildTestPod("high", 1000, 1, priority(100))},
+			wantPods:        []string{"high"},
+			wantPodsOnNodes: map[string][]string{"n1": {"low"}},
+		},
+		"preempted pod needs new capacity": {
+			nodes: []*apiv1.Node{BuildTestNode("n1", 2000, 1000)},
+			scheduledPods: []*apiv1.Pod{
+				BuildTestPod("low-1", 1000, 1, WithNodeName("n1"), withRSController),
+				BuildTestPod("low-2", 1000, 1, WithNodeName("n1"), priority(10), withRSController),
+			},
+			pods:            []*apiv1.Pod{BuildTestPod("high", 1000, 1, priority(100))},
+			wantPods:        []string{"low-1"},
+			wantPodsOnNodes: map[string][]string{"n1": {"low-2", "high"}},
+		},
+		"expendable preempted pod": {
+			nodes:           []*apiv1.Node{BuildTestNode("n1", 2000, 1000)},
+			scheduledPods:   []*apiv1.Pod{BuildTestPod("expendable", 2000, 1, WithNodeName("n1"), priority(-20), withRSController)},
+			pods:            []*apiv1.Pod{BuildTestPod("high", 1000, 1, priority(100))},
+			wantPodsOnNodes: map[string][]string{"n1": {"high"}},
+		},
+		"preempted pod fits on another node": {
+			nodes: []*apiv1.Node{BuildTestNode("n1", 2000, 1000), BuildTestNode("n2", 2000, 1000)},
+			scheduledPods: []*apiv1.Pod{
+				BuildTestPod("low", 1000, 1, WithNodeName("n1"), withRSController),
+				BuildTestPod("mid", 1000, 1, WithNodeName("n1"), priority(50)),
+				BuildTestPod("other", 1000, 1, WithNodeName("n2"), priority(50)),
+			},
+			pods:            []*apiv1.Pod{BuildTestPod("high", 1000, 1, priority(100))},
+			wantPodsOnNodes: map[string][]string{"n1": {"mid", "high"}, "n2": {"other", "low"}},
+		},
+		"node with lowest priority victims is preferred": {
+			nodes: []*apiv1.Node{BuildTestNode("n1", 1000, 1000), BuildTestNode("n2", 1000, 1000)},
+			scheduledPods: []*apiv1.Pod{
+				BuildTestPod("mid", 1000, 1, WithNodeName("n1"), priority(50)),
+				BuildTestPod("low", 1000, 1, WithNodeName("n2"), withRSController),
+			},
+			pods:            []*apiv1.Pod{BuildTestPod("high", 1000, 1, priority(100))},
+			wantPods:        []string{"low"},
+			wantPodsOnNodes: map[string][]string{"n1": {"mid"}, "n2": {"high"}},
+		},
+		"higher priority pods preempt first": {
+			nodes:         []*apiv1.Node{BuildTestNode("n1", 1000, 1000)},
+			scheduledPods: []*apiv1.Pod{BuildTestPod("low", 1000, 1, WithNodeName("n1"), withRSController)},
+			pods: []*apiv1.Pod{
+				BuildTestPod("mid", 1000, 1, priority(50)),
+				BuildTestPod("high", 1000, 1, priority(100)),
+			},
+			wantPods:        []string{"mid", "low"},
+			wantPodsOnNodes: map[string][]string{"n1": {"high"}},
+		},
+		"preempted pod without a controller": {
+			nodes:           []*apiv1.Node{BuildTestNode("n1", 1000, 1000)},
+			scheduledPods:   []*apiv1.Pod{BuildTestPod("bare", 1000, 1, WithNodeName("n1"))},
+			pods:            []*apiv1.Pod{BuildTestPod("high", 1000, 1, priority(100))},
+			wantPodsOnNodes: map[string][]string{"n1": {"high"}},
+		},
+		"pod which doesn't preempt": {
+			nodes:           []*apiv1.Node{BuildTestNode("n1", 1000, 1000)},
+			scheduledPods:   []*apiv1.Pod{BuildTestPod("low", 1000, 1, WithNodeName("n1"))},
+			pods:            []*apiv1.Pod{BuildTestPod("high", 1000, 1, priority(100), func(pod *apiv1.Pod) { pod.Spec.PreemptionPolicy = &preemptNever })},
+			wantPods:        []string{"high"},
+			wantPodsOnNodes: map[string][]string{"n1": {"low"}},
+		},
+		"preemption doesn't free enough capacity": {
+			nodes: []*apiv1.Node{BuildTestNode("n1", 2000, 1000)},
+			scheduledPods: []*apiv1.Pod{
+				BuildTestPod("low", 500, 1, WithNodeName("n1")),
+				BuildTestPod("higher", 1500, 1, WithNodeName("n1"), priority(200)),
+			},
+			pods:            []*apiv1.Pod{BuildTestPod("high", 1000, 1, priority(100))},
+			wantPods:        []string{"high"},
+			wantPodsOnNodes: map[string][]string{"n1": {"low", "higher"}},
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			snapshot := clustersnapshot.NewBasicClusterSnapshot()
+			for _, node := range tc.nodes {
+				var pods []*apiv1.Pod
+				for _, pod := range tc.scheduledPods {
+					if pod.Spec.NodeName == node.Name {
+						pods = append(pods, pod)
+					}
+				}
+				assert.NoError(t, snapshot.AddNodeWithPods(node, pods))
+			}
+			predicateChecker, err := predicatechecker.NewTestPredicateChecker()
+			assert.NoError(t, err)
+
+			processor := NewFilterOutPreemptingPodListProcessor(predicateChecker, scheduling.ScheduleAnywhere)
+			pods, err := processor.Process(&context.AutoscalingContext{
+				ClusterSnapshot: snapshot,
+				AutoscalingOptions: config.AutoscalingOptions{
+					SimulatePreemption:           !tc.disabled,
+					ExpendablePodsPriorityCutoff: -10,
+				},
+			}, tc.pods)
+			assert.NoError(t, err)
+
+			var podNames []string
+			for _, pod := range pods {
+				assert.Empty(t, pod.Spec.NodeName)
+				podNames = append(podNames, pod.Name)
+			}
+			assert.Equal(t, tc.wantPods, podNames)
+			for nodeName, wantPods := range tc.wantPodsOnNodes {
+				nodeInfo, err := snapshot.NodeInfos().Get(nodeName)
+				assert.NoError(t, err)
+				var podsOnNode []string
+				for _, podInfo := range nodeInfo.Pods {
+					podsOnNode = append(podsOnNode, podInfo.Pod.Name)
+				}
+				assert.ElementsMatch(t, wantPods, podsOnNode, nodeName)
+			}
+		})
+	}
+}
+
+func withRSController(pod *apiv1.Pod) {
+	SetRSPodSpec(pod, "rs")
+}
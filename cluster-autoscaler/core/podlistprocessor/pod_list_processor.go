@@ -30,6 +30,7 @@ func NewDefaultPodListProcessor(predicateChecker predicatechecker.PredicateCheck
 		NewFilterOutExpendablePodListProcessor(),
 		NewCurrentlyDrainedNodesPodListProcessor(),
 		NewFilterOutSchedulablePodListProcessor(predicateChecker, nodeFilter),
+		NewFilterOutPreemptingPodListProcessor(predicateChecker, nodeFilter),
 		NewFilterOutDaemonSetPodListProcessor(),
 	})
 }
@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// MaintenanceWindow is a weekly recurring time range, in UTC, in which nodes may be rotated.
+type MaintenanceWindow struct {
+	// Days on which the window starts.
+	Days map[time.Weekday]bool
+	// Start and End are offsets from midnight. If End isn't after Start, the window
+	// ends on the next day.
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseMaintenanceWindows parses a comma separated list of windows in the "<days> <HH:MM>-<HH:MM>"
+// format, where days is a day of week ("Sat"), a range of days ("Mon-Fri") or "*" for every day,
+// e.g. "Mon-Fri 22:00-06:00,Sat 00:00-24:00".
+func ParseMaintenanceWindows(value string) ([]MaintenanceWindow, error) {
+	var windows []MaintenanceWindow
+	for _, spec := range strings.Split(value, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		window, err := parseMaintenanceWindow(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %q: %v", spec, err)
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+func parseMaintenanceWindow(spec string) (MaintenanceWindow, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 2 {
+		return MaintenanceWindow{}, fmt.Errorf("expected \"<days> <HH:MM>-<HH:MM>\"")
+	}
+	days, err := parseDays(fields[0])
+	if err != nil {
+		return MaintenanceWindow{}, err
+	}
+	times := strings.Split(fields[1], "-")
+	if len(times) != 2 {
+		return MaintenanceWindow{}, fmt.Errorf("expected time range <HH:MM>-<HH:MM>, got %q", fields[1])
+	}
+	start, err := parseTimeOfDay(times[0])
+	if err != nil {
+		return MaintenanceWindow{}, err
+	}
+	end, err := parseTimeOfDay(times[1])
+	if err != nil {
+		return MaintenanceWindow{}, err
+	}
+	return MaintenanceWindow{Days: days, Start: start, End: end}, nil
+}
+
+func parseDays(value string) (map[time.Weekday]bool, error) {
+	days := make(map[time.Weekday]bool)
+	if value == "*" {
+		for _, day := range weekdays {
+			days[day] = true
+		}
+		return days, nil
+	}
+	bounds := strings.Split(strings.ToLower(value), "-")
+	if len(bounds) > 2 {
+		return nil, fmt.Errorf("invalid days %q", value)
+	}
+	first, found := weekdays[bounds[0]]
+	if !found {
+		return nil, fmt.Errorf("unknown day %q", bounds[0])
+	}
+	last := first
+	if len(bounds) == 2 {
+		if last, found = weekdays[bounds[1]]; !found {
+			return nil, fmt.Errorf("unknown day %q", bounds[1])
+		}
+	}
+	for day := first; ; day = (day + 1) % 7 {
+		days[day] = true
+		if day == last {
+			break
+		}
+	}
+	return days, nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	var hours, minutes int
+	if _, err := fmt.Sscanf(value, "%d:%d", &hours, &minutes); err != nil {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+	if hours < 0 || minutes < 0 || minutes > 59 || hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+// Contains returns true if t falls into the window.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	t = t.UTC()
+	sinceMidnight := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if w.Start < w.End {
+		return w.Days[t.Weekday()] && sinceMidnight >= w.Start && sinceMidnight < w.End
+	}
+	// The window wraps around midnight: it is either the part started today, or the part started yesterday.
+	yesterday := (t.Weekday() + 6) % 7
+	return (w.Days[t.Weekday()] && sinceMidnight >= w.Start) || (w.Days[yesterday] && sinceMidnight < w.End)
+}
+
+// InMaintenanceWindow returns true if there are no windows, or t falls into any of them.
+func InMaintenanceWindow(windows []MaintenanceWindow, t time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	for _, window := range windows {
+		if window.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMaintenanceWindows(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		"empty":          {},
+		"single day":     {value: "Sat 02:00-06:00", want: 1},
+		"multiple":       {value: "Mon-Fri 22:00-06:00, Sat 00:00-24:00", want: 2},
+		"every day":      {value: "* 01:00-02:00", want: 1},
+		"unknown day":    {value: "Someday 01:00-02:00", wantErr: true},
+		"missing range":  {value: "Sat", wantErr: true},
+		"invalid time":   {value: "Sat 25:00-26:00", wantErr: true},
+		"invalid minute": {value: "Sat 01:60-02:00", wantErr: true},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			windows, err := ParseMaintenanceWindows(tc.value)
+			assert.Equal(t, tc.wantErr, err != nil, "unexpected error: %v", err)
+			assert.Len(t, windows, tc.want)
+		})
+	}
+}
+
+func TestInMaintenanceWindow(t *testing.T) {
+	windows, err := ParseMaintenanceWindows("Mon-Fri 22:00-06:00,Sat 00:00-24:00")
+	assert.NoError(t, err)
+
+	// 2024-05-06 is a Monday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 5, day, hour, minute, 0, 0, time.UTC)
+	}
+	for tn, tc := range map[string]struct {
+		time time.Time
+		want bool
+	}{
+		"monday evening":       {time: at(6, 23, 0), want: true},
+		"tuesday early hours":  {time: at(7, 5, 59), want: true},
+		"tuesday morning":      {time: at(7, 6, 0), want: false},
+		"monday early hours":   {time: at(6, 3, 0), want: false},
+		"saturday early hours": {time: at(11, 3, 0), want: true},
+		"saturday afternoon":   {time: at(11, 15, 0), want: true},
+		"sunday early hours":   {time: at(12, 3, 0), want: false},
+		"other time zone":      {time: time.Date(2024, 5, 7, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*3600)), want: true},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.want, InMaintenanceWindow(windows, tc.time))
+		})
+	}
+	assert.True(t, InMaintenanceWindow(nil, at(6, 12, 0)), "no windows means any time")
+}
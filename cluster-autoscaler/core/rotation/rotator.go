@@ -0,0 +1,355 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotation
+
+import (
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/eligibility"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	klog "k8s.io/klog/v2"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const (
+	// retryDelay is how long a node whose rotation failed isn't rotated again.
+	retryDelay = 30 * time.Minute
+	// drainStartTimeout is how long a rotation may wait for the deletion of its node to start once
+	// the replacement is ready, e.g. when scale-down budgets keep the node from being deleted.
+	drainStartTimeout = time.Hour
+)
+
+// OutdatedNodeDetector may be implemented by node groups able to tell if a node was
+// created from a previous version of the node group template, e.g. an old image or
+// launch template. Such nodes are rotated regardless of their age.
+type OutdatedNodeDetector interface {
+	IsNodeOutdated(node *apiv1.Node) (bool, error)
+}
+
+// ScaleDownBlocker may keep nodes from being scaled down, e.g. when an operator paused scale-down
+// or protected nodes through the admin API.
+type ScaleDownBlocker interface {
+	ScaleDownPaused(now time.Time) bool
+	FilterScaleDownCandidates(cp cloudprovider.CloudProvider, nodes []*apiv1.Node, now time.Time) []*apiv1.Node
+}
+
+type rotation struct {
+	nodeGroupId string
+	startTime   time.Time
+	// surge is true if the node group was scaled up to make room for pods of the rotated node.
+	surge bool
+	// draining is true once the deletion of the rotated node was started.
+	draining bool
+	// replacementReadyTime is when the replacement was first seen ready, or when the deletion of the
+	// rotated node was first attempted if no replacement was added.
+	replacementReadyTime time.Time
+}
+
+// Rotator replaces nodes older than the max node age, or outdated according to their node
+// group, during maintenance windows. A replacement is requested first by increasing the size
+// of the node group, and the old node is drained by the Actuator once the replacement is
+// ready, so scale-down budgets and PDBs are respected as for any other scale-down.
+// At most one node per node group is rotated at a time. Node groups scaled in
+// ZeroOrMaxNodeScaling mode aren't rotated, as their nodes can't be deleted one by one.
+// Nodes protected from scale-down aren't rotated or drained.
+type Rotator struct {
+	context     *context.AutoscalingContext
+	csr         *clusterstate.ClusterStateRegistry
+	actuator    scaledown.Actuator
+	scaler      scaleup.NodeGroupScaler
+	blocker     ScaleDownBlocker
+	maxAge      time.Duration
+	outdated    bool
+	windows     []MaintenanceWindow
+	maxInFlight int
+	inProgress  map[string]*rotation
+	retryAfter  map[string]time.Time
+}
+
+// NewRotator returns a Rotator configured by the autoscaling options. Replacement nodes are
+// requested from scaler, so they respect the same limits as regular scale-ups.
+func NewRotator(context *context.AutoscalingContext, csr *clusterstate.ClusterStateRegistry, actuator scaledown.Actuator, scaler scaleup.NodeGroupScaler) (*Rotator, error) {
+	windows, err := ParseMaintenanceWindows(context.NodeRotationMaintenanceWindows)
+	if err != nil {
+		return nil, err
+	}
+	maxInFlight := context.MaxNodeRotationsInParallel
+	if maxInFlight <= 0 {
+		maxInFlight = 1
+	}
+	return &Rotator{
+		context:     context,
+		csr:         csr,
+		actuator:    actuator,
+		scaler:      scaler,
+		maxAge:      context.MaxNodeAge,
+		outdated:    context.RotateOutdatedNodes,
+		windows:     windows,
+		maxInFlight: maxInFlight,
+		inProgress:  make(map[string]*rotation),
+		retryAfter:  make(map[string]time.Time),
+	}, nil
+}
+
+// SetScaleDownBlocker makes the rotator respect scale-down pauses and node protections of blocker.
+func (r *Rotator) SetScaleDownBlocker(blocker ScaleDownBlocker) {
+	r.blocker = blocker
+}
+
+// NodeGroupsInRotation returns ids of node groups in which a node is being rotated. Nodes
+// of these node groups shouldn't be scaled down, as the replacement node would be seen as
+// unneeded until the rotated node is drained.
+func (r *Rotator) NodeGroupsInRotation() map[string]bool {
+	result := make(map[string]bool, len(r.inProgress))
+	for _, rot := range r.inProgress {
+		result[rot.nodeGroupId] = true
+	}
+	return result
+}
+
+// Rotate advances rotations in progress and starts new ones. readyNodes are used to tell when
+// replacements are ready and, with nodeInfos, to check limits of replacement nodes.
+func (r *Rotator) Rotate(allNodes, readyNodes []*apiv1.Node, nodeInfos map[string]*schedulerframework.NodeInfo, currentTime time.Time) {
+	nodesByName := make(map[string]*apiv1.Node, len(allNodes))
+	for _, node := range allNodes {
+		nodesByName[node.Name] = node
+	}
+	r.advance(nodesByName, readyNodes, currentTime)
+	if !InMaintenanceWindow(r.windows, currentTime) {
+		return
+	}
+	r.start(allNodes, readyNodes, nodeInfos, currentTime)
+}
+
+func (r *Rotator) advance(nodesByName map[string]*apiv1.Node, readyNodes []*apiv1.Node, currentTime time.Time) {
+	empty, drained := r.actuator.CheckStatus().DeletionsInProgress()
+	beingDeleted := make(map[string]bool, len(empty)+len(drained))
+	for _, name := range append(empty, drained...) {
+		beingDeleted[name] = true
+	}
+
+	for nodeName, rot := range r.inProgress {
+		node, found := nodesByName[nodeName]
+		if !found {
+			klog.V(1).Infof("Rotation of node %s finished", nodeName)
+			delete(r.inProgress, nodeName)
+			continue
+		}
+		if rot.draining {
+			if !beingDeleted[nodeName] {
+				// Either the node is about to disappear, or it couldn't be drained. Don't retry
+				// immediately in the latter case, the replacement will be scaled down if unneeded.
+				klog.V(1).Infof("Deletion of rotated node %s is no longer in progress", nodeName)
+				delete(r.inProgress, nodeName)
+				r.retryAfter[nodeName] = currentTime.Add(retryDelay)
+			}
+			continue
+		}
+		nodeGroup, err := r.context.CloudProvider.NodeGroupForNode(node)
+		if err != nil || nodeGroup == nil {
+			delete(r.inProgress, nodeName)
+			continue
+		}
+		if rot.surge && rot.replacementReadyTime.IsZero() && !r.replacementReady(rot, readyNodes) {
+			maxNodeProvisionTime, err := r.csr.MaxNodeProvisionTime(nodeGroup)
+			if err == nil && rot.startTime.Add(maxNodeProvisionTime).Before(currentTime) {
+				r.fail(node, rot, currentTime, "replacement node didn't become ready in time")
+			}
+			continue
+		}
+		if rot.replacementReadyTime.IsZero() {
+			rot.replacementReadyTime = currentTime
+		} else if currentTime.Sub(rot.replacementReadyTime) > drainStartTimeout {
+			r.fail(node, rot, currentTime, "deletion of the node didn't start in time")
+			continue
+		}
+		// Protection may have been added since the rotation started.
+		if r.protected(node, currentTime) {
+			klog.V(2).Infof("Not draining rotated node %s, it is protected from scale down", nodeName)
+			continue
+		}
+		_, scaledDown, err := r.actuator.StartDeletion(nil, []*apiv1.Node{node})
+		if err != nil {
+			r.fail(node, rot, currentTime, err.Error())
+			continue
+		}
+		for _, scaledDownNode := range scaledDown {
+			if scaledDownNode.Node.Name == nodeName {
+				rot.draining = true
+				klog.V(1).Infof("Draining rotated node %s", nodeName)
+				r.context.Recorder.Event(node, apiv1.EventTypeNormal, "NodeRotationDraining", "Replacement is ready, draining the node")
+				metrics.RegisterNodeRotation(metrics.NodeRotationDrained)
+			}
+		}
+	}
+}
+
+// replacementReady returns true if a node of the rotated node group created after the rotation
+// started is ready and schedulable.
+func (r *Rotator) replacementReady(rot *rotation, readyNodes []*apiv1.Node) bool {
+	// Creation timestamps have a resolution of one second.
+	since := rot.startTime.Truncate(time.Second)
+	for _, node := range readyNodes {
+		if node.CreationTimestamp.Time.Before(since) || node.Spec.Unschedulable || taints.HasToBeDeletedTaint(node) {
+			continue
+		}
+		nodeGroup, err := r.context.CloudProvider.NodeGroupForNode(node)
+		if err == nil && nodeGroup != nil && nodeGroup.Id() == rot.nodeGroupId {
+			return true
+		}
+	}
+	return false
+}
+
+// protected returns true if the node is protected from scale down by annotations of the node
+// or its pods, or through the scale-down blocker.
+func (r *Rotator) protected(node *apiv1.Node, currentTime time.Time) bool {
+	if eligibility.HasNoScaleDownAnnotation(node) {
+		return true
+	}
+	nodeInfo := r.nodeInfo(node)
+	if eligibility.HasScaleDownProtection(nodeInfo, currentTime) {
+		return true
+	}
+	if r.blocker == nil {
+		return false
+	}
+	return r.blocker.ScaleDownPaused(currentTime) || len(r.blocker.FilterScaleDownCandidates(r.context.CloudProvider, []*apiv1.Node{node}, currentTime)) == 0
+}
+
+// nodeInfo returns the node with its pods from the cluster snapshot, or just the node if it isn't there.
+func (r *Rotator) nodeInfo(node *apiv1.Node) *schedulerframework.NodeInfo {
+	if r.context.ClusterSnapshot != nil {
+		if nodeInfo, err := r.context.ClusterSnapshot.NodeInfos().Get(node.Name); err == nil {
+			return nodeInfo
+		}
+	}
+	nodeInfo := schedulerframework.NewNodeInfo()
+	nodeInfo.SetNode(node)
+	return nodeInfo
+}
+
+func (r *Rotator) fail(node *apiv1.Node, rot *rotation, currentTime time.Time, reason string) {
+	klog.Warningf("Rotation of node %s failed: %s", node.Name, reason)
+	r.context.Recorder.Eventf(node, apiv1.EventTypeWarning, "NodeRotationFailed", "Node rotation failed: %s", reason)
+	metrics.RegisterNodeRotation(metrics.NodeRotationFailed)
+	delete(r.inProgress, node.Name)
+	r.retryAfter[node.Name] = currentTime.Add(retryDelay)
+}
+
+func (r *Rotator) start(allNodes, readyNodes []*apiv1.Node, nodeInfos map[string]*schedulerframework.NodeInfo, currentTime time.Time) {
+	if len(r.inProgress) >= r.maxInFlight {
+		return
+	}
+	if r.blocker != nil && r.blocker.ScaleDownPaused(currentTime) {
+		return
+	}
+	for name, after := range r.retryAfter {
+		if after.Before(currentTime) {
+			delete(r.retryAfter, name)
+		}
+	}
+	inRotation := r.NodeGroupsInRotation()
+	candidates := make([]*apiv1.Node, len(allNodes))
+	copy(candidates, allNodes)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].CreationTimestamp.Before(&candidates[j].CreationTimestamp)
+	})
+	for _, node := range candidates {
+		if len(r.inProgress) >= r.maxInFlight {
+			return
+		}
+		if _, found := r.retryAfter[node.Name]; found || taints.HasToBeDeletedTaint(node) {
+			continue
+		}
+		nodeGroup, err := r.context.CloudProvider.NodeGroupForNode(node)
+		if err != nil || nodeGroup == nil || inRotation[nodeGroup.Id()] || isAtomic(nodeGroup, r.context.NodeGroupDefaults) {
+			continue
+		}
+		reason, due := r.dueForRotation(node, nodeGroup, currentTime)
+		if !due || r.protected(node, currentTime) || !r.canDrain(node) || !r.csr.IsNodeGroupHealthy(nodeGroup.Id()) {
+			continue
+		}
+		rot := &rotation{nodeGroupId: nodeGroup.Id(), startTime: currentTime}
+		// Without room for a replacement, the node is drained right away like during a regular scale-down.
+		added, aErr := r.scaler.ScaleUpNodeGroup(nodeGroup, 1, readyNodes, nodeInfos, currentTime)
+		if aErr != nil {
+			klog.Warningf("Failed to add a replacement of node %s to node group %s: %v", node.Name, nodeGroup.Id(), aErr)
+			continue
+		}
+		rot.surge = added > 0
+		klog.V(1).Infof("Rotating node %s of node group %s: %s", node.Name, nodeGroup.Id(), reason)
+		r.context.Recorder.Eventf(node, apiv1.EventTypeNormal, "NodeRotationStarted", "Rotating the node: %s", reason)
+		metrics.RegisterNodeRotation(metrics.NodeRotationStarted)
+		r.inProgress[node.Name] = rot
+		inRotation[nodeGroup.Id()] = true
+	}
+}
+
+// isAtomic returns true if the node group is scaled in ZeroOrMaxNodeScaling mode.
+func isAtomic(nodeGroup cloudprovider.NodeGroup, defaults config.NodeGroupAutoscalingOptions) bool {
+	options, err := nodeGroup.GetOptions(defaults)
+	if err != nil && err != cloudprovider.ErrNotImplemented {
+		klog.Warningf("Failed to get autoscaling options of node group %s: %v", nodeGroup.Id(), err)
+	}
+	return options != nil && options.ZeroOrMaxNodeScaling
+}
+
+// dueForRotation returns true and the reason if the node should be rotated.
+func (r *Rotator) dueForRotation(node *apiv1.Node, nodeGroup cloudprovider.NodeGroup, currentTime time.Time) (string, bool) {
+	if detector, ok := nodeGroup.(OutdatedNodeDetector); ok && r.outdated {
+		outdated, err := detector.IsNodeOutdated(node)
+		if err != nil {
+			klog.Warningf("Failed to check if node %s is outdated: %v", node.Name, err)
+		} else if outdated {
+			return "node template changed", true
+		}
+	}
+	if r.maxAge > 0 && currentTime.Sub(node.CreationTimestamp.Time) > r.maxAge {
+		return "node is older than " + r.maxAge.String(), true
+	}
+	return "", false
+}
+
+// canDrain returns false if pods of the node can't be evicted because of PDBs.
+func (r *Rotator) canDrain(node *apiv1.Node) bool {
+	if r.context.ClusterSnapshot == nil || r.context.RemainingPdbTracker == nil {
+		return true
+	}
+	nodeInfo, err := r.context.ClusterSnapshot.NodeInfos().Get(node.Name)
+	if err != nil {
+		return true
+	}
+	pods := make([]*apiv1.Pod, 0, len(nodeInfo.Pods))
+	for _, podInfo := range nodeInfo.Pods {
+		pods = append(pods, podInfo.Pod)
+	}
+	canRemove, _, blockingPod := r.context.RemainingPdbTracker.CanRemovePods(pods)
+	if !canRemove && blockingPod != nil {
+		klog.V(2).Infof("Not rotating node %s, pod %s/%s is blocked by a PDB", node.Name, blockingPod.Pod.Namespace, blockingPod.Pod.Name)
+	}
+	return canRemove
+}
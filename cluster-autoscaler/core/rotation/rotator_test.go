@@ -0,0 +1,374 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	clusterstate_utils "k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/eligibility"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+	kube_record "k8s.io/client-go/tools/record"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+type actuatorMock struct {
+	scaledown.Actuator
+	deleting []string
+	started  []string
+	// crop makes StartDeletion skip all nodes, as when scale-down budgets are exhausted.
+	crop bool
+}
+
+func (a *actuatorMock) StartDeletion(empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	var scaledDown []*status.ScaleDownNode
+	if a.crop {
+		return status.ScaleDownNoNodeDeleted, nil, nil
+	}
+	for _, node := range append(empty, needDrain...) {
+		a.started = append(a.started, node.Name)
+		a.deleting = append(a.deleting, node.Name)
+		scaledDown = append(scaledDown, &status.ScaleDownNode{Node: node})
+	}
+	return status.ScaleDownNodeDeleteStarted, scaledDown, nil
+}
+
+func (a *actuatorMock) CheckStatus() scaledown.ActuationStatus {
+	return a
+}
+
+func (a *actuatorMock) DeletionsInProgress() (empty, drained []string) {
+	return nil, a.deleting
+}
+
+func (a *actuatorMock) DeletionsCount(string) int {
+	return len(a.deleting)
+}
+
+func (a *actuatorMock) RecentEvictions() []*apiv1.Pod {
+	return nil
+}
+
+type scalerMock struct {
+	csr  *clusterstate.ClusterStateRegistry
+	full bool
+}
+
+func (s *scalerMock) ScaleUpNodeGroup(nodeGroup cloudprovider.NodeGroup, delta int, _ []*apiv1.Node, _ map[string]*schedulerframework.NodeInfo, now time.Time) (int, errors.AutoscalerError) {
+	if s.full {
+		return 0, nil
+	}
+	if err := nodeGroup.IncreaseSize(delta); err != nil {
+		return 0, errors.ToAutoscalerError(errors.CloudProviderError, err)
+	}
+	s.csr.RegisterScaleUp(nodeGroup, delta, now)
+	return delta, nil
+}
+
+type rotationTest struct {
+	t        *testing.T
+	provider *testprovider.TestCloudProvider
+	csr      *clusterstate.ClusterStateRegistry
+	actuator *actuatorMock
+	scaler   *scalerMock
+	rotator  *Rotator
+	nodes    []*apiv1.Node
+}
+
+func newRotationTest(t *testing.T, options config.AutoscalingOptions, now time.Time) *rotationTest {
+	return newRotationTestWithNodeGroupOptions(t, options, nil, now)
+}
+
+func newRotationTestWithNodeGroupOptions(t *testing.T, options config.AutoscalingOptions, ngOptions *config.NodeGroupAutoscalingOptions, now time.Time) *rotationTest {
+	provider := testprovider.NewTestCloudProvider(func(string, int) error { return nil }, nil)
+	provider.AddNodeGroupWithCustomOptions("ng1", 0, 3, 2, ngOptions)
+	var nodes []*apiv1.Node
+	for i, age := range []time.Duration{48 * time.Hour, time.Hour} {
+		node := BuildTestNode([]string{"old", "new"}[i], 1000, 1000)
+		node.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		SetNodeReadyState(node, true, now.Add(-age))
+		provider.AddNode("ng1", node)
+		nodes = append(nodes, node)
+	}
+
+	fakeClient := fake.NewSimpleClientset()
+	fakeLogRecorder, _ := clusterstate_utils.NewStatusMapRecorder(fakeClient, "kube-system", kube_record.NewFakeRecorder(5), false, "my-cool-configmap")
+	options.NodeGroupDefaults.MaxNodeProvisionTime = 15 * time.Minute
+	csr := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{MaxTotalUnreadyPercentage: 10, OkTotalUnreadyCount: 1},
+		fakeLogRecorder, backoff.NewIdBasedExponentialBackoff(5*time.Minute, 30*time.Minute, 3*time.Hour), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults))
+	autoscalingContext := &context.AutoscalingContext{
+		AutoscalingOptions: options,
+		CloudProvider:      provider,
+		AutoscalingKubeClients: context.AutoscalingKubeClients{
+			Recorder: kube_record.NewFakeRecorder(10),
+		},
+	}
+	actuator := &actuatorMock{}
+	scaler := &scalerMock{csr: csr}
+	rotator, err := NewRotator(autoscalingContext, csr, actuator, scaler)
+	assert.NoError(t, err)
+	return &rotationTest{t: t, provider: provider, csr: csr, actuator: actuator, scaler: scaler, rotator: rotator, nodes: nodes}
+}
+
+func (rt *rotationTest) rotate(now time.Time) {
+	assert.NoError(rt.t, rt.csr.UpdateNodes(rt.nodes, nil, now))
+	var readyNodes []*apiv1.Node
+	for _, node := range rt.nodes {
+		if ready, _, _ := kube_util.GetReadinessState(node); ready {
+			readyNodes = append(readyNodes, node)
+		}
+	}
+	rt.rotator.Rotate(rt.nodes, readyNodes, nil, now)
+}
+
+func (rt *rotationTest) addReplacement(now time.Time, ready bool) *apiv1.Node {
+	replacement := BuildTestNode("replacement", 1000, 1000)
+	replacement.CreationTimestamp = metav1.NewTime(now)
+	SetNodeReadyState(replacement, ready, now)
+	rt.provider.AddNode("ng1", replacement)
+	rt.nodes = append(rt.nodes, replacement)
+	return replacement
+}
+
+type blockerMock struct {
+	paused    bool
+	protected map[string]bool
+}
+
+func (b *blockerMock) ScaleDownPaused(time.Time) bool {
+	return b.paused
+}
+
+func (b *blockerMock) FilterScaleDownCandidates(_ cloudprovider.CloudProvider, nodes []*apiv1.Node, _ time.Time) []*apiv1.Node {
+	var result []*apiv1.Node
+	for _, node := range nodes {
+		if !b.protected[node.Name] {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+func (rt *rotationTest) targetSize() int {
+	size, err := rt.provider.GetNodeGroup("ng1").TargetSize()
+	assert.NoError(rt.t, err)
+	return size
+}
+
+func TestRotate(t *testing.T) {
+	now := time.Date(2024, 5, 6, 23, 0, 0, 0, time.UTC)
+	rt := newRotationTest(t, config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour}, now)
+
+	rt.rotate(now)
+	assert.Equal(t, 3, rt.targetSize(), "replacement should be requested")
+	assert.Equal(t, map[string]bool{"ng1": true}, rt.rotator.NodeGroupsInRotation())
+	assert.Empty(t, rt.actuator.started)
+
+	now = now.Add(time.Minute)
+	rt.rotate(now)
+	assert.Equal(t, 3, rt.targetSize(), "only one node per node group is rotated")
+	assert.Empty(t, rt.actuator.started, "old node shouldn't be drained before its replacement is ready")
+
+	replacement := rt.addReplacement(now, false)
+	now = now.Add(time.Minute)
+	rt.rotate(now)
+	assert.Empty(t, rt.actuator.started, "old node shouldn't be drained before its replacement is ready")
+
+	SetNodeReadyState(replacement, true, now)
+	RemoveNodeNotReadyTaint(replacement)
+	replacement.Spec.Unschedulable = true
+	now = now.Add(time.Minute)
+	rt.rotate(now)
+	assert.Empty(t, rt.actuator.started, "old node shouldn't be drained before its replacement is schedulable")
+
+	replacement.Spec.Unschedulable = false
+	now = now.Add(time.Minute)
+	rt.rotate(now)
+	assert.Equal(t, []string{"old"}, rt.actuator.started)
+
+	now = now.Add(time.Minute)
+	rt.rotate(now)
+	assert.Equal(t, []string{"old"}, rt.actuator.started, "deletion is started once")
+	assert.NotEmpty(t, rt.rotator.NodeGroupsInRotation())
+
+	rt.provider.DeleteNode(rt.nodes[0])
+	rt.nodes = rt.nodes[1:]
+	rt.actuator.deleting = nil
+	now = now.Add(time.Minute)
+	rt.rotate(now)
+	assert.Empty(t, rt.rotator.NodeGroupsInRotation())
+}
+
+func TestRotateSkipsNodes(t *testing.T) {
+	monday := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+	for tn, tc := range map[string]struct {
+		options config.AutoscalingOptions
+		update  func(*rotationTest)
+		now     time.Time
+	}{
+		"outside of maintenance window": {
+			options: config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour, NodeRotationMaintenanceWindows: "Sat 00:00-24:00"},
+			now:     monday,
+		},
+		"scale-down disabled": {
+			options: config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour},
+			update: func(rt *rotationTest) {
+				rt.nodes[0].Annotations = map[string]string{eligibility.ScaleDownDisabledKey: "true"}
+			},
+			now: monday,
+		},
+		"node protected until later": {
+			options: config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour},
+			update: func(rt *rotationTest) {
+				rt.nodes[0].Annotations = map[string]string{eligibility.ScaleDownProtectedUntilKey: monday.Add(time.Hour).Format(time.RFC3339)}
+			},
+			now: monday,
+		},
+		"node running a protecting pod": {
+			options: config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour},
+			update: func(rt *rotationTest) {
+				pod := BuildTestPod("p", 100, 100)
+				pod.Annotations[eligibility.ProtectNodeFromScaleDownKey] = "true"
+				rt.rotator.context.ClusterSnapshot = clustersnapshot.NewBasicClusterSnapshot()
+				assert.NoError(rt.t, rt.rotator.context.ClusterSnapshot.AddNodeWithPods(rt.nodes[0], []*apiv1.Pod{pod}))
+			},
+			now: monday,
+		},
+		"scale-down paused": {
+			options: config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour},
+			update: func(rt *rotationTest) {
+				rt.rotator.SetScaleDownBlocker(&blockerMock{paused: true})
+			},
+			now: monday,
+		},
+		"node protected through the blocker": {
+			options: config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour},
+			update: func(rt *rotationTest) {
+				rt.rotator.SetScaleDownBlocker(&blockerMock{protected: map[string]bool{"old": true}})
+			},
+			now: monday,
+		},
+		"node not old enough": {
+			options: config.AutoscalingOptions{MaxNodeAge: 72 * time.Hour},
+			now:     monday,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			rt := newRotationTest(t, tc.options, tc.now)
+			if tc.update != nil {
+				tc.update(rt)
+			}
+			rt.rotate(tc.now)
+			assert.Equal(t, 2, rt.targetSize())
+			assert.Empty(t, rt.rotator.NodeGroupsInRotation())
+		})
+	}
+}
+
+func TestRotateReplacementTimeout(t *testing.T) {
+	now := time.Date(2024, 5, 6, 23, 0, 0, 0, time.UTC)
+	rt := newRotationTest(t, config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour}, now)
+	rt.rotate(now)
+	assert.NotEmpty(t, rt.rotator.NodeGroupsInRotation())
+
+	now = now.Add(20 * time.Minute)
+	rt.rotate(now)
+	assert.Empty(t, rt.rotator.NodeGroupsInRotation(), "rotation should be abandoned")
+	assert.Empty(t, rt.actuator.started)
+
+	rt.rotate(now.Add(time.Minute))
+	assert.Empty(t, rt.rotator.NodeGroupsInRotation(), "failed rotation shouldn't be retried immediately")
+}
+
+func TestRotateWithoutRoomForReplacement(t *testing.T) {
+	now := time.Date(2024, 5, 6, 23, 0, 0, 0, time.UTC)
+	rt := newRotationTest(t, config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour}, now)
+	rt.scaler.full = true
+
+	rt.rotate(now)
+	assert.Equal(t, 2, rt.targetSize())
+	assert.Equal(t, map[string]bool{"ng1": true}, rt.rotator.NodeGroupsInRotation())
+
+	rt.rotate(now.Add(time.Minute))
+	assert.Equal(t, []string{"old"}, rt.actuator.started, "node should be drained without waiting for a replacement")
+}
+
+func TestRotateSkipsAtomicNodeGroups(t *testing.T) {
+	now := time.Date(2024, 5, 6, 23, 0, 0, 0, time.UTC)
+	rt := newRotationTestWithNodeGroupOptions(t, config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour}, &config.NodeGroupAutoscalingOptions{ZeroOrMaxNodeScaling: true}, now)
+
+	rt.rotate(now)
+	assert.Equal(t, 2, rt.targetSize())
+	assert.Empty(t, rt.rotator.NodeGroupsInRotation())
+}
+
+func TestRotateDrainStartTimeout(t *testing.T) {
+	now := time.Date(2024, 5, 6, 23, 0, 0, 0, time.UTC)
+	rt := newRotationTest(t, config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour}, now)
+	rt.scaler.full = true
+	rt.actuator.crop = true
+
+	rt.rotate(now)
+	rt.rotate(now.Add(time.Minute))
+	assert.NotEmpty(t, rt.rotator.NodeGroupsInRotation(), "rotation should wait while deletion is blocked")
+
+	rt.rotate(now.Add(time.Minute + drainStartTimeout + time.Second))
+	assert.Empty(t, rt.rotator.NodeGroupsInRotation(), "rotation should be abandoned")
+	assert.Empty(t, rt.actuator.started)
+}
+
+func TestRotateDoesNotDrainProtectedNodes(t *testing.T) {
+	now := time.Date(2024, 5, 6, 23, 0, 0, 0, time.UTC)
+	rt := newRotationTest(t, config.AutoscalingOptions{MaxNodeAge: 24 * time.Hour}, now)
+	blocker := &blockerMock{}
+	rt.rotator.SetScaleDownBlocker(blocker)
+
+	rt.rotate(now)
+	assert.NotEmpty(t, rt.rotator.NodeGroupsInRotation())
+	rt.addReplacement(now, true)
+
+	blocker.protected = map[string]bool{"old": true}
+	rt.rotate(now.Add(time.Minute))
+	assert.Empty(t, rt.actuator.started, "node protected since the rotation started shouldn't be drained")
+
+	blocker.protected = nil
+	rt.nodes[0].Annotations = map[string]string{eligibility.ScaleDownProtectedUntilKey: now.Add(time.Hour).Format(time.RFC3339)}
+	rt.rotate(now.Add(2 * time.Minute))
+	assert.Empty(t, rt.actuator.started, "node protected since the rotation started shouldn't be drained")
+
+	rt.nodes[0].Annotations = nil
+	rt.rotate(now.Add(3 * time.Minute))
+	assert.Equal(t, []string{"old"}, rt.actuator.started)
+}
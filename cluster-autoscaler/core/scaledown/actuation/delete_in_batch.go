@@ -17,11 +17,14 @@ limitations under the License.
 package actuation
 
 import (
+	stdcontext "context"
 	"fmt"
 	"reflect"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
@@ -30,6 +33,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 	"k8s.io/klog/v2"
 
 	apiv1 "k8s.io/api/core/v1"
@@ -54,6 +58,8 @@ type NodeDeletionBatcher struct {
 	deletionsPerNodeGroup map[string][]*apiv1.Node
 	deleteInterval        time.Duration
 	drainedNodeDeletions  map[string]bool
+	// traceContexts are contexts of the loop iterations which started batches, keyed by node group id.
+	traceContexts map[string]stdcontext.Context
 }
 
 // NewNodeDeletionBatcher return new NodeBatchDeleter
@@ -65,6 +71,7 @@ func NewNodeDeletionBatcher(ctx *context.AutoscalingContext, scaleStateNotifier
 		deleteInterval:        deleteInterval,
 		drainedNodeDeletions:  make(map[string]bool),
 		scaleStateNotifier:    scaleStateNotifier,
+		traceContexts:         make(map[string]stdcontext.Context),
 	}
 }
 
@@ -72,7 +79,7 @@ func NewNodeDeletionBatcher(ctx *context.AutoscalingContext, scaleStateNotifier
 func (d *NodeDeletionBatcher) AddNodes(nodes []*apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool) {
 	// If delete interval is 0, than instantly start node deletion.
 	if d.deleteInterval == 0 {
-		go d.deleteNodesAndRegisterStatus(nodes, nodeGroup.Id(), drain)
+		go d.deleteNodesAndRegisterStatus(d.ctx.TraceContext, nodes, nodeGroup.Id(), drain)
 		return
 	}
 	first := d.addNodesToBucket(nodes, nodeGroup, drain)
@@ -85,8 +92,8 @@ func (d *NodeDeletionBatcher) AddNodes(nodes []*apiv1.Node, nodeGroup cloudprovi
 	}
 }
 
-func (d *NodeDeletionBatcher) deleteNodesAndRegisterStatus(nodes []*apiv1.Node, nodeGroupId string, drain bool) {
-	nodeGroup, err := deleteNodesFromCloudProvider(d.ctx, d.scaleStateNotifier, nodes)
+func (d *NodeDeletionBatcher) deleteNodesAndRegisterStatus(traceContext stdcontext.Context, nodes []*apiv1.Node, nodeGroupId string, drain bool) {
+	nodeGroup, err := deleteNodesFromCloudProvider(traceContext, d.ctx, d.scaleStateNotifier, nodes)
 	for _, node := range nodes {
 		if err != nil {
 			result := status.NodeDeleteResult{ResultType: status.NodeDeleteErrorFailedToDelete, Err: err}
@@ -107,6 +114,7 @@ func (d *NodeDeletionBatcher) addNodesToBucket(nodes []*apiv1.Node, nodeGroup cl
 	val, ok := d.deletionsPerNodeGroup[nodeGroup.Id()]
 	if !ok || len(val) == 0 {
 		d.deletionsPerNodeGroup[nodeGroup.Id()] = nodes
+		d.traceContexts[nodeGroup.Id()] = d.ctx.TraceContext
 		return true
 	}
 	d.deletionsPerNodeGroup[nodeGroup.Id()] = append(d.deletionsPerNodeGroup[nodeGroup.Id()], nodes...)
@@ -122,6 +130,8 @@ func (d *NodeDeletionBatcher) remove(nodeGroupId string) error {
 		return fmt.Errorf("Node Group %s is not present in the batch deleter", nodeGroupId)
 	}
 	delete(d.deletionsPerNodeGroup, nodeGroupId)
+	traceContext := d.traceContexts[nodeGroupId]
+	delete(d.traceContexts, nodeGroupId)
 	drainedNodeDeletions := make(map[string]bool)
 	for _, node := range nodes {
 		drainedNodeDeletions[node.Name] = d.drainedNodeDeletions[node.Name]
@@ -130,7 +140,7 @@ func (d *NodeDeletionBatcher) remove(nodeGroupId string) error {
 
 	go func(nodes []*apiv1.Node, drainedNodeDeletions map[string]bool) {
 		var result status.NodeDeleteResult
-		nodeGroup, err := deleteNodesFromCloudProvider(d.ctx, d.scaleStateNotifier, nodes)
+		nodeGroup, err := deleteNodesFromCloudProvider(traceContext, d.ctx, d.scaleStateNotifier, nodes)
 		for _, node := range nodes {
 			drain := drainedNodeDeletions[node.Name]
 			if err != nil {
@@ -145,8 +155,8 @@ func (d *NodeDeletionBatcher) remove(nodeGroupId string) error {
 }
 
 // deleteNodeFromCloudProvider removes the given nodes from cloud provider. No extra pre-deletion actions are executed on
-// the Kubernetes side.
-func deleteNodesFromCloudProvider(ctx *context.AutoscalingContext, scaleStateNotifier nodegroupchange.NodeGroupChangeObserver, nodes []*apiv1.Node) (cloudprovider.NodeGroup, error) {
+// the Kubernetes side. The deletion is traced as a child of the span carried by traceContext.
+func deleteNodesFromCloudProvider(traceContext stdcontext.Context, ctx *context.AutoscalingContext, scaleStateNotifier nodegroupchange.NodeGroupChangeObserver, nodes []*apiv1.Node) (cloudprovider.NodeGroup, error) {
 	nodeGroup, err := ctx.CloudProvider.NodeGroupForNode(nodes[0])
 	if err != nil {
 		return nodeGroup, errors.NewAutoscalerError(errors.CloudProviderError, "failed to find node group for %s: %v", nodes[0].Name, err)
@@ -154,7 +164,10 @@ func deleteNodesFromCloudProvider(ctx *context.AutoscalingContext, scaleStateNot
 	if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
 		return nil, errors.NewAutoscalerError(errors.InternalError, "picked node that doesn't belong to a node group: %s", nodes[0].Name)
 	}
-	if err := nodeGroup.DeleteNodes(nodes); err != nil {
+	span := tracing.StartSpan(traceContext, "CloudProvider.DeleteNodes", attribute.String("nodeGroup", nodeGroup.Id()), attribute.Int("nodes", len(nodes)))
+	err = tracing.Bind(nodeGroup, span.Context()).DeleteNodes(nodes)
+	span.EndWithError(err)
+	if err != nil {
 		scaleStateNotifier.RegisterFailedScaleDown(nodeGroup,
 			string(errors.CloudProviderError),
 			time.Now())
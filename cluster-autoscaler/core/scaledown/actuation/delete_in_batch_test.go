@@ -17,6 +17,7 @@ limitations under the License.
 package actuation
 
 import (
+	stdcontext "context"
 	"fmt"
 	"testing"
 	"time"
@@ -86,6 +87,7 @@ func TestAddNodeToBucket(t *testing.T) {
 			nodeDeletionTracker:   nil,
 			deletionsPerNodeGroup: make(map[string][]*apiv1.Node),
 			drainedNodeDeletions:  make(map[string]bool),
+			traceContexts:         make(map[string]stdcontext.Context),
 		}
 		batchCount := 0
 		for _, node := range test.nodes {
@@ -175,6 +177,7 @@ func TestRemove(t *testing.T) {
 				deletionsPerNodeGroup: make(map[string][]*apiv1.Node),
 				scaleStateNotifier:    scaleStateNotifier,
 				drainedNodeDeletions:  make(map[string]bool),
+				traceContexts:         make(map[string]stdcontext.Context),
 			}
 			nodes := generateNodes(0, test.numNodes, ng)
 			failedDeletion := test.failedDeletion
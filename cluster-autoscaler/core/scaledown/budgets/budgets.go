@@ -48,7 +48,8 @@ func NewScaleDownBudgetProcessor(ctx *context.AutoscalingContext) *ScaleDownBudg
 	}
 }
 
-// CropNodes crops the provided node lists to respect scale-down max parallelism budgets.
+// CropNodes crops the provided node lists to respect scale-down max parallelism budgets
+// and the replica disruption budget.
 // The returned nodes are grouped by a node group.
 // This function assumes that each node group may occur at most once in each of the "empty" and "drain" lists.
 func (bp *ScaleDownBudgetProcessor) CropNodes(as scaledown.ActuationStatus, empty, drain []*apiv1.Node) (emptyToDelete, drainToDelete []*NodeGroupView) {
@@ -144,7 +145,7 @@ func (bp *ScaleDownBudgetProcessor) CropNodes(as scaledown.ActuationStatus, empt
 
 	drainToDelete, _ = cropIndividualNodes(drainToDelete, drainIndividual, drainBudget)
 
-	return emptyToDelete, drainToDelete
+	return bp.cropToDisruptionBudget(as, emptyToDelete, drainToDelete)
 }
 
 func groupBuckets(buckets []*NodeGroupView) map[string]*NodeGroupView {
@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budgets
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+)
+
+// DisruptionBudget limits the number of replicas of a single controller that
+// can be disrupted at once by all the nodes undergoing drain, regardless of
+// whether the controller is covered by a PodDisruptionBudget.
+type DisruptionBudget struct {
+	maxPercent int
+	maxCount   int
+	// replicas is the number of replicas of each controller in the cluster.
+	replicas map[types.UID]int
+	// disrupted is the number of replicas of each controller which are
+	// already being disrupted.
+	disrupted map[types.UID]int
+}
+
+// NewDisruptionBudget creates a DisruptionBudget allowing for at most maxPercent
+// percent (rounded up) and at most maxCount replicas of each controller to be
+// disrupted at once. Non-positive values disable the corresponding limit.
+// Replica counts are taken from the snapshot, while pods running on nodes that
+// are already being drained, as well as recently evicted pods, are considered
+// disrupted.
+func NewDisruptionBudget(maxPercent, maxCount int, snapshot clustersnapshot.ClusterSnapshot, as scaledown.ActuationStatus) (*DisruptionBudget, error) {
+	b := &DisruptionBudget{
+		maxPercent: maxPercent,
+		maxCount:   maxCount,
+		replicas:   map[types.UID]int{},
+		disrupted:  map[types.UID]int{},
+	}
+	nodeInfos, err := snapshot.NodeInfos().List()
+	if err != nil {
+		return nil, err
+	}
+	_, drainInProgress := as.DeletionsInProgress()
+	draining := make(map[string]bool, len(drainInProgress))
+	for _, name := range drainInProgress {
+		draining[name] = true
+	}
+	seen := map[types.UID]bool{}
+	for _, nodeInfo := range nodeInfos {
+		for _, podInfo := range nodeInfo.Pods {
+			uid, ok := controllerUID(podInfo.Pod)
+			if !ok {
+				continue
+			}
+			seen[podInfo.Pod.UID] = true
+			b.replicas[uid]++
+			if draining[nodeInfo.Node().Name] {
+				b.disrupted[uid]++
+			}
+		}
+	}
+	for _, pod := range as.RecentEvictions() {
+		uid, ok := controllerUID(pod)
+		if !ok || seen[pod.UID] {
+			continue
+		}
+		// Evicted pods are no longer running, but they still count towards
+		// the size of their controller until the replacements show up.
+		b.replicas[uid]++
+		b.disrupted[uid]++
+	}
+	return b, nil
+}
+
+// TryDisrupt checks whether the given pods can be disrupted without exceeding
+// the budget of any of their controllers. If so, the pods are accounted for
+// and true is returned. Otherwise, the budget is left unchanged.
+func (b *DisruptionBudget) TryDisrupt(pods []*apiv1.Pod) bool {
+	toDisrupt := map[types.UID]int{}
+	for _, pod := range pods {
+		if uid, ok := controllerUID(pod); ok {
+			toDisrupt[uid]++
+		}
+	}
+	for uid, count := range toDisrupt {
+		if b.disrupted[uid]+count > b.allowed(uid) {
+			return false
+		}
+	}
+	for uid, count := range toDisrupt {
+		b.disrupted[uid] += count
+	}
+	return true
+}
+
+func (b *DisruptionBudget) allowed(uid types.UID) int {
+	replicas := b.replicas[uid]
+	allowed := replicas
+	if b.maxPercent > 0 {
+		allowed = min(allowed, (replicas*b.maxPercent+99)/100)
+	}
+	if b.maxCount > 0 {
+		allowed = min(allowed, b.maxCount)
+	}
+	return allowed
+}
+
+// controllerUID returns the UID of the controller of a pod which would be
+// disrupted by draining its node.
+func controllerUID(pod *apiv1.Pod) (types.UID, bool) {
+	if pod_util.IsDaemonSetPod(pod) || pod_util.IsMirrorPod(pod) {
+		return "", false
+	}
+	ref := drain.ControllerRef(pod)
+	if ref == nil {
+		return "", false
+	}
+	return ref.UID, true
+}
+
+// cropToDisruptionBudget drops nodes which would disrupt too many replicas
+// of a single controller. Groups scaled atomically are dropped as a whole,
+// including their empty nodes.
+func (bp *ScaleDownBudgetProcessor) cropToDisruptionBudget(as scaledown.ActuationStatus, emptyToDelete, drainToDelete []*NodeGroupView) ([]*NodeGroupView, []*NodeGroupView) {
+	if bp.ctx.MaxDisruptedReplicasPercent <= 0 && bp.ctx.MaxDisruptedReplicas <= 0 {
+		return emptyToDelete, drainToDelete
+	}
+	if len(drainToDelete) == 0 {
+		return emptyToDelete, drainToDelete
+	}
+	budget, err := NewDisruptionBudget(bp.ctx.MaxDisruptedReplicasPercent, bp.ctx.MaxDisruptedReplicas, bp.ctx.ClusterSnapshot, as)
+	if err != nil {
+		klog.Errorf("Failed to compute replica disruption budget, not draining any nodes: %v", err)
+		return emptyToDelete, []*NodeGroupView{}
+	}
+	rejectedAtomic := map[string]bool{}
+	drainAllowed := []*NodeGroupView{}
+	for _, bucket := range drainToDelete {
+		if bucket.BatchSize > 0 {
+			if budget.TryDisrupt(bp.podsToDisrupt(bucket.Nodes)) {
+				drainAllowed = append(drainAllowed, bucket)
+			} else {
+				klog.V(2).Infof("Not scaling down atomic group %v: would exceed replica disruption budget", bucket.Group.Id())
+				rejectedAtomic[bucket.Group.Id()] = true
+			}
+			continue
+		}
+		var nodes []*apiv1.Node
+		for _, node := range bucket.Nodes {
+			if budget.TryDisrupt(bp.podsToDisrupt([]*apiv1.Node{node})) {
+				nodes = append(nodes, node)
+			} else {
+				klog.V(2).Infof("Not scaling down node %v: would exceed replica disruption budget", node.Name)
+			}
+		}
+		if len(nodes) > 0 {
+			bucket.Nodes = nodes
+			drainAllowed = append(drainAllowed, bucket)
+		}
+	}
+	if len(rejectedAtomic) == 0 {
+		return emptyToDelete, drainAllowed
+	}
+	emptyAllowed := []*NodeGroupView{}
+	for _, bucket := range emptyToDelete {
+		if !rejectedAtomic[bucket.Group.Id()] {
+			emptyAllowed = append(emptyAllowed, bucket)
+		}
+	}
+	return emptyAllowed, drainAllowed
+}
+
+func (bp *ScaleDownBudgetProcessor) podsToDisrupt(nodes []*apiv1.Node) []*apiv1.Pod {
+	var pods []*apiv1.Pod
+	for _, node := range nodes {
+		nodeInfo, err := bp.ctx.ClusterSnapshot.NodeInfos().Get(node.Name)
+		if err != nil {
+			klog.Errorf("Failed to get node %s from cluster snapshot: %v", node.Name, err)
+			continue
+		}
+		for _, podInfo := range nodeInfo.Pods {
+			pods = append(pods, podInfo.Pod)
+		}
+	}
+	return pods
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budgets
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apiv1 "k8s.io/api/core/v1"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestCropNodesToDisruptionBudget(t *testing.T) {
+	testNg := testprovider.NewTestNodeGroup("test-ng", 0, 100, 3, true, false, "n1-standard-2", nil, nil)
+	atomic2 := sizedNodeGroup("atomic-2", 2, true)
+	for tn, tc := range map[string]struct {
+		maxPercent int
+		maxCount   int
+		// podsPerNode maps node name to the names of ReplicaSets which have a replica on it.
+		podsPerNode  map[string][]string
+		drainingNode string
+		empty        []*NodeGroupView
+		drain        []*NodeGroupView
+		wantEmpty    []*NodeGroupView
+		wantDrain    []*NodeGroupView
+	}{
+		"limits disabled": {
+			podsPerNode: map[string][]string{
+				"test-ng-node-0": {"rs"},
+				"test-ng-node-1": {"rs"},
+				"test-ng-node-2": {"rs"},
+			},
+			drain:     generateNodeGroupViewList(testNg, 0, 3),
+			wantDrain: generateNodeGroupViewList(testNg, 0, 3),
+		},
+		"percent limit": {
+			maxPercent: 50,
+			podsPerNode: map[string][]string{
+				"test-ng-node-0": {"rs"},
+				"test-ng-node-1": {"rs"},
+				"test-ng-node-2": {"rs"},
+				"test-ng-node-3": {"rs"},
+			},
+			drain:     generateNodeGroupViewList(testNg, 0, 4),
+			wantDrain: generateNodeGroupViewList(testNg, 0, 2),
+		},
+		"percent limit is rounded up": {
+			maxPercent: 10,
+			podsPerNode: map[string][]string{
+				"test-ng-node-0": {"rs"},
+				"test-ng-node-1": {"rs"},
+			},
+			drain:     generateNodeGroupViewList(testNg, 0, 2),
+			wantDrain: generateNodeGroupViewList(testNg, 0, 1),
+		},
+		"count limit": {
+			maxCount: 1,
+			podsPerNode: map[string][]string{
+				"test-ng-node-0": {"rs"},
+				"test-ng-node-1": {"rs"},
+				"test-ng-node-2": {"rs"},
+			},
+			drain:     generateNodeGroupViewList(testNg, 0, 3),
+			wantDrain: generateNodeGroupViewList(testNg, 0, 1),
+		},
+		"stricter limit wins": {
+			maxPercent: 100,
+			maxCount:   2,
+			podsPerNode: map[string][]string{
+				"test-ng-node-0": {"rs"},
+				"test-ng-node-1": {"rs"},
+				"test-ng-node-2": {"rs"},
+			},
+			drain:     generateNodeGroupViewList(testNg, 0, 3),
+			wantDrain: generateNodeGroupViewList(testNg, 0, 2),
+		},
+		"controllers are tracked separately": {
+			maxCount: 1,
+			podsPerNode: map[string][]string{
+				"test-ng-node-0": {"rs1"},
+				"test-ng-node-1": {"rs2"},
+				"test-ng-node-2": {"rs1", "rs2"},
+			},
+			drain:     generateNodeGroupViewList(testNg, 0, 3),
+			wantDrain: generateNodeGroupViewList(testNg, 0, 2),
+		},
+		"nodes already draining use up the budget": {
+			maxCount: 2,
+			podsPerNode: map[string][]string{
+				"draining":       {"rs"},
+				"test-ng-node-0": {"rs"},
+				"test-ng-node-1": {"rs"},
+			},
+			drainingNode: "draining",
+			drain:        generateNodeGroupViewList(testNg, 0, 2),
+			wantDrain:    generateNodeGroupViewList(testNg, 0, 1),
+		},
+		"atomic group exceeding budget is dropped with its empty nodes": {
+			maxCount: 1,
+			podsPerNode: map[string][]string{
+				"atomic-2-node-1": {"rs", "rs"},
+			},
+			empty:     append(generateNodeGroupViewList(atomic2, 0, 1), generateNodeGroupViewList(testNg, 0, 1)...),
+			drain:     generateNodeGroupViewList(atomic2, 1, 2),
+			wantEmpty: generateNodeGroupViewList(testNg, 0, 1),
+			wantDrain: []*NodeGroupView{},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			provider := testprovider.NewTestCloudProvider(nil, func(nodeGroup string, node string) error {
+				return nil
+			})
+			snapshot := clustersnapshot.NewBasicClusterSnapshot()
+			for _, bucket := range append(tc.empty, tc.drain...) {
+				bucket.Group.(*testprovider.TestNodeGroup).SetCloudProvider(provider)
+				provider.InsertNodeGroup(bucket.Group)
+				for _, node := range bucket.Nodes {
+					provider.AddNode(bucket.Group.Id(), node)
+					if err := snapshot.AddNode(node); err != nil {
+						t.Fatalf("AddNode() unexpected error: %v", err)
+					}
+				}
+			}
+			if tc.drainingNode != "" {
+				if err := snapshot.AddNode(generateNode(tc.drainingNode)); err != nil {
+					t.Fatalf("AddNode() unexpected error: %v", err)
+				}
+			}
+			for nodeName, rsNames := range tc.podsPerNode {
+				for i, rsName := range rsNames {
+					pod := SetRSPodSpec(BuildScheduledTestPod(fmt.Sprintf("%s-%s-%d", nodeName, rsName, i), 100, 0, nodeName), rsName)
+					if err := snapshot.AddPod(pod, nodeName); err != nil {
+						t.Fatalf("AddPod() unexpected error: %v", err)
+					}
+				}
+			}
+
+			ctx := &context.AutoscalingContext{
+				AutoscalingOptions: config.AutoscalingOptions{
+					MaxScaleDownParallelism:     10,
+					MaxDrainParallelism:         5,
+					MaxDisruptedReplicasPercent: tc.maxPercent,
+					MaxDisruptedReplicas:        tc.maxCount,
+				},
+				CloudProvider:   provider,
+				ClusterSnapshot: snapshot,
+			}
+			ndt := deletiontracker.NewNodeDeletionTracker(1 * time.Hour)
+			if tc.drainingNode != "" {
+				ndt.StartDeletionWithDrain("other-ng", tc.drainingNode)
+			}
+			emptyList, drainList := []*apiv1.Node{}, []*apiv1.Node{}
+			for _, bucket := range tc.empty {
+				emptyList = append(emptyList, bucket.Nodes...)
+			}
+			for _, bucket := range tc.drain {
+				drainList = append(drainList, bucket.Nodes...)
+			}
+
+			budgeter := NewScaleDownBudgetProcessor(ctx)
+			gotEmpty, gotDrain := budgeter.CropNodes(ndt, emptyList, drainList)
+			if diff := cmp.Diff(tc.wantEmpty, gotEmpty, cmpopts.EquateEmpty(), transformNodeGroupView); diff != "" {
+				t.Errorf("CropNodes empty nodes diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantDrain, gotDrain, cmpopts.EquateEmpty(), transformNodeGroupView); diff != "" {
+				t.Errorf("CropNodes drain nodes diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
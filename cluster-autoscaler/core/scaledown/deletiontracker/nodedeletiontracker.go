@@ -17,6 +17,8 @@ limitations under the License.
 package deletiontracker
 
 import (
+	"errors"
+	"sort"
 	"sync"
 	"time"
 
@@ -53,6 +55,22 @@ type deletionResult struct {
 	result   status.NodeDeleteResult
 }
 
+// State is the part of NodeDeletionTracker state which can be saved and restored, e.g. to
+// survive a restart of Cluster Autoscaler. Recent evictions aren't included, as they are
+// only relevant for a short time and would require saving whole pods.
+type State struct {
+	// InProgress contains names of nodes undergoing deletion.
+	InProgress []string `json:"inProgress,omitempty"`
+	// Results contains deletion results not cleared yet, keyed by node name.
+	Results map[string]ResultState `json:"results,omitempty"`
+}
+
+// ResultState is a saved deletion result of a single node.
+type ResultState struct {
+	ResultType status.NodeDeleteResultType `json:"resultType"`
+	Error      string                      `json:"error,omitempty"`
+}
+
 // NewNodeDeletionTracker creates new NodeDeletionTracker.
 func NewNodeDeletionTracker(podEvictionsTTL time.Duration) *NodeDeletionTracker {
 	return &NodeDeletionTracker{
@@ -195,3 +213,46 @@ func (n *NodeDeletionTracker) Snapshot() *NodeDeletionTracker {
 	}
 	return snapshot
 }
+
+// State returns the state of deletions which can be saved and restored with RestoreState.
+func (n *NodeDeletionTracker) State() State {
+	n.Lock()
+	defer n.Unlock()
+	state := State{}
+	for _, m := range []map[string]bool{n.emptyNodeDeletions, n.drainedNodeDeletions} {
+		state.InProgress = append(state.InProgress, mapKeysSlice(m)...)
+	}
+	sort.Strings(state.InProgress)
+	for _, el := range n.deletionResults.ToSlice() {
+		if state.Results == nil {
+			state.Results = make(map[string]ResultState)
+		}
+		dr := el.(*deletionResult)
+		resultState := ResultState{ResultType: dr.result.ResultType}
+		if dr.result.Err != nil {
+			resultState.Error = dr.result.Err.Error()
+		}
+		state.Results[dr.nodeName] = resultState
+	}
+	return state
+}
+
+// RestoreState restores deletion results from a saved state. The process performing deletions
+// which were in progress when the state was saved is gone, so they are restored as failed.
+func (n *NodeDeletionTracker) RestoreState(state State) {
+	n.Lock()
+	defer n.Unlock()
+	for nodeName, resultState := range state.Results {
+		result := status.NodeDeleteResult{ResultType: resultState.ResultType}
+		if resultState.Error != "" {
+			result.Err = errors.New(resultState.Error)
+		}
+		n.deletionResults.RegisterElement(&deletionResult{nodeName, result})
+	}
+	for _, nodeName := range state.InProgress {
+		n.deletionResults.RegisterElement(&deletionResult{nodeName, status.NodeDeleteResult{
+			ResultType: status.NodeDeleteErrorInternal,
+			Err:        errors.New("deletion interrupted by a restart of cluster autoscaler"),
+		}})
+	}
+}
@@ -120,6 +120,12 @@ func (c *Checker) unremovableReasonAndNodeUtilization(context *context.Autoscali
 		return simulator.ScaleDownDisabledAnnotation, nil
 	}
 
+	// Skip nodes protected from scale down by node or pod annotations
+	if HasScaleDownProtection(nodeInfo, timestamp) {
+		klog.V(1).Infof("Skipping %s from delete consideration - the node is protected from scale down", node.Name)
+		return simulator.ScaleDownProtected, nil
+	}
+
 	nodeGroup, err := context.CloudProvider.NodeGroupForNode(node)
 	if err != nil {
 		klog.Warningf("Node group not found for node %v: %v", node.Name, err)
@@ -60,6 +60,18 @@ func getTestCases(ignoreDaemonSetsUtilization bool, suffix string, now time.Time
 	unreadyNode := BuildTestNode("unready", 1000, 10)
 	SetNodeReadyState(unreadyNode, false, time.Time{})
 
+	protectedNode := BuildTestNode("protected", 1000, 10)
+	protectedNode.Annotations = map[string]string{ScaleDownProtectedUntilKey: now.Add(time.Hour).Format(time.RFC3339)}
+	SetNodeReadyState(protectedNode, true, time.Time{})
+
+	protectionExpiredNode := BuildTestNode("protectionExpired", 1000, 10)
+	protectionExpiredNode.Annotations = map[string]string{ScaleDownProtectedUntilKey: now.Add(-time.Hour).Format(time.RFC3339)}
+	SetNodeReadyState(protectionExpiredNode, true, time.Time{})
+
+	invalidProtectionNode := BuildTestNode("invalidProtection", 1000, 10)
+	invalidProtectionNode.Annotations = map[string]string{ScaleDownProtectedUntilKey: "tomorrow"}
+	SetNodeReadyState(invalidProtectionNode, true, time.Time{})
+
 	bigPod := BuildTestPod("bigPod", 600, 0)
 	bigPod.Spec.NodeName = "regular"
 
@@ -69,6 +81,15 @@ func getTestCases(ignoreDaemonSetsUtilization bool, suffix string, now time.Time
 	dsPod := BuildTestPod("dsPod", 500, 0, WithDSController())
 	dsPod.Spec.NodeName = "regular"
 
+	protectingPod := BuildTestPod("protectingPod", 100, 0)
+	protectingPod.Spec.NodeName = "regular"
+	protectingPod.Annotations[ProtectNodeFromScaleDownKey] = "true"
+
+	completedProtectingPod := BuildTestPod("completedProtectingPod", 100, 0)
+	completedProtectingPod.Spec.NodeName = "regular"
+	completedProtectingPod.Annotations[ProtectNodeFromScaleDownKey] = "true"
+	completedProtectingPod.Status.Phase = apiv1.PodSucceeded
+
 	testCases := []testCase{
 		{
 			desc:             "regular node stays",
@@ -88,6 +109,38 @@ func getTestCases(ignoreDaemonSetsUtilization bool, suffix string, now time.Time
 			want:             []string{"regular"},
 			scaleDownUnready: true,
 		},
+		{
+			desc:             "node protected from scale down is filtered out",
+			nodes:            []*apiv1.Node{protectedNode, regularNode},
+			want:             []string{"regular"},
+			scaleDownUnready: true,
+		},
+		{
+			desc:             "node with expired protection stays",
+			nodes:            []*apiv1.Node{protectionExpiredNode},
+			want:             []string{"protectionExpired"},
+			scaleDownUnready: true,
+		},
+		{
+			desc:             "node with invalid protection is filtered out",
+			nodes:            []*apiv1.Node{invalidProtectionNode, regularNode},
+			want:             []string{"regular"},
+			scaleDownUnready: true,
+		},
+		{
+			desc:             "node running a protecting pod is filtered out",
+			nodes:            []*apiv1.Node{regularNode},
+			pods:             []*apiv1.Pod{protectingPod},
+			want:             []string{},
+			scaleDownUnready: true,
+		},
+		{
+			desc:             "node with a completed protecting pod stays",
+			nodes:            []*apiv1.Node{regularNode},
+			pods:             []*apiv1.Pod{completedProtectingPod},
+			want:             []string{"regular"},
+			scaleDownUnready: true,
+		},
 		{
 			desc:             "highly utilized node is filtered out",
 			nodes:            []*apiv1.Node{regularNode},
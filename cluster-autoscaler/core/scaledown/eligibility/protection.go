@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eligibility
+
+import (
+	ctx "context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kube_client "k8s.io/client-go/kubernetes"
+	kube_record "k8s.io/client-go/tools/record"
+	klog "k8s.io/klog/v2"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const (
+	// ScaleDownProtectedUntilKey is the name of annotation protecting a node from scale down until a given time,
+	// in RFC 3339 format. Expired annotations are removed by the autoscaler.
+	ScaleDownProtectedUntilKey = "cluster-autoscaler.kubernetes.io/scale-down-protected-until"
+	// ProtectNodeFromScaleDownKey is the name of pod annotation protecting the node running the pod from scale down.
+	ProtectNodeFromScaleDownKey = "cluster-autoscaler.kubernetes.io/protect-node-from-scale-down"
+)
+
+// protectedUntilLayouts are accepted formats of the scale down protection timestamp. Seconds may be omitted.
+var protectedUntilLayouts = []string{time.RFC3339, "2006-01-02T15:04Z07:00"}
+
+// ScaleDownProtectedUntil returns the time until which the node is protected from scale down by an annotation.
+// The second return value is false if the node has no protection annotation. An error is returned if the
+// annotation can't be parsed.
+func ScaleDownProtectedUntil(node *apiv1.Node) (time.Time, bool, error) {
+	value, found := node.Annotations[ScaleDownProtectedUntilKey]
+	if !found {
+		return time.Time{}, false, nil
+	}
+	for _, layout := range protectedUntilLayouts {
+		if until, err := time.Parse(layout, value); err == nil {
+			return until, true, nil
+		}
+	}
+	return time.Time{}, true, fmt.Errorf("invalid %s annotation %q of node %s", ScaleDownProtectedUntilKey, value, node.Name)
+}
+
+// HasScaleDownProtection checks whether the node is protected from scale down at a given time, either by
+// a node annotation which hasn't expired yet or by an annotation of a pod running on the node. A node with
+// an invalid protection annotation stays protected until the annotation is fixed or removed.
+func HasScaleDownProtection(nodeInfo *schedulerframework.NodeInfo, timestamp time.Time) bool {
+	if until, found, err := ScaleDownProtectedUntil(nodeInfo.Node()); found && (err != nil || timestamp.Before(until)) {
+		return true
+	}
+	for _, podInfo := range nodeInfo.Pods {
+		if protectsNode(podInfo.Pod) {
+			return true
+		}
+	}
+	return false
+}
+
+func protectsNode(pod *apiv1.Pod) bool {
+	if pod.Status.Phase == apiv1.PodSucceeded || pod.Status.Phase == apiv1.PodFailed {
+		return false
+	}
+	return pod.Annotations[ProtectNodeFromScaleDownKey] == "true"
+}
+
+// CleanExpiredScaleDownProtection removes scale down protection annotations which expired at a given time from nodes.
+func CleanExpiredScaleDownProtection(nodes []*apiv1.Node, client kube_client.Interface, recorder kube_record.EventRecorder, timestamp time.Time) {
+	for _, node := range nodes {
+		until, found, err := ScaleDownProtectedUntil(node)
+		if err != nil {
+			klog.Warningf("Keeping node %s protected from scale down: %v", node.Name, err)
+			continue
+		}
+		if !found || timestamp.Before(until) {
+			continue
+		}
+		if err := removeAnnotation(node, client, ScaleDownProtectedUntilKey); err != nil {
+			klog.Warningf("Failed to remove expired %s annotation from node %s: %v", ScaleDownProtectedUntilKey, node.Name, err)
+			recorder.Eventf(node, apiv1.EventTypeWarning, "ClusterAutoscalerCleanup",
+				"failed to remove expired scale down protection from node %v: %v", node.Name, err)
+			continue
+		}
+		klog.V(1).Infof("Removed scale down protection of node %s expired at %s", node.Name, until)
+		recorder.Eventf(node, apiv1.EventTypeNormal, "ClusterAutoscalerCleanup",
+			"removed scale down protection expired at %v from node %v", until.Format(time.RFC3339), node.Name)
+	}
+}
+
+func removeAnnotation(node *apiv1.Node, client kube_client.Interface, key string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				key: nil,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().Nodes().Patch(ctx.TODO(), node.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eligibility
+
+import (
+	ctx "context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+	kube_record "k8s.io/client-go/tools/record"
+)
+
+func TestScaleDownProtectedUntil(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		annotations map[string]string
+		wantUntil   time.Time
+		wantFound   bool
+		wantErr     bool
+	}{
+		"no annotation": {},
+		"rfc3339 timestamp": {
+			annotations: map[string]string{ScaleDownProtectedUntilKey: "2026-10-20T10:00:00+02:00"},
+			wantUntil:   time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC),
+			wantFound:   true,
+		},
+		"timestamp without seconds": {
+			annotations: map[string]string{ScaleDownProtectedUntilKey: "2026-10-20T10:00Z"},
+			wantUntil:   time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC),
+			wantFound:   true,
+		},
+		"invalid timestamp": {
+			annotations: map[string]string{ScaleDownProtectedUntilKey: "tomorrow"},
+			wantFound:   true,
+			wantErr:     true,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			node := BuildTestNode("n", 1000, 10)
+			node.Annotations = tc.annotations
+			until, found, err := ScaleDownProtectedUntil(node)
+			assert.Equal(t, tc.wantFound, found)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.True(t, tc.wantUntil.Equal(until), "got %v, want %v", until, tc.wantUntil)
+		})
+	}
+}
+
+func TestCleanExpiredScaleDownProtection(t *testing.T) {
+	now := time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC)
+	buildNode := func(name, protectedUntil string) *apiv1.Node {
+		node := BuildTestNode(name, 1000, 10)
+		node.Annotations = map[string]string{"other": "value"}
+		if protectedUntil != "" {
+			node.Annotations[ScaleDownProtectedUntilKey] = protectedUntil
+		}
+		return node
+	}
+	nodes := []*apiv1.Node{
+		buildNode("expired", "2026-10-20T09:00Z"),
+		buildNode("active", "2026-10-20T11:00Z"),
+		buildNode("invalid", "tomorrow"),
+		buildNode("unprotected", ""),
+	}
+	client := fake.NewSimpleClientset(nodes[0], nodes[1], nodes[2], nodes[3])
+
+	CleanExpiredScaleDownProtection(nodes, client, kube_record.NewFakeRecorder(10), now)
+
+	wantAnnotations := map[string]map[string]string{
+		"expired":     {"other": "value"},
+		"active":      {"other": "value", ScaleDownProtectedUntilKey: "2026-10-20T11:00Z"},
+		"invalid":     {"other": "value", ScaleDownProtectedUntilKey: "tomorrow"},
+		"unprotected": {"other": "value"},
+	}
+	for name, want := range wantAnnotations {
+		node, err := client.CoreV1().Nodes().Get(ctx.TODO(), name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, want, node.Annotations, "annotations of node %s", name)
+	}
+	patches := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "patch" {
+			patches++
+		}
+	}
+	assert.Equal(t, 1, patches)
+}
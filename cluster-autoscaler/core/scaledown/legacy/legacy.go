@@ -31,6 +31,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/unremovable"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
@@ -93,6 +94,16 @@ func (sd *ScaleDown) UnneededNodes() []*apiv1.Node {
 	return sd.unneededNodes.AsList()
 }
 
+// UnneededSince returns the times at which unneeded nodes became unneeded, keyed by node name.
+func (sd *ScaleDown) UnneededSince() map[string]time.Time {
+	return sd.unneededNodes.UnneededSince()
+}
+
+// RestoreUnneededSince sets the times at which nodes became unneeded, e.g. saved before a restart.
+func (sd *ScaleDown) RestoreUnneededSince(since map[string]time.Time) {
+	sd.unneededNodes.RestoreUnneededSince(since)
+}
+
 // UpdateUnneededNodes calculates which nodes are not needed, i.e. all pods can be scheduled somewhere else,
 // and updates unneededNodes accordingly. It also computes information where pods can be rescheduled and
 // node utilization level. The computations are made only for the nodes managed by CA.
@@ -396,7 +407,8 @@ func (sd *ScaleDown) getEmptyNodesToRemove(candidates []string, resourcesLimits
 				continue
 			}
 			deletionsInProgress := sd.nodeDeletionTracker.DeletionsCount(nodeGroup.Id())
-			available = size - nodeGroup.MinSize() - deletionsInProgress
+			minSize, _ := nodegroupconfig.GetSizeRange(sd.processors.NodeGroupConfigProcessor, nodeGroup)
+			available = size - minSize - deletionsInProgress
 			if available < 0 {
 				available = 0
 			}
@@ -20,7 +20,6 @@ import (
 	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
-	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/actuation"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
@@ -33,13 +32,13 @@ import (
 // scaledown.Actuator interfaces.
 type ScaleDownWrapper struct {
 	sd                      *ScaleDown
-	actuator                *actuation.Actuator
+	actuator                scaledown.Actuator
 	lastNodesToDeleteResult status.ScaleDownResult
 	lastNodesToDeleteErr    errors.AutoscalerError
 }
 
 // NewScaleDownWrapper returns a new ScaleDownWrapper
-func NewScaleDownWrapper(sd *ScaleDown, actuator *actuation.Actuator) *ScaleDownWrapper {
+func NewScaleDownWrapper(sd *ScaleDown, actuator scaledown.Actuator) *ScaleDownWrapper {
 	return &ScaleDownWrapper{
 		sd:       sd,
 		actuator: actuator,
@@ -62,6 +61,16 @@ func (p *ScaleDownWrapper) UnneededNodes() []*apiv1.Node {
 	return p.sd.UnneededNodes()
 }
 
+// UnneededSince returns the times at which unneeded nodes became unneeded, keyed by node name.
+func (p *ScaleDownWrapper) UnneededSince() map[string]time.Time {
+	return p.sd.UnneededSince()
+}
+
+// RestoreUnneededSince sets the times at which nodes became unneeded, e.g. saved before a restart.
+func (p *ScaleDownWrapper) RestoreUnneededSince(since map[string]time.Time) {
+	p.sd.RestoreUnneededSince(since)
+}
+
 // UnremovableNodes returns a list of nodes that cannot be removed.
 func (p *ScaleDownWrapper) UnremovableNodes() []*simulator.UnremovableNode {
 	return p.sd.UnremovableNodes()
@@ -19,6 +19,7 @@ package planner
 import (
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/draincost"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/scheduling"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
@@ -86,11 +88,15 @@ func New(context *context.AutoscalingContext, processors *processors.Autoscaling
 	if minUpdateInterval == 0*time.Nanosecond {
 		minUpdateInterval = 1 * time.Nanosecond
 	}
+	rs := simulator.NewRemovalSimulator(context.ListerRegistry, context.ClusterSnapshot, context.PredicateChecker, simulator.NewUsageTracker(), deleteOptions, drainabilityRules, true)
+	if context.AutoscalingOptions.DrainCost.Enabled {
+		rs.WithDrainCost(draincost.NewCalculator(context.ListerRegistry, context.AutoscalingOptions.DrainCost.ControllerKindCosts, context.AutoscalingOptions.DrainCost.SingletonCost))
+	}
 	return &Planner{
 		context:               context,
 		unremovableNodes:      unremovable.NewNodes(),
 		unneededNodes:         unneeded.NewNodes(processors.NodeGroupConfigProcessor, resourceLimitsFinder),
-		rs:                    simulator.NewRemovalSimulator(context.ListerRegistry, context.ClusterSnapshot, context.PredicateChecker, simulator.NewUsageTracker(), deleteOptions, drainabilityRules, true),
+		rs:                    rs,
 		actuationInjector:     scheduling.NewHintingSimulator(context.PredicateChecker),
 		eligibilityChecker:    eligibility.NewChecker(processors.NodeGroupConfigProcessor),
 		nodeUtilizationMap:    make(map[string]utilization.Info),
@@ -152,7 +158,7 @@ func (p *Planner) NodesToDelete(_ time.Time) (empty, needDrain []*apiv1.Node) {
 	for _, u := range unremovable {
 		p.unremovableNodes.Add(u)
 	}
-	needDrainRemovable = sortByRisk(needDrainRemovable)
+	needDrainRemovable = sortByRisk(sortByDrainCost(needDrainRemovable))
 	nodesToRemove := p.scaleDownSetProcessor.GetNodesToRemove(
 		p.context,
 		// We need to pass empty nodes first, as there might be some non-empty scale
@@ -192,6 +198,16 @@ func (p *Planner) UnneededNodes() []*apiv1.Node {
 	return p.unneededNodes.AsList()
 }
 
+// UnneededSince returns the times at which unneeded nodes became unneeded, keyed by node name.
+func (p *Planner) UnneededSince() map[string]time.Time {
+	return p.unneededNodes.UnneededSince()
+}
+
+// RestoreUnneededSince sets the times at which nodes became unneeded, e.g. saved before a restart.
+func (p *Planner) RestoreUnneededSince(since map[string]time.Time) {
+	p.unneededNodes.RestoreUnneededSince(since)
+}
+
 // UnremovableNodes returns a list of nodes currently considered as unremovable.
 func (p *Planner) UnremovableNodes() []*simulator.UnremovableNode {
 	return p.unremovableNodes.AsList()
@@ -420,6 +436,14 @@ func sortByRisk(nodes []simulator.NodeToBeRemoved) []simulator.NodeToBeRemoved {
 	return append(okNodes, riskyNodes...)
 }
 
+// sortByDrainCost orders nodes so that the ones whose pods are cheaper to move come first.
+func sortByDrainCost(nodes []simulator.NodeToBeRemoved) []simulator.NodeToBeRemoved {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].DrainCost < nodes[j].DrainCost
+	})
+	return nodes
+}
+
 func timedOut(timer *time.Timer) bool {
 	select {
 	case <-timer.C:
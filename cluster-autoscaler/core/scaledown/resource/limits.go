@@ -92,6 +92,26 @@ func (lf *LimitsFinder) LimitsLeft(context *context.AutoscalingContext, nodes []
 			}
 		}
 	}
+
+	var setLimits []cloudprovider.NodeGroupSetLimit
+	for _, limit := range resourceLimiter.GetNodeGroupSetLimits() {
+		if limit.Min > 0 {
+			setLimits = append(setLimits, limit)
+		}
+	}
+	if len(setLimits) > 0 {
+		setTotals, err := lf.nodeGroupSetsTotal(context, nodes, setLimits, timestamp)
+		if err != nil {
+			klog.Errorf("Failed to compute resources in node group sets: %v", err)
+		}
+		for _, limit := range setLimits {
+			if err != nil {
+				resultScaleDownLimits[limit.Key()] = limitUnknown
+			} else {
+				resultScaleDownLimits[limit.Key()] = computeAboveMin(setTotals[limit.Key()], limit.Min)
+			}
+		}
+	}
 	return resultScaleDownLimits
 }
 
@@ -164,6 +184,37 @@ func (lf *LimitsFinder) customResourcesTotal(context *context.AutoscalingContext
 	return result, nil
 }
 
+// nodeGroupSetsTotal returns the amount of limited resources in node group sets, keyed by NodeGroupSetLimit.Key().
+func (lf *LimitsFinder) nodeGroupSetsTotal(context *context.AutoscalingContext, nodes []*apiv1.Node, limits []cloudprovider.NodeGroupSetLimit, timestamp time.Time) (map[string]int64, errors.AutoscalerError) {
+	keys := make([]string, 0, len(limits))
+	for _, limit := range limits {
+		keys = append(keys, limit.Key())
+	}
+	result := make(map[string]int64)
+	for _, node := range nodes {
+		if actuation.IsNodeBeingDeleted(node, timestamp) {
+			// Nodes being deleted do not count towards total cluster resources
+			continue
+		}
+		nodeGroup, err := context.CloudProvider.NodeGroupForNode(node)
+		if err != nil {
+			return nil, errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("cannot get node group for node %v when calculating node group set resource usage", node.Name)
+		}
+		if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+			// Nodes from not autoscaled groups don't belong to any node group set.
+			continue
+		}
+		delta, aErr := lf.DeltaForNode(context, node, nodeGroup, keys)
+		if aErr != nil {
+			return nil, aErr
+		}
+		for _, key := range keys {
+			result[key] += delta[key]
+		}
+	}
+	return result, nil
+}
+
 // DeepCopy returns a copy of the original limits.
 func (l Limits) DeepCopy() Limits {
 	copy := Limits{}
@@ -182,7 +233,33 @@ func (lf *LimitsFinder) DeltaForNode(context *context.AutoscalingContext, node *
 	resultScaleDownDelta[cloudprovider.ResourceNameCores] = nodeCPU
 	resultScaleDownDelta[cloudprovider.ResourceNameMemory] = nodeMemory
 
-	if cloudprovider.ContainsCustomResources(resourcesWithLimits) {
+	var clusterResources []string
+	setLimitKeys := sets.NewString()
+	for _, resource := range resourcesWithLimits {
+		if _, _, ok := cloudprovider.ParseNodeGroupSetLimitKey(resource); ok {
+			setLimitKeys.Insert(resource)
+		} else {
+			clusterResources = append(clusterResources, resource)
+		}
+	}
+	var setLimits []cloudprovider.NodeGroupSetLimit
+	if setLimitKeys.Len() > 0 && nodeGroup != nil {
+		resourceLimiter, err := context.CloudProvider.GetResourceLimiter()
+		if err != nil {
+			return Delta{}, errors.ToAutoscalerError(errors.CloudProviderError, err)
+		}
+		for _, limit := range resourceLimiter.GetNodeGroupSetLimitsFor(nodeGroup.Id(), node.Labels) {
+			if setLimitKeys.Has(limit.Key()) {
+				setLimits = append(setLimits, limit)
+			}
+		}
+	}
+
+	customResourceLimited := cloudprovider.ContainsCustomResources(clusterResources)
+	for _, limit := range setLimits {
+		customResourceLimited = customResourceLimited || cloudprovider.IsCustomResource(limit.Resource)
+	}
+	if customResourceLimited {
 		resourceTargets, err := lf.crp.GetNodeResourceTargets(context, node, nodeGroup)
 		if err != nil {
 			return Delta{}, errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("failed to get node %v custom resources: %v", node.Name)
@@ -191,6 +268,11 @@ func (lf *LimitsFinder) DeltaForNode(context *context.AutoscalingContext, node *
 			resultScaleDownDelta[resourceTarget.ResourceType] = resourceTarget.ResourceCount
 		}
 	}
+
+	// Node group set limits are tracked like separate resources, present only for nodes in the set.
+	for _, limit := range setLimits {
+		resultScaleDownDelta[limit.Key()] = resultScaleDownDelta[limit.Resource]
+	}
 	return resultScaleDownDelta, nil
 }
 
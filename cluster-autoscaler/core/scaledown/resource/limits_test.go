@@ -21,13 +21,18 @@ import (
 	"testing"
 	"time"
 
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
 	"k8s.io/autoscaler/cluster-autoscaler/core/utils"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 
 	"github.com/stretchr/testify/assert"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestCalculateCoresAndMemoryTotal(t *testing.T) {
@@ -105,3 +110,43 @@ func TestCheckDeltaWithinLimits(t *testing.T) {
 		assert.Equal(t, LimitsCheckResult{test.exceededResources}, checkResult)
 	}
 }
+
+func TestNodeGroupSetLimits(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	limit, err := cloudprovider.NewNodeGroupSetLimit(config.NodeGroupSetResourceLimits{Name: "big", NodeGroupIdRegex: "big", Resource: cloudprovider.ResourceNameCores, Min: 10})
+	assert.NoError(t, err)
+	resourceLimiter := cloudprovider.NewResourceLimiter(nil, nil).WithNodeGroupSetLimits([]cloudprovider.NodeGroupSetLimit{limit})
+	provider.SetResourceLimiter(resourceLimiter)
+
+	var nodes []*apiv1.Node
+	for _, ng := range []struct {
+		name string
+		size int
+	}{{"big", 2}, {"small", 1}} {
+		provider.AddNodeGroup(ng.name, 0, 10, ng.size)
+		for i := 0; i < ng.size; i++ {
+			node := BuildTestNode(fmt.Sprintf("%s-%d", ng.name, i), 8000, 1000)
+			provider.AddNode(ng.name, node)
+			nodes = append(nodes, node)
+		}
+	}
+
+	listers := kube_util.NewListerRegistry(nil, nil, kube_util.NewTestPodLister(nil), nil, nil, nil, nil, nil, nil)
+	context, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, &fake.Clientset{}, listers, provider, nil, nil)
+	assert.NoError(t, err)
+	lf := NewLimitsFinder(NewTestProcessors(&context).CustomResourcesProcessor)
+
+	left := lf.LimitsLeft(&context, nodes, resourceLimiter, time.Now())
+	assert.Equal(t, Limits{limit.Key(): 6}, left) // 8*2-10=6
+
+	resources := []string{limit.Key()}
+	bigDelta, err := lf.DeltaForNode(&context, nodes[0], provider.GetNodeGroup("big"), resources)
+	assert.NoError(t, err)
+	assert.Equal(t, Delta{"cpu": 8, "memory": 1000, limit.Key(): 8}, bigDelta)
+	smallDelta, err := lf.DeltaForNode(&context, nodes[2], provider.GetNodeGroup("small"), resources)
+	assert.NoError(t, err)
+	assert.Equal(t, Delta{"cpu": 8, "memory": 1000}, smallDelta)
+
+	assert.Equal(t, []string{limit.Key()}, left.TryDecrementBy(bigDelta).ExceededResources)
+	assert.False(t, left.TryDecrementBy(smallDelta).Exceeded())
+}
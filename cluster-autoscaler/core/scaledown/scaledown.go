@@ -49,6 +49,16 @@ type Planner interface {
 	NodeUtilizationMap() map[string]utilization.Info
 }
 
+// PersistentPlanner is implemented by Planners whose unneeded node timers can be saved
+// and restored, e.g. to survive a restart of Cluster Autoscaler.
+type PersistentPlanner interface {
+	// UnneededSince returns the times at which unneeded nodes became unneeded, keyed by node name.
+	UnneededSince() map[string]time.Time
+	// RestoreUnneededSince sets the times at which nodes became unneeded. The times are
+	// only used for nodes found unneeded in the next update of the cluster state.
+	RestoreUnneededSince(since map[string]time.Time)
+}
+
 // Actuator is responsible for making changes in the cluster: draining and
 // deleting nodes.
 type Actuator interface {
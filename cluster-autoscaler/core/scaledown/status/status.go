@@ -31,6 +31,7 @@ import (
 type ScaleDownStatus struct {
 	Result                ScaleDownResult
 	ScaledDownNodes       []*ScaleDownNode
+	UnneededNodes         []*apiv1.Node
 	UnremovableNodes      []*UnremovableNode
 	RemovedNodeGroups     []cloudprovider.NodeGroup
 	NodeDeleteResults     map[string]NodeDeleteResult
@@ -26,20 +26,23 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/eligibility"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/utils"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 
 	apiv1 "k8s.io/api/core/v1"
 	klog "k8s.io/klog/v2"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
 )
 
 // Nodes tracks the state of cluster nodes that are not needed.
 type Nodes struct {
-	sdtg         scaleDownTimeGetter
+	sdtg         nodeGroupConfigGetter
 	limitsFinder *resource.LimitsFinder
 	cachedList   []*apiv1.Node
 	byName       map[string]*node
+	restored     map[string]time.Time
 }
 
 type node struct {
@@ -47,7 +50,8 @@ type node struct {
 	since time.Time
 }
 
-type scaleDownTimeGetter interface {
+type nodeGroupConfigGetter interface {
+	nodegroupconfig.NodeGroupSizeGetter
 	// GetScaleDownUnneededTime returns ScaleDownUnneededTime value that should be used for a given NodeGroup.
 	GetScaleDownUnneededTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
 	// GetScaleDownUnreadyTime returns ScaleDownUnreadyTime value that should be used for a given NodeGroup.
@@ -55,7 +59,7 @@ type scaleDownTimeGetter interface {
 }
 
 // NewNodes returns a new initialized Nodes object.
-func NewNodes(sdtg scaleDownTimeGetter, limitsFinder *resource.LimitsFinder) *Nodes {
+func NewNodes(sdtg nodeGroupConfigGetter, limitsFinder *resource.LimitsFinder) *Nodes {
 	return &Nodes{
 		sdtg:         sdtg,
 		limitsFinder: limitsFinder,
@@ -73,11 +77,14 @@ func (n *Nodes) Update(nodes []simulator.NodeToBeRemoved, ts time.Time) {
 		}
 		if val, found := n.byName[name]; found {
 			updated[name].since = val.since
+		} else if since, found := n.restored[name]; found {
+			updated[name].since = since
 		} else {
 			updated[name].since = ts
 		}
 	}
 	n.byName = updated
+	n.restored = nil
 	n.cachedList = nil
 	if klog.V(4).Enabled() {
 		for k, v := range n.byName {
@@ -86,6 +93,22 @@ func (n *Nodes) Update(nodes []simulator.NodeToBeRemoved, ts time.Time) {
 	}
 }
 
+// UnneededSince returns the times at which tracked nodes became unneeded, keyed by node name.
+func (n *Nodes) UnneededSince() map[string]time.Time {
+	since := make(map[string]time.Time, len(n.byName))
+	for name, v := range n.byName {
+		since[name] = v.since
+	}
+	return since
+}
+
+// RestoreUnneededSince sets the times at which nodes became unneeded, e.g. saved
+// before a restart. The times are used for nodes found unneeded in the next Update
+// and discarded for the remaining ones.
+func (n *Nodes) RestoreUnneededSince(since map[string]time.Time) {
+	n.restored = since
+}
+
 // Clear resets the internal state, dropping information about all tracked nodes.
 func (n *Nodes) Clear() {
 	n.Update(nil, time.Time{})
@@ -148,6 +171,16 @@ func (n *Nodes) unremovableReason(context *context.AutoscalingContext, v *node,
 		klog.V(4).Infof("Skipping %s - scale down disabled annotation found", node.Name)
 		return simulator.ScaleDownDisabledAnnotation
 	}
+	// Check if node or pods running on it were annotated with scale down protection since the node became unneeded.
+	nodeInfo, err := context.ClusterSnapshot.NodeInfos().Get(node.Name)
+	if err != nil {
+		nodeInfo = schedulerframework.NewNodeInfo()
+		nodeInfo.SetNode(node)
+	}
+	if eligibility.HasScaleDownProtection(nodeInfo, ts) {
+		klog.V(4).Infof("Skipping %s - scale down protection found", node.Name)
+		return simulator.ScaleDownProtected
+	}
 	ready, _, _ := kube_util.GetReadinessState(node)
 
 	nodeGroup, err := context.CloudProvider.NodeGroupForNode(node)
@@ -167,6 +200,10 @@ func (n *Nodes) unremovableReason(context *context.AutoscalingContext, v *node,
 			klog.Errorf("Error trying to get ScaleDownUnneededTime for node %s (in group: %s)", node.Name, nodeGroup.Id())
 			return simulator.UnexpectedError
 		}
+		// Nodes whose pods are expensive to move need to be unneeded for longer.
+		if drainCost := context.AutoscalingOptions.DrainCost; drainCost.Enabled && v.ntbr.DrainCost >= drainCost.ExpensiveThreshold && drainCost.ExpensiveUnneededTime > unneededTime {
+			unneededTime = drainCost.ExpensiveUnneededTime
+		}
 		if !v.since.Add(unneededTime).Before(ts) {
 			return simulator.NotUnneededLongEnough
 		}
@@ -182,7 +219,7 @@ func (n *Nodes) unremovableReason(context *context.AutoscalingContext, v *node,
 		}
 	}
 
-	if reason := verifyMinSize(node.Name, nodeGroup, nodeGroupSize, as); reason != simulator.NoReason {
+	if reason := n.verifyMinSize(node.Name, nodeGroup, nodeGroupSize, as); reason != simulator.NoReason {
 		return reason
 	}
 
@@ -195,7 +232,11 @@ func (n *Nodes) unremovableReason(context *context.AutoscalingContext, v *node,
 	checkResult := resourcesLeft.TryDecrementBy(resourceDelta)
 	if checkResult.Exceeded() {
 		klog.V(4).Infof("Skipping %s - minimal limit exceeded for %v", node.Name, checkResult.ExceededResources)
+		reason := simulator.NodeGroupSetMinimalResourceLimitExceeded
 		for _, resource := range checkResult.ExceededResources {
+			if _, _, ok := cloudprovider.ParseNodeGroupSetLimitKey(resource); !ok {
+				reason = simulator.MinimalResourceLimitExceeded
+			}
 			switch resource {
 			case cloudprovider.ResourceNameCores:
 				metrics.RegisterSkippedScaleDownCPU()
@@ -205,7 +246,7 @@ func (n *Nodes) unremovableReason(context *context.AutoscalingContext, v *node,
 				continue
 			}
 		}
-		return simulator.MinimalResourceLimitExceeded
+		return reason
 	}
 
 	nodeGroupSize[nodeGroup.Id()]--
@@ -225,14 +266,15 @@ func (n *Nodes) splitEmptyAndNonEmptyNodes() (empty, needDrain map[string]*node)
 	return
 }
 
-func verifyMinSize(nodeName string, nodeGroup cloudprovider.NodeGroup, nodeGroupSize map[string]int, as scaledown.ActuationStatus) simulator.UnremovableReason {
+func (n *Nodes) verifyMinSize(nodeName string, nodeGroup cloudprovider.NodeGroup, nodeGroupSize map[string]int, as scaledown.ActuationStatus) simulator.UnremovableReason {
 	size, found := nodeGroupSize[nodeGroup.Id()]
 	if !found {
 		klog.Errorf("Error while checking node group size %s: group size not found in cache", nodeGroup.Id())
 		return simulator.UnexpectedError
 	}
 	deletionsInProgress := as.DeletionsCount(nodeGroup.Id())
-	if size-deletionsInProgress <= nodeGroup.MinSize() {
+	minSize, _ := nodegroupconfig.GetSizeRange(n.sdtg, nodeGroup)
+	if size-deletionsInProgress <= minSize {
 		klog.V(1).Infof("Skipping %s - node group min size reached", nodeName)
 		return simulator.NodeGroupMinSizeReached
 	}
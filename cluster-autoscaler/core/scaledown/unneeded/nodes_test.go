@@ -25,6 +25,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/eligibility"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
 	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
@@ -114,6 +115,21 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestRestoreUnneededSince(t *testing.T) {
+	restoredTimestamp := time.Now().Add(-10 * time.Minute)
+	firstTimestamp := restoredTimestamp.Add(15 * time.Minute)
+	secondTimestamp := firstTimestamp.Add(time.Minute)
+
+	nodes := NewNodes(nil, nil)
+	nodes.RestoreUnneededSince(map[string]time.Time{"n1": restoredTimestamp, "n2": restoredTimestamp})
+	nodes.Update([]simulator.NodeToBeRemoved{makeNode("n1", "v1"), makeNode("n3", "v1")}, firstTimestamp)
+	assert.Equal(t, map[string]time.Time{"n1": restoredTimestamp, "n3": firstTimestamp}, nodes.UnneededSince())
+
+	// Restored times are only used in the first update.
+	nodes.Update([]simulator.NodeToBeRemoved{makeNode("n1", "v1"), makeNode("n2", "v1")}, secondTimestamp)
+	assert.Equal(t, map[string]time.Time{"n1": restoredTimestamp, "n2": secondTimestamp}, nodes.UnneededSince())
+}
+
 const testVersion = "testVersion"
 
 func makeNode(name, version string) simulator.NodeToBeRemoved {
@@ -211,6 +227,78 @@ func TestRemovableAt(t *testing.T) {
 	}
 }
 
+func TestRemovableAtExpensiveDrain(t *testing.T) {
+	drainCostOptions := config.DrainCostOptions{
+		Enabled:               true,
+		ExpensiveThreshold:    10,
+		ExpensiveUnneededTime: time.Hour,
+	}
+	for tn, tc := range map[string]struct {
+		drainCost     config.DrainCostOptions
+		nodeDrainCost float64
+		unneededFor   time.Duration
+		wantRemovable bool
+		wantReason    simulator.UnremovableReason
+	}{
+		"cheap node": {
+			drainCost:     drainCostOptions,
+			nodeDrainCost: 2,
+			unneededFor:   time.Minute,
+			wantRemovable: true,
+		},
+		"expensive node unneeded for too short": {
+			drainCost:     drainCostOptions,
+			nodeDrainCost: 10,
+			unneededFor:   time.Minute,
+			wantReason:    simulator.NotUnneededLongEnough,
+		},
+		"expensive node unneeded for long enough": {
+			drainCost:     drainCostOptions,
+			nodeDrainCost: 10,
+			unneededFor:   2 * time.Hour,
+			wantRemovable: true,
+		},
+		"expensive node with drain cost disabled": {
+			nodeDrainCost: 10,
+			unneededFor:   time.Minute,
+			wantRemovable: true,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ng := testprovider.NewTestNodeGroup("ng", 100, 0, 10, true, false, "", nil, nil)
+			node := simulator.NodeToBeRemoved{
+				Node:             BuildTestNode("n", 10, 100),
+				PodsToReschedule: []*apiv1.Pod{BuildTestPod("p", 1, 1)},
+				DrainCost:        tc.nodeDrainCost,
+			}
+			SetNodeReadyState(node.Node, true, time.Time{})
+			provider := testprovider.NewTestCloudProvider(nil, nil)
+			provider.InsertNodeGroup(ng)
+			provider.AddNode("ng", node.Node)
+
+			rsLister, err := kube_util.NewTestReplicaSetLister(nil)
+			assert.NoError(t, err)
+			registry := kube_util.NewListerRegistry(nil, nil, nil, nil, nil, nil, nil, rsLister, nil)
+			ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{ScaleDownSimulationTimeout: 5 * time.Minute, DrainCost: tc.drainCost}, &fake.Clientset{}, registry, provider, nil, nil)
+			assert.NoError(t, err)
+
+			now := time.Now()
+			n := NewNodes(&fakeScaleDownTimeGetter{}, &resource.LimitsFinder{})
+			n.Update([]simulator.NodeToBeRemoved{node}, now.Add(-tc.unneededFor))
+			_, gotDrain, gotUnremovable := n.RemovableAt(&ctx, now, resource.Limits{}, []string{}, &fakeActuationStatus{})
+			if tc.wantRemovable {
+				assert.Len(t, gotDrain, 1)
+				assert.Empty(t, gotUnremovable)
+			} else {
+				assert.Empty(t, gotDrain)
+				if assert.Len(t, gotUnremovable, 1) {
+					assert.Equal(t, tc.wantReason, gotUnremovable[0].Reason)
+				}
+			}
+		})
+	}
+}
+
 type fakeActuationStatus struct {
 	recentEvictions []*apiv1.Pod
 	deletionCount   map[string]int
@@ -241,3 +329,77 @@ func (f *fakeScaleDownTimeGetter) GetScaleDownUnneededTime(cloudprovider.NodeGro
 func (f *fakeScaleDownTimeGetter) GetScaleDownUnreadyTime(cloudprovider.NodeGroup) (time.Duration, error) {
 	return 0 * time.Second, nil
 }
+
+func (f *fakeScaleDownTimeGetter) GetMinSize(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	return nodeGroup.MinSize(), nil
+}
+
+func (f *fakeScaleDownTimeGetter) GetMaxSize(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	return nodeGroup.MaxSize(), nil
+}
+
+func TestRemovableAtScaleDownProtection(t *testing.T) {
+	now := time.Now()
+	for tn, tc := range map[string]struct {
+		nodeAnnotations map[string]string
+		podAnnotations  map[string]string
+		wantRemovable   bool
+	}{
+		"unprotected node": {
+			wantRemovable: true,
+		},
+		"node protected by annotation": {
+			nodeAnnotations: map[string]string{eligibility.ScaleDownProtectedUntilKey: now.Add(time.Hour).Format(time.RFC3339)},
+		},
+		"node with expired protection": {
+			nodeAnnotations: map[string]string{eligibility.ScaleDownProtectedUntilKey: now.Add(-time.Hour).Format(time.RFC3339)},
+			wantRemovable:   true,
+		},
+		"node with invalid protection": {
+			nodeAnnotations: map[string]string{eligibility.ScaleDownProtectedUntilKey: "tomorrow"},
+		},
+		"node running a protecting pod": {
+			podAnnotations: map[string]string{eligibility.ProtectNodeFromScaleDownKey: "true"},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ng := testprovider.NewTestNodeGroup("ng", 100, 0, 10, true, false, "", nil, nil)
+			node := BuildTestNode("n", 10, 100)
+			SetNodeReadyState(node, true, time.Time{})
+			pod := BuildTestPod("p", 1, 1)
+			pod.Spec.NodeName = node.Name
+			provider := testprovider.NewTestCloudProvider(nil, nil)
+			provider.InsertNodeGroup(ng)
+			provider.AddNode("ng", node)
+
+			rsLister, err := kube_util.NewTestReplicaSetLister(nil)
+			assert.NoError(t, err)
+			registry := kube_util.NewListerRegistry(nil, nil, nil, nil, nil, nil, nil, rsLister, nil)
+			ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{ScaleDownSimulationTimeout: 5 * time.Minute}, &fake.Clientset{}, registry, provider, nil, nil)
+			assert.NoError(t, err)
+
+			n := NewNodes(&fakeScaleDownTimeGetter{}, &resource.LimitsFinder{})
+			n.Update([]simulator.NodeToBeRemoved{{Node: node, PodsToReschedule: []*apiv1.Pod{pod}}}, now.Add(-time.Hour))
+
+			// Protection is added after the node became unneeded.
+			protectedNode := node.DeepCopy()
+			protectedNode.Annotations = tc.nodeAnnotations
+			protectingPod := pod.DeepCopy()
+			for k, v := range tc.podAnnotations {
+				protectingPod.Annotations[k] = v
+			}
+			assert.NoError(t, ctx.ClusterSnapshot.AddNodeWithPods(protectedNode, []*apiv1.Pod{protectingPod}))
+
+			_, gotDrain, gotUnremovable := n.RemovableAt(&ctx, now, resource.Limits{}, []string{}, &fakeActuationStatus{})
+			if tc.wantRemovable {
+				assert.Len(t, gotDrain, 1)
+				assert.Empty(t, gotUnremovable)
+			} else {
+				assert.Empty(t, gotDrain)
+				if assert.Len(t, gotUnremovable, 1) {
+					assert.Equal(t, simulator.ScaleDownProtected, gotUnremovable[0].Reason)
+				}
+			}
+		})
+	}
+}
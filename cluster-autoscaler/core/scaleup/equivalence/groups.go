@@ -18,6 +18,7 @@ package equivalence
 
 import (
 	"reflect"
+	"sort"
 
 	"k8s.io/autoscaler/cluster-autoscaler/utils"
 
@@ -26,6 +27,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
 	pod_utils "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
 )
 
 // PodGroup contains a group of pods that are equivalent in terms of schedulability.
@@ -49,6 +51,13 @@ func BuildPodGroups(pods []*apiv1.Pod) []*PodGroup {
 	return podEquivalenceGroups
 }
 
+// SortByPriority sorts pod groups by decreasing priority of their pods.
+func SortByPriority(podGroups []*PodGroup) {
+	sort.SliceStable(podGroups, func(i, j int) bool {
+		return corev1helpers.PodPriority(podGroups[i].Pods[0]) > corev1helpers.PodPriority(podGroups[j].Pods[0])
+	})
+}
+
 type equivalenceGroupId int
 type equivalenceGroup struct {
 	id           equivalenceGroupId
@@ -184,3 +184,19 @@ func TestEquivalenceGroupIgnoresDaemonSets(t *testing.T) {
 	podGroups := groupPodsBySchedulingProperties(pods)
 	assert.Equal(t, 2, len(podGroups))
 }
+
+func TestSortByPriority(t *testing.T) {
+	buildGroup := func(name string, priority *int32) *PodGroup {
+		pod := BuildTestPod(name, 100, 100)
+		pod.Spec.Priority = priority
+		return &PodGroup{Pods: []*apiv1.Pod{pod}}
+	}
+	low, high := int32(-5), int32(1000)
+	podGroups := []*PodGroup{buildGroup("low", &low), buildGroup("default-1", nil), buildGroup("high", &high), buildGroup("default-2", nil)}
+	SortByPriority(podGroups)
+	var names []string
+	for _, podGroup := range podGroups {
+		names = append(names, podGroup.Pods[0].Name)
+	}
+	assert.Equal(t, []string{"high", "default-1", "default-2", "low"}, names)
+}
@@ -17,12 +17,14 @@ limitations under the License.
 package orchestrator
 
 import (
+	stdcontext "context"
 	"fmt"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
@@ -34,6 +36,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 )
 
 // ScaleUpExecutor scales up node groups.
@@ -57,7 +60,9 @@ func newScaleUpExecutor(
 // May scale up groups concurrently when autoscler option is enabled.
 // In case of issues returns an error and a scale up info which failed to execute.
 // If there were multiple concurrent errors one combined error is returned.
+// Calls to the cloud provider are traced as children of the span carried by traceContext.
 func (e *scaleUpExecutor) ExecuteScaleUps(
+	traceContext stdcontext.Context,
 	scaleUpInfos []nodegroupset.ScaleUpInfo,
 	nodeInfos map[string]*schedulerframework.NodeInfo,
 	now time.Time,
@@ -65,12 +70,13 @@ func (e *scaleUpExecutor) ExecuteScaleUps(
 ) (errors.AutoscalerError, []cloudprovider.NodeGroup) {
 	options := e.autoscalingContext.AutoscalingOptions
 	if options.ParallelScaleUp {
-		return e.executeScaleUpsParallel(scaleUpInfos, nodeInfos, now, atomic)
+		return e.executeScaleUpsParallel(traceContext, scaleUpInfos, nodeInfos, now, atomic)
 	}
-	return e.executeScaleUpsSync(scaleUpInfos, nodeInfos, now, atomic)
+	return e.executeScaleUpsSync(traceContext, scaleUpInfos, nodeInfos, now, atomic)
 }
 
 func (e *scaleUpExecutor) executeScaleUpsSync(
+	traceContext stdcontext.Context,
 	scaleUpInfos []nodegroupset.ScaleUpInfo,
 	nodeInfos map[string]*schedulerframework.NodeInfo,
 	now time.Time,
@@ -83,7 +89,7 @@ func (e *scaleUpExecutor) executeScaleUpsSync(
 			klog.Errorf("ExecuteScaleUp: failed to get node info for node group %s", scaleUpInfo.Group.Id())
 			continue
 		}
-		if aErr := e.executeScaleUp(scaleUpInfo, nodeInfo, availableGPUTypes, now, atomic); aErr != nil {
+		if aErr := e.executeScaleUp(traceContext, scaleUpInfo, nodeInfo, availableGPUTypes, now, atomic); aErr != nil {
 			return aErr, []cloudprovider.NodeGroup{scaleUpInfo.Group}
 		}
 	}
@@ -91,6 +97,7 @@ func (e *scaleUpExecutor) executeScaleUpsSync(
 }
 
 func (e *scaleUpExecutor) executeScaleUpsParallel(
+	traceContext stdcontext.Context,
 	scaleUpInfos []nodegroupset.ScaleUpInfo,
 	nodeInfos map[string]*schedulerframework.NodeInfo,
 	now time.Time,
@@ -116,7 +123,7 @@ func (e *scaleUpExecutor) executeScaleUpsParallel(
 				klog.Errorf("ExecuteScaleUp: failed to get node info for node group %s", info.Group.Id())
 				return
 			}
-			if aErr := e.executeScaleUp(info, nodeInfo, availableGPUTypes, now, atomic); aErr != nil {
+			if aErr := e.executeScaleUp(traceContext, info, nodeInfo, availableGPUTypes, now, atomic); aErr != nil {
 				errResults <- errResult{err: aErr, info: &info}
 			}
 		}(scaleUpInfo)
@@ -139,18 +146,26 @@ func (e *scaleUpExecutor) executeScaleUpsParallel(
 	return nil, nil
 }
 
-func (e *scaleUpExecutor) increaseSize(nodeGroup cloudprovider.NodeGroup, increase int, atomic bool) error {
+func (e *scaleUpExecutor) increaseSize(traceContext stdcontext.Context, nodeGroup cloudprovider.NodeGroup, increase int, atomic bool) error {
 	if atomic {
-		if err := nodeGroup.AtomicIncreaseSize(increase); err != cloudprovider.ErrNotImplemented {
+		span := tracing.StartSpan(traceContext, "CloudProvider.AtomicIncreaseSize", attribute.String("nodeGroup", nodeGroup.Id()), attribute.Int("increase", increase))
+		err := tracing.Bind(nodeGroup, span.Context()).AtomicIncreaseSize(increase)
+		if err != cloudprovider.ErrNotImplemented {
+			span.EndWithError(err)
 			return err
 		}
+		span.End()
 		// If error is cloudprovider.ErrNotImplemented, fall back to non-atomic
 		// increase - cloud provider doesn't support it.
 	}
-	return nodeGroup.IncreaseSize(increase)
+	span := tracing.StartSpan(traceContext, "CloudProvider.IncreaseSize", attribute.String("nodeGroup", nodeGroup.Id()), attribute.Int("increase", increase))
+	err := tracing.Bind(nodeGroup, span.Context()).IncreaseSize(increase)
+	span.EndWithError(err)
+	return err
 }
 
 func (e *scaleUpExecutor) executeScaleUp(
+	traceContext stdcontext.Context,
 	info nodegroupset.ScaleUpInfo,
 	nodeInfo *schedulerframework.NodeInfo,
 	availableGPUTypes map[string]struct{},
@@ -163,7 +178,7 @@ func (e *scaleUpExecutor) executeScaleUp(
 	e.autoscalingContext.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaledUpGroup",
 		"Scale-up: setting group %s size to %d instead of %d (max: %d)", info.Group.Id(), info.NewSize, info.CurrentSize, info.MaxSize)
 	increase := info.NewSize - info.CurrentSize
-	if err := e.increaseSize(info.Group, increase, atomic); err != nil {
+	if err := e.increaseSize(traceContext, info.Group, increase, atomic); err != nil {
 		e.autoscalingContext.LogRecorder.Eventf(apiv1.EventTypeWarning, "FailedToScaleUpGroup", "Scale-up failed for group %s: %v", info.Group.Id(), err)
 		aerr := errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("failed to increase node group size: ")
 		e.scaleStateNotifier.RegisterFailedScaleUp(info.Group, string(aerr.Type()), aerr.Error(), gpuResourceName, gpuType, now)
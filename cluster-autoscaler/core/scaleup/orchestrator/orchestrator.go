@@ -17,30 +17,36 @@ limitations under the License.
 package orchestrator
 
 import (
+	stdcontext "context"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
 	"k8s.io/klog/v2"
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/utils/integer"
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/equivalence"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/ratelimit"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/core/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/klogx"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 )
 
 // ScaleUpOrchestrator implements scaleup.Orchestrator interface.
@@ -52,6 +58,7 @@ type ScaleUpOrchestrator struct {
 	scaleUpExecutor      *scaleUpExecutor
 	estimatorBuilder     estimator.EstimatorBuilder
 	taintConfig          taints.TaintConfig
+	rateLimiter          *ratelimit.Limiter
 	initialized          bool
 }
 
@@ -77,6 +84,15 @@ func (o *ScaleUpOrchestrator) Initialize(
 	o.taintConfig = taintConfig
 	o.resourceManager = resource.NewManager(processors.CustomResourcesProcessor)
 	o.scaleUpExecutor = newScaleUpExecutor(autoscalingContext, processors.ScaleStateNotifier)
+	// Initialize is called again when options are reloaded, the limiter keeps its history then.
+	if o.rateLimiter == nil {
+		o.rateLimiter = ratelimit.NewLimiter(processors.NodeGroupConfigProcessor)
+		if processors.ScaleStateNotifier != nil {
+			processors.ScaleStateNotifier.Register(o.rateLimiter)
+		}
+	} else {
+		o.rateLimiter.SetConfigProcessor(processors.NodeGroupConfigProcessor)
+	}
 	o.initialized = true
 }
 
@@ -93,6 +109,25 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	if !o.initialized {
 		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, errors.NewAutoscalerError(errors.InternalError, "ScaleUpOrchestrator is not initialized"))
 	}
+	span := tracing.StartSpan(o.autoscalingContext.TraceContext, "ScaleUp", attribute.Int("pods", len(unschedulablePods)))
+	defer span.End()
+	return o.scaleUp(span.Context(), unschedulablePods, nodes, daemonSets, nodeInfos, allOrNothing, nil, nil)
+}
+
+// scaleUp implements ScaleUp. Node groups listed in scaledUp were already scaled up in this
+// loop, so they are skipped for the given reason. If plan is set, the scale-up is a further
+// step of a multi-step scale-up: it is added to the plan instead of being executed.
+// The scale-up is traced as a child of the span carried by traceContext.
+func (o *ScaleUpOrchestrator) scaleUp(
+	traceContext stdcontext.Context,
+	unschedulablePods []*apiv1.Pod,
+	nodes []*apiv1.Node,
+	daemonSets []*appsv1.DaemonSet,
+	nodeInfos map[string]*schedulerframework.NodeInfo,
+	allOrNothing bool,
+	scaledUp map[string]status.Reasons,
+	plan *scaleUpPlan,
+) (*status.ScaleUpStatus, errors.AutoscalerError) {
 
 	loggingQuota := klogx.PodsLoggingQuota()
 	for _, pod := range unschedulablePods {
@@ -102,6 +137,9 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 
 	buildPodEquivalenceGroupsStart := time.Now()
 	podEquivalenceGroups := equivalence.BuildPodGroups(unschedulablePods)
+	if o.autoscalingContext.PriorityOrderedScaleUp {
+		equivalence.SortByPriority(podEquivalenceGroups)
+	}
 	metrics.UpdateDurationFromStart(metrics.BuildPodEquivalenceGroups, buildPodEquivalenceGroupsStart)
 
 	upcomingNodes, aErr := o.UpcomingNodes(nodeInfos)
@@ -126,11 +164,25 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	if aErr != nil {
 		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, aErr.AddPrefix("could not compute total resources: "))
 	}
+	plan.subtractFrom(resourcesLeft)
+	currentNodeCount := len(nodes) + len(upcomingNodes) + plan.plannedNodes()
 
 	now := time.Now()
 
 	// Filter out invalid node groups
-	validNodeGroups, skippedNodeGroups := o.filterValidScaleUpNodeGroups(nodeGroups, nodeInfos, resourcesLeft, len(nodes)+len(upcomingNodes), now)
+	validNodeGroups, skippedNodeGroups := o.filterValidScaleUpNodeGroups(nodeGroups, nodeInfos, resourcesLeft, currentNodeCount, now)
+	if len(scaledUp) > 0 {
+		var notScaledUp []cloudprovider.NodeGroup
+		for _, nodeGroup := range validNodeGroups {
+			if _, found := scaledUp[nodeGroup.Id()]; !found {
+				notScaledUp = append(notScaledUp, nodeGroup)
+			}
+		}
+		validNodeGroups = notScaledUp
+		for nodeGroupId, reason := range scaledUp {
+			skippedNodeGroups[nodeGroupId] = reason
+		}
+	}
 
 	// Mark skipped node groups as processed.
 	for nodegroupID := range skippedNodeGroups {
@@ -146,7 +198,7 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	}
 
 	for _, nodeGroup := range validNodeGroups {
-		option := o.ComputeExpansionOption(nodeGroup, schedulablePodGroups, nodeInfos, len(nodes)+len(upcomingNodes), now, allOrNothing)
+		option := o.ComputeExpansionOption(traceContext, nodeGroup, schedulablePodGroups, nodeInfos, currentNodeCount, now, allOrNothing)
 		o.processors.BinpackingLimiter.MarkProcessed(o.autoscalingContext, nodeGroup.Id())
 
 		if len(option.Pods) == 0 || option.NodeCount == 0 {
@@ -175,12 +227,13 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	}
 
 	// Pick some expansion option.
-	bestOption := o.autoscalingContext.ExpanderStrategy.BestOption(options, nodeInfos)
+	bestOption := tracing.Bind(o.autoscalingContext.ExpanderStrategy, traceContext).BestOption(options, nodeInfos)
 	if bestOption == nil || bestOption.NodeCount <= 0 {
 		return &status.ScaleUpStatus{
 			Result:                  status.ScaleUpNoOptionsAvailable,
 			PodsRemainUnschedulable: GetRemainingPods(podEquivalenceGroups, skippedNodeGroups),
 			ConsideredNodeGroups:    nodeGroups,
+			ExpansionOptions:        options,
 		}, nil
 	}
 	klog.V(1).Infof("Best option to resize: %s", bestOption.NodeGroup.Id())
@@ -190,10 +243,22 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	klog.V(1).Infof("Estimated %d nodes needed in %s", bestOption.NodeCount, bestOption.NodeGroup.Id())
 
 	// Cap new nodes to supported number of nodes in the cluster.
-	newNodes, aErr := o.GetCappedNewNodeCount(bestOption.NodeCount, len(nodes)+len(upcomingNodes))
+	newNodes, aErr := o.GetCappedNewNodeCount(bestOption.NodeCount, currentNodeCount)
 	if aErr != nil {
 		return status.UpdateScaleUpError(&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods}, aErr)
 	}
+	if maxNodes := o.autoscalingContext.MaxNodesPerScaleUp; plan != nil && maxNodes > 0 && newNodes > maxNodes-plan.newNodes {
+		// Steps of a multi-step scale-up share the limit of nodes added in a single scale-up.
+		newNodes = maxNodes - plan.newNodes
+		if newNodes <= 0 {
+			return &status.ScaleUpStatus{
+				Result:                  status.ScaleUpNoOptionsAvailable,
+				PodsRemainUnschedulable: GetRemainingPods(podEquivalenceGroups, skippedNodeGroups),
+				ConsideredNodeGroups:    nodeGroups,
+				ExpansionOptions:        options,
+			}, nil
+		}
+	}
 
 	newNodes, aErr = o.applyLimits(newNodes, resourcesLeft, bestOption.NodeGroup, nodeInfos)
 	if aErr != nil {
@@ -215,8 +280,9 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	// If necessary, create the node group. This is no longer simulation, an empty node group will be created by cloud provider if supported.
 	createNodeGroupResults := make([]nodegroups.CreateNodeGroupResult, 0)
 	if !bestOption.NodeGroup.Exist() {
-		if allOrNothing && bestOption.NodeGroup.MaxSize() < newNodes {
-			klog.V(1).Infof("Can only create a new node group with max %d nodes, need %d nodes", bestOption.NodeGroup.MaxSize(), newNodes)
+		_, maxSize := nodegroupconfig.GetSizeRange(o.processors.NodeGroupConfigProcessor, bestOption.NodeGroup)
+		if allOrNothing && maxSize < newNodes {
+			klog.V(1).Infof("Can only create a new node group with max %d nodes, need %d nodes", maxSize, newNodes)
 			// Can't execute a scale-up that will accommodate all pods, so nothing is considered schedulable.
 			klog.V(1).Info("Not attempting scale-up due to all-or-nothing strategy: not all pods would be accommodated")
 			markedEquivalenceGroups := markAllGroupsAsUnschedulable(podEquivalenceGroups, AllOrNothingReason)
@@ -241,6 +307,7 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	for _, sui := range scaleUpInfos {
 		totalCapacity += sui.NewSize - sui.CurrentSize
 	}
+	rateLimited := o.isScaleUpRateLimited(scaleUpInfos, now)
 	if totalCapacity < newNodes {
 		klog.V(1).Infof("Can only add %d nodes due to node group limits, need %d nodes", totalCapacity, newNodes)
 		if allOrNothing {
@@ -251,30 +318,175 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 		}
 	}
 
+	scaleUpStatus := &status.ScaleUpStatus{
+		Result:                  status.ScaleUpSuccessful,
+		ScaleUpInfos:            scaleUpInfos,
+		PodsRemainUnschedulable: GetRemainingPods(podEquivalenceGroups, skippedNodeGroups),
+		ConsideredNodeGroups:    nodeGroups,
+		CreateNodeGroupResults:  createNodeGroupResults,
+		PodsTriggeredScaleUp:    bestOption.Pods,
+		PodsAwaitEvaluation:     GetPodsAwaitingEvaluation(podEquivalenceGroups, bestOption.NodeGroup.Id()),
+		ExpansionOptions:        options,
+		BestOption:              bestOption,
+	}
+
+	if plan != nil {
+		// Further steps are executed together with the first one.
+		delta, aErr := o.scaleUpDelta(scaleUpInfos, nodeInfos)
+		if aErr != nil {
+			return status.UpdateScaleUpError(&status.ScaleUpStatus{CreateNodeGroupResults: createNodeGroupResults, PodsTriggeredScaleUp: bestOption.Pods}, aErr)
+		}
+		plan.addStep(scaleUpInfos, createNodeGroupResults, bestOption.Pods, delta)
+		return scaleUpStatus, nil
+	}
+
+	multiStep := o.autoscalingContext.MaxScaleUpPlanSteps > 1 && !allOrNothing
+	if multiStep {
+		delta, aErr := o.scaleUpDelta(scaleUpInfos, nodeInfos)
+		if aErr != nil {
+			return status.UpdateScaleUpError(&status.ScaleUpStatus{CreateNodeGroupResults: createNodeGroupResults, PodsTriggeredScaleUp: bestOption.Pods}, aErr)
+		}
+		plan = newScaleUpPlan(scaledUp)
+		plan.addStep(scaleUpInfos, createNodeGroupResults, bestOption.Pods, delta)
+		if lastStepStatus := o.planNextSteps(traceContext, plan, unschedulablePods, nodes, daemonSets, nodeInfos); lastStepStatus != nil {
+			scaleUpStatus.PodsRemainUnschedulable = lastStepStatus.PodsRemainUnschedulable
+			scaleUpStatus.PodsAwaitEvaluation = lastStepStatus.PodsAwaitEvaluation
+		}
+		scaleUpStatus.ScaleUpInfos = plan.scaleUpInfos
+		scaleUpStatus.CreateNodeGroupResults = plan.createNodeGroupResults
+		scaleUpStatus.PodsTriggeredScaleUp = plan.podsTriggeredScaleUp
+	}
+
 	// Execute scale up.
-	klog.V(1).Infof("Final scale-up plan: %v", scaleUpInfos)
-	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(scaleUpInfos, nodeInfos, now, allOrNothing)
+	klog.V(1).Infof("Final scale-up plan: %v", scaleUpStatus.ScaleUpInfos)
+	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(traceContext, scaleUpStatus.ScaleUpInfos, nodeInfos, now, allOrNothing)
 	if aErr != nil {
 		return status.UpdateScaleUpError(
 			&status.ScaleUpStatus{
-				CreateNodeGroupResults: createNodeGroupResults,
+				CreateNodeGroupResults: scaleUpStatus.CreateNodeGroupResults,
 				FailedResizeNodeGroups: failedNodeGroups,
-				PodsTriggeredScaleUp:   bestOption.Pods,
+				PodsTriggeredScaleUp:   scaleUpStatus.PodsTriggeredScaleUp,
+				ExpansionOptions:       options,
+				BestOption:             bestOption,
 			},
 			aErr,
 		)
 	}
-
 	o.clusterStateRegistry.Recalculate()
-	return &status.ScaleUpStatus{
-		Result:                  status.ScaleUpSuccessful,
-		ScaleUpInfos:            scaleUpInfos,
-		PodsRemainUnschedulable: GetRemainingPods(podEquivalenceGroups, skippedNodeGroups),
-		ConsideredNodeGroups:    nodeGroups,
-		CreateNodeGroupResults:  createNodeGroupResults,
-		PodsTriggeredScaleUp:    bestOption.Pods,
-		PodsAwaitEvaluation:     GetPodsAwaitingEvaluation(podEquivalenceGroups, bestOption.NodeGroup.Id()),
-	}, nil
+
+	// Pods that didn't fit because of the scale-up rate limit spill over to other node groups.
+	// Steps of a multi-step scale-up already skip node groups scaled up by earlier steps.
+	if rateLimited && !allOrNothing && !multiStep {
+		if remainingPods := podsNotTriggeringScaleUp(unschedulablePods, bestOption.Pods); len(remainingPods) > 0 {
+			if scaledUp == nil {
+				scaledUp = map[string]status.Reasons{}
+			}
+			for _, sui := range scaleUpInfos {
+				scaledUp[sui.Group.Id()] = ScaleUpRateLimitReachedReason
+			}
+			klog.V(1).Infof("Scale-up rate limit reached, trying to fit %d remaining pods in other node groups", len(remainingPods))
+			spilloverStatus, aErr := o.scaleUp(traceContext, remainingPods, nodes, daemonSets, nodeInfos, false, scaledUp, nil)
+			if aErr != nil {
+				klog.Errorf("Failed to scale up other node groups after reaching scale-up rate limit: %v", aErr)
+				return scaleUpStatus, nil
+			}
+			return mergeSpilloverStatus(scaleUpStatus, spilloverStatus), nil
+		}
+	}
+	return scaleUpStatus, nil
+}
+
+// isScaleUpRateLimited returns true if any of the planned scale-ups uses up the whole
+// scale-up rate limit allowance of its node group.
+func (o *ScaleUpOrchestrator) isScaleUpRateLimited(scaleUpInfos []nodegroupset.ScaleUpInfo, now time.Time) bool {
+	for _, sui := range scaleUpInfos {
+		if allowance, limited := o.rateLimiter.Allowance(sui.Group, now); limited && sui.NewSize-sui.CurrentSize >= allowance {
+			return true
+		}
+	}
+	return false
+}
+
+// planNextSteps adds steps to a multi-step scale-up plan for pods not helped by earlier steps,
+// until all pods are helped, no more options are available or a limit is reached. It returns
+// the status of the last planning attempt, or nil if no attempt was made.
+func (o *ScaleUpOrchestrator) planNextSteps(
+	traceContext stdcontext.Context,
+	plan *scaleUpPlan,
+	unschedulablePods []*apiv1.Pod,
+	nodes []*apiv1.Node,
+	daemonSets []*appsv1.DaemonSet,
+	nodeInfos map[string]*schedulerframework.NodeInfo,
+) *status.ScaleUpStatus {
+	var lastStepStatus *status.ScaleUpStatus
+	for plan.steps < o.autoscalingContext.MaxScaleUpPlanSteps {
+		if maxNodes := o.autoscalingContext.MaxNodesPerScaleUp; maxNodes > 0 && plan.newNodes >= maxNodes {
+			klog.V(1).Infof("Scale-up plan reached the limit of %d new nodes", maxNodes)
+			break
+		}
+		remainingPods := podsNotTriggeringScaleUp(unschedulablePods, plan.podsTriggeredScaleUp)
+		if len(remainingPods) == 0 {
+			break
+		}
+		steps := plan.steps
+		klog.V(1).Infof("Planning step %d of scale-up for %d remaining pods", steps+1, len(remainingPods))
+		stepStatus, aErr := o.scaleUp(traceContext, remainingPods, nodes, daemonSets, nodeInfos, false, plan.skipped, plan)
+		if aErr != nil {
+			klog.Errorf("Failed to plan step %d of scale-up: %v", steps+1, aErr)
+			break
+		}
+		lastStepStatus = stepStatus
+		if plan.steps == steps {
+			break
+		}
+	}
+	return lastStepStatus
+}
+
+// scaleUpDelta calculates the amount of resources added to the cluster by the scale-ups.
+func (o *ScaleUpOrchestrator) scaleUpDelta(scaleUpInfos []nodegroupset.ScaleUpInfo, nodeInfos map[string]*schedulerframework.NodeInfo) (resource.Delta, errors.AutoscalerError) {
+	delta := resource.Delta{}
+	for _, sui := range scaleUpInfos {
+		nodeInfo, found := nodeInfos[sui.Group.Id()]
+		if !found {
+			return nil, errors.NewAutoscalerError(errors.InternalError, "no node info for node group %s", sui.Group.Id())
+		}
+		nodeDelta, aErr := o.resourceManager.DeltaForNode(o.autoscalingContext, nodeInfo, sui.Group)
+		if aErr != nil {
+			return nil, aErr
+		}
+		for resourceName, resourceDelta := range nodeDelta {
+			delta[resourceName] += resourceDelta * int64(sui.NewSize-sui.CurrentSize)
+		}
+	}
+	return delta, nil
+}
+
+func podsNotTriggeringScaleUp(unschedulablePods, triggeredScaleUp []*apiv1.Pod) []*apiv1.Pod {
+	triggered := make(map[*apiv1.Pod]bool, len(triggeredScaleUp))
+	for _, pod := range triggeredScaleUp {
+		triggered[pod] = true
+	}
+	var remaining []*apiv1.Pod
+	for _, pod := range unschedulablePods {
+		if !triggered[pod] {
+			remaining = append(remaining, pod)
+		}
+	}
+	return remaining
+}
+
+// mergeSpilloverStatus merges status of a scale-up of other node groups, done after the
+// best option reached its scale-up rate limit, into the status of the initial scale-up.
+func mergeSpilloverStatus(scaleUpStatus, spilloverStatus *status.ScaleUpStatus) *status.ScaleUpStatus {
+	scaleUpStatus.PodsRemainUnschedulable = spilloverStatus.PodsRemainUnschedulable
+	scaleUpStatus.PodsAwaitEvaluation = spilloverStatus.PodsAwaitEvaluation
+	if spilloverStatus.WasSuccessful() {
+		scaleUpStatus.ScaleUpInfos = append(scaleUpStatus.ScaleUpInfos, spilloverStatus.ScaleUpInfos...)
+		scaleUpStatus.CreateNodeGroupResults = append(scaleUpStatus.CreateNodeGroupResults, spilloverStatus.CreateNodeGroupResults...)
+		scaleUpStatus.PodsTriggeredScaleUp = append(scaleUpStatus.PodsTriggeredScaleUp, spilloverStatus.PodsTriggeredScaleUp...)
+	}
+	return scaleUpStatus
 }
 
 func (o *ScaleUpOrchestrator) applyLimits(newNodes int, resourcesLeft resource.Limits, nodeGroup cloudprovider.NodeGroup, nodeInfos map[string]*schedulerframework.NodeInfo) (int, errors.AutoscalerError) {
@@ -320,8 +532,9 @@ func (o *ScaleUpOrchestrator) ScaleUpToNodeGroupMinSize(
 			continue
 		}
 
-		klog.V(4).Infof("ScaleUpToNodeGroupMinSize: NodeGroup %s, TargetSize %d, MinSize %d, MaxSize %d", ng.Id(), targetSize, ng.MinSize(), ng.MaxSize())
-		if targetSize >= ng.MinSize() {
+		minSize, maxSize := nodegroupconfig.GetSizeRange(o.processors.NodeGroupConfigProcessor, ng)
+		klog.V(4).Infof("ScaleUpToNodeGroupMinSize: NodeGroup %s, TargetSize %d, MinSize %d, MaxSize %d", ng.Id(), targetSize, minSize, maxSize)
+		if targetSize >= minSize {
 			continue
 		}
 
@@ -341,7 +554,7 @@ func (o *ScaleUpOrchestrator) ScaleUpToNodeGroupMinSize(
 			continue
 		}
 
-		newNodeCount := ng.MinSize() - targetSize
+		newNodeCount := minSize - targetSize
 		newNodeCount, err = o.resourceManager.ApplyLimits(o.autoscalingContext, newNodeCount, resourcesLeft, nodeInfo, ng)
 		if err != nil {
 			klog.Warningf("ScaleUpToNodeGroupMinSize: failed to apply resource limits: %v", err)
@@ -358,7 +571,7 @@ func (o *ScaleUpOrchestrator) ScaleUpToNodeGroupMinSize(
 			Group:       ng,
 			CurrentSize: targetSize,
 			NewSize:     targetSize + newNodeCount,
-			MaxSize:     ng.MaxSize(),
+			MaxSize:     maxSize,
 		}
 		scaleUpInfos = append(scaleUpInfos, info)
 	}
@@ -369,7 +582,7 @@ func (o *ScaleUpOrchestrator) ScaleUpToNodeGroupMinSize(
 	}
 
 	klog.V(1).Infof("ScaleUpToNodeGroupMinSize: final scale-up plan: %v", scaleUpInfos)
-	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(scaleUpInfos, nodeInfos, now, false /* allOrNothing disabled */)
+	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(o.autoscalingContext.TraceContext, scaleUpInfos, nodeInfos, now, false /* allOrNothing disabled */)
 	if aErr != nil {
 		return status.UpdateScaleUpError(
 			&status.ScaleUpStatus{
@@ -387,6 +600,89 @@ func (o *ScaleUpOrchestrator) ScaleUpToNodeGroupMinSize(
 	}, nil
 }
 
+// ScaleUpNodeGroup increases the size of the node group by up to delta nodes. The increase
+// is capped by the max size and the scale-up rate limit of the node group, and by cluster wide
+// node count and resource limits. Node groups scaled in ZeroOrMaxNodeScaling mode are either
+// scaled up by delta nodes or not at all. Returns the number of added nodes.
+func (o *ScaleUpOrchestrator) ScaleUpNodeGroup(
+	nodeGroup cloudprovider.NodeGroup,
+	delta int,
+	nodes []*apiv1.Node,
+	nodeInfos map[string]*schedulerframework.NodeInfo,
+	now time.Time,
+) (int, errors.AutoscalerError) {
+	if !o.initialized {
+		return 0, errors.NewAutoscalerError(errors.InternalError, "ScaleUpOrchestrator is not initialized")
+	}
+	if skipReason := o.IsNodeGroupReadyToScaleUp(nodeGroup, now); skipReason != nil {
+		return 0, errors.NewAutoscalerError(errors.TransientError, "node group %s is not ready to scale up: %s", nodeGroup.Id(), strings.Join(skipReason.Reasons(), ", "))
+	}
+	nodeInfo, found := nodeInfos[nodeGroup.Id()]
+	if !found {
+		return 0, errors.NewAutoscalerError(errors.InternalError, "no node info for node group %s", nodeGroup.Id())
+	}
+	targetSize, err := nodeGroup.TargetSize()
+	if err != nil {
+		return 0, errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("failed to get target size of node group %s: ", nodeGroup.Id())
+	}
+
+	_, maxSize := nodegroupconfig.GetSizeRange(o.processors.NodeGroupConfigProcessor, nodeGroup)
+	newNodeCount := integer.IntMin(delta, maxSize-targetSize)
+	if allowance, limited := o.rateLimiter.Allowance(nodeGroup, now); limited {
+		newNodeCount = integer.IntMin(newNodeCount, allowance)
+	}
+	if newNodeCount > 0 {
+		resourcesLeft, aErr := o.resourceManager.ResourcesLeft(o.autoscalingContext, nodeInfos, nodes)
+		if aErr != nil {
+			return 0, aErr.AddPrefix("could not compute total resources: ")
+		}
+		newNodeCount, aErr = o.resourceManager.ApplyLimits(o.autoscalingContext, newNodeCount, resourcesLeft, nodeInfo, nodeGroup)
+		if aErr != nil {
+			return 0, aErr.AddPrefix("failed to apply resource limits: ")
+		}
+	}
+	if newNodeCount > 0 {
+		upcomingNodes, aErr := o.UpcomingNodes(nodeInfos)
+		if aErr != nil {
+			return 0, aErr.AddPrefix("could not get upcoming nodes: ")
+		}
+		if capped, aErr := o.GetCappedNewNodeCount(newNodeCount, len(nodes)+len(upcomingNodes)); aErr != nil {
+			newNodeCount = 0
+		} else {
+			newNodeCount = capped
+		}
+	}
+
+	atomic := false
+	autoscalingOptions, err := nodeGroup.GetOptions(o.autoscalingContext.NodeGroupDefaults)
+	if err != nil && err != cloudprovider.ErrNotImplemented {
+		klog.Errorf("Couldn't get autoscaling options for ng: %v", nodeGroup.Id())
+	}
+	if autoscalingOptions != nil && autoscalingOptions.ZeroOrMaxNodeScaling {
+		atomic = true
+		if newNodeCount < delta {
+			klog.V(1).Infof("Not scaling up atomic node group %s, only %d of %d nodes can be added", nodeGroup.Id(), newNodeCount, delta)
+			return 0, nil
+		}
+	}
+	if newNodeCount <= 0 {
+		klog.V(1).Infof("Node group %s has no room to add nodes", nodeGroup.Id())
+		return 0, nil
+	}
+
+	scaleUpInfos := []nodegroupset.ScaleUpInfo{{
+		Group:       nodeGroup,
+		CurrentSize: targetSize,
+		NewSize:     targetSize + newNodeCount,
+		MaxSize:     maxSize,
+	}}
+	if aErr, _ := o.scaleUpExecutor.ExecuteScaleUps(o.autoscalingContext.TraceContext, scaleUpInfos, nodeInfos, now, atomic); aErr != nil {
+		return 0, aErr
+	}
+	o.clusterStateRegistry.Recalculate()
+	return newNodeCount, nil
+}
+
 // filterValidScaleUpNodeGroups filters the node groups that are valid for scale-up
 func (o *ScaleUpOrchestrator) filterValidScaleUpNodeGroups(
 	nodeGroups []cloudprovider.NodeGroup,
@@ -410,7 +706,8 @@ func (o *ScaleUpOrchestrator) filterValidScaleUpNodeGroups(
 			skippedNodeGroups[nodeGroup.Id()] = NotReadyReason
 			continue
 		}
-		if currentTargetSize >= nodeGroup.MaxSize() {
+		_, maxSize := nodegroupconfig.GetSizeRange(o.processors.NodeGroupConfigProcessor, nodeGroup)
+		if currentTargetSize >= maxSize {
 			klog.V(4).Infof("Skipping node group %s - max size reached", nodeGroup.Id())
 			skippedNodeGroups[nodeGroup.Id()] = MaxLimitReachedReason
 			continue
@@ -421,13 +718,18 @@ func (o *ScaleUpOrchestrator) filterValidScaleUpNodeGroups(
 		}
 		numNodes := 1
 		if autoscalingOptions != nil && autoscalingOptions.ZeroOrMaxNodeScaling {
-			numNodes = nodeGroup.MaxSize() - currentTargetSize
+			numNodes = maxSize - currentTargetSize
 			if o.autoscalingContext.MaxNodesTotal != 0 && currentNodeCount+numNodes > o.autoscalingContext.MaxNodesTotal {
 				klog.V(4).Infof("Skipping node group %s - atomic scale-up exceeds cluster node count limit", nodeGroup.Id())
 				skippedNodeGroups[nodeGroup.Id()] = NewSkippedReasons("atomic scale-up exceeds cluster node count limit")
 				continue
 			}
 		}
+		if allowance, limited := o.rateLimiter.Allowance(nodeGroup, now); limited && allowance < numNodes {
+			klog.V(4).Infof("Skipping node group %s - scale-up rate limit reached", nodeGroup.Id())
+			skippedNodeGroups[nodeGroup.Id()] = ScaleUpRateLimitReachedReason
+			continue
+		}
 
 		nodeInfo, found := nodeInfos[nodeGroup.Id()]
 		if !found {
@@ -446,7 +748,9 @@ func (o *ScaleUpOrchestrator) filterValidScaleUpNodeGroups(
 }
 
 // ComputeExpansionOption computes expansion option based on pending pods and cluster state.
+// The estimation is traced as a child of the span carried by traceContext.
 func (o *ScaleUpOrchestrator) ComputeExpansionOption(
+	traceContext stdcontext.Context,
 	nodeGroup cloudprovider.NodeGroup,
 	schedulablePodGroups map[string][]estimator.PodEquivalenceGroup,
 	nodeInfos map[string]*schedulerframework.NodeInfo,
@@ -468,9 +772,12 @@ func (o *ScaleUpOrchestrator) ComputeExpansionOption(
 	expansionEstimator := o.estimatorBuilder(
 		o.autoscalingContext.PredicateChecker,
 		o.autoscalingContext.ClusterSnapshot,
-		estimator.NewEstimationContext(o.autoscalingContext.MaxNodesTotal, option.SimilarNodeGroups, currentNodeCount),
+		estimator.NewEstimationContext(o.autoscalingContext.MaxNodesTotal, option.SimilarNodeGroups, currentNodeCount, o.rateLimiter.ScaleUpLimit(nodeGroup, option.SimilarNodeGroups, now)),
 	)
+	span := tracing.StartSpan(traceContext, "Estimate", attribute.String("nodeGroup", nodeGroup.Id()))
 	option.NodeCount, option.Pods = expansionEstimator.Estimate(podGroups, nodeInfo, nodeGroup)
+	span.SetAttributes(attribute.Int("nodeCount", option.NodeCount), attribute.Int("pods", len(option.Pods)))
+	span.End()
 	metrics.UpdateDurationFromStart(metrics.Estimate, estimateStart)
 
 	autoscalingOptions, err := nodeGroup.GetOptions(o.autoscalingContext.NodeGroupDefaults)
@@ -480,8 +787,9 @@ func (o *ScaleUpOrchestrator) ComputeExpansionOption(
 
 	// Special handling for groups that only scale from zero to max.
 	if autoscalingOptions != nil && autoscalingOptions.ZeroOrMaxNodeScaling {
+		_, maxSize := nodegroupconfig.GetSizeRange(o.processors.NodeGroupConfigProcessor, nodeGroup)
 		// For zero-or-max scaling groups, the only valid value of node count is node group's max size.
-		if allOrNothing && option.NodeCount > nodeGroup.MaxSize() {
+		if allOrNothing && option.NodeCount > maxSize {
 			// We would have to cap the node count, which means not all pods will be
 			// accommodated. This violates the principle of all-or-nothing strategy.
 			option.Pods = nil
@@ -489,7 +797,7 @@ func (o *ScaleUpOrchestrator) ComputeExpansionOption(
 		}
 		if option.NodeCount > 0 {
 			// Cap or increase the number of nodes to the only valid value - node group's max size.
-			option.NodeCount = nodeGroup.MaxSize()
+			option.NodeCount = maxSize
 		}
 	}
 
@@ -703,7 +1011,23 @@ func (o *ScaleUpOrchestrator) balanceScaleUps(
 		}
 		klog.V(1).Infof("Splitting scale-up between %v similar node groups: {%v}", len(targetNodeGroups), strings.Join(names, ", "))
 	}
-	return o.processors.NodeGroupSetProcessor.BalanceScaleUpBetweenGroups(o.autoscalingContext, targetNodeGroups, newNodes)
+	scaleUpInfos, aErr := o.processors.NodeGroupSetProcessor.BalanceScaleUpBetweenGroups(o.autoscalingContext, targetNodeGroups, newNodes, o.processors.NodeGroupConfigProcessor)
+	if aErr != nil {
+		return nil, aErr
+	}
+	// Balancing doesn't respect scale-up rate limits, node groups which can't grow at all are dropped from the plan.
+	var result []nodegroupset.ScaleUpInfo
+	for i := range scaleUpInfos {
+		if allowance, limited := o.rateLimiter.Allowance(scaleUpInfos[i].Group, now); limited && scaleUpInfos[i].NewSize > scaleUpInfos[i].CurrentSize+allowance {
+			klog.V(1).Infof("Capping scale-up of %s to %d nodes due to its scale-up rate limit", scaleUpInfos[i].Group.Id(), allowance)
+			scaleUpInfos[i].NewSize = scaleUpInfos[i].CurrentSize + allowance
+			if allowance == 0 {
+				continue
+			}
+		}
+		result = append(result, scaleUpInfos[i])
+	}
+	return result, nil
 }
 
 // ComputeSimilarNodeGroups finds similar node groups which can schedule the same
@@ -747,6 +1071,14 @@ func (o *ScaleUpOrchestrator) ComputeSimilarNodeGroups(
 		}
 	}
 
+	if podAwareProcessor, ok := o.processors.NodeGroupSetProcessor.(nodegroupset.PodAwareNodeGroupSetProcessor); ok {
+		var pods []*apiv1.Pod
+		for _, podGroup := range podGroups {
+			pods = append(pods, podGroup.Pods...)
+		}
+		validSimilarNodeGroups = podAwareProcessor.FilterSimilarNodeGroups(o.autoscalingContext, nodeGroup, validSimilarNodeGroups, pods, nodeInfos)
+	}
+
 	return validSimilarNodeGroups
 }
 
@@ -1284,7 +1284,7 @@ func (p *constNodeGroupSetProcessor) FindSimilarNodeGroups(_ *context.Autoscalin
 	return p.similarNodeGroups, nil
 }
 
-func (p *constNodeGroupSetProcessor) BalanceScaleUpBetweenGroups(_ *context.AutoscalingContext, _ []cloudprovider.NodeGroup, _ int) ([]nodegroupset.ScaleUpInfo, errors.AutoscalerError) {
+func (p *constNodeGroupSetProcessor) BalanceScaleUpBetweenGroups(_ *context.AutoscalingContext, _ []cloudprovider.NodeGroup, _ int, _ nodegroupconfig.NodeGroupSizeGetter) ([]nodegroupset.ScaleUpInfo, errors.AutoscalerError) {
 	return nil, nil
 }
 
@@ -1501,6 +1501,81 @@ func TestScaleUpBalanceGroups(t *testing.T) {
 	assert.Equal(t, 2, ng3size)
 }
 
+func TestScaleUpRateLimitSpillover(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(func(string, int) error {
+		return nil
+	}, nil)
+
+	now := time.Now()
+	var nodes []*apiv1.Node
+	for _, gid := range []string{"ng1", "ng2"} {
+		provider.AddNodeGroup(gid, 1, 10, 1)
+		provider.GetNodeGroup(gid).(*testprovider.TestNodeGroup).SetOptions(&config.NodeGroupAutoscalingOptions{
+			MaxNodeProvisionTime:     15 * time.Minute,
+			MaxScaleUpNodesPerMinute: 1,
+		})
+		node := BuildTestNode(gid+"-node", 1000, 1000)
+		SetNodeReadyState(node, true, now.Add(-2*time.Minute))
+		provider.AddNode(gid, node)
+		nodes = append(nodes, node)
+	}
+
+	podLister := kube_util.NewTestPodLister(nil)
+	listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+
+	options := config.AutoscalingOptions{
+		EstimatorName:  estimator.BinpackingEstimatorName,
+		MaxCoresTotal:  config.DefaultMaxClusterCores,
+		MaxMemoryTotal: config.DefaultMaxClusterMemory,
+	}
+	context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+	assert.NoError(t, err)
+
+	nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+	processors := NewTestProcessors(&context)
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder, NewBackoff(), processors.NodeGroupConfigProcessor)
+	clusterState.UpdateNodes(nodes, nodeInfos, time.Now())
+
+	var pods []*apiv1.Pod
+	for i := 0; i < 3; i++ {
+		pods = append(pods, BuildTestPod(fmt.Sprintf("test-pod-%v", i), 800, 0))
+	}
+
+	estimatorBuilder, _ := estimator.NewEstimatorBuilder(
+		estimator.BinpackingEstimatorName,
+		estimator.NewThresholdBasedEstimationLimiter([]estimator.Threshold{estimator.NewScaleUpRateThreshold()}),
+		estimator.NewDecreasingPodOrderer(),
+		nil,
+	)
+	suOrchestrator := New()
+	suOrchestrator.Initialize(&context, processors, clusterState, estimatorBuilder, taints.TaintConfig{})
+	scaleUpStatus, typedErr := suOrchestrator.ScaleUp(pods, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+
+	assert.NoError(t, typedErr)
+	assert.True(t, scaleUpStatus.WasSuccessful())
+	assert.Len(t, scaleUpStatus.ScaleUpInfos, 2)
+	assert.Len(t, scaleUpStatus.PodsTriggeredScaleUp, 2)
+	assert.Len(t, scaleUpStatus.PodsRemainUnschedulable, 1)
+	for _, gid := range []string{"ng1", "ng2"} {
+		size, err := provider.GetNodeGroup(gid).TargetSize()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, size, "node group %s should be scaled up by its rate limit", gid)
+		assert.Equal(t, ScaleUpRateLimitReachedReason, scaleUpStatus.PodsRemainUnschedulable[0].SkippedNodeGroups[gid])
+	}
+
+	scaleUpStatus, typedErr = suOrchestrator.ScaleUp(pods[2:], nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+	assert.NoError(t, typedErr)
+	assert.False(t, scaleUpStatus.WasSuccessful(), "both node groups should still be rate limited")
+
+	// Reloading options initializes the orchestrator again, recent scale-ups still count.
+	rateLimiter := suOrchestrator.rateLimiter
+	suOrchestrator.Initialize(&context, processors, clusterState, estimatorBuilder, taints.TaintConfig{})
+	assert.Same(t, rateLimiter, suOrchestrator.rateLimiter)
+	scaleUpStatus, typedErr = suOrchestrator.ScaleUp(pods[2:], nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+	assert.NoError(t, typedErr)
+	assert.False(t, scaleUpStatus.WasSuccessful(), "both node groups should still be rate limited after reload")
+}
+
 func TestScaleUpAutoprovisionedNodeGroup(t *testing.T) {
 	createdGroups := make(chan string, 10)
 	expandedGroups := make(chan string, 10)
@@ -1614,6 +1689,69 @@ func TestScaleUpBalanceAutoprovisionedNodeGroups(t *testing.T) {
 	assert.True(t, expandedGroupMap["autoprovisioned-T1-2-1"])
 }
 
+func TestScaleUpNodeGroup(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(func(string, int) error {
+		return nil
+	}, nil)
+
+	// ng1: max size caps the scale-up, ng2: rate limit caps the scale-up,
+	// ng3: atomic node group without room for the whole scale-up.
+	now := time.Now()
+	var nodes []*apiv1.Node
+	for gid, ngOptions := range map[string]*config.NodeGroupAutoscalingOptions{
+		"ng1": {MaxNodeProvisionTime: 15 * time.Minute},
+		"ng2": {MaxNodeProvisionTime: 15 * time.Minute, MaxScaleUpNodesPerMinute: 1},
+		"ng3": {MaxNodeProvisionTime: 15 * time.Minute, ZeroOrMaxNodeScaling: true},
+	} {
+		maxSize := 10
+		if gid != "ng2" {
+			maxSize = 2
+		}
+		provider.AddNodeGroupWithCustomOptions(gid, 1, maxSize, 1, ngOptions)
+		node := BuildTestNode(gid+"-node", 1000, 1000)
+		SetNodeReadyState(node, true, now.Add(-2*time.Minute))
+		provider.AddNode(gid, node)
+		nodes = append(nodes, node)
+	}
+
+	podLister := kube_util.NewTestPodLister(nil)
+	listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+	options := config.AutoscalingOptions{
+		EstimatorName:  estimator.BinpackingEstimatorName,
+		MaxCoresTotal:  config.DefaultMaxClusterCores,
+		MaxMemoryTotal: config.DefaultMaxClusterMemory,
+	}
+	context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+	assert.NoError(t, err)
+
+	nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+	processors := NewTestProcessors(&context)
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{OkTotalUnreadyCount: 10}, context.LogRecorder, NewBackoff(), processors.NodeGroupConfigProcessor)
+	clusterState.UpdateNodes(nodes, nodeInfos, now)
+
+	suOrchestrator := New()
+	suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
+	for _, tc := range []struct {
+		gid         string
+		delta       int
+		wantAdded   int
+		wantSize    int
+		wantMessage string
+	}{
+		{gid: "ng1", delta: 3, wantAdded: 1, wantSize: 2, wantMessage: "scale-up should be capped to max size"},
+		{gid: "ng2", delta: 3, wantAdded: 1, wantSize: 2, wantMessage: "scale-up should be capped to rate limit"},
+		{gid: "ng2", delta: 1, wantAdded: 0, wantSize: 2, wantMessage: "rate limit should be exhausted"},
+		{gid: "ng3", delta: 2, wantAdded: 0, wantSize: 1, wantMessage: "atomic node group shouldn't be partially scaled up"},
+	} {
+		added, aErr := suOrchestrator.ScaleUpNodeGroup(provider.GetNodeGroup(tc.gid), tc.delta, nodes, nodeInfos, now)
+		assert.NoError(t, aErr)
+		assert.Equal(t, tc.wantAdded, added, tc.wantMessage)
+		size, err := provider.GetNodeGroup(tc.gid).TargetSize()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.wantSize, size, tc.wantMessage)
+	}
+}
+
 func TestScaleUpToMeetNodeGroupMinSize(t *testing.T) {
 	podLister := kube_util.NewTestPodLister([]*apiv1.Pod{})
 	listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
@@ -1779,3 +1917,115 @@ func newEstimatorBuilder() estimator.EstimatorBuilder {
 
 	return estimatorBuilder
 }
+
+func TestScaleUpMultiStepPlan(t *testing.T) {
+	testCases := map[string]struct {
+		maxSteps           int
+		maxNodesPerScaleUp int
+		wantScaledUpGroups int
+		wantNewNodes       int
+		wantAllPodsHelped  bool
+	}{
+		"single step": {
+			maxSteps:           1,
+			maxNodesPerScaleUp: 1000,
+			wantScaledUpGroups: 1,
+		},
+		"all pods helped": {
+			maxSteps:           3,
+			maxNodesPerScaleUp: 1000,
+			wantScaledUpGroups: 3,
+			wantNewNodes:       4,
+			wantAllPodsHelped:  true,
+		},
+		"step limit": {
+			maxSteps:           2,
+			maxNodesPerScaleUp: 1000,
+			wantScaledUpGroups: 2,
+		},
+		"node limit shared by steps": {
+			maxSteps:           3,
+			maxNodesPerScaleUp: 2,
+			wantNewNodes:       2,
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			provider := testprovider.NewTestCloudProvider(func(string, int) error {
+				return nil
+			}, nil)
+			now := time.Now()
+			var nodes []*apiv1.Node
+			for _, ng := range []struct {
+				id  string
+				cpu int64
+			}{{"ng-cpu", 1000}, {"ng-big", 4000}, {"ng-arm", 1000}} {
+				provider.AddNodeGroup(ng.id, 1, 10, 1)
+				node := BuildTestNode(ng.id+"-node", ng.cpu, 1000)
+				node.Labels["pool"] = ng.id
+				SetNodeReadyState(node, true, now.Add(-2*time.Minute))
+				provider.AddNode(ng.id, node)
+				nodes = append(nodes, node)
+			}
+
+			podLister := kube_util.NewTestPodLister(nil)
+			listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+			options := config.AutoscalingOptions{
+				EstimatorName:       estimator.BinpackingEstimatorName,
+				MaxCoresTotal:       config.DefaultMaxClusterCores,
+				MaxMemoryTotal:      config.DefaultMaxClusterMemory,
+				MaxNodesPerScaleUp:  tc.maxNodesPerScaleUp,
+				MaxScaleUpPlanSteps: tc.maxSteps,
+			}
+			context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+			assert.NoError(t, err)
+
+			nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+			processors := NewTestProcessors(&context)
+			clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder, NewBackoff(), processors.NodeGroupConfigProcessor)
+			clusterState.UpdateNodes(nodes, nodeInfos, time.Now())
+
+			var pods []*apiv1.Pod
+			for i, pool := range []string{"ng-cpu", "ng-cpu", "ng-big", "ng-arm"} {
+				cpu := int64(800)
+				if pool == "ng-big" {
+					cpu = 3000
+				}
+				pod := BuildTestPod(fmt.Sprintf("test-pod-%v", i), cpu, 0)
+				pod.Spec.NodeSelector = map[string]string{"pool": pool}
+				pods = append(pods, pod)
+			}
+
+			suOrchestrator := New()
+			suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
+			scaleUpStatus, typedErr := suOrchestrator.ScaleUp(pods, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+			assert.NoError(t, typedErr)
+			assert.True(t, scaleUpStatus.WasSuccessful())
+
+			newNodes, scaledUpGroups := 0, 0
+			for _, ng := range provider.NodeGroups() {
+				size, err := ng.TargetSize()
+				assert.NoError(t, err)
+				if size > 1 {
+					scaledUpGroups++
+					newNodes += size - 1
+				}
+			}
+			assert.Len(t, scaleUpStatus.ScaleUpInfos, scaledUpGroups)
+			if tc.wantScaledUpGroups > 0 {
+				assert.Equal(t, tc.wantScaledUpGroups, scaledUpGroups)
+			}
+			if tc.wantNewNodes > 0 {
+				assert.Equal(t, tc.wantNewNodes, newNodes)
+			}
+			if tc.wantAllPodsHelped {
+				assert.Len(t, scaleUpStatus.PodsTriggeredScaleUp, len(pods))
+				assert.Empty(t, scaleUpStatus.PodsRemainUnschedulable)
+				assert.Empty(t, scaleUpStatus.PodsAwaitEvaluation)
+			} else {
+				assert.Less(t, len(scaleUpStatus.PodsTriggeredScaleUp), len(pods))
+				assert.NotEmpty(t, scaleUpStatus.PodsAwaitEvaluation)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orchestrator
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+)
+
+// scaleUpPlan accumulates steps of a multi-step scale-up. Each step is planned for the
+// pods not helped by earlier steps, and all steps are executed together.
+type scaleUpPlan struct {
+	steps                  int
+	newNodes               int
+	delta                  resource.Delta
+	skipped                map[string]status.Reasons
+	scaleUpInfos           []nodegroupset.ScaleUpInfo
+	createNodeGroupResults []nodegroups.CreateNodeGroupResult
+	podsTriggeredScaleUp   []*apiv1.Pod
+}
+
+// newScaleUpPlan creates a plan skipping node groups which were already scaled up.
+func newScaleUpPlan(scaledUp map[string]status.Reasons) *scaleUpPlan {
+	skipped := make(map[string]status.Reasons, len(scaledUp))
+	for nodeGroupId, reason := range scaledUp {
+		skipped[nodeGroupId] = reason
+	}
+	return &scaleUpPlan{
+		delta:   resource.Delta{},
+		skipped: skipped,
+	}
+}
+
+// addStep adds a step to the plan. Node groups scaled up by the step are skipped in next steps.
+func (p *scaleUpPlan) addStep(scaleUpInfos []nodegroupset.ScaleUpInfo, createNodeGroupResults []nodegroups.CreateNodeGroupResult, pods []*apiv1.Pod, delta resource.Delta) {
+	p.steps++
+	for _, sui := range scaleUpInfos {
+		p.newNodes += sui.NewSize - sui.CurrentSize
+		p.skipped[sui.Group.Id()] = PlannedInEarlierStepReason
+	}
+	for resourceName, resourceDelta := range delta {
+		p.delta[resourceName] += resourceDelta
+	}
+	p.scaleUpInfos = append(p.scaleUpInfos, scaleUpInfos...)
+	p.createNodeGroupResults = append(p.createNodeGroupResults, createNodeGroupResults...)
+	p.podsTriggeredScaleUp = append(p.podsTriggeredScaleUp, pods...)
+}
+
+// plannedNodes returns the number of nodes added by the plan.
+func (p *scaleUpPlan) plannedNodes() int {
+	if p == nil {
+		return 0
+	}
+	return p.newNodes
+}
+
+// subtractFrom subtracts resources of nodes added by the plan from resources left in the cluster.
+func (p *scaleUpPlan) subtractFrom(resourcesLeft resource.Limits) {
+	if p == nil {
+		return
+	}
+	for resourceName, left := range resourcesLeft {
+		if left == resource.LimitUnknown {
+			continue
+		}
+		left -= p.delta[resourceName]
+		if left < 0 {
+			left = 0
+		}
+		resourcesLeft[resourceName] = left
+	}
+}
@@ -19,6 +19,8 @@ package orchestrator
 import (
 	"fmt"
 	"strings"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 )
 
 // SkippedReasons contains information why given node group was skipped.
@@ -43,6 +45,10 @@ var (
 	MaxLimitReachedReason = NewSkippedReasons("max node group size reached")
 	// NotReadyReason node group is not ready.
 	NotReadyReason = NewSkippedReasons("not ready for scale-up")
+	// ScaleUpRateLimitReachedReason node group reached its scale-up rate limit.
+	ScaleUpRateLimitReachedReason = NewSkippedReasons("scale-up rate limit reached")
+	// PlannedInEarlierStepReason node group is already scaled up by an earlier step of a multi-step scale-up.
+	PlannedInEarlierStepReason = NewSkippedReasons("already scaled up in an earlier step of the scale-up plan")
 )
 
 // MaxResourceLimitReached contains information why given node group was skipped.
@@ -61,10 +67,21 @@ func (sr *MaxResourceLimitReached) Resources() []string {
 	return sr.resources
 }
 
-// NewMaxResourceLimitReached returns a reason describing which cluster wide resource limits were reached.
+// NewMaxResourceLimitReached returns a reason describing which cluster wide and node group set resource limits were reached.
 func NewMaxResourceLimitReached(resources []string) *MaxResourceLimitReached {
+	var clusterResources, messages []string
+	for _, resource := range resources {
+		if setName, setResource, ok := cloudprovider.ParseNodeGroupSetLimitKey(resource); ok {
+			messages = append(messages, fmt.Sprintf("max node group set %s %s limit reached", setName, setResource))
+		} else {
+			clusterResources = append(clusterResources, resource)
+		}
+	}
+	if len(clusterResources) > 0 || len(messages) == 0 {
+		messages = append([]string{fmt.Sprintf("max cluster %s limit reached", strings.Join(clusterResources, ", "))}, messages...)
+	}
 	return &MaxResourceLimitReached{
-		messages:  []string{fmt.Sprintf("max cluster %s limit reached", strings.Join(resources, ", "))},
+		messages:  messages,
 		resources: resources,
 	}
 }
@@ -37,6 +37,11 @@ func TestMaxResourceLimitReached(t *testing.T) {
 			resources:   []string{"gpu1", "gpu3", "tpu", "ram"},
 			wantReasons: []string{"max cluster gpu1, gpu3, tpu, ram limit reached"},
 		},
+		{
+			name:        "node group set limits",
+			resources:   []string{"cpu", "nodegroupset/a100/nvidia-a100", "nodegroupset/team-x/memory"},
+			wantReasons: []string{"max cluster cpu limit reached", "max node group set a100 nvidia-a100 limit reached", "max node group set team-x memory limit reached"},
+		},
 		{
 			name:        "no resources",
 			wantReasons: []string{"max cluster  limit reached"},
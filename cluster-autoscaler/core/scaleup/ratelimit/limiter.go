@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	klog "k8s.io/klog/v2"
+)
+
+// window is the period over which MaxScaleUpNodesPerMinute is enforced.
+const window = time.Minute
+
+type scaleUp struct {
+	time  time.Time
+	delta int
+}
+
+// Limiter limits how fast node groups grow, according to their MaxScaleUpNodesPerMinute
+// and MaxScaleUpGrowthPercent options. It observes scale-ups through the
+// NodeGroupChangeObserver interface.
+type Limiter struct {
+	sync.Mutex
+	configProcessor nodegroupconfig.NodeGroupConfigProcessor
+	scaleUps        map[string][]scaleUp
+}
+
+// NewLimiter returns a new Limiter reading per node group options from configProcessor.
+func NewLimiter(configProcessor nodegroupconfig.NodeGroupConfigProcessor) *Limiter {
+	return &Limiter{
+		configProcessor: configProcessor,
+		scaleUps:        map[string][]scaleUp{},
+	}
+}
+
+// SetConfigProcessor replaces the source of per node group options, e.g. after options are
+// reloaded. Recent scale-ups are kept.
+func (l *Limiter) SetConfigProcessor(configProcessor nodegroupconfig.NodeGroupConfigProcessor) {
+	l.Lock()
+	defer l.Unlock()
+	l.configProcessor = configProcessor
+}
+
+// Allowance returns how many nodes can be added to the node group now. The second return value
+// is false if the node group isn't rate limited, in which case the first one should be ignored.
+func (l *Limiter) Allowance(nodeGroup cloudprovider.NodeGroup, now time.Time) (int, bool) {
+	l.Lock()
+	configProcessor := l.configProcessor
+	l.Unlock()
+	allowance, limited := 0, false
+	nodesPerMinute, err := configProcessor.GetMaxScaleUpNodesPerMinute(nodeGroup)
+	if err != nil {
+		klog.Warningf("Failed to get max scale-up nodes per minute of node group %s: %v", nodeGroup.Id(), err)
+	} else if nodesPerMinute > 0 {
+		allowance, limited = nodesPerMinute-l.recentlyAdded(nodeGroup.Id(), now), true
+	}
+	growthPercent, err := configProcessor.GetMaxScaleUpGrowthPercent(nodeGroup)
+	if err != nil {
+		klog.Warningf("Failed to get max scale-up growth percent of node group %s: %v", nodeGroup.Id(), err)
+	} else if growthPercent > 0 {
+		size, err := nodeGroup.TargetSize()
+		if err != nil {
+			klog.Warningf("Failed to get target size of node group %s: %v", nodeGroup.Id(), err)
+			size = 0
+		}
+		maxGrowth := size * growthPercent / 100
+		if maxGrowth < 1 {
+			maxGrowth = 1
+		}
+		if !limited || maxGrowth < allowance {
+			allowance, limited = maxGrowth, true
+		}
+	}
+	if allowance < 0 {
+		allowance = 0
+	}
+	return allowance, limited
+}
+
+// ScaleUpLimit returns maximum number of nodes that can be added to the node group and its similar node
+// groups, in the format of estimator.EstimationContext.ScaleUpRateLimit: 0 means no limit, negative value
+// means that no nodes can be added.
+func (l *Limiter) ScaleUpLimit(nodeGroup cloudprovider.NodeGroup, similarNodeGroups []cloudprovider.NodeGroup, now time.Time) int {
+	total := 0
+	for _, ng := range append([]cloudprovider.NodeGroup{nodeGroup}, similarNodeGroups...) {
+		allowance, limited := l.Allowance(ng, now)
+		if !limited {
+			return 0
+		}
+		total += allowance
+	}
+	if total == 0 {
+		return -1
+	}
+	return total
+}
+
+func (l *Limiter) recentlyAdded(nodeGroupId string, now time.Time) int {
+	l.Lock()
+	defer l.Unlock()
+	added := 0
+	recent := l.scaleUps[nodeGroupId][:0]
+	for _, s := range l.scaleUps[nodeGroupId] {
+		if now.Sub(s.time) < window {
+			recent = append(recent, s)
+			added += s.delta
+		}
+	}
+	if len(recent) == 0 {
+		delete(l.scaleUps, nodeGroupId)
+	} else {
+		l.scaleUps[nodeGroupId] = recent
+	}
+	return added
+}
+
+// RegisterScaleUp records a scale-up, counted against MaxScaleUpNodesPerMinute of the node group.
+func (l *Limiter) RegisterScaleUp(nodeGroup cloudprovider.NodeGroup, delta int, currentTime time.Time) {
+	l.Lock()
+	defer l.Unlock()
+	l.scaleUps[nodeGroup.Id()] = append(l.scaleUps[nodeGroup.Id()], scaleUp{time: currentTime, delta: delta})
+}
+
+// RegisterScaleDown is a no-op.
+func (l *Limiter) RegisterScaleDown(cloudprovider.NodeGroup, string, time.Time, time.Time) {
+}
+
+// RegisterFailedScaleUp is a no-op, failed scale-ups still count against the limits.
+func (l *Limiter) RegisterFailedScaleUp(cloudprovider.NodeGroup, string, string, string, string, time.Time) {
+}
+
+// RegisterFailedScaleDown is a no-op.
+func (l *Limiter) RegisterFailedScaleDown(cloudprovider.NodeGroup, string, time.Time) {
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+)
+
+func TestAllowance(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name          string
+		options       config.NodeGroupAutoscalingOptions
+		size          int
+		scaleUps      []scaleUp
+		wantAllowance int
+		wantLimited   bool
+	}{
+		{
+			name: "no limits",
+			size: 10,
+		},
+		{
+			name:          "nodes per minute",
+			options:       config.NodeGroupAutoscalingOptions{MaxScaleUpNodesPerMinute: 5},
+			size:          10,
+			scaleUps:      []scaleUp{{time: now.Add(-30 * time.Second), delta: 2}, {time: now.Add(-2 * time.Minute), delta: 3}},
+			wantAllowance: 3,
+			wantLimited:   true,
+		},
+		{
+			name:          "nodes per minute exhausted",
+			options:       config.NodeGroupAutoscalingOptions{MaxScaleUpNodesPerMinute: 5},
+			size:          10,
+			scaleUps:      []scaleUp{{time: now.Add(-10 * time.Second), delta: 4}, {time: now.Add(-5 * time.Second), delta: 4}},
+			wantAllowance: 0,
+			wantLimited:   true,
+		},
+		{
+			name:          "growth percent",
+			options:       config.NodeGroupAutoscalingOptions{MaxScaleUpGrowthPercent: 25},
+			size:          10,
+			wantAllowance: 2,
+			wantLimited:   true,
+		},
+		{
+			name:          "growth percent allows one node in empty group",
+			options:       config.NodeGroupAutoscalingOptions{MaxScaleUpGrowthPercent: 25},
+			size:          0,
+			wantAllowance: 1,
+			wantLimited:   true,
+		},
+		{
+			name:          "stricter limit wins",
+			options:       config.NodeGroupAutoscalingOptions{MaxScaleUpNodesPerMinute: 5, MaxScaleUpGrowthPercent: 50},
+			size:          20,
+			scaleUps:      []scaleUp{{time: now.Add(-10 * time.Second), delta: 1}},
+			wantAllowance: 4,
+			wantLimited:   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := testprovider.NewTestCloudProvider(nil, nil)
+			provider.AddNodeGroup("ng1", 0, 100, tc.size)
+			ng := provider.GetNodeGroup("ng1")
+			l := NewLimiter(nodegroupconfig.NewDefaultNodeGroupConfigProcessor(tc.options))
+			for _, s := range tc.scaleUps {
+				l.RegisterScaleUp(ng, s.delta, s.time)
+			}
+			allowance, limited := l.Allowance(ng, now)
+			assert.Equal(t, tc.wantAllowance, allowance)
+			assert.Equal(t, tc.wantLimited, limited)
+		})
+	}
+}
+
+func TestScaleUpLimit(t *testing.T) {
+	now := time.Now()
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	for _, id := range []string{"limited1", "limited2", "unlimited"} {
+		provider.AddNodeGroup(id, 0, 100, 10)
+	}
+	limited1 := provider.GetNodeGroup("limited1")
+	limited2 := provider.GetNodeGroup("limited2")
+	unlimited := provider.GetNodeGroup("unlimited")
+	for _, ng := range []cloudprovider.NodeGroup{limited1, limited2} {
+		ng.(*testprovider.TestNodeGroup).SetOptions(&config.NodeGroupAutoscalingOptions{MaxScaleUpNodesPerMinute: 3})
+	}
+	l := NewLimiter(nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{}))
+	l.RegisterScaleUp(limited2, 1, now)
+
+	assert.Equal(t, 3, l.ScaleUpLimit(limited1, nil, now))
+	assert.Equal(t, 5, l.ScaleUpLimit(limited1, []cloudprovider.NodeGroup{limited2}, now))
+	assert.Equal(t, 0, l.ScaleUpLimit(limited1, []cloudprovider.NodeGroup{unlimited}, now))
+	l.RegisterScaleUp(limited1, 3, now)
+	assert.Equal(t, -1, l.ScaleUpLimit(limited1, nil, now))
+	assert.Equal(t, 3, l.ScaleUpLimit(limited1, nil, now.Add(time.Minute)))
+}
@@ -70,7 +70,8 @@ func (m *Manager) DeltaForNode(ctx *context.AutoscalingContext, nodeInfo *schedu
 		return nil, errors.ToAutoscalerError(errors.CloudProviderError, err)
 	}
 
-	if cloudprovider.ContainsCustomResources(resourceLimiter.GetResources()) {
+	setLimits := resourceLimiter.GetNodeGroupSetLimitsFor(nodeGroup.Id(), nodeInfo.Node().Labels)
+	if cloudprovider.ContainsCustomResources(resourceLimiter.GetResources()) || containsCustomResources(setLimits) {
 		resourceTargets, err := m.crp.GetNodeResourceTargets(ctx, nodeInfo.Node(), nodeGroup)
 		if err != nil {
 			return Delta{}, errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("failed to get target custom resources for node group %v: ", nodeGroup.Id())
@@ -81,6 +82,11 @@ func (m *Manager) DeltaForNode(ctx *context.AutoscalingContext, nodeInfo *schedu
 		}
 	}
 
+	// Node group set limits are tracked like separate resources, present only for node groups in the set.
+	for _, limit := range setLimits {
+		resultScaleUpDelta[limit.Key()] = resultScaleUpDelta[limit.Resource]
+	}
+
 	return resultScaleUpDelta, nil
 }
 
@@ -139,6 +145,26 @@ func (m *Manager) ResourcesLeft(ctx *context.AutoscalingContext, nodeInfos map[s
 		}
 	}
 
+	var setLimits []cloudprovider.NodeGroupSetLimit
+	for _, limit := range resourceLimiter.GetNodeGroupSetLimits() {
+		if limit.Max > 0 {
+			setLimits = append(setLimits, limit)
+		}
+	}
+	if len(setLimits) > 0 {
+		setTotals, err := m.nodeGroupSetsTotal(ctx, nodeInfos, setLimits)
+		if err != nil {
+			klog.Errorf("Failed to compute resources in node group sets: %v", err)
+		}
+		for _, limit := range setLimits {
+			if err != nil {
+				resultScaleUpLimits[limit.Key()] = LimitUnknown
+			} else {
+				resultScaleUpLimits[limit.Key()] = computeBelowMax(setTotals[limit.Key()], limit.Max)
+			}
+		}
+	}
+
 	return resultScaleUpLimits, nil
 }
 
@@ -278,6 +304,43 @@ func (m *Manager) customResourcesTotal(ctx *context.AutoscalingContext, nodeInfo
 	return result, nil
 }
 
+// nodeGroupSetsTotal returns the amount of limited resources in node group sets, keyed by NodeGroupSetLimit.Key().
+func (m *Manager) nodeGroupSetsTotal(ctx *context.AutoscalingContext, nodeInfos map[string]*schedulerframework.NodeInfo, limits []cloudprovider.NodeGroupSetLimit) (map[string]int64, errors.AutoscalerError) {
+	result := make(map[string]int64)
+	for _, nodeGroup := range ctx.CloudProvider.NodeGroups() {
+		currentSize, err := nodeGroup.TargetSize()
+		if err != nil {
+			return nil, errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("failed to get node group size of %v: ", nodeGroup.Id())
+		}
+		if currentSize == 0 {
+			continue
+		}
+
+		nodeInfo, found := nodeInfos[nodeGroup.Id()]
+		if !found {
+			return nil, errors.NewAutoscalerError(errors.CloudProviderError, "No node info for: %s", nodeGroup.Id())
+		}
+
+		delta, aErr := m.DeltaForNode(ctx, nodeInfo, nodeGroup)
+		if aErr != nil {
+			return nil, aErr
+		}
+		for _, limit := range limits {
+			result[limit.Key()] += delta[limit.Key()] * int64(currentSize)
+		}
+	}
+	return result, nil
+}
+
+func containsCustomResources(limits []cloudprovider.NodeGroupSetLimit) bool {
+	for _, limit := range limits {
+		if cloudprovider.IsCustomResource(limit.Resource) {
+			return true
+		}
+	}
+	return false
+}
+
 func computeBelowMax(total int64, max int64) int64 {
 	if total < max {
 		return max - total
@@ -247,6 +247,44 @@ func TestResourceManagerWithGpuResource(t *testing.T) {
 	assert.Equal(t, 3, newNodeCount) // gpu left / grpu per node: 12 / 4 = 3
 }
 
+func TestResourceManagerWithNodeGroupSetLimits(t *testing.T) {
+	provider := newCloudProvider(t, 1000, 1000)
+	limit, err := cloudprovider.NewNodeGroupSetLimit(config.NodeGroupSetResourceLimits{Name: "big", NodeGroupIdRegex: "big-.*", Resource: cloudprovider.ResourceNameCores, Max: 40})
+	assert.NoError(t, err)
+	resourceLimiter, err := provider.GetResourceLimiter()
+	assert.NoError(t, err)
+	provider.SetResourceLimiter(resourceLimiter.WithNodeGroupSetLimits([]cloudprovider.NodeGroupSetLimit{limit}))
+
+	context := newContext(t, provider)
+	processors := test.NewTestProcessors(&context)
+
+	big1, nodes := newNodeGroup(t, provider, "big-1", 0, 10, 2, 8, 16)
+	_, bigNodes := newNodeGroup(t, provider, "big-2", 0, 10, 1, 8, 16)
+	small, smallNodes := newNodeGroup(t, provider, "small", 0, 10, 3, 8, 16)
+	nodes = append(append(nodes, bigNodes...), smallNodes...)
+	nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, time.Now())
+
+	rm := NewManager(processors.CustomResourcesProcessor)
+
+	delta, err := rm.DeltaForNode(&context, nodeInfos["big-1"], big1)
+	assert.NoError(t, err)
+	assert.Equal(t, Delta{"cpu": 8, "memory": 16, limit.Key(): 8}, delta)
+	delta, err = rm.DeltaForNode(&context, nodeInfos["small"], small)
+	assert.NoError(t, err)
+	assert.Equal(t, Delta{"cpu": 8, "memory": 16}, delta)
+
+	left, err := rm.ResourcesLeft(&context, nodeInfos, nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, Limits{"cpu": 952, "memory": 904, limit.Key(): 16}, left) // set: 40-8*(2+1)=16
+
+	newNodeCount, err := rm.ApplyLimits(&context, 10, left, nodeInfos["big-1"], big1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, newNodeCount) // set left / cpu per node: 16 / 8 = 2
+	newNodeCount, err = rm.ApplyLimits(&context, 10, left, nodeInfos["small"], small)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, newNodeCount)
+}
+
 func newCloudProvider(t *testing.T, cpu, mem int64) *testprovider.TestCloudProvider {
 	provider := testprovider.NewTestCloudProvider(nil, nil)
 	assert.NotNil(t, provider)
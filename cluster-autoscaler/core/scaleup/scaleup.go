@@ -17,8 +17,11 @@ limitations under the License.
 package scaleup
 
 import (
+	"time"
+
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
@@ -59,3 +62,18 @@ type Orchestrator interface {
 		nodeInfos map[string]*schedulerframework.NodeInfo,
 	) (*status.ScaleUpStatus, errors.AutoscalerError)
 }
+
+// NodeGroupScaler may be implemented by orchestrators able to scale up a single node group
+// outside of a regular scale-up, e.g. on request of an operator or to replace an old node.
+type NodeGroupScaler interface {
+	// ScaleUpNodeGroup increases the size of the node group by up to delta nodes, within the
+	// same limits as regular scale-ups. Returns the number of added nodes, which is 0 if the
+	// node group or the cluster has no room left.
+	ScaleUpNodeGroup(
+		nodeGroup cloudprovider.NodeGroup,
+		delta int,
+		nodes []*apiv1.Node,
+		nodeInfos map[string]*schedulerframework.NodeInfo,
+		now time.Time,
+	) (int, errors.AutoscalerError)
+}
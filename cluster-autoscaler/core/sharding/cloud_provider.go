@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"sync"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	klog "k8s.io/klog/v2"
+)
+
+// cloudProvider hides node groups owned by other shards. Nodes of hidden node groups are
+// treated as not autoscaled: they are still part of the simulated cluster, but are never
+// scaled down, and their node groups are never scaled up, fixed or cleaned up by this shard.
+type cloudProvider struct {
+	cloudprovider.CloudProvider
+	shard *Shard
+
+	sync.Mutex
+	// owned caches ownership of node groups selected by labels, as computing it
+	// requires building a template node.
+	owned map[string]bool
+}
+
+// NewCloudProvider wraps provider so that only node groups owned by the shard are visible.
+func NewCloudProvider(provider cloudprovider.CloudProvider, shard *Shard) cloudprovider.CloudProvider {
+	return &cloudProvider{
+		CloudProvider: provider,
+		shard:         shard,
+		owned:         make(map[string]bool),
+	}
+}
+
+// NodeGroups returns node groups owned by the shard.
+func (p *cloudProvider) NodeGroups() []cloudprovider.NodeGroup {
+	var result []cloudprovider.NodeGroup
+	for _, nodeGroup := range p.CloudProvider.NodeGroups() {
+		if p.owns(nodeGroup) {
+			result = append(result, nodeGroup)
+		}
+	}
+	return result
+}
+
+// NodeGroupForNode returns the node group for the given node, nil if the node
+// belongs to a node group owned by another shard.
+func (p *cloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudprovider.NodeGroup, error) {
+	nodeGroup, err := p.CloudProvider.NodeGroupForNode(node)
+	if err != nil || nodeGroup == nil || !p.owns(nodeGroup) {
+		return nil, err
+	}
+	return nodeGroup, nil
+}
+
+func (p *cloudProvider) owns(nodeGroup cloudprovider.NodeGroup) bool {
+	if p.shard.Selector == nil {
+		return p.shard.OwnsId(nodeGroup.Id())
+	}
+	p.Lock()
+	defer p.Unlock()
+	if owned, found := p.owned[nodeGroup.Id()]; found {
+		return owned
+	}
+	nodeInfo, err := nodeGroup.TemplateNodeInfo()
+	if err != nil {
+		// Not cached, so the ownership is checked again in the next iteration.
+		klog.Warningf("Failed to get template node of node group %s, assuming it belongs to another shard: %v", nodeGroup.Id(), err)
+		return false
+	}
+	owned := p.shard.OwnsLabels(nodeInfo.Node().Labels)
+	p.owned[nodeGroup.Id()] = owned
+	klog.V(2).Infof("Node group %s owned by this shard: %v", nodeGroup.Id(), owned)
+	return owned
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/labels"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func TestCloudProvider(t *testing.T) {
+	template := func(pool string) *schedulerframework.NodeInfo {
+		node := BuildTestNode("template-"+pool, 1000, 1000)
+		node.Labels = map[string]string{"pool": pool}
+		nodeInfo := schedulerframework.NewNodeInfo()
+		nodeInfo.SetNode(node)
+		return nodeInfo
+	}
+	provider := testprovider.NewTestAutoprovisioningCloudProvider(nil, nil, nil, nil, nil, map[string]*schedulerframework.NodeInfo{
+		"ng-a": template("a"),
+		"ng-b": template("b"),
+	})
+	provider.AddNodeGroup("ng-a", 0, 10, 1)
+	provider.AddNodeGroup("ng-b", 0, 10, 1)
+	provider.AddNodeGroup("ng-no-template", 0, 10, 1)
+	nodeA, nodeB := BuildTestNode("a1", 1000, 1000), BuildTestNode("b1", 1000, 1000)
+	provider.AddNode("ng-a", nodeA)
+	provider.AddNode("ng-b", nodeB)
+
+	selector, err := labels.Parse("pool=a")
+	assert.NoError(t, err)
+	sharded := NewCloudProvider(provider, &Shard{Selector: selector})
+
+	var ids []string
+	for _, nodeGroup := range sharded.NodeGroups() {
+		ids = append(ids, nodeGroup.Id())
+	}
+	assert.Equal(t, []string{"ng-a"}, ids)
+
+	nodeGroup, err := sharded.NodeGroupForNode(nodeA)
+	assert.NoError(t, err)
+	if assert.NotNil(t, nodeGroup) {
+		assert.Equal(t, "ng-a", nodeGroup.Id())
+	}
+	nodeGroup, err = sharded.NodeGroupForNode(nodeB)
+	assert.NoError(t, err)
+	assert.Nil(t, nodeGroup, "node of another shard shouldn't have a node group")
+}
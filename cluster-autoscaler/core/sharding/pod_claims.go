@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	ctx "context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	klog "k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+)
+
+// PodClaimAnnotationKey is the pod annotation set by the shard which scaled up for the pod,
+// so that other shards don't scale up for it too. The value is "<shard>,<RFC3339 time>".
+const PodClaimAnnotationKey = "cluster-autoscaler.kubernetes.io/scale-up-claim"
+
+// claimOwner returns the shard claiming the pod and the time of the claim, or an empty
+// string if the pod isn't claimed.
+func claimOwner(pod *apiv1.Pod) (string, time.Time) {
+	value, found := pod.Annotations[PodClaimAnnotationKey]
+	if !found {
+		return "", time.Time{}
+	}
+	parts := strings.SplitN(value, ",", 2)
+	if len(parts) != 2 {
+		return "", time.Time{}
+	}
+	claimTime, err := time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return "", time.Time{}
+	}
+	return parts[0], claimTime
+}
+
+// PodClaimFilter is a PodListProcessor removing pods for which another shard recently scaled up.
+type PodClaimFilter struct {
+	shard *Shard
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// NewPodClaimFilter returns a PodClaimFilter ignoring claims older than ttl.
+func NewPodClaimFilter(shard *Shard, ttl time.Duration) *PodClaimFilter {
+	return &PodClaimFilter{shard: shard, ttl: ttl, now: time.Now}
+}
+
+// Process removes pods claimed by other shards from unschedulable pods.
+func (f *PodClaimFilter) Process(_ *context.AutoscalingContext, unschedulablePods []*apiv1.Pod) ([]*apiv1.Pod, error) {
+	now := f.now()
+	result := make([]*apiv1.Pod, 0, len(unschedulablePods))
+	for _, pod := range unschedulablePods {
+		owner, claimTime := claimOwner(pod)
+		if owner != "" && owner != f.shard.Name() && now.Sub(claimTime) < f.ttl {
+			klog.V(4).Infof("Pod %s/%s is claimed by shard %s, ignoring it", pod.Namespace, pod.Name, owner)
+			continue
+		}
+		result = append(result, pod)
+	}
+	return result, nil
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (f *PodClaimFilter) CleanUp() {
+}
+
+// PodClaimingScaleUpStatusProcessor claims pods which triggered a successful scale-up, before
+// passing the status to the wrapped processor.
+type PodClaimingScaleUpStatusProcessor struct {
+	shard    *Shard
+	now      func() time.Time
+	delegate status.ScaleUpStatusProcessor
+}
+
+// NewPodClaimingScaleUpStatusProcessor returns a PodClaimingScaleUpStatusProcessor wrapping delegate.
+func NewPodClaimingScaleUpStatusProcessor(shard *Shard, delegate status.ScaleUpStatusProcessor) *PodClaimingScaleUpStatusProcessor {
+	return &PodClaimingScaleUpStatusProcessor{shard: shard, now: time.Now, delegate: delegate}
+}
+
+// Process claims pods which triggered the scale-up.
+func (p *PodClaimingScaleUpStatusProcessor) Process(context *context.AutoscalingContext, scaleUpStatus *status.ScaleUpStatus) {
+	if scaleUpStatus.WasSuccessful() {
+		claim := fmt.Sprintf("%s,%s", p.shard.Name(), p.now().UTC().Format(time.RFC3339))
+		for _, pod := range scaleUpStatus.PodsTriggeredScaleUp {
+			if err := patchPodClaim(context, pod, claim); err != nil {
+				klog.Warningf("Failed to claim pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			}
+		}
+	}
+	p.delegate.Process(context, scaleUpStatus)
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *PodClaimingScaleUpStatusProcessor) CleanUp() {
+	p.delegate.CleanUp()
+}
+
+func patchPodClaim(context *context.AutoscalingContext, pod *apiv1.Pod, claim string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{PodClaimAnnotationKey: claim},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = context.ClientSet.CoreV1().Pods(pod.Namespace).Patch(ctx.TODO(), pod.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
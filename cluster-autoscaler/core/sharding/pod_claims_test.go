@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	ctx "context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPodClaimFilter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	claimed := func(name, claim string) *apiv1.Pod {
+		pod := BuildTestPod(name, 100, 100)
+		if claim != "" {
+			pod.Annotations = map[string]string{PodClaimAnnotationKey: claim}
+		}
+		return pod
+	}
+	pods := []*apiv1.Pod{
+		claimed("unclaimed", ""),
+		claimed("own", "1,2024-05-01T11:59:00Z"),
+		claimed("other", "2,2024-05-01T11:59:00Z"),
+		claimed("other-expired", "2,2024-05-01T11:00:00Z"),
+		claimed("malformed", "2"),
+	}
+	filter := NewPodClaimFilter(&Shard{Index: 1, Count: 3}, 10*time.Minute)
+	filter.now = func() time.Time { return now }
+
+	result, err := filter.Process(nil, pods)
+	assert.NoError(t, err)
+	var names []string
+	for _, pod := range result {
+		names = append(names, pod.Name)
+	}
+	assert.Equal(t, []string{"unclaimed", "own", "other-expired", "malformed"}, names)
+}
+
+type scaleUpStatusProcessorMock struct {
+	processed []*status.ScaleUpStatus
+}
+
+func (p *scaleUpStatusProcessorMock) Process(_ *context.AutoscalingContext, scaleUpStatus *status.ScaleUpStatus) {
+	p.processed = append(p.processed, scaleUpStatus)
+}
+
+func (p *scaleUpStatusProcessorMock) CleanUp() {}
+
+func TestPodClaimingScaleUpStatusProcessor(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	p1, p2 := BuildTestPod("p1", 100, 100), BuildTestPod("p2", 100, 100)
+	client := fake.NewSimpleClientset(p1, p2)
+	autoscalingContext := &context.AutoscalingContext{AutoscalingKubeClients: context.AutoscalingKubeClients{ClientSet: client}}
+	delegate := &scaleUpStatusProcessorMock{}
+	processor := NewPodClaimingScaleUpStatusProcessor(&Shard{Index: 1, Count: 3}, delegate)
+	processor.now = func() time.Time { return now }
+
+	processor.Process(autoscalingContext, &status.ScaleUpStatus{Result: status.ScaleUpNoOptionsAvailable, PodsRemainUnschedulable: []status.NoScaleUpInfo{{Pod: p2}}})
+	processor.Process(autoscalingContext, &status.ScaleUpStatus{Result: status.ScaleUpSuccessful, PodsTriggeredScaleUp: []*apiv1.Pod{p1}})
+	assert.Len(t, delegate.processed, 2)
+
+	pod, err := client.CoreV1().Pods(p1.Namespace).Get(ctx.TODO(), "p1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "1,2024-05-01T12:00:00Z", pod.Annotations[PodClaimAnnotationKey])
+	pod, err = client.CoreV1().Pods(p2.Namespace).Get(ctx.TODO(), "p2", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, pod.Annotations, PodClaimAnnotationKey)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+)
+
+// Shard describes which node groups are owned by a single instance of Cluster Autoscaler
+// when node groups are split between multiple active instances.
+type Shard struct {
+	// Index identifies the shard when node groups are hashed, it has to be unique among instances.
+	Index int
+	// ExplicitName, if set, identifies the shard instead of Index. It's required with Selector.
+	ExplicitName string
+	// Count is the number of shards node groups are split between by hashing node group ids.
+	Count int
+	// Selector, if set, selects owned node groups by labels of their nodes instead of hashing.
+	Selector labels.Selector
+}
+
+// NewShard validates sharding options and returns the shard they describe, or nil if
+// sharding isn't enabled.
+func NewShard(options config.AutoscalingOptions) (*Shard, error) {
+	if options.ShardCount <= 1 && options.ShardNodeGroupSelector == "" {
+		return nil, nil
+	}
+	if options.ShardIndex < 0 {
+		return nil, fmt.Errorf("shard index %d can't be negative", options.ShardIndex)
+	}
+	if options.ShardName != "" {
+		if errs := validation.IsDNS1123Label(options.ShardName); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid shard name %q: %v", options.ShardName, errs)
+		}
+	}
+	shard := &Shard{Index: options.ShardIndex, Count: options.ShardCount, ExplicitName: options.ShardName}
+	if options.ShardNodeGroupSelector == "" {
+		if options.ShardIndex >= options.ShardCount {
+			return nil, fmt.Errorf("shard index %d out of range for %d shards", options.ShardIndex, options.ShardCount)
+		}
+		return shard, nil
+	}
+	// Shard indexes have no meaning with selectors, so they can't be trusted to be unique.
+	if options.ShardName == "" {
+		return nil, fmt.Errorf("shard name is required when node groups are selected by labels")
+	}
+	selector, err := labels.Parse(options.ShardNodeGroupSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shard node group selector %q: %v", options.ShardNodeGroupSelector, err)
+	}
+	shard.Selector = selector
+	return shard, nil
+}
+
+// Name identifies the shard in pod claims and leader election.
+func (s *Shard) Name() string {
+	if s.ExplicitName != "" {
+		return s.ExplicitName
+	}
+	return strconv.Itoa(s.Index)
+}
+
+// ObjectName returns the name of an object owned by the shard, e.g. its leader election lease,
+// so that instances of the same shard share the object and other shards don't.
+func (s *Shard) ObjectName(resourceName string) string {
+	return fmt.Sprintf("%s-shard-%s", resourceName, s.Name())
+}
+
+// RenameObjects makes names of the status ConfigMap, the status CRD object and the state checkpoint in options
+// unique to the shard, so that shards don't read or overwrite each other's status and state.
+func (s *Shard) RenameObjects(options *config.AutoscalingOptions) {
+	options.StatusConfigMapName = s.ObjectName(options.StatusConfigMapName)
+	options.StatusCRDName = s.ObjectName(options.StatusCRDName)
+	options.StateConfigMapName = s.ObjectName(options.StateConfigMapName)
+}
+
+// OwnsLabels returns true if a node group with nodes labeled with nodeLabels belongs to the shard.
+// It is only meaningful for shards using a selector.
+func (s *Shard) OwnsLabels(nodeLabels map[string]string) bool {
+	return s.Selector != nil && s.Selector.Matches(labels.Set(nodeLabels))
+}
+
+// OwnsId returns true if the node group with the given id is hashed to the shard. Rendezvous
+// hashing is used, so changing the number of shards only moves node groups to or from the
+// added or removed shards.
+func (s *Shard) OwnsId(nodeGroupId string) bool {
+	best, bestScore := 0, uint64(0)
+	for i := 0; i < s.Count; i++ {
+		h := fnv.New64a()
+		h.Write([]byte(nodeGroupId))
+		h.Write([]byte{0})
+		h.Write([]byte(strconv.Itoa(i)))
+		if score := h.Sum64(); i == 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best == s.Index
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+)
+
+func TestNewShard(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		options     config.AutoscalingOptions
+		wantSharded bool
+		wantErr     bool
+	}{
+		"sharding disabled": {
+			options: config.AutoscalingOptions{ShardCount: 1},
+		},
+		"hashing": {
+			options:     config.AutoscalingOptions{ShardCount: 3, ShardIndex: 2},
+			wantSharded: true,
+		},
+		"index out of range": {
+			options: config.AutoscalingOptions{ShardCount: 3, ShardIndex: 3},
+			wantErr: true,
+		},
+		"negative index": {
+			options: config.AutoscalingOptions{ShardNodeGroupSelector: "pool=a", ShardName: "a", ShardIndex: -1},
+			wantErr: true,
+		},
+		"selector": {
+			options:     config.AutoscalingOptions{ShardCount: 1, ShardName: "pools-a-b", ShardNodeGroupSelector: "pool in (a, b)"},
+			wantSharded: true,
+		},
+		"selector without name": {
+			options: config.AutoscalingOptions{ShardCount: 1, ShardIndex: 5, ShardNodeGroupSelector: "pool in (a, b)"},
+			wantErr: true,
+		},
+		"invalid name": {
+			options: config.AutoscalingOptions{ShardName: "a,b", ShardNodeGroupSelector: "pool=a"},
+			wantErr: true,
+		},
+		"invalid selector": {
+			options: config.AutoscalingOptions{ShardName: "a", ShardNodeGroupSelector: "pool in a"},
+			wantErr: true,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			shard, err := NewShard(tc.options)
+			assert.Equal(t, tc.wantErr, err != nil, "unexpected error: %v", err)
+			assert.Equal(t, tc.wantSharded, shard != nil)
+		})
+	}
+}
+
+func TestName(t *testing.T) {
+	hashed, err := NewShard(config.AutoscalingOptions{ShardCount: 3, ShardIndex: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", hashed.Name())
+	assert.Equal(t, "cluster-autoscaler-shard-2", hashed.ObjectName("cluster-autoscaler"))
+
+	named, err := NewShard(config.AutoscalingOptions{ShardCount: 3, ShardIndex: 2, ShardName: "gpu"})
+	assert.NoError(t, err)
+	assert.Equal(t, "gpu", named.Name())
+
+	selected, err := NewShard(config.AutoscalingOptions{ShardName: "pool-a", ShardNodeGroupSelector: "pool=a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "pool-a", selected.Name())
+	assert.Equal(t, "cluster-autoscaler-shard-pool-a", selected.ObjectName("cluster-autoscaler"))
+}
+
+func TestRenameObjects(t *testing.T) {
+	options := config.AutoscalingOptions{
+		ShardCount:          2,
+		StatusConfigMapName: "cluster-autoscaler-status",
+		StatusCRDName:       "cluster-autoscaler",
+		StateConfigMapName:  "cluster-autoscaler-state",
+	}
+	var renamed []config.AutoscalingOptions
+	for index := 0; index < 2; index++ {
+		shardOptions := options
+		shardOptions.ShardIndex = index
+		shard, err := NewShard(shardOptions)
+		assert.NoError(t, err)
+		shard.RenameObjects(&shardOptions)
+		renamed = append(renamed, shardOptions)
+	}
+
+	assert.Equal(t, "cluster-autoscaler-status-shard-0", renamed[0].StatusConfigMapName)
+	assert.Equal(t, "cluster-autoscaler-shard-0", renamed[0].StatusCRDName)
+	assert.Equal(t, "cluster-autoscaler-state-shard-0", renamed[0].StateConfigMapName)
+	assert.NotEqual(t, renamed[0].StatusConfigMapName, renamed[1].StatusConfigMapName)
+	assert.NotEqual(t, renamed[0].StatusCRDName, renamed[1].StatusCRDName)
+	assert.NotEqual(t, renamed[0].StateConfigMapName, renamed[1].StateConfigMapName)
+}
+
+func TestOwnsId(t *testing.T) {
+	ids := make([]string, 100)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("ng-%d", i)
+	}
+	owners := func(count int) map[string]int {
+		result := make(map[string]int)
+		for index := 0; index < count; index++ {
+			shard := &Shard{Index: index, Count: count}
+			for _, id := range ids {
+				if shard.OwnsId(id) {
+					_, found := result[id]
+					assert.False(t, found, "node group %s owned by multiple shards", id)
+					result[id] = index
+				}
+			}
+		}
+		return result
+	}
+
+	three := owners(3)
+	assert.Len(t, three, len(ids), "every node group has to be owned by a shard")
+	perShard := make(map[int]int)
+	for _, index := range three {
+		perShard[index]++
+	}
+	for index := 0; index < 3; index++ {
+		assert.Greater(t, perShard[index], 15, "node groups should be spread between shards")
+	}
+
+	// Adding a shard only moves node groups to the new shard.
+	for id, index := range owners(4) {
+		if index != 3 {
+			assert.Equal(t, three[id], index, "node group %s moved between existing shards", id)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/core/checkpoint"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	klog "k8s.io/klog/v2"
+)
+
+// unlimitedCheckpointRefreshInterval is how often an unchanged checkpoint is saved again when
+// checkpoints don't expire.
+const unlimitedCheckpointRefreshInterval = time.Hour
+
+// restoreState restores the state saved by the previous instance of the autoscaler, e.g. before
+// a restart or a leader change. Loading is retried in every iteration until it succeeds, so that
+// a transient failure doesn't lead to overwriting the saved state.
+func (a *StaticAutoscaler) restoreState(currentTime time.Time) {
+	if a.stateStore == nil || a.stateRestored {
+		return
+	}
+	saved, err := a.stateStore.Load()
+	if err != nil {
+		klog.Errorf("Failed to load state checkpoint: %v", err)
+		return
+	}
+	a.stateRestored = true
+	if saved == nil {
+		klog.V(1).Info("No state checkpoint found, starting with empty state")
+		return
+	}
+	if age := currentTime.Sub(saved.Time); a.StateCheckpointMaxAge > 0 && age > a.StateCheckpointMaxAge {
+		klog.Warningf("Ignoring state checkpoint saved %v ago, older than %v", age, a.StateCheckpointMaxAge)
+		return
+	}
+	if planner, ok := a.scaleDownPlanner.(scaledown.PersistentPlanner); ok {
+		planner.RestoreUnneededSince(saved.UnneededSince)
+	}
+	a.clusterStateRegistry.RestoreBackoffEntries(saved.Backoff)
+	a.nodeDeletionTracker.RestoreState(saved.NodeDeletions)
+	a.lastCheckpoint = saved
+	klog.V(1).Infof("Restored state checkpoint saved at %v: %d unneeded nodes, %d backed off node groups, %d node deletions in progress",
+		saved.Time, len(saved.UnneededSince), len(saved.Backoff), len(saved.NodeDeletions.InProgress))
+}
+
+// saveState checkpoints the state of the autoscaler. To limit writes, the checkpoint is only saved
+// when the state changed, or when the last checkpoint is getting close to StateCheckpointMaxAge.
+// Without StateCheckpointMaxAge, an unchanged checkpoint is refreshed every unlimitedCheckpointRefreshInterval.
+func (a *StaticAutoscaler) saveState(currentTime time.Time) {
+	if a.stateStore == nil || !a.stateRestored {
+		return
+	}
+	current := &checkpoint.Checkpoint{
+		Time:          currentTime,
+		Backoff:       a.clusterStateRegistry.BackoffEntries(),
+		NodeDeletions: a.nodeDeletionTracker.State(),
+	}
+	if planner, ok := a.scaleDownPlanner.(scaledown.PersistentPlanner); ok {
+		current.UnneededSince = planner.UnneededSince()
+	}
+	refreshInterval := a.StateCheckpointMaxAge / 2
+	if a.StateCheckpointMaxAge <= 0 {
+		refreshInterval = unlimitedCheckpointRefreshInterval
+	}
+	if a.lastCheckpoint != nil && current.EqualState(a.lastCheckpoint) && currentTime.Sub(a.lastCheckpoint.Time) < refreshInterval {
+		return
+	}
+	if err := a.stateStore.Save(current); err != nil {
+		klog.Errorf("Failed to save state checkpoint: %v", err)
+		return
+	}
+	a.lastCheckpoint = current
+}
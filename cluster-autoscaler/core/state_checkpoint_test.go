@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	clusterstate_utils "k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/checkpoint"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
+	"k8s.io/client-go/kubernetes/fake"
+	kube_record "k8s.io/client-go/tools/record"
+)
+
+type persistentPlannerMock struct {
+	scaledown.Planner
+	unneededSince map[string]time.Time
+}
+
+func (p *persistentPlannerMock) UnneededSince() map[string]time.Time {
+	return p.unneededSince
+}
+
+func (p *persistentPlannerMock) RestoreUnneededSince(since map[string]time.Time) {
+	p.unneededSince = since
+}
+
+func newStateCheckpointTestAutoscaler(store checkpoint.Store) *StaticAutoscaler {
+	return newStateCheckpointTestAutoscalerWithMaxAge(store, 10*time.Minute)
+}
+
+func newStateCheckpointTestAutoscalerWithMaxAge(store checkpoint.Store, maxAge time.Duration) *StaticAutoscaler {
+	options := config.AutoscalingOptions{StateCheckpointMaxAge: maxAge}
+	fakeLogRecorder, _ := clusterstate_utils.NewStatusMapRecorder(fake.NewSimpleClientset(), "kube-system", kube_record.NewFakeRecorder(5), false, "my-cool-configmap")
+	csr := clusterstate.NewClusterStateRegistry(testprovider.NewTestCloudProvider(nil, nil), clusterstate.ClusterStateRegistryConfig{},
+		fakeLogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults))
+	return &StaticAutoscaler{
+		AutoscalingContext:   &context.AutoscalingContext{AutoscalingOptions: options},
+		clusterStateRegistry: csr,
+		scaleDownPlanner:     &persistentPlannerMock{},
+		nodeDeletionTracker:  deletiontracker.NewNodeDeletionTracker(0),
+		stateStore:           store,
+	}
+}
+
+func TestRestoreState(t *testing.T) {
+	now := time.Now()
+	saved := &checkpoint.Checkpoint{
+		Time:          now.Add(-time.Minute),
+		UnneededSince: map[string]time.Time{"n1": now.Add(-5 * time.Minute)},
+		Backoff: map[string]backoff.Entry{
+			"ng1": {Duration: 5 * time.Minute, BackoffUntil: now.Add(4 * time.Minute), LastFailedExecution: now.Add(-time.Minute)},
+		},
+		NodeDeletions: deletiontracker.State{InProgress: []string{"n2"}},
+	}
+
+	for tn, tc := range map[string]struct {
+		saved        *checkpoint.Checkpoint
+		wantRestored bool
+	}{
+		"no checkpoint": {},
+		"recent checkpoint": {
+			saved:        saved,
+			wantRestored: true,
+		},
+		"stale checkpoint": {
+			saved: &checkpoint.Checkpoint{
+				Time:          now.Add(-time.Hour),
+				UnneededSince: saved.UnneededSince,
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			store := checkpoint.NewInMemoryStore()
+			if tc.saved != nil {
+				assert.NoError(t, store.Save(tc.saved))
+				store.Saves = 0
+			}
+			a := newStateCheckpointTestAutoscaler(store)
+
+			a.restoreState(now)
+			unneededSince := a.scaleDownPlanner.(*persistentPlannerMock).unneededSince
+			if tc.wantRestored {
+				assert.Equal(t, saved.UnneededSince, unneededSince)
+				assert.Equal(t, saved.Backoff, a.clusterStateRegistry.BackoffEntries())
+				result, found := a.nodeDeletionTracker.State().Results["n2"]
+				assert.True(t, found)
+				assert.Equal(t, status.NodeDeleteErrorInternal, result.ResultType)
+			} else {
+				assert.Empty(t, unneededSince)
+				assert.Empty(t, a.clusterStateRegistry.BackoffEntries())
+				assert.Empty(t, a.nodeDeletionTracker.State())
+			}
+
+			a.saveState(now)
+			assert.Equal(t, 1, store.Saves)
+			a.saveState(now.Add(time.Minute))
+			assert.Equal(t, 1, store.Saves, "unchanged state isn't saved again")
+			a.scaleDownPlanner.(*persistentPlannerMock).unneededSince = map[string]time.Time{"n3": now}
+			a.saveState(now.Add(2 * time.Minute))
+			assert.Equal(t, 2, store.Saves)
+			a.saveState(now.Add(8 * time.Minute))
+			assert.Equal(t, 3, store.Saves, "checkpoint is refreshed before reaching max age")
+
+			last, err := store.Load()
+			assert.NoError(t, err)
+			assert.Equal(t, now.Add(8*time.Minute), last.Time)
+			assert.Equal(t, map[string]time.Time{"n3": now}, last.UnneededSince)
+		})
+	}
+}
+
+func TestSaveStateWithoutMaxAge(t *testing.T) {
+	now := time.Now()
+	store := checkpoint.NewInMemoryStore()
+	a := newStateCheckpointTestAutoscalerWithMaxAge(store, 0)
+	a.restoreState(now)
+
+	a.saveState(now)
+	assert.Equal(t, 1, store.Saves)
+	for i := 1; i <= 10; i++ {
+		a.saveState(now.Add(time.Duration(i) * time.Minute))
+	}
+	assert.Equal(t, 1, store.Saves, "unchanged state isn't saved again")
+	a.saveState(now.Add(unlimitedCheckpointRefreshInterval))
+	assert.Equal(t, 2, store.Saves, "unchanged checkpoint is refreshed")
+}
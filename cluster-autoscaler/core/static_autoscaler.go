@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
@@ -38,10 +39,16 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/config/reload"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/admin"
+	"k8s.io/autoscaler/cluster-autoscaler/core/checkpoint"
+	"k8s.io/autoscaler/cluster-autoscaler/core/dryrun"
+	"k8s.io/autoscaler/cluster-autoscaler/core/rotation"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/actuation"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/eligibility"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/legacy"
 	core_utils "k8s.io/autoscaler/cluster-autoscaler/core/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
@@ -49,6 +56,8 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/observers/loopstart"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/customresources"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
@@ -58,6 +67,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
 	caerrors "k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	scheduler_utils "k8s.io/autoscaler/cluster-autoscaler/utils/scheduler"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 	"k8s.io/utils/integer"
 
 	klog "k8s.io/klog/v2"
@@ -95,6 +105,13 @@ type StaticAutoscaler struct {
 	processorCallbacks      *staticAutoscalerProcessorCallbacks
 	initialized             bool
 	taintConfig             taints.TaintConfig
+	optionsReloader         *reload.Reloader
+	nodeDeletionTracker     *deletiontracker.NodeDeletionTracker
+	stateStore              checkpoint.Store
+	stateRestored           bool
+	lastCheckpoint          *checkpoint.Checkpoint
+	rotator                 *rotation.Rotator
+	adminController         *admin.Controller
 }
 
 type staticAutoscalerProcessorCallbacks struct {
@@ -175,7 +192,10 @@ func NewStaticAutoscaler(
 	// during the struct creation rather than here.
 	ndt := deletiontracker.NewNodeDeletionTracker(0 * time.Second)
 	scaleDown := legacy.NewScaleDown(autoscalingContext, processors, ndt, deleteOptions, drainabilityRules)
-	actuator := actuation.NewActuator(autoscalingContext, processors.ScaleStateNotifier, ndt, deleteOptions, drainabilityRules, processors.NodeGroupConfigProcessor)
+	var actuator scaledown.Actuator = actuation.NewActuator(autoscalingContext, processors.ScaleStateNotifier, ndt, deleteOptions, drainabilityRules, processors.NodeGroupConfigProcessor)
+	if opts.DryRun {
+		actuator = dryrun.NewActuator(autoscalingContext)
+	}
 	autoscalingContext.ScaleDownActuator = actuator
 
 	var scaleDownPlanner scaledown.Planner
@@ -196,6 +216,20 @@ func NewStaticAutoscaler(
 	}
 	scaleUpOrchestrator.Initialize(autoscalingContext, processors, clusterStateRegistry, estimatorBuilder, taintConfig)
 
+	var rotator *rotation.Rotator
+	if opts.MaxNodeAge > 0 || opts.RotateOutdatedNodes {
+		var err error
+		scaler, ok := scaleUpOrchestrator.(scaleup.NodeGroupScaler)
+		if ok {
+			rotator, err = rotation.NewRotator(autoscalingContext, clusterStateRegistry, scaleDownActuator, scaler)
+		} else {
+			err = fmt.Errorf("scale-up orchestrator can't scale up individual node groups")
+		}
+		if err != nil {
+			klog.Errorf("Node rotation disabled: %v", err)
+		}
+	}
+
 	// Set the initial scale times to be less than the start time so as to
 	// not start in cooldown mode.
 	initialScaleTime := time.Now().Add(-time.Hour)
@@ -212,6 +246,8 @@ func NewStaticAutoscaler(
 		processorCallbacks:      processorCallbacks,
 		clusterStateRegistry:    clusterStateRegistry,
 		taintConfig:             taintConfig,
+		nodeDeletionTracker:     ndt,
+		rotator:                 rotator,
 	}
 }
 
@@ -239,7 +275,10 @@ func (a *StaticAutoscaler) cleanUpIfRequired() {
 	}
 
 	// CA can die at any time. Removing taints that might have been left from the previous run.
-	if allNodes, err := a.AllNodeLister().List(); err != nil {
+	// In dry-run mode taints belong to another instance actively scaling the cluster.
+	if a.DryRun {
+		klog.V(1).Info("Dry-run mode, not cleaning up taints")
+	} else if allNodes, err := a.AllNodeLister().List(); err != nil {
 		klog.Errorf("Failed to list ready nodes, not cleaning up taints: %v", err)
 	} else {
 		// Make sure we are only cleaning taints from selected node groups.
@@ -256,6 +295,8 @@ func (a *StaticAutoscaler) cleanUpIfRequired() {
 }
 
 func (a *StaticAutoscaler) initializeClusterSnapshot(nodes []*apiv1.Node, scheduledPods []*apiv1.Pod) caerrors.AutoscalerError {
+	span := tracing.StartSpan(a.TraceContext, "InitializeClusterSnapshot", attribute.Int("nodes", len(nodes)), attribute.Int("pods", len(scheduledPods)))
+	defer span.End()
 	a.ClusterSnapshot.Clear()
 
 	knownNodes := make(map[string]bool)
@@ -294,11 +335,17 @@ func (a *StaticAutoscaler) initializeRemainingPdbTracker() caerrors.AutoscalerEr
 
 // RunOnce iterates over node groups and scales them up/down if necessary
 func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerError {
+	a.reloadOptions()
+	a.restoreState(currentTime)
+	defer a.saveState(currentTime)
 	a.cleanUpIfRequired()
 	a.processorCallbacks.reset()
 	a.clusterStateRegistry.PeriodicCleanup()
 	a.DebuggingSnapshotter.StartDataCollection()
 	defer a.DebuggingSnapshotter.Flush()
+	span := tracing.StartRootSpan("RunOnce")
+	defer span.End()
+	a.AutoscalingContext.TraceContext = span.Context()
 
 	podLister := a.AllPodLister()
 	autoscalingContext := a.AutoscalingContext
@@ -345,7 +392,9 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 	scaleDownActuationStatus := a.scaleDownActuator.CheckStatus()
 	// Call CloudProvider.Refresh before any other calls to cloud provider.
 	refreshStart := time.Now()
-	err = a.AutoscalingContext.CloudProvider.Refresh()
+	refreshSpan := tracing.StartSpan(span.Context(), "CloudProvider.Refresh")
+	err = tracing.Bind(a.AutoscalingContext.CloudProvider, refreshSpan.Context()).Refresh()
+	refreshSpan.EndWithError(err)
 	metrics.UpdateDurationFromStart(metrics.CloudProviderRefresh, refreshStart)
 	if err != nil {
 		klog.Errorf("Failed to refresh cloud provider config: %v", err)
@@ -353,18 +402,6 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 	}
 	a.loopStartNotifier.Refresh()
 
-	// Update node groups min/max and maximum number of nodes being set for all node groups after cloud provider refresh
-	maxNodesCount := 0
-	for _, nodeGroup := range a.AutoscalingContext.CloudProvider.NodeGroups() {
-		metrics.UpdateNodeGroupMin(nodeGroup.Id(), nodeGroup.MinSize())
-		metrics.UpdateNodeGroupMax(nodeGroup.Id(), nodeGroup.MaxSize())
-		maxNodesCount += nodeGroup.MaxSize()
-	}
-	if a.MaxNodesTotal > 0 {
-		metrics.UpdateMaxNodesCount(integer.IntMin(a.MaxNodesTotal, maxNodesCount))
-	} else {
-		metrics.UpdateMaxNodesCount(maxNodesCount)
-	}
 	nonExpendableScheduledPods := core_utils.FilterOutExpendablePods(originalScheduledPods, a.ExpendablePodsPriorityCutoff)
 	// Initialize cluster state to ClusterSnapshot
 	if typedErr := a.initializeClusterSnapshot(allNodes, nonExpendableScheduledPods); typedErr != nil {
@@ -383,12 +420,38 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 
 	a.DebuggingSnapshotter.SetTemplateNodes(nodeInfosForGroups)
 
+	if err := a.processors.NodeGroupConfigProcessor.Refresh(a.AutoscalingContext.CloudProvider.NodeGroups(), nodeInfosForGroups); err != nil {
+		klog.Errorf("Failed to refresh node group config: %v", err)
+	}
+	if observer, ok := a.processors.CustomResourcesProcessor.(customresources.TemplateNodeInfosObserver); ok {
+		observer.UpdateTemplateNodeInfos(nodeInfosForGroups)
+	}
+
+	// Update node groups min/max and maximum number of nodes being set for all node groups after cloud provider refresh
+	maxNodesCount := 0
+	for _, nodeGroup := range a.AutoscalingContext.CloudProvider.NodeGroups() {
+		minSize, maxSize := nodegroupconfig.GetSizeRange(a.processors.NodeGroupConfigProcessor, nodeGroup)
+		metrics.UpdateNodeGroupMin(nodeGroup.Id(), minSize)
+		metrics.UpdateNodeGroupMax(nodeGroup.Id(), maxSize)
+		maxNodesCount += maxSize
+	}
+	if a.MaxNodesTotal > 0 {
+		metrics.UpdateMaxNodesCount(integer.IntMin(a.MaxNodesTotal, maxNodesCount))
+	} else {
+		metrics.UpdateMaxNodesCount(maxNodesCount)
+	}
+
 	if typedErr := a.updateClusterState(allNodes, nodeInfosForGroups, currentTime); typedErr != nil {
 		klog.Errorf("Failed to update cluster state: %v", typedErr)
 		return typedErr
 	}
 	metrics.UpdateDurationFromStart(metrics.UpdateState, stateUpdateStart)
 
+	if a.adminController != nil {
+		scaler, _ := a.scaleUpOrchestrator.(scaleup.NodeGroupScaler)
+		a.adminController.Apply(autoscalingContext, a.clusterStateRegistry, scaler, a.processors.NodeGroupConfigProcessor, readyNodes, nodeInfosForGroups, currentTime)
+	}
+
 	scaleUpStatus := &status.ScaleUpStatus{Result: status.ScaleUpNotTried}
 	scaleUpStatusProcessorAlreadyCalled := false
 	scaleDownStatus := &scaledownstatus.ScaleDownStatus{Result: scaledownstatus.ScaleDownNotTried}
@@ -413,6 +476,7 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 			scaleDownStatus.NodeDeleteResultsAsOf = nodeDeletionResultsAsOf
 			a.scaleDownActuator.ClearResultsNotNewerThan(scaleDownStatus.NodeDeleteResultsAsOf)
 			scaleDownStatus.SetUnremovableNodesInfo(a.scaleDownPlanner.UnremovableNodes(), a.scaleDownPlanner.NodeUtilizationMap(), a.CloudProvider)
+			scaleDownStatus.UnneededNodes = a.scaleDownPlanner.UnneededNodes()
 
 			a.processors.ScaleDownStatusProcessor.Process(a.AutoscalingContext, scaleDownStatus)
 		}
@@ -576,9 +640,18 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		}
 	}
 
+	if a.rotator != nil {
+		a.rotator.Rotate(allNodes, readyNodes, nodeInfosForGroups, currentTime)
+	}
+
 	if a.ScaleDownEnabled {
 		unneededStart := time.Now()
 
+		// In dry-run mode annotations belong to another instance actively scaling the cluster.
+		if !a.DryRun {
+			eligibility.CleanExpiredScaleDownProtection(allNodes, a.AutoscalingContext.ClientSet, a.Recorder, currentTime)
+		}
+
 		klog.V(4).Infof("Calculating unneeded nodes")
 
 		var scaleDownCandidates []*apiv1.Node
@@ -607,8 +680,20 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 				return err
 			}
 		}
+		if a.rotator != nil {
+			scaleDownCandidates = filterNodesInRotation(a.CloudProvider, a.rotator.NodeGroupsInRotation(), scaleDownCandidates)
+		}
+		if a.adminController != nil {
+			scaleDownCandidates = a.adminController.FilterScaleDownCandidates(a.CloudProvider, scaleDownCandidates, currentTime)
+			if a.adminController.ScaleDownPaused(currentTime) {
+				klog.V(1).Info("Scale down paused through the admin API")
+				a.processorCallbacks.DisableScaleDownForLoop()
+			}
+		}
 
+		simulationSpan := tracing.StartSpan(span.Context(), "ScaleDown.Simulate", attribute.Int("candidates", len(scaleDownCandidates)))
 		typedErr := a.scaleDownPlanner.UpdateClusterState(podDestinations, scaleDownCandidates, scaleDownActuationStatus, currentTime)
+		simulationSpan.EndWithError(typedErr)
 		// Update clusterStateRegistry and metrics regardless of whether ScaleDown was successful or not.
 		unneededNodes := a.scaleDownPlanner.UnneededNodes()
 		a.processors.ScaleDownCandidatesNotifier.Update(unneededNodes, currentTime)
@@ -648,7 +733,9 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 			scaleDownStart := time.Now()
 			metrics.UpdateLastTime(metrics.ScaleDown, scaleDownStart)
 			empty, needDrain := a.scaleDownPlanner.NodesToDelete(currentTime)
+			deletionSpan := tracing.StartSpan(span.Context(), "ScaleDown.StartDeletion", attribute.Int("empty", len(empty)), attribute.Int("drain", len(needDrain)))
 			scaleDownResult, scaledDownNodes, typedErr := a.scaleDownActuator.StartDeletion(empty, needDrain)
+			deletionSpan.EndWithError(typedErr)
 			scaleDownStatus.Result = scaleDownResult
 			scaleDownStatus.ScaledDownNodes = scaledDownNodes
 			metrics.UpdateDurationFromStart(metrics.ScaleDown, scaleDownStart)
@@ -663,7 +750,7 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 
 			if (scaleDownStatus.Result == scaledownstatus.ScaleDownNoNodeDeleted ||
 				scaleDownStatus.Result == scaledownstatus.ScaleDownNoUnneeded) &&
-				a.AutoscalingContext.AutoscalingOptions.MaxBulkSoftTaintCount != 0 {
+				a.AutoscalingContext.AutoscalingOptions.MaxBulkSoftTaintCount != 0 && !a.DryRun {
 				taintableNodes := a.scaleDownPlanner.UnneededNodes()
 
 				// Make sure we are only cleaning taints from selected node groups.
@@ -776,7 +863,8 @@ func (a *StaticAutoscaler) removeOldUnregisteredNodes(allUnregisteredNodes []clu
 			klog.Warningf("Failed to get node group size; nodeGroup=%v; err=%v", nodeGroup.Id(), err)
 			continue
 		}
-		possibleToDelete := size - nodeGroup.MinSize()
+		minSize, _ := csr.NodeGroupSizeRange(nodeGroup)
+		possibleToDelete := size - minSize
 		if possibleToDelete <= 0 {
 			klog.Warningf("Node group %s min size reached, skipping removal of %v unregistered nodes", nodeGroupId, len(unregisteredNodesToDelete))
 			continue
@@ -976,6 +1064,21 @@ func filterNodesFromSelectedGroups(cp cloudprovider.CloudProvider, nodes ...*api
 	return filtered
 }
 
+// filterNodesInRotation removes nodes of node groups in which a node is being rotated.
+func filterNodesInRotation(cp cloudprovider.CloudProvider, nodeGroupsInRotation map[string]bool, nodes []*apiv1.Node) []*apiv1.Node {
+	if len(nodeGroupsInRotation) == 0 {
+		return nodes
+	}
+	filtered := make([]*apiv1.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if ng, err := cp.NodeGroupForNode(n); err == nil && ng != nil && nodeGroupsInRotation[ng.Id()] {
+			continue
+		}
+		filtered = append(filtered, n)
+	}
+	return filtered
+}
+
 func (a *StaticAutoscaler) updateClusterState(allNodes []*apiv1.Node, nodeInfosForGroups map[string]*schedulerframework.NodeInfo, currentTime time.Time) caerrors.AutoscalerError {
 	err := a.clusterStateRegistry.UpdateNodes(allNodes, nodeInfosForGroups, currentTime)
 	if err != nil {
@@ -574,7 +574,7 @@ func TestStaticAutoscalerRunOnceWithScaleDownDelayPerNG(t *testing.T) {
 	processors.ScaleStateNotifier.Register(sddProcessor)
 	scaleDownCandidatesComparers := []scaledowncandidates.CandidatesComparer{}
 	cp := scaledowncandidates.NewCombinedScaleDownCandidatesProcessor()
-	cp.Register(scaledowncandidates.NewScaleDownCandidatesSortingProcessor(scaleDownCandidatesComparers))
+	cp.Register(scaledowncandidates.NewScaleDownCandidatesSortingProcessor(processors.NodeGroupConfigProcessor, scaleDownCandidatesComparers))
 	cp.Register(sddProcessor)
 	processors.ScaleDownNodeProcessor = cp
 	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterStateConfig, context.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults))
@@ -2560,7 +2560,7 @@ func TestStaticAutoscalerRunOnceInvokesScaleDownStatusProcessor(t *testing.T) {
 				// These fields are not important for this check and may clutter the whole plot
 				cmpopts.IgnoreFields(status.UnremovableNode{}, "NodeGroup", "UtilInfo"),
 				cmpopts.IgnoreFields(status.ScaleDownNode{}, "NodeGroup", "UtilInfo"),
-				cmpopts.IgnoreFields(status.ScaleDownStatus{}, "NodeDeleteResultsAsOf"),
+				cmpopts.IgnoreFields(status.ScaleDownStatus{}, "NodeDeleteResultsAsOf", "UnneededNodes"),
 				cmpopts.EquateEmpty(),
 			}
 			if diff := cmp.Diff(test.expectedStatus, statusProcessor.scaleDownStatus, opts); diff != "" {
@@ -2618,3 +2618,17 @@ func newEstimatorBuilder() estimator.EstimatorBuilder {
 
 	return estimatorBuilder
 }
+
+func TestFilterNodesInRotation(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 2)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	n1, n2, n3, n4 := BuildTestNode("n1", 1000, 1000), BuildTestNode("n2", 1000, 1000), BuildTestNode("n3", 1000, 1000), BuildTestNode("n4", 1000, 1000)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	provider.AddNode("ng2", n3)
+	nodes := []*apiv1.Node{n1, n2, n3, n4}
+
+	assert.Equal(t, nodes, filterNodesInRotation(provider, nil, nodes))
+	assert.Equal(t, []*apiv1.Node{n3, n4}, filterNodesInRotation(provider, map[string]bool{"ng1": true}, nodes))
+}
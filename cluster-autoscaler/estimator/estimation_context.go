@@ -25,21 +25,24 @@ type EstimationContext interface {
 	SimilarNodeGroups() []cloudprovider.NodeGroup
 	ClusterMaxNodeLimit() int
 	CurrentNodeCount() int
+	ScaleUpRateLimit() int
 }
 
 type estimationContext struct {
 	similarNodeGroups   []cloudprovider.NodeGroup
 	currentNodeCount    int
 	clusterMaxNodeLimit int
+	scaleUpRateLimit    int
 }
 
 // NewEstimationContext creates a patch for estimation context with runtime properties.
 // This patch is used to update existing context.
-func NewEstimationContext(clusterMaxNodeLimit int, similarNodeGroups []cloudprovider.NodeGroup, currentNodeCount int) EstimationContext {
+func NewEstimationContext(clusterMaxNodeLimit int, similarNodeGroups []cloudprovider.NodeGroup, currentNodeCount int, scaleUpRateLimit int) EstimationContext {
 	return &estimationContext{
 		similarNodeGroups:   similarNodeGroups,
 		currentNodeCount:    currentNodeCount,
 		clusterMaxNodeLimit: clusterMaxNodeLimit,
+		scaleUpRateLimit:    scaleUpRateLimit,
 	}
 }
 
@@ -57,3 +60,10 @@ func (c *estimationContext) ClusterMaxNodeLimit() int {
 func (c *estimationContext) CurrentNodeCount() int {
 	return c.currentNodeCount
 }
+
+// ScaleUpRateLimit returns maximum number of nodes that can be added to the node group and its
+// similar node groups due to their scale-up rate limits. 0 means no limit, negative value means
+// that no nodes can be added.
+func (c *estimationContext) ScaleUpRateLimit() int {
+	return c.scaleUpRateLimit
+}
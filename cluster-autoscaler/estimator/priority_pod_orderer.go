@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"sort"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// PriorityPodOrderer is an EstimationPodOrderer sorting pods by decreasing priority,
+// so that pods of higher priority are packed first when the estimation is limited.
+// Pods of the same priority are ordered by the wrapped orderer.
+type PriorityPodOrderer struct {
+	wrapped EstimationPodOrderer
+}
+
+// NewPriorityPodOrderer returns the object of PriorityPodOrderer
+func NewPriorityPodOrderer(wrapped EstimationPodOrderer) *PriorityPodOrderer {
+	return &PriorityPodOrderer{wrapped: wrapped}
+}
+
+// Order sorts pods by priority, keeping the order of the wrapped orderer for pods of the same priority.
+func (p *PriorityPodOrderer) Order(podsEquivalentGroups []PodEquivalenceGroup, nodeTemplate *framework.NodeInfo, nodeGroup cloudprovider.NodeGroup) []PodEquivalenceGroup {
+	sorted := p.wrapped.Order(podsEquivalentGroups, nodeTemplate, nodeGroup)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return groupPriority(sorted[i]) > groupPriority(sorted[j])
+	})
+	return sorted
+}
+
+func groupPriority(podsEquivalentGroup PodEquivalenceGroup) int32 {
+	samplePod := podsEquivalentGroup.Exemplar()
+	if samplePod == nil {
+		return 0
+	}
+	return corev1helpers.PodPriority(samplePod)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func TestPriorityPodOrderer(t *testing.T) {
+	buildPodGroup := func(name string, cpu int64, priority *int32) PodEquivalenceGroup {
+		pod := test.BuildTestPod(name, cpu, 1)
+		pod.Spec.Priority = priority
+		return PodEquivalenceGroup{Pods: []*v1.Pod{pod}}
+	}
+	low, high := int32(-10), int32(100)
+	smallLow := buildPodGroup("small-low", 1, &low)
+	bigLow := buildPodGroup("big-low", 3, &low)
+	smallDefault := buildPodGroup("small-default", 1, nil)
+	smallHigh := buildPodGroup("small-high", 1, &high)
+	bigHigh := buildPodGroup("big-high", 3, &high)
+	node := makeNode(4, 600, 10, "node1", "zone-sun")
+	testCases := map[string]struct {
+		input    []PodEquivalenceGroup
+		expected []PodEquivalenceGroup
+	}{
+		"same priority sorted by size": {
+			input:    []PodEquivalenceGroup{smallLow, bigLow},
+			expected: []PodEquivalenceGroup{bigLow, smallLow},
+		},
+		"higher priority first": {
+			input:    []PodEquivalenceGroup{bigLow, smallDefault, smallHigh},
+			expected: []PodEquivalenceGroup{smallHigh, smallDefault, bigLow},
+		},
+		"priority then size": {
+			input:    []PodEquivalenceGroup{smallLow, smallHigh, bigLow, bigHigh},
+			expected: []PodEquivalenceGroup{bigHigh, smallHigh, bigLow, smallLow},
+		},
+		"empty pod list": {
+			input:    []PodEquivalenceGroup{},
+			expected: []PodEquivalenceGroup{},
+		},
+	}
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			orderer := NewPriorityPodOrderer(NewDecreasingPodOrderer())
+			nodeInfo := schedulerframework.NewNodeInfo()
+			nodeInfo.SetNode(node)
+			assert.Equal(t, tc.expected, orderer.Order(tc.input, nodeInfo, nil))
+		})
+	}
+}
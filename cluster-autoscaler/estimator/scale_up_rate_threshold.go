@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+)
+
+type scaleUpRateThreshold struct {
+}
+
+// NodeLimit returns maximum number of new nodes that can be added to the node group
+// and its similar node groups without exceeding their scale-up rate limits. Possible return values are:
+//   - -1 when the node groups can't be scaled up until their rate limits allow it again
+//   - 0 when context is not set or the node groups aren't rate limited. Return value of 0 means that there is no limit.
+//   - Any positive number representing maximum possible number of new nodes
+func (t *scaleUpRateThreshold) NodeLimit(_ cloudprovider.NodeGroup, context EstimationContext) int {
+	if context == nil {
+		return 0
+	}
+	return context.ScaleUpRateLimit()
+}
+
+// DurationLimit always returns 0 for this threshold, meaning that no limit is set.
+func (t *scaleUpRateThreshold) DurationLimit(cloudprovider.NodeGroup, EstimationContext) time.Duration {
+	return 0
+}
+
+// NewScaleUpRateThreshold returns a Threshold that can be used to limit binpacking
+// by scale-up rate limits of node groups
+func NewScaleUpRateThreshold() Threshold {
+	return &scaleUpRateThreshold{}
+}
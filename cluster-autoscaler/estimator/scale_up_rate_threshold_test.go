@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScaleUpRateThreshold(t *testing.T) {
+	tests := []struct {
+		name          string
+		context       EstimationContext
+		wantThreshold int
+	}{
+		{
+			name:          "no threshold is set without context",
+			wantThreshold: 0,
+		},
+		{
+			name:          "no threshold is set if node groups aren't rate limited",
+			context:       &estimationContext{},
+			wantThreshold: 0,
+		},
+		{
+			name:          "returns nodes allowed by rate limits",
+			context:       &estimationContext{scaleUpRateLimit: 3},
+			wantThreshold: 3,
+		},
+		{
+			name:          "threshold is negative if rate limits are exhausted",
+			context:       &estimationContext{scaleUpRateLimit: -1},
+			wantThreshold: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantThreshold, NewScaleUpRateThreshold().NodeLimit(nil, tt.context))
+			assert.True(t, NewScaleUpRateThreshold().DurationLimit(nil, nil) == 0)
+		})
+	}
+}
@@ -20,10 +20,12 @@ import (
 	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/klog/v2"
 )
 
 type sngCapacityThreshold struct {
+	sizeGetter nodegroupconfig.NodeGroupSizeGetter
 }
 
 // NodeLimit returns maximum number of new nodes that can be added to the cluster
@@ -52,7 +54,8 @@ func (t *sngCapacityThreshold) computeNodeGroupCapacity(nodeGroup cloudprovider.
 		klog.Errorf("Error while computing available capacity of a node group %v: can't get target size of the group: %v", nodeGroup.Id(), err)
 		return 0
 	}
-	groupCapacity := nodeGroup.MaxSize() - nodeGroupTargetSize
+	_, maxSize := nodegroupconfig.GetSizeRange(t.sizeGetter, nodeGroup)
+	groupCapacity := maxSize - nodeGroupTargetSize
 	if groupCapacity > 0 {
 		return groupCapacity
 	}
@@ -65,7 +68,8 @@ func (t *sngCapacityThreshold) DurationLimit(cloudprovider.NodeGroup, Estimation
 }
 
 // NewSngCapacityThreshold returns a Threshold that can be used to limit binpacking
-// by available capacity of similar node groups
-func NewSngCapacityThreshold() Threshold {
-	return &sngCapacityThreshold{}
+// by available capacity of similar node groups. Max sizes of the node groups are
+// provided by sizeGetter.
+func NewSngCapacityThreshold(sizeGetter nodegroupconfig.NodeGroupSizeGetter) Threshold {
+	return &sngCapacityThreshold{sizeGetter: sizeGetter}
 }
@@ -20,9 +20,26 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 )
 
+// fakeSizeGetter overrides max sizes of node groups found in maxSizes.
+type fakeSizeGetter struct {
+	maxSizes map[string]int
+}
+
+func (f *fakeSizeGetter) GetMinSize(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	return nodeGroup.MinSize(), nil
+}
+
+func (f *fakeSizeGetter) GetMaxSize(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	if maxSize, found := f.maxSizes[nodeGroup.Id()]; found {
+		return maxSize, nil
+	}
+	return nodeGroup.MaxSize(), nil
+}
+
 func TestSngCapacityThreshold(t *testing.T) {
 	type nodeGroupConfig struct {
 		name       string
@@ -33,6 +50,7 @@ func TestSngCapacityThreshold(t *testing.T) {
 		name             string
 		nodeGroupsConfig []nodeGroupConfig
 		currentNodeGroup nodeGroupConfig
+		maxSizeOverrides map[string]int
 		wantThreshold    int
 	}{
 		{
@@ -45,6 +63,16 @@ func TestSngCapacityThreshold(t *testing.T) {
 			currentNodeGroup: nodeGroupConfig{name: "main-ng", maxNodes: 20, nodesCount: 10},
 			wantThreshold:    67,
 		},
+		{
+			name: "uses max sizes from size getter",
+			nodeGroupsConfig: []nodeGroupConfig{
+				{name: "ng1", maxNodes: 10, nodesCount: 5},
+				{name: "ng2", maxNodes: 100, nodesCount: 50},
+			},
+			currentNodeGroup: nodeGroupConfig{name: "main-ng", maxNodes: 20, nodesCount: 10},
+			maxSizeOverrides: map[string]int{"ng2": 60, "main-ng": 12},
+			wantThreshold:    17,
+		},
 		{
 			name: "returns available capacity and skips over-provisioned groups",
 			nodeGroupsConfig: []nodeGroupConfig{
@@ -85,7 +113,7 @@ func TestSngCapacityThreshold(t *testing.T) {
 			context := estimationContext{similarNodeGroups: provider.NodeGroups()}
 			provider.AddNodeGroup(tt.currentNodeGroup.name, 0, tt.currentNodeGroup.maxNodes, tt.currentNodeGroup.nodesCount)
 			currentNodeGroup := provider.GetNodeGroup(tt.currentNodeGroup.name)
-			assert.Equalf(t, tt.wantThreshold, NewSngCapacityThreshold().NodeLimit(currentNodeGroup, &context), "NewSngCapacityThreshold()")
+			assert.Equalf(t, tt.wantThreshold, NewSngCapacityThreshold(&fakeSizeGetter{maxSizes: tt.maxSizeOverrides}).NodeLimit(currentNodeGroup, &context), "NewSngCapacityThreshold()")
 			assert.True(t, NewClusterCapacityThreshold().DurationLimit(currentNodeGroup, &context) == 0)
 		})
 	}
@@ -24,7 +24,7 @@ import (
 
 var (
 	// AvailableExpanders is a list of available expander options
-	AvailableExpanders = []string{RandomExpanderName, MostPodsExpanderName, LeastWasteExpanderName, PriceBasedExpanderName, PriorityBasedExpanderName, GRPCExpanderName}
+	AvailableExpanders = []string{RandomExpanderName, MostPodsExpanderName, LeastWasteExpanderName, PriceBasedExpanderName, PriorityBasedExpanderName, GRPCExpanderName, GpuPartitionExpanderName}
 	// RandomExpanderName selects a node group at random
 	RandomExpanderName = "random"
 	// MostPodsExpanderName selects a node group that fits the most pods
@@ -38,6 +38,9 @@ var (
 	PriceBasedExpanderName = "price"
 	// PriorityBasedExpanderName selects a node group based on a user-configured priorities assigned to group names
 	PriorityBasedExpanderName = "priority"
+	// GpuPartitionExpanderName selects a node group whose GPU partitions (MIG profiles or time-sliced GPUs)
+	// are used by the pods to the highest degree
+	GpuPartitionExpanderName = "gpu-partition"
 	// GRPCExpanderName uses the gRPC client expander to call to an external gRPC server to select a node group for scale up
 	GRPCExpanderName = "grpc"
 )
@@ -17,7 +17,10 @@ limitations under the License.
 package factory
 
 import (
+	"context"
+
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
 )
@@ -34,6 +37,15 @@ func newChainStrategy(filters []expander.Filter, fallback expander.Strategy) exp
 	}
 }
 
+// WithContext returns a copy of the strategy with all filters bound to ctx.
+func (c *chainStrategy) WithContext(ctx context.Context) expander.Strategy {
+	filters := make([]expander.Filter, len(c.filters))
+	for i, filter := range c.filters {
+		filters[i] = tracing.Bind(filter, ctx)
+	}
+	return newChainStrategy(filters, tracing.Bind(c.fallback, ctx))
+}
+
 func (c *chainStrategy) BestOption(options []expander.Option, nodeInfo map[string]*schedulerframework.NodeInfo) *expander.Option {
 	filteredOptions := options
 	for _, filter := range c.filters {
@@ -20,6 +20,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/gpupartition"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/grpcplugin"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/leastnodes"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/mostpods"
@@ -66,14 +67,14 @@ func (f *Factory) Build(names []string) (expander.Strategy, errors.AutoscalerErr
 		seenExpanders[name] = struct{}{}
 
 		create, known := f.createFunc[name]
-		if known {
-			filters = append(filters, create())
-		} else {
+		if !known {
 			return nil, errors.NewAutoscalerError(errors.InternalError, "Expander %s not supported", name)
 		}
-		if _, ok := filters[len(filters)-1].(expander.Strategy); ok {
+		filter := create()
+		if _, ok := filter.(expander.Strategy); ok {
 			strategySeen = true
 		}
+		filters = append(filters, newTracedFilter(name, filter))
 	}
 	return newChainStrategy(filters, random.NewStrategy()), nil
 }
@@ -84,6 +85,7 @@ func (f *Factory) RegisterDefaultExpanders(cloudProvider cloudprovider.CloudProv
 	f.RegisterFilter(expander.MostPodsExpanderName, mostpods.NewFilter)
 	f.RegisterFilter(expander.LeastWasteExpanderName, waste.NewFilter)
 	f.RegisterFilter(expander.LeastNodesExpanderName, leastnodes.NewFilter)
+	f.RegisterFilter(expander.GpuPartitionExpanderName, gpupartition.NewFilter)
 	f.RegisterFilter(expander.PriceBasedExpanderName, func() expander.Filter {
 		if _, err := cloudProvider.Pricing(); err != nil {
 			klog.Fatalf("Couldn't access cloud provider pricing for %s expander: %v", expander.PriceBasedExpanderName, err)
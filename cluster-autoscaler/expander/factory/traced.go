@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
+
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// tracedFilter records a span for every call to the wrapped expander.
+// Spans are children of the span carried by ctx.
+type tracedFilter struct {
+	name   string
+	filter expander.Filter
+	ctx    context.Context
+}
+
+func newTracedFilter(name string, filter expander.Filter) expander.Filter {
+	return &tracedFilter{name: name, filter: filter}
+}
+
+// WithContext returns a copy of the filter recording spans as children of the span carried by ctx.
+func (t *tracedFilter) WithContext(ctx context.Context) expander.Filter {
+	return &tracedFilter{name: t.name, filter: t.filter, ctx: ctx}
+}
+
+func (t *tracedFilter) BestOptions(options []expander.Option, nodeInfo map[string]*schedulerframework.NodeInfo) []expander.Option {
+	span := tracing.StartSpan(t.ctx, "Expander", attribute.String("expander", t.name), attribute.Int("options", len(options)))
+	defer span.End()
+	best := tracing.Bind(t.filter, span.Context()).BestOptions(options, nodeInfo)
+	span.SetAttributes(attribute.Int("bestOptions", len(best)))
+	return best
+}
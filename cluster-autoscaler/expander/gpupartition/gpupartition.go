@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpupartition
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	resourcehelper "k8s.io/kubernetes/pkg/api/v1/resource"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+type gpupartition struct {
+}
+
+// NewFilter returns a scale up filter that picks the node groups whose GPU partition layout fits the pods best
+func NewFilter() expander.Filter {
+	return &gpupartition{}
+}
+
+// BestOptions selects the expansion options in which pods use the highest fraction of GPU partitions of the new
+// nodes, weighted by the number of GPU compute slices of each partition. If pods of no option request GPU
+// partitions, all options are returned.
+func (g *gpupartition) BestOptions(expansionOptions []expander.Option, nodeInfo map[string]*schedulerframework.NodeInfo) []expander.Option {
+	var bestOptions []expander.Option
+	bestFit := 0.0
+	for _, option := range expansionOptions {
+		requested := partitionRequests(option.Pods)
+		if requested == 0 {
+			continue
+		}
+		info, found := nodeInfo[option.NodeGroup.Id()]
+		if !found {
+			continue
+		}
+		allocatable := partitionAllocatable(info.Node()) * int64(option.NodeCount)
+		if allocatable == 0 {
+			continue
+		}
+		fit := float64(requested) / float64(allocatable)
+		if fit > bestFit {
+			bestFit = fit
+			bestOptions = []expander.Option{option}
+		} else if fit == bestFit {
+			bestOptions = append(bestOptions, option)
+		}
+	}
+	if len(bestOptions) == 0 {
+		return expansionOptions
+	}
+	return bestOptions
+}
+
+// partitionRequests returns the sum of weighted requests of GPU partitions of the pods.
+func partitionRequests(pods []*apiv1.Pod) int64 {
+	var requested int64
+	for _, pod := range pods {
+		for resourceName, quantity := range resourcehelper.PodRequests(pod, resourcehelper.PodResourcesOptions{}) {
+			if gpu.IsGpuPartitionResource(resourceName) {
+				requested += quantity.Value() * gpu.GpuPartitionWeight(resourceName)
+			}
+		}
+	}
+	return requested
+}
+
+// partitionAllocatable returns the sum of weighted allocatable GPU partitions of the node.
+func partitionAllocatable(node *apiv1.Node) int64 {
+	var allocatable int64
+	for _, resourceName := range gpu.NodeGpuPartitions(node) {
+		quantity := node.Status.Allocatable[resourceName]
+		allocatable += quantity.Value() * gpu.GpuPartitionWeight(resourceName)
+	}
+	return allocatable
+}
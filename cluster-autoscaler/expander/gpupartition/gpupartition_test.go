@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpupartition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func buildNodeInfo(name string, partitions map[apiv1.ResourceName]int64) *schedulerframework.NodeInfo {
+	node := BuildTestNode(name, 8000, 32000)
+	for resourceName, count := range partitions {
+		node.Status.Allocatable[resourceName] = *resource.NewQuantity(count, resource.DecimalSI)
+	}
+	nodeInfo := schedulerframework.NewNodeInfo()
+	nodeInfo.SetNode(node)
+	return nodeInfo
+}
+
+func buildPods(count int, partition apiv1.ResourceName) []*apiv1.Pod {
+	var pods []*apiv1.Pod
+	for i := 0; i < count; i++ {
+		pod := BuildTestPod("p", 100, 1000)
+		if partition != "" {
+			pod.Spec.Containers[0].Resources.Requests[partition] = *resource.NewQuantity(1, resource.DecimalSI)
+		}
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+func TestGpuPartitionBestOptions(t *testing.T) {
+	const small, large = apiv1.ResourceName("nvidia.com/mig-1g.10gb"), apiv1.ResourceName("nvidia.com/mig-3g.40gb")
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("small-partitions", 0, 10, 0)
+	provider.AddNodeGroup("large-partitions", 0, 10, 0)
+	provider.AddNodeGroup("mixed-partitions", 0, 10, 0)
+	provider.AddNodeGroup("no-gpu", 0, 10, 0)
+	nodeInfos := map[string]*schedulerframework.NodeInfo{
+		"small-partitions": buildNodeInfo("small", map[apiv1.ResourceName]int64{small: 7}),
+		"large-partitions": buildNodeInfo("large", map[apiv1.ResourceName]int64{large: 2}),
+		"mixed-partitions": buildNodeInfo("mixed", map[apiv1.ResourceName]int64{small: 4, large: 1}),
+		"no-gpu":           buildNodeInfo("no-gpu", nil),
+	}
+	option := func(id string, nodeCount int, pods []*apiv1.Pod) expander.Option {
+		return expander.Option{NodeGroup: provider.GetNodeGroup(id), NodeCount: nodeCount, Pods: pods, Debug: id}
+	}
+
+	testCases := map[string]struct {
+		options []expander.Option
+		want    []string
+	}{
+		"small partitions fill the node with small partitions": {
+			options: []expander.Option{
+				option("small-partitions", 1, buildPods(7, small)),
+				option("mixed-partitions", 2, buildPods(7, small)),
+			},
+			want: []string{"small-partitions"},
+		},
+		"mixed layout fits mixed pods": {
+			options: []expander.Option{
+				option("mixed-partitions", 1, append(buildPods(4, small), buildPods(1, large)...)),
+				option("small-partitions", 1, buildPods(4, small)),
+				option("large-partitions", 1, buildPods(1, large)),
+			},
+			want: []string{"mixed-partitions"},
+		},
+		"equally good options are kept": {
+			options: []expander.Option{
+				option("large-partitions", 1, buildPods(2, large)),
+				option("small-partitions", 1, buildPods(7, small)),
+			},
+			want: []string{"large-partitions", "small-partitions"},
+		},
+		"pods without partition requests": {
+			options: []expander.Option{
+				option("no-gpu", 1, buildPods(3, "")),
+				option("small-partitions", 1, buildPods(3, "")),
+			},
+			want: []string{"no-gpu", "small-partitions"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			for _, option := range NewFilter().BestOptions(tc.options, nodeInfos) {
+				got = append(got, option.Debug)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -176,7 +176,7 @@ func TestBestOptionsValid(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockClient := mocks.NewMockExpanderClient(ctrl)
-	g := &grpcclientstrategy{mockClient}
+	g := &grpcclientstrategy{grpcClient: mockClient}
 
 	nodeInfos := makeFakeNodeInfos()
 	grpcNodeInfoMap := make(map[string]*v1.Node)
@@ -202,7 +202,7 @@ func TestBestOptionsErrors(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockClient := mocks.NewMockExpanderClient(ctrl)
-	g := grpcclientstrategy{mockClient}
+	g := grpcclientstrategy{grpcClient: mockClient}
 
 	badProtosOption := protos.Option{
 		NodeGroupId: "badID",
@@ -220,7 +220,7 @@ func TestBestOptionsErrors(t *testing.T) {
 	}{
 		{
 			desc:         "Bad gRPC client config",
-			client:       grpcclientstrategy{nil},
+			client:       grpcclientstrategy{grpcClient: nil},
 			nodeInfo:     makeFakeNodeInfos(),
 			mockResponse: protos.BestOptionsResponse{},
 			errResponse:  nil,
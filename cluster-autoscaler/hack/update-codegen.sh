@@ -16,7 +16,7 @@
 
 ###
 # This script is to be used when updating the generated clients of 
-# the Provisioning Request CRD.
+# the Provisioning Request, Cluster Autoscaler status and NodeGroupPolicy CRDs.
 ###
 
 set -o errexit
@@ -36,6 +36,18 @@ bash "${CODEGEN_PKG}"/generate-groups.sh "applyconfiguration,client,deepcopy,inf
   autoscaling.x-k8s.io:v1beta1 \
   --go-header-file "${SCRIPT_ROOT}"/../hack/boilerplate/boilerplate.generatego.txt
 
+bash "${CODEGEN_PKG}"/generate-groups.sh "applyconfiguration,client,deepcopy,informer,lister" \
+  k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client \
+  k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus \
+  autoscaling.x-k8s.io:v1alpha1 \
+  --go-header-file "${SCRIPT_ROOT}"/../hack/boilerplate/boilerplate.generatego.txt
+
+bash "${CODEGEN_PKG}"/generate-groups.sh "applyconfiguration,client,deepcopy,informer,lister" \
+  k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/client \
+  k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy \
+  autoscaling.x-k8s.io:v1alpha1 \
+  --go-header-file "${SCRIPT_ROOT}"/../hack/boilerplate/boilerplate.generatego.txt
+
 chmod -x "${CODEGEN_PKG}"/generate-groups.sh
 chmod -x "${CODEGEN_PKG}"/generate-internal-groups.sh
 popd
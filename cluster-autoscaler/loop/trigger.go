@@ -22,6 +22,7 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	kube_client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -29,7 +30,11 @@ import (
 	podv1 "k8s.io/kubernetes/pkg/api/v1/pod"
 )
 
-const maxPodChangeAge = 10 * time.Second
+const (
+	maxPodChangeAge = 10 * time.Second
+	// podChanSize bounds the number of unschedulable pods buffered between iterations.
+	podChanSize = 1000
+)
 
 var (
 	podsResource             = "pods"
@@ -43,11 +48,33 @@ type scalingTimesGetter interface {
 	LastScaleDownDeleteTime() time.Time
 }
 
+// PodBatchingConfig configures batching of unschedulable pods before an autoscaling
+// iteration is triggered, so that pods of a large rollout are handled by a single scale-up.
+type PodBatchingConfig struct {
+	// QuietPeriod is the time without newly observed unschedulable pods after which
+	// a batch is complete. Zero disables batching.
+	QuietPeriod time.Duration
+	// MaxDelay bounds the time a batch is collected for, counted from its first pod.
+	MaxDelay time.Duration
+	// PriorityClassMaxDelay overrides MaxDelay for batches with pods of given priority
+	// classes. Zero triggers an iteration immediately.
+	PriorityClassMaxDelay map[string]time.Duration
+}
+
+// maxDelay returns the maximum batching delay for a pod of the given priority class.
+func (c *PodBatchingConfig) maxDelay(priorityClassName string) time.Duration {
+	if delay, found := c.PriorityClassMaxDelay[priorityClassName]; found {
+		return delay
+	}
+	return c.MaxDelay
+}
+
 // LoopTrigger object implements criteria used to start new autoscaling iteration
 type LoopTrigger struct {
 	podObserver        *UnschedulablePodObserver
 	scanInterval       time.Duration
 	scalingTimesGetter scalingTimesGetter
+	batching           *PodBatchingConfig
 }
 
 // NewLoopTrigger creates a LoopTrigger object
@@ -59,6 +86,15 @@ func NewLoopTrigger(podObserver *UnschedulablePodObserver, scalingTimesGetter sc
 	}
 }
 
+// WithPodBatching makes the LoopTrigger wait until the arrival of unschedulable pods
+// settles before triggering an iteration.
+func (t *LoopTrigger) WithPodBatching(batching PodBatchingConfig) *LoopTrigger {
+	if batching.QuietPeriod > 0 {
+		t.batching = &batching
+	}
+	return t
+}
+
 // Wait waits for the next autoscaling iteration
 func (t *LoopTrigger) Wait(lastRun time.Time) {
 	sleepStart := time.Now()
@@ -71,38 +107,98 @@ func (t *LoopTrigger) Wait(lastRun time.Time) {
 		select {
 		case <-t.podObserver.unschedulablePodChan:
 			klog.Info("Autoscaler loop triggered by unschedulable pod appearing")
+			t.podObserver.drain()
 		default:
 			klog.Infof("Autoscaler loop triggered immediately after a productive iteration")
 		}
 		return
 	}
 
-	// Unschedulable pod triggers autoscaling immediately.
+	// Unschedulable pod triggers autoscaling immediately, or after a batch of pods is collected.
 	select {
 	case <-time.After(t.scanInterval):
 		klog.Infof("Autoscaler loop triggered by a %v timer", t.scanInterval)
-	case <-t.podObserver.unschedulablePodChan:
-		klog.Info("Autoscaler loop triggered by unschedulable pod appearing")
+	case pod := <-t.podObserver.unschedulablePodChan:
+		if t.batching == nil {
+			klog.Info("Autoscaler loop triggered by unschedulable pod appearing")
+			t.podObserver.drain()
+			return
+		}
+		size, delay := t.collectBatch(pod)
+		metrics.ObservePendingPodBatch(size, delay)
+		klog.Infof("Autoscaler loop triggered by a batch of %d unschedulable pods collected for %v", size, delay)
+	}
+}
+
+// collectBatch collects unschedulable pods until none arrives for the quiet period or
+// the maximum delay of the pods in the batch passes. It returns the number of pods in
+// the batch and the time it was collected for.
+func (t *LoopTrigger) collectBatch(first unschedulablePod) (int, time.Duration) {
+	start := time.Now()
+	pods := map[types.UID]bool{first.uid: true}
+	deadline := start.Add(t.batching.maxDelay(first.priorityClassName))
+	lastArrival := start
+	for {
+		end := lastArrival.Add(t.batching.QuietPeriod)
+		if deadline.Before(end) {
+			end = deadline
+		}
+		wait := time.Until(end)
+		if wait <= 0 {
+			return len(pods), time.Since(start)
+		}
+		select {
+		case <-time.After(wait):
+			return len(pods), time.Since(start)
+		case pod := <-t.podObserver.unschedulablePodChan:
+			// Updates of already observed pods don't extend the batch.
+			if pods[pod.uid] {
+				continue
+			}
+			pods[pod.uid] = true
+			lastArrival = time.Now()
+			if podDeadline := start.Add(t.batching.maxDelay(pod.priorityClassName)); podDeadline.Before(deadline) {
+				deadline = podDeadline
+			}
+		}
 	}
 }
 
+// unschedulablePod identifies an unschedulable pod observed by UnschedulablePodObserver.
+type unschedulablePod struct {
+	uid               types.UID
+	priorityClassName string
+}
+
 // UnschedulablePodObserver triggers a new loop if there are new unschedulable pods
 type UnschedulablePodObserver struct {
-	unschedulablePodChan <-chan any
+	unschedulablePodChan <-chan unschedulablePod
+}
+
+// drain discards pods observed before the current iteration was triggered.
+func (o *UnschedulablePodObserver) drain() {
+	for {
+		select {
+		case <-o.unschedulablePodChan:
+		default:
+			return
+		}
+	}
 }
 
 // StartPodObserver creates an informer and starts a goroutine watching for newly added
 // or updated pods. Each time a new unschedulable pod appears or a change causes a pod to become
 // unschedulable, a message is sent to the UnschedulablePodObserver's channel.
 func StartPodObserver(ctx context.Context, kubeClient kube_client.Interface) *UnschedulablePodObserver {
-	podChan := make(chan any, 1)
+	podChan := make(chan unschedulablePod, podChanSize)
 	listWatch := cache.NewListWatchFromClient(kubeClient.CoreV1().RESTClient(), podsResource, apiv1.NamespaceAll, unschedulablePodSelector)
 	informer := cache.NewSharedInformer(listWatch, &apiv1.Pod{}, time.Hour)
 	addEventHandlerFunc := func(obj any) {
 		if isRecentUnschedulablePod(obj) {
 			klog.V(5).Infof(" filterPodChanUntilClose emits signal")
+			pod := obj.(*apiv1.Pod)
 			select {
-			case podChan <- struct{}{}:
+			case podChan <- unschedulablePod{uid: pod.UID, priorityClassName: pod.Spec.PriorityClassName}:
 			default:
 			}
 		}
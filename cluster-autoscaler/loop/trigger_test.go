@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loop
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeScalingTimesGetter struct {
+	lastScaleUp time.Time
+}
+
+func (f *fakeScalingTimesGetter) LastScaleUpTime() time.Time {
+	return f.lastScaleUp
+}
+
+func (f *fakeScalingTimesGetter) LastScaleDownDeleteTime() time.Time {
+	return time.Time{}
+}
+
+func TestCollectBatch(t *testing.T) {
+	testCases := map[string]struct {
+		batching     PodBatchingConfig
+		pods         []unschedulablePod
+		interval     time.Duration
+		wantSize     int
+		wantMinDelay time.Duration
+		wantMaxDelay time.Duration
+	}{
+		"batch closes after quiet period": {
+			batching:     PodBatchingConfig{QuietPeriod: 100 * time.Millisecond, MaxDelay: 10 * time.Second},
+			pods:         []unschedulablePod{{uid: "p2"}, {uid: "p3"}, {uid: "p2"}},
+			interval:     10 * time.Millisecond,
+			wantSize:     3,
+			wantMinDelay: 100 * time.Millisecond,
+			wantMaxDelay: 5 * time.Second,
+		},
+		"max delay bounds batch": {
+			batching:     PodBatchingConfig{QuietPeriod: 100 * time.Millisecond, MaxDelay: 200 * time.Millisecond},
+			pods:         buildPods(50),
+			interval:     20 * time.Millisecond,
+			wantMinDelay: 200 * time.Millisecond,
+			wantMaxDelay: 900 * time.Millisecond,
+		},
+		"priority class override triggers immediately": {
+			batching: PodBatchingConfig{
+				QuietPeriod:           time.Second,
+				MaxDelay:              10 * time.Second,
+				PriorityClassMaxDelay: map[string]time.Duration{"critical": 0},
+			},
+			pods:         []unschedulablePod{{uid: "p2", priorityClassName: "critical"}},
+			interval:     10 * time.Millisecond,
+			wantSize:     2,
+			wantMaxDelay: 900 * time.Millisecond,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			podChan := make(chan unschedulablePod, podChanSize)
+			trigger := NewLoopTrigger(&UnschedulablePodObserver{unschedulablePodChan: podChan}, &fakeScalingTimesGetter{}, time.Minute).WithPodBatching(tc.batching)
+			stop := make(chan struct{})
+			defer close(stop)
+			go func() {
+				for _, pod := range tc.pods {
+					select {
+					case <-stop:
+						return
+					case <-time.After(tc.interval):
+						podChan <- pod
+					}
+				}
+			}()
+			size, delay := trigger.collectBatch(unschedulablePod{uid: "p1"})
+			if tc.wantSize > 0 {
+				assert.Equal(t, tc.wantSize, size)
+			}
+			assert.GreaterOrEqual(t, delay, tc.wantMinDelay)
+			assert.Less(t, delay, tc.wantMaxDelay)
+		})
+	}
+}
+
+func TestWaitDrainsObservedPods(t *testing.T) {
+	podChan := make(chan unschedulablePod, podChanSize)
+	for _, pod := range buildPods(3) {
+		podChan <- pod
+	}
+	trigger := NewLoopTrigger(&UnschedulablePodObserver{unschedulablePodChan: podChan}, &fakeScalingTimesGetter{}, time.Minute)
+	trigger.Wait(time.Now())
+	assert.Empty(t, podChan)
+}
+
+func TestWithPodBatchingDisabled(t *testing.T) {
+	trigger := NewLoopTrigger(nil, &fakeScalingTimesGetter{}, time.Minute).WithPodBatching(PodBatchingConfig{MaxDelay: time.Second})
+	assert.Nil(t, trigger.batching)
+}
+
+func buildPods(count int) []unschedulablePod {
+	var pods []unschedulablePod
+	for i := 0; i < count; i++ {
+		pods = append(pods, unschedulablePod{uid: types.UID(fmt.Sprintf("pod-%d", i))})
+	}
+	return pods
+}
@@ -18,6 +18,7 @@ package main
 
 import (
 	ctx "context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"net/http"
@@ -28,8 +29,10 @@ import (
 	"syscall"
 	"time"
 
+	"k8s.io/autoscaler/cluster-autoscaler/core/dryrun"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/actuation"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/orchestrator"
+	"k8s.io/autoscaler/cluster-autoscaler/core/sharding"
 	"k8s.io/autoscaler/cluster-autoscaler/debuggingsnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/loop"
 	"k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/besteffortatomic"
@@ -46,29 +49,39 @@ import (
 	"k8s.io/apiserver/pkg/server/mux"
 	"k8s.io/apiserver/pkg/server/routes"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	statusclient "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned"
+	nodegrouppolicyclient "k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/client/clientset/versioned"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	cloudBuilder "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/builder"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/gce/localssdsize"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/config/reload"
 	"k8s.io/autoscaler/cluster-autoscaler/core"
+	"k8s.io/autoscaler/cluster-autoscaler/core/admin"
 	"k8s.io/autoscaler/cluster-autoscaler/core/podlistprocessor"
+	"k8s.io/autoscaler/cluster-autoscaler/core/rotation"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/observers/loopstart"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodeinfosprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/provreq"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates/draincostcandidates"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates/emptycandidates"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates/previouscandidates"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	provreqorchestrator "k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/orchestrator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/draincost"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	scheduler_util "k8s.io/autoscaler/cluster-autoscaler/utils/scheduler"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
 	"k8s.io/autoscaler/cluster-autoscaler/version"
 	"k8s.io/client-go/informers"
@@ -160,6 +173,7 @@ var (
 	coresTotal                  = flag.String("cores-total", minMaxFlagString(0, config.DefaultMaxClusterCores), "Minimum and maximum number of cores in cluster, in the format <min>:<max>. Cluster autoscaler will not scale the cluster beyond these numbers.")
 	memoryTotal                 = flag.String("memory-total", minMaxFlagString(0, config.DefaultMaxClusterMemory), "Minimum and maximum number of gigabytes of memory in cluster, in the format <min>:<max>. Cluster autoscaler will not scale the cluster beyond these numbers.")
 	gpuTotal                    = multiStringFlag("gpu-total", "Minimum and maximum number of different GPUs in cluster, in the format <gpu_type>:<min>:<max>. Cluster autoscaler will not scale the cluster beyond these numbers. Can be passed multiple times. CURRENTLY THIS FLAG ONLY WORKS ON GKE.")
+	nodeGroupSetResourceLimit   = multiStringFlag("node-group-set-resource-limit", "Minimum and maximum amount of a resource in a set of node groups, in the format <name>:<node_group_id_regex>:<label_selector>:<resource>:<min>:<max> or as a JSON object {\"name\":..., \"nodeGroupIdRegex\":..., \"labelSelector\":..., \"resource\":..., \"min\":..., \"max\":...}; use the JSON form if the regex contains ':'. Resource is cpu, memory (in gigabytes) or a GPU type. Empty regex or label selector matches all node groups, max 0 means no upper bound. Can be passed multiple times.")
 	cloudProviderFlag           = flag.String("cloud-provider", cloudBuilder.DefaultCloudProvider,
 		"Cloud provider type. Available values: ["+strings.Join(cloudBuilder.AvailableCloudProviders, ",")+"]")
 	maxBulkSoftTaintCount      = flag.Int("max-bulk-soft-taint-count", 10, "Maximum number of nodes that can be tainted/untainted PreferNoSchedule at the same time. Set to 0 to turn off such tainting.")
@@ -195,11 +209,20 @@ var (
 
 	ignoreDaemonSetsUtilization = flag.Bool("ignore-daemonsets-utilization", false,
 		"Should CA ignore DaemonSet pods when calculating resource utilization for scaling down")
+	maxScaleUpNodesPerMinute = flag.Int("max-scale-up-nodes-per-minute", 0,
+		"Maximum number of nodes added to a single node group within a minute. Pods which don't fit within the limit are considered for other node groups in the same iteration. 0 means no limit.")
+	maxScaleUpGrowthPercent = flag.Int("max-scale-up-growth-percent", 0,
+		"Maximum number of nodes added to a single node group in one scale-up, as a percentage of its current size. At least one node can always be added. 0 means no limit.")
 	ignoreMirrorPodsUtilization = flag.Bool("ignore-mirror-pods-utilization", false,
 		"Should CA ignore Mirror pods when calculating resource utilization for scaling down")
 
 	writeStatusConfigMapFlag         = flag.Bool("write-status-configmap", true, "Should CA write status information to a configmap")
 	statusConfigMapName              = flag.String("status-config-map-name", "cluster-autoscaler-status", "Status configmap name")
+	writeStatusCRDFlag               = flag.Bool("write-status-crd", false, "Should CA write status information to ClusterAutoscalerStatus and NodeGroupStatus objects. Requires the CRDs to be installed.")
+	statusCRDName                    = flag.String("status-crd-name", "cluster-autoscaler", "Name of the ClusterAutoscalerStatus object CA writes. NodeGroupStatus objects are named after it.")
+	podScaleUpExplanations           = flag.Bool("pod-scale-up-explanations", false, "Should CA annotate pods that didn't trigger a scale-up with a per node group explanation, set the "+string(status.TriggeredScaleUpPodCondition)+" pod condition and include the explanation in NotTriggerScaleUp events")
+	podScaleUpExplanationInterval    = flag.Duration("pod-scale-up-explanation-interval", 5*time.Minute, "Minimum time between updates of a single pod's scale-up explanation")
+	podScaleUpExplanationQPS         = flag.Float64("pod-scale-up-explanation-qps", 5, "Maximum number of pod scale-up explanation updates per second")
 	maxInactivityTimeFlag            = flag.Duration("max-inactivity", 10*time.Minute, "Maximum time from last recorded autoscaler activity before automatic restart")
 	maxBinpackingTimeFlag            = flag.Duration("max-binpacking-time", 5*time.Minute, "Maximum time spend on binpacking for a single scale-up. If binpacking is limited by this, scale-up will continue with the already calculated scale-up options.")
 	maxFailingTimeFlag               = flag.Duration("max-failing-time", 15*time.Minute, "Maximum time from last recorded successful autoscaler run before automatic restart")
@@ -209,15 +232,19 @@ var (
 
 	unremovableNodeRecheckTimeout = flag.Duration("unremovable-node-recheck-timeout", 5*time.Minute, "The timeout before we check again a node that couldn't be removed before")
 	expendablePodsPriorityCutoff  = flag.Int("expendable-pods-priority-cutoff", -10, "Pods with priority below cutoff will be expendable. They can be killed without any consideration during scale down and they don't cause scale up. Pods with null priority (PodPriority disabled) are non expendable.")
+	simulatePreemption            = flag.Bool("simulate-preemption", false, "Should CA simulate preemption of lower priority pods on existing nodes. Pods which the scheduler will schedule by preempting other pods don't cause scale up, while preempted pods which don't fit elsewhere do.")
+	priorityOrderedScaleUp        = flag.Bool("priority-ordered-scale-up", false, "Should CA process pending pods in decreasing priority order during scale up, so that limits like max-nodes-per-scaleup are used by the most important pods first")
 	regional                      = flag.Bool("regional", false, "Cluster is regional.")
 	newPodScaleUpDelay            = flag.Duration("new-pod-scale-up-delay", 0*time.Second, "Pods less than this old will not be considered for scale-up. Can be increased for individual pods through annotation 'cluster-autoscaler.kubernetes.io/pod-scale-up-delay'.")
 
 	ignoreTaintsFlag          = multiStringFlag("ignore-taint", "Specifies a taint to ignore in node templates when considering to scale a node group (Deprecated, use startup-taints instead)")
 	startupTaintsFlag         = multiStringFlag("startup-taint", "Specifies a taint to ignore in node templates when considering to scale a node group (Equivalent to ignore-taint)")
 	statusTaintsFlag          = multiStringFlag("status-taint", "Specifies a taint to ignore in node templates when considering to scale a node group but nodes will not be treated as unready")
+	extendedResourcesFlag     = multiStringFlag("extended-resource", "Specifies a resource exposed by a device plugin, e.g. an FPGA or a SR-IOV NIC. Nodes are treated as unready until the resource expected from their node group template becomes allocatable. Can be passed multiple times.")
 	balancingIgnoreLabelsFlag = multiStringFlag("balancing-ignore-label", "Specifies a label to ignore in addition to the basic and cloud-provider set of labels when comparing if two node groups are similar")
 	balancingLabelsFlag       = multiStringFlag("balancing-label", "Specifies a label to use for comparing if two node groups are similar, rather than the built in heuristics. Setting this flag disables all other comparison logic, and cannot be combined with --balancing-ignore-label.")
 	awsUseStaticInstanceList  = flag.Bool("aws-use-static-instance-list", false, "Should CA fetch instance types in runtime or use a static list. AWS only")
+	spotPriceDiscountsFile    = flag.String("spot-price-discounts-file", "", "Path to a YAML or JSON file with spot discounts, keyed by instance type, instance family or 'default', applied to static prices used by the price expander. AWS and Azure only")
 
 	// GCE specific flags
 	concurrentGceRefreshes             = flag.Int("gce-concurrent-refreshes", 1, "Maximum number of concurrent refreshes per cloud object type.")
@@ -233,6 +260,12 @@ var (
 	emitPerNodeGroupMetrics            = flag.Bool("emit-per-nodegroup-metrics", false, "If true, emit per node group metrics.")
 	debuggingSnapshotEnabled           = flag.Bool("debugging-snapshot-enabled", false, "Whether the debugging snapshot of cluster autoscaler feature is enabled")
 	nodeInfoCacheExpireTime            = flag.Duration("node-info-cache-expire-time", 87600*time.Hour, "Node Info cache expire time for each item. Default value is 10 years.")
+	nodeInfoCacheConfigMap             = flag.String("node-info-cache-configmap", "", "Name of a ConfigMap in --namespace in which templates built from real nodes are saved, so that they survive restarts. Empty disables saving.")
+	nodeInfoCacheFile                  = flag.String("node-info-cache-file", "", "Path of a local file in which templates built from real nodes are saved, so that they survive restarts. Empty disables saving. Can't be used together with --node-info-cache-configmap.")
+	nodeInfoCacheMaxAge                = flag.Duration("node-info-cache-max-age", 7*24*time.Hour, "Maximum age of saved templates which are loaded after a restart, older templates are ignored. 0 means no limit.")
+	learnCSIAttachLimits               = flag.Bool("learn-csi-attach-limits", true, "Should CA add CSI volume attach limits learned from CSINode objects of nodes of node groups to their templates, so that scale-up simulations respect them")
+	nodeTemplateOverlaysConfigMap      = flag.String("node-template-overlays-configmap", "", "Name of a ConfigMap in --namespace with template overlays, declaring labels, annotations, taints and resources of nodes of node groups which have no nodes. Empty disables it.")
+	nodeTemplateOverlaysFile           = flag.String("node-template-overlays-file", "", "Path of a YAML or JSON file with template overlays, declaring labels, annotations, taints and resources of nodes of node groups which have no nodes. Empty disables it. Can't be used together with --node-template-overlays-configmap.")
 
 	initialNodeGroupBackoffDuration = flag.Duration("initial-node-group-backoff-duration", 5*time.Minute,
 		"initialNodeGroupBackoffDuration is the duration of first backoff after a new node failed to start.")
@@ -242,8 +275,16 @@ var (
 		"nodeGroupBackoffResetTimeout is the time after last failed scale-up when the backoff duration is reset.")
 	maxScaleDownParallelismFlag             = flag.Int("max-scale-down-parallelism", 10, "Maximum number of nodes (both empty and needing drain) that can be deleted in parallel.")
 	maxDrainParallelismFlag                 = flag.Int("max-drain-parallelism", 1, "Maximum number of nodes needing drain, that can be drained and deleted in parallel.")
+	maxDisruptedReplicasPercent             = flag.Int("max-disrupted-replicas-percent", 0, "Maximum percentage (rounded up) of replicas of a single controller that can be disrupted at once by nodes being scaled down. Set to 0 to disable.")
+	maxDisruptedReplicas                    = flag.Int("max-disrupted-replicas", 0, "Maximum number of replicas of a single controller that can be disrupted at once by nodes being scaled down. Set to 0 to disable.")
+	scaleDownDrainCostEnabled               = flag.Bool("scale-down-drain-cost-enabled", false, "Should CA prefer removing nodes whose pods are cheaper to move and wait longer before removing nodes whose pods are expensive to move. The cost of a pod can be overridden with the cluster-autoscaler.kubernetes.io/drain-cost annotation.")
+	drainCostFlag                           = multiStringFlag("drain-cost", "Cost of moving a pod of a controller kind during scale-down, in the format <controller_kind>=<cost>. Pods of other kinds cost 1, pods of StatefulSets cost 2 unless overridden. Can be passed multiple times.")
+	singletonDrainCost                      = flag.Float64("singleton-drain-cost", draincost.DefaultSingletonCost, "Minimal cost of moving a pod without a controller or with a single replica controller during scale-down.")
+	scaleDownExpensiveDrainCost             = flag.Float64("scale-down-expensive-drain-cost", 10, "Drain cost of a node at or above which scale-down-expensive-drain-unneeded-time applies.")
+	scaleDownExpensiveDrainUnneededTime     = flag.Duration("scale-down-expensive-drain-unneeded-time", time.Hour, "How long a node whose pods are expensive to move should be unneeded before it is eligible for scale down. Only used if longer than the unneeded time of its node group.")
 	recordDuplicatedEvents                  = flag.Bool("record-duplicated-events", false, "enable duplication of similar events within a 5 minute window.")
 	maxNodesPerScaleUp                      = flag.Int("max-nodes-per-scaleup", 1000, "Max nodes added in a single scale-up. This is intended strictly for optimizing CA algorithm latency and not a tool to rate-limit scale-up throughput.")
+	maxScaleUpPlanSteps                     = flag.Int("max-scale-up-plan-steps", 1, "Max number of node group options combined in a single scale-up. After the best option is selected, further options are selected for pods it doesn't help, within max-nodes-per-scaleup and resource limits, and all of them are executed together. Values below 2 disable multi-step scale-ups.")
 	maxNodeGroupBinpackingDuration          = flag.Duration("max-nodegroup-binpacking-duration", 10*time.Second, "Maximum time that will be spent in binpacking simulation for each NodeGroup.")
 	skipNodesWithSystemPods                 = flag.Bool("skip-nodes-with-system-pods", true, "If true cluster autoscaler will never delete nodes with pods from kube-system (except for DaemonSet or mirror pods)")
 	skipNodesWithLocalStorage               = flag.Bool("skip-nodes-with-local-storage", true, "If true cluster autoscaler will never delete nodes with pods with local storage, e.g. EmptyDir or HostPath")
@@ -263,8 +304,37 @@ var (
 			"--max-graceful-termination-sec flag should not be set when this flag is set. Not setting this flag will use unordered evictor by default."+
 			"Priority evictor reuses the concepts of drain logic in kubelet(https://github.com/kubernetes/enhancements/tree/master/keps/sig-node/2712-pod-priority-based-graceful-node-shutdown#migration-from-the-node-graceful-shutdown-feature)."+
 			"Eg. flag usage:  '10000:20,1000:100,0:60'")
-	provisioningRequestsEnabled = flag.Bool("enable-provisioning-requests", false, "Whether the clusterautoscaler will be handling the ProvisioningRequest CRs.")
-	frequentLoopsEnabled        = flag.Bool("frequent-loops-enabled", false, "Whether clusterautoscaler triggers new iterations more frequently when it's needed")
+	provisioningRequestsEnabled   = flag.Bool("enable-provisioning-requests", false, "Whether the clusterautoscaler will be handling the ProvisioningRequest CRs.")
+	nodeGroupPoliciesEnabled      = flag.Bool("enable-node-group-policies", false, "Whether per node group options and min/max sizes are overridden by NodeGroupPolicy CRs. Requires the CRD to be installed.")
+	optionsOverridesFile          = flag.String("options-overrides-file", "", "Path to a YAML or JSON file overriding values of flags which can be changed without restart, keyed by flag name. The file is checked before every iteration.")
+	optionsOverridesConfigMap     = flag.String("options-overrides-configmap", "", "Name of a ConfigMap in --namespace overriding values of flags which can be changed without restart, keyed by flag name. Can't be combined with --options-overrides-file.")
+	writeStateConfigMapFlag       = flag.Bool("write-state-configmap", false, "Should CA checkpoint unneeded node timers, backoff and node deletions to a configmap and restore them after a restart or a leader change")
+	stateConfigMapName            = flag.String("state-config-map-name", "cluster-autoscaler-state", "State checkpoint configmap name")
+	stateCheckpointMaxAge         = flag.Duration("state-checkpoint-max-age", 15*time.Minute, "Maximum age of a state checkpoint which is restored on startup, older checkpoints are ignored")
+	shardCount                    = flag.Int("shard-count", 1, "Number of active Cluster Autoscaler instances node groups are split between by hashing node group ids. Each instance needs a unique --shard-index.")
+	shardIndex                    = flag.Int("shard-index", 0, "Index of the shard of this instance, in [0, --shard-count) unless --shard-node-group-selector is used")
+	shardName                     = flag.String("shard-name", "", "Unique name of the shard of this instance, used in pod claims and appended to the leader election lease name. Required with --shard-node-group-selector, defaults to --shard-index otherwise.")
+	shardNodeGroupSelector        = flag.String("shard-node-group-selector", "", "Label selector choosing node groups owned by this instance by labels of their template nodes, instead of hashing node group ids")
+	shardPodClaimTTL              = flag.Duration("shard-pod-claim-ttl", 15*time.Minute, "How long a pod that triggered a scale-up in one shard is ignored by other shards")
+	maxNodeAge                    = flag.Duration("max-node-age", 0, "Nodes older than this are replaced: a replacement node is added first and the old node is drained once it is ready. 0 disables rotation of old nodes.")
+	rotateOutdatedNodes           = flag.Bool("rotate-outdated-nodes", false, "Should CA replace nodes created from a previous version of their node group template, for cloud providers able to detect it")
+	nodeRotationWindows           = flag.String("node-rotation-maintenance-windows", "", "Comma separated weekly windows in UTC in which node rotations may start, e.g. 'Mon-Fri 22:00-06:00,Sat 00:00-24:00'. Empty means any time.")
+	maxNodeRotationsInParallel    = flag.Int("max-node-rotations-in-parallel", 1, "Maximum number of nodes rotated at the same time")
+	dryRun                        = flag.Bool("dry-run", false, "Compute scale-up and scale-down decisions without executing them: node groups aren't resized, created or deleted, and nodes aren't tainted or drained. Intended actions are logged and counted in the dry_run_actions_total metric.")
+	adminAPITokenFile             = flag.String("admin-api-token-file", "", "Path to a file with a bearer token enabling the admin API under /admin/ for pausing scale-down, forcing scale-ups, protecting nodes and clearing backoff at runtime. Pauses and protections are kept in memory only and are lost on restart or leader change. Empty disables the admin API.")
+	adminAPIAddress               = flag.String("admin-api-address", "", "The address to expose the admin API on. Empty serves it on --address next to metrics, over plain HTTP.")
+	adminAPITLSCertFile           = flag.String("admin-api-tls-cert-file", "", "Path to a TLS certificate for the admin API listener set by --admin-api-address. Requires --admin-api-tls-key-file.")
+	adminAPITLSKeyFile            = flag.String("admin-api-tls-key-file", "", "Path to the private key of --admin-api-tls-cert-file.")
+	frequentLoopsEnabled          = flag.Bool("frequent-loops-enabled", false, "Whether clusterautoscaler triggers new iterations more frequently when it's needed")
+	pendingPodBatchingQuietPeriod = flag.Duration("pending-pod-batching-quiet-period", 0, "With frequent-loops-enabled, an iteration triggered by unschedulable pods starts once no new unschedulable pod was observed for this long. 0 triggers iterations immediately.")
+	pendingPodBatchingMaxDelay    = flag.Duration("pending-pod-batching-max-delay", 10*time.Second, "Maximum time an iteration triggered by unschedulable pods is delayed to batch them, counted from the first pod")
+	pendingPodBatchingClassDelays = multiStringFlag("pending-pod-batching-priority-class-max-delay", "Overrides pending-pod-batching-max-delay for pods of a priority class, in the format <priority_class>=<duration>. 0 triggers iterations immediately. Can be passed multiple times.")
+	auditLogPath                  = flag.String("audit-log-path", "", "Path to a file to which a structured (JSON lines) record of scale-up and scale-down decisions is appended in every iteration. Use '-' for stdout. Empty string disables the audit log.")
+	tracingExporter               = flag.String("tracing-exporter", tracing.ExporterNone, "OpenTelemetry exporter for traces of the autoscaling loop. One of: "+strings.Join([]string{tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterFile}, ", "))
+	tracingOTLPEndpoint           = flag.String("tracing-otlp-endpoint", "", "host:port of the OTLP gRPC collector traces are sent to. If empty, the standard OTEL_EXPORTER_OTLP_* environment variables are used.")
+	tracingOTLPInsecure           = flag.Bool("tracing-otlp-insecure", false, "Disables TLS for the connection to the OTLP collector.")
+	tracingFilePath               = flag.String("tracing-file-path", "", "Path to a file to which spans are appended as JSON lines when --tracing-exporter=file.")
+	tracingSamplingRatio          = flag.Float64("tracing-sampling-ratio", 1.0, "Fraction of autoscaling loop iterations which are traced.")
 )
 
 func isFlagPassed(name string) bool {
@@ -294,6 +364,14 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 	if err != nil {
 		klog.Fatalf("Failed to parse flags: %v", err)
 	}
+	parsedNodeGroupSetResourceLimits, err := parseNodeGroupSetResourceLimits(*nodeGroupSetResourceLimit)
+	if err != nil {
+		klog.Fatalf("Failed to parse flags: %v", err)
+	}
+	parsedDrainCosts, err := parseDrainCosts(*drainCostFlag)
+	if err != nil {
+		klog.Fatalf("Failed to parse flags: %v", err)
+	}
 	if *maxDrainParallelismFlag > 1 && !*parallelDrain {
 		klog.Fatalf("Invalid configuration, could not use --max-drain-parallelism > 1 if --parallel-drain is false")
 	}
@@ -345,6 +423,8 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 			ScaleDownUnreadyTime:             *scaleDownUnreadyTime,
 			IgnoreDaemonSetsUtilization:      *ignoreDaemonSetsUtilization,
 			MaxNodeProvisionTime:             *maxNodeProvisionTime,
+			MaxScaleUpNodesPerMinute:         *maxScaleUpNodesPerMinute,
+			MaxScaleUpGrowthPercent:          *maxScaleUpGrowthPercent,
 		},
 		CloudConfig:                      *cloudConfig,
 		CloudProviderName:                *cloudProviderFlag,
@@ -369,6 +449,7 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		MaxMemoryTotal:                   maxMemoryTotal,
 		MinMemoryTotal:                   minMemoryTotal,
 		GpuTotal:                         parsedGpuTotal,
+		NodeGroupSetResourceLimits:       parsedNodeGroupSetResourceLimits,
 		NodeGroups:                       *nodeGroupsFlag,
 		EnforceNodeGroupMinSize:          *enforceNodeGroupMinSize,
 		ScaleDownDelayAfterAdd:           *scaleDownDelayAfterAdd,
@@ -384,6 +465,11 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		SchedulerConfig:                  parsedSchedConfig,
 		WriteStatusConfigMap:             *writeStatusConfigMapFlag,
 		StatusConfigMapName:              *statusConfigMapName,
+		WriteStatusCRD:                   *writeStatusCRDFlag,
+		StatusCRDName:                    *statusCRDName,
+		PodScaleUpExplanations:           *podScaleUpExplanations,
+		PodScaleUpExplanationInterval:    *podScaleUpExplanationInterval,
+		PodScaleUpExplanationQPS:         float32(*podScaleUpExplanationQPS),
 		BalanceSimilarNodeGroups:         *balanceSimilarNodeGroupsFlag,
 		ConfigNamespace:                  *namespace,
 		ClusterName:                      *clusterName,
@@ -391,10 +477,13 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		MaxAutoprovisionedNodeGroupCount: *maxAutoprovisionedNodeGroupCount,
 		UnremovableNodeRecheckTimeout:    *unremovableNodeRecheckTimeout,
 		ExpendablePodsPriorityCutoff:     *expendablePodsPriorityCutoff,
+		SimulatePreemption:               *simulatePreemption,
+		PriorityOrderedScaleUp:           *priorityOrderedScaleUp,
 		Regional:                         *regional,
 		NewPodScaleUpDelay:               *newPodScaleUpDelay,
 		StartupTaints:                    append(*ignoreTaintsFlag, *startupTaintsFlag...),
 		StatusTaints:                     *statusTaintsFlag,
+		ExtendedResources:                *extendedResourcesFlag,
 		BalancingExtraIgnoredLabels:      *balancingIgnoreLabelsFlag,
 		BalancingLabels:                  *balancingLabelsFlag,
 		KubeClientOpts: config.KubeClientOptions{
@@ -404,6 +493,7 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		},
 		NodeDeletionDelayTimeout: *nodeDeletionDelayTimeout,
 		AWSUseStaticInstanceList: *awsUseStaticInstanceList,
+		SpotPriceDiscountsFile:   *spotPriceDiscountsFile,
 		GCEOptions: config.GCEOptions{
 			ConcurrentRefreshes:            *concurrentGceRefreshes,
 			MigInstancesMinRefreshWaitTime: *gceMigInstancesMinRefreshWaitTime,
@@ -420,8 +510,11 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		NodeGroupBackoffResetTimeout:       *nodeGroupBackoffResetTimeout,
 		MaxScaleDownParallelism:            *maxScaleDownParallelismFlag,
 		MaxDrainParallelism:                *maxDrainParallelismFlag,
+		MaxDisruptedReplicasPercent:        *maxDisruptedReplicasPercent,
+		MaxDisruptedReplicas:               *maxDisruptedReplicas,
 		RecordDuplicatedEvents:             *recordDuplicatedEvents,
 		MaxNodesPerScaleUp:                 *maxNodesPerScaleUp,
+		MaxScaleUpPlanSteps:                *maxScaleUpPlanSteps,
 		MaxNodeGroupBinpackingDuration:     *maxNodeGroupBinpackingDuration,
 		MaxBinpackingTime:                  *maxBinpackingTimeFlag,
 		NodeDeletionBatcherInterval:        *nodeDeletionBatcherInterval,
@@ -440,10 +533,33 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		DynamicNodeDeleteDelayAfterTaintEnabled: *dynamicNodeDeleteDelayAfterTaintEnabled,
 		BypassedSchedulers:                      scheduler_util.GetBypassedSchedulersMap(*bypassedSchedulers),
 		ProvisioningRequestEnabled:              *provisioningRequestsEnabled,
+		NodeGroupPoliciesEnabled:                *nodeGroupPoliciesEnabled,
+		OptionsOverridesFile:                    *optionsOverridesFile,
+		OptionsOverridesConfigMapName:           *optionsOverridesConfigMap,
+		WriteStateConfigMap:                     *writeStateConfigMapFlag,
+		StateConfigMapName:                      *stateConfigMapName,
+		StateCheckpointMaxAge:                   *stateCheckpointMaxAge,
+		ShardCount:                              *shardCount,
+		ShardIndex:                              *shardIndex,
+		ShardName:                               *shardName,
+		ShardNodeGroupSelector:                  *shardNodeGroupSelector,
+		ShardPodClaimTTL:                        *shardPodClaimTTL,
+		MaxNodeAge:                              *maxNodeAge,
+		RotateOutdatedNodes:                     *rotateOutdatedNodes,
+		NodeRotationMaintenanceWindows:          *nodeRotationWindows,
+		MaxNodeRotationsInParallel:              *maxNodeRotationsInParallel,
+		DryRun:                                  *dryRun,
+		DrainCost: config.DrainCostOptions{
+			Enabled:               *scaleDownDrainCostEnabled,
+			ControllerKindCosts:   parsedDrainCosts,
+			SingletonCost:         *singletonDrainCost,
+			ExpensiveThreshold:    *scaleDownExpensiveDrainCost,
+			ExpensiveUnneededTime: *scaleDownExpensiveDrainUnneededTime,
+		},
 	}
 }
 
-func registerSignalHandlers(autoscaler core.Autoscaler) {
+func registerSignalHandlers(autoscaler core.Autoscaler, shutdownTracing func(ctx.Context) error) {
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, os.Interrupt, os.Kill, syscall.SIGTERM, syscall.SIGQUIT)
 	klog.V(1).Info("Registered cleanup signal handler")
@@ -452,15 +568,32 @@ func registerSignalHandlers(autoscaler core.Autoscaler) {
 		<-sigs
 		klog.V(1).Info("Received signal, attempting cleanup")
 		autoscaler.ExitCleanUp()
+		if err := shutdownTracing(ctx.Background()); err != nil {
+			klog.Errorf("Failed to flush traces: %v", err)
+		}
 		klog.V(1).Info("Cleaned up, exiting...")
 		klog.Flush()
 		os.Exit(0)
 	}()
 }
 
-func buildAutoscaler(debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter) (core.Autoscaler, error) {
+func buildAutoscaler(debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter, adminController *admin.Controller) (core.Autoscaler, error) {
 	// Create basic config from flags.
 	autoscalingOptions := createAutoscalingOptions()
+	if err := dryrun.CheckOptions(autoscalingOptions); err != nil {
+		return nil, err
+	}
+	if autoscalingOptions.DryRun {
+		dryrun.DisableWrites(&autoscalingOptions)
+	}
+	shard, err := sharding.NewShard(autoscalingOptions)
+	if err != nil {
+		return nil, err
+	}
+	if shard != nil {
+		// Shards scale different node groups, so each of them reports its own status and state.
+		shard.RenameObjects(&autoscalingOptions)
+	}
 
 	autoscalingOptions.KubeClientOpts.KubeClientBurst = int(*kubeClientBurst)
 	autoscalingOptions.KubeClientOpts.KubeClientQPS = float32(*kubeClientQPS)
@@ -492,10 +625,28 @@ func buildAutoscaler(debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter
 		DeleteOptions:        deleteOptions,
 		DrainabilityRules:    drainabilityRules,
 		ScaleUpOrchestrator:  orchestrator.New(),
+		AdminController:      adminController,
 	}
 
 	opts.Processors = ca_processors.DefaultProcessors(autoscalingOptions)
-	opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nodeInfoCacheExpireTime, *forceDaemonSets)
+	mixedTemplateNodeInfoProvider := nodeinfosprovider.NewMixedTemplateNodeInfoProvider(nodeInfoCacheExpireTime, *forceDaemonSets)
+	if *nodeInfoCacheConfigMap != "" && *nodeInfoCacheFile != "" {
+		return nil, fmt.Errorf("--node-info-cache-configmap and --node-info-cache-file can't be used together")
+	}
+	if autoscalingOptions.DryRun && (*nodeInfoCacheConfigMap != "" || *nodeInfoCacheFile != "") {
+		klog.Info("Dry-run mode, not persisting learned templates")
+	} else if *nodeInfoCacheConfigMap != "" {
+		name := *nodeInfoCacheConfigMap
+		if shard != nil {
+			// Shards learn templates of different node groups.
+			name = shard.ObjectName(name)
+		}
+		store := nodeinfosprovider.NewConfigMapTemplateStore(kubeClient, autoscalingOptions.ConfigNamespace, name)
+		mixedTemplateNodeInfoProvider.WithTemplateStore(store, *nodeInfoCacheMaxAge)
+	} else if *nodeInfoCacheFile != "" {
+		mixedTemplateNodeInfoProvider.WithTemplateStore(nodeinfosprovider.NewFileTemplateStore(*nodeInfoCacheFile), *nodeInfoCacheMaxAge)
+	}
+	opts.Processors.TemplateNodeInfoProvider = mixedTemplateNodeInfoProvider
 	podListProcessor := podlistprocessor.NewDefaultPodListProcessor(opts.PredicateChecker, scheduling.ScheduleAnywhere)
 
 	if autoscalingOptions.ProvisioningRequestEnabled {
@@ -525,19 +676,50 @@ func buildAutoscaler(debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter
 		podListProcessor.AddProcessor(injector)
 		podListProcessor.AddProcessor(provreqProcesor)
 	}
+	if _, err := rotation.ParseMaintenanceWindows(autoscalingOptions.NodeRotationMaintenanceWindows); err != nil {
+		return nil, err
+	}
+
+	if shard != nil {
+		opts.Shard = shard
+		podListProcessor.AddProcessor(sharding.NewPodClaimFilter(shard, autoscalingOptions.ShardPodClaimTTL))
+	}
 	opts.Processors.PodListProcessor = podListProcessor
+
+	if autoscalingOptions.NodeGroupPoliciesEnabled {
+		restConfig := kube_util.GetKubeConfig(autoscalingOptions.KubeClientOpts)
+		client, err := nodegrouppolicyclient.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create NodeGroupPolicy client: %v", err)
+		}
+		lister, err := nodegroupconfig.NewNodeGroupPolicyLister(client, make(chan struct{}))
+		if err != nil {
+			return nil, err
+		}
+		policyProcessor := nodegroupconfig.NewNodeGroupPolicyProcessor(opts.Processors.NodeGroupConfigProcessor, client, lister)
+		if autoscalingOptions.DryRun {
+			policyProcessor.WithoutStatusUpdates()
+		}
+		opts.Processors.NodeGroupConfigProcessor = policyProcessor
+	}
 	scaleDownCandidatesComparers := []scaledowncandidates.CandidatesComparer{}
+	if autoscalingOptions.ParallelDrain {
+		scaleDownCandidatesComparers = append(scaleDownCandidatesComparers,
+			emptycandidates.NewEmptySortingProcessor(emptycandidates.NewNodeInfoGetter(opts.ClusterSnapshot), deleteOptions, drainabilityRules))
+	}
+	if autoscalingOptions.DrainCost.Enabled {
+		drainCostCalculator := draincost.NewCalculator(kube_util.NewListerRegistryWithDefaultListers(informerFactory), autoscalingOptions.DrainCost.ControllerKindCosts, autoscalingOptions.DrainCost.SingletonCost)
+		scaleDownCandidatesComparers = append(scaleDownCandidatesComparers,
+			draincostcandidates.NewDrainCostSortingProcessor(emptycandidates.NewNodeInfoGetter(opts.ClusterSnapshot), drainCostCalculator))
+	}
 	if autoscalingOptions.ParallelDrain {
 		sdCandidatesSorting := previouscandidates.NewPreviousCandidates()
-		scaleDownCandidatesComparers = []scaledowncandidates.CandidatesComparer{
-			emptycandidates.NewEmptySortingProcessor(emptycandidates.NewNodeInfoGetter(opts.ClusterSnapshot), deleteOptions, drainabilityRules),
-			sdCandidatesSorting,
-		}
+		scaleDownCandidatesComparers = append(scaleDownCandidatesComparers, sdCandidatesSorting)
 		opts.Processors.ScaleDownCandidatesNotifier.Register(sdCandidatesSorting)
 	}
 
 	cp := scaledowncandidates.NewCombinedScaleDownCandidatesProcessor()
-	cp.Register(scaledowncandidates.NewScaleDownCandidatesSortingProcessor(scaleDownCandidatesComparers))
+	cp.Register(scaledowncandidates.NewScaleDownCandidatesSortingProcessor(opts.Processors.NodeGroupConfigProcessor, scaleDownCandidatesComparers))
 
 	if autoscalingOptions.ScaleDownDelayTypeLocal {
 		sdp := scaledowncandidates.NewScaleDownCandidatesDelayProcessor()
@@ -547,25 +729,74 @@ func buildAutoscaler(debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter
 	}
 	opts.Processors.ScaleDownNodeProcessor = cp
 
-	var nodeInfoComparator nodegroupset.NodeInfoComparator
-	if len(autoscalingOptions.BalancingLabels) > 0 {
-		nodeInfoComparator = nodegroupset.CreateLabelNodeInfoComparator(autoscalingOptions.BalancingLabels)
-	} else {
-		nodeInfoComparatorBuilder := nodegroupset.CreateGenericNodeInfoComparator
-		if autoscalingOptions.CloudProviderName == cloudprovider.AzureProviderName {
-			nodeInfoComparatorBuilder = nodegroupset.CreateAzureNodeInfoComparator
-		} else if autoscalingOptions.CloudProviderName == cloudprovider.AwsProviderName {
-			nodeInfoComparatorBuilder = nodegroupset.CreateAwsNodeInfoComparator
-			opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewAsgTagResourceNodeInfoProvider(nodeInfoCacheExpireTime, *forceDaemonSets)
+	if len(autoscalingOptions.BalancingLabels) == 0 {
+		if autoscalingOptions.CloudProviderName == cloudprovider.AwsProviderName {
+			opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewCustomAsgTagResourceNodeInfoProvider(mixedTemplateNodeInfoProvider)
 		} else if autoscalingOptions.CloudProviderName == cloudprovider.GceProviderName {
-			nodeInfoComparatorBuilder = nodegroupset.CreateGceNodeInfoComparator
-			opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewAnnotationNodeInfoProvider(nodeInfoCacheExpireTime, *forceDaemonSets)
+			opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewCustomAnnotationNodeInfoProvider(mixedTemplateNodeInfoProvider)
 		}
-		nodeInfoComparator = nodeInfoComparatorBuilder(autoscalingOptions.BalancingExtraIgnoredLabels, autoscalingOptions.NodeGroupSetRatios)
+	}
+	if *learnCSIAttachLimits {
+		csiNodeLister := informerFactory.Storage().V1().CSINodes().Lister()
+		opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewCSILimitsNodeInfoProvider(opts.Processors.TemplateNodeInfoProvider, csiNodeLister)
+	}
+	if *nodeTemplateOverlaysConfigMap != "" && *nodeTemplateOverlaysFile != "" {
+		return nil, fmt.Errorf("--node-template-overlays-configmap and --node-template-overlays-file can't be used together")
+	}
+	if *nodeTemplateOverlaysConfigMap != "" {
+		source := nodeinfosprovider.NewConfigMapTemplateOverlaySource(kubeClient, autoscalingOptions.ConfigNamespace, *nodeTemplateOverlaysConfigMap, make(chan struct{}))
+		opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewTemplateOverlayNodeInfoProvider(opts.Processors.TemplateNodeInfoProvider, source)
+	} else if *nodeTemplateOverlaysFile != "" {
+		source := nodeinfosprovider.NewFileTemplateOverlaySource(*nodeTemplateOverlaysFile)
+		opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewTemplateOverlayNodeInfoProvider(opts.Processors.TemplateNodeInfoProvider, source)
 	}
 
 	opts.Processors.NodeGroupSetProcessor = &nodegroupset.BalancingNodeGroupSetProcessor{
-		Comparator: nodeInfoComparator,
+		Comparator: nodegroupset.CreateNodeInfoComparatorFromOptions(autoscalingOptions),
+		VolumeListers: &nodegroupset.VolumeListers{
+			PersistentVolumeClaimLister: informerFactory.Core().V1().PersistentVolumeClaims().Lister(),
+			PersistentVolumeLister:      informerFactory.Core().V1().PersistentVolumes().Lister(),
+		},
+	}
+
+	if *auditLogPath != "" {
+		auditLog, err := status.OpenAuditLog(*auditLogPath)
+		if err != nil {
+			return nil, err
+		}
+		opts.Processors.ScaleUpStatusProcessor = status.NewAuditLogScaleUpStatusProcessor(auditLog, opts.Processors.ScaleUpStatusProcessor)
+		opts.Processors.ScaleDownStatusProcessor = status.NewAuditLogScaleDownStatusProcessor(auditLog, opts.Processors.ScaleDownStatusProcessor)
+	}
+
+	if autoscalingOptions.WriteStatusCRD {
+		restConfig := kube_util.GetKubeConfig(autoscalingOptions.KubeClientOpts)
+		client, err := statusclient.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create status CRD client: %v", err)
+		}
+		statusWriter := status.NewStatusCRDWriter(client, autoscalingOptions.StatusCRDName)
+		opts.Processors.ScaleUpStatusProcessor = status.NewStatusCRDScaleUpStatusProcessor(statusWriter, opts.Processors.ScaleUpStatusProcessor)
+		opts.Processors.ScaleDownStatusProcessor = status.NewStatusCRDScaleDownStatusProcessor(statusWriter, opts.Processors.ScaleDownStatusProcessor)
+		opts.Processors.AutoscalingStatusProcessor = status.NewStatusCRDAutoscalingStatusProcessor(statusWriter, opts.Processors.AutoscalingStatusProcessor)
+	}
+
+	if autoscalingOptions.PodScaleUpExplanations {
+		opts.Processors.ScaleUpStatusProcessor = status.NewNoScaleUpExplanationProcessor(autoscalingOptions.PodScaleUpExplanationInterval, autoscalingOptions.PodScaleUpExplanationQPS, opts.Processors.ScaleUpStatusProcessor)
+	}
+
+	// Pods are only claimed by the shard actively scaling the cluster.
+	if shard != nil && !autoscalingOptions.DryRun {
+		opts.Processors.ScaleUpStatusProcessor = sharding.NewPodClaimingScaleUpStatusProcessor(shard, opts.Processors.ScaleUpStatusProcessor)
+	}
+
+	if autoscalingOptions.OptionsOverridesFile != "" && autoscalingOptions.OptionsOverridesConfigMapName != "" {
+		return nil, fmt.Errorf("--options-overrides-file and --options-overrides-configmap can't be used together")
+	}
+	if autoscalingOptions.OptionsOverridesFile != "" {
+		opts.OptionsReloader = reload.NewReloader(reload.NewFileSource(autoscalingOptions.OptionsOverridesFile), autoscalingOptions)
+	} else if autoscalingOptions.OptionsOverridesConfigMapName != "" {
+		source := reload.NewConfigMapSource(kubeClient, autoscalingOptions.ConfigNamespace, autoscalingOptions.OptionsOverridesConfigMapName, make(chan struct{}))
+		opts.OptionsReloader = reload.NewReloader(source, autoscalingOptions)
 	}
 
 	// These metrics should be published only once.
@@ -587,16 +818,27 @@ func buildAutoscaler(debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter
 	return autoscaler, nil
 }
 
-func run(healthCheck *metrics.HealthCheck, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter) {
+func run(healthCheck *metrics.HealthCheck, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter, adminController *admin.Controller) {
 	metrics.RegisterAll(*emitPerNodeGroupMetrics)
 
-	autoscaler, err := buildAutoscaler(debuggingSnapshotter)
+	shutdownTracing, err := tracing.Setup(tracing.Options{
+		Exporter:      *tracingExporter,
+		OTLPEndpoint:  *tracingOTLPEndpoint,
+		OTLPInsecure:  *tracingOTLPInsecure,
+		FilePath:      *tracingFilePath,
+		SamplingRatio: *tracingSamplingRatio,
+	})
+	if err != nil {
+		klog.Fatalf("Failed to set up tracing: %v", err)
+	}
+
+	autoscaler, err := buildAutoscaler(debuggingSnapshotter, adminController)
 	if err != nil {
 		klog.Fatalf("Failed to create autoscaler: %v", err)
 	}
 
 	// Register signal handlers for graceful shutdown.
-	registerSignalHandlers(autoscaler)
+	registerSignalHandlers(autoscaler, shutdownTracing)
 
 	// Start updating health check endpoint.
 	healthCheck.StartMonitoring()
@@ -611,7 +853,15 @@ func run(healthCheck *metrics.HealthCheck, debuggingSnapshotter debuggingsnapsho
 	defer cancel()
 	if *frequentLoopsEnabled {
 		podObserver := loop.StartPodObserver(context, kube_util.CreateKubeClient(createAutoscalingOptions().KubeClientOpts))
-		trigger := loop.NewLoopTrigger(podObserver, autoscaler, *scanInterval)
+		priorityClassMaxDelay, err := parsePriorityClassMaxDelays(*pendingPodBatchingClassDelays)
+		if err != nil {
+			klog.Fatalf("Failed to parse flags: %v", err)
+		}
+		trigger := loop.NewLoopTrigger(podObserver, autoscaler, *scanInterval).WithPodBatching(loop.PodBatchingConfig{
+			QuietPeriod:           *pendingPodBatchingQuietPeriod,
+			MaxDelay:              *pendingPodBatchingMaxDelay,
+			PriorityClassMaxDelay: priorityClassMaxDelay,
+		})
 		lastRun := time.Now()
 		for {
 			trigger.Wait(lastRun)
@@ -649,12 +899,53 @@ func main() {
 		klog.Fatalf("Failed to validate and apply logging configuration: %v", err)
 	}
 
+	shard, err := sharding.NewShard(createAutoscalingOptions())
+	if err != nil {
+		klog.Fatalf("Invalid sharding configuration: %v", err)
+	}
+	if shard != nil {
+		leaderElection.ResourceName = shard.ObjectName(leaderElection.ResourceName)
+	}
+
 	healthCheck := metrics.NewHealthCheck(*maxInactivityTimeFlag, *maxFailingTimeFlag)
 
 	klog.V(1).Infof("Cluster Autoscaler %s", version.ClusterAutoscalerVersion)
 
 	debuggingSnapshotter := debuggingsnapshot.NewDebuggingSnapshotter(*debuggingSnapshotEnabled)
 
+	var adminController *admin.Controller
+	var adminAPIToken string
+	if *adminAPITokenFile != "" {
+		token, err := os.ReadFile(*adminAPITokenFile)
+		if err != nil {
+			klog.Fatalf("Failed to read admin API token: %v", err)
+		}
+		adminAPIToken = strings.TrimSpace(string(token))
+		if adminAPIToken == "" {
+			klog.Fatalf("Admin API token file %s is empty", *adminAPITokenFile)
+		}
+		adminController = admin.NewController()
+	}
+	if (*adminAPITLSCertFile == "") != (*adminAPITLSKeyFile == "") {
+		klog.Fatalf("--admin-api-tls-cert-file and --admin-api-tls-key-file must be set together")
+	}
+	if *adminAPITLSCertFile != "" && *adminAPIAddress == "" {
+		klog.Fatalf("--admin-api-tls-cert-file requires --admin-api-address")
+	}
+	if adminController != nil && *adminAPIAddress != "" {
+		go func() {
+			adminMux := http.NewServeMux()
+			adminMux.Handle(admin.PathPrefix, admin.NewHandler(adminController, adminAPIToken))
+			var err error
+			if *adminAPITLSCertFile != "" {
+				err = http.ListenAndServeTLS(*adminAPIAddress, *adminAPITLSCertFile, *adminAPITLSKeyFile, adminMux)
+			} else {
+				err = http.ListenAndServe(*adminAPIAddress, adminMux)
+			}
+			klog.Fatalf("Failed to start admin API: %v", err)
+		}()
+	}
+
 	go func() {
 		pathRecorderMux := mux.NewPathRecorderMux("cluster-autoscaler")
 		defaultMetricsHandler := legacyregistry.Handler().ServeHTTP
@@ -665,6 +956,9 @@ func main() {
 			pathRecorderMux.HandleFunc("/snapshotz", debuggingSnapshotter.ResponseHandler)
 		}
 		pathRecorderMux.HandleFunc("/health-check", healthCheck.ServeHTTP)
+		if adminController != nil && *adminAPIAddress == "" {
+			pathRecorderMux.HandlePrefix(admin.PathPrefix, admin.NewHandler(adminController, adminAPIToken))
+		}
 		if *enableProfiling {
 			routes.Profiling{}.Install(pathRecorderMux)
 		}
@@ -673,7 +967,7 @@ func main() {
 	}()
 
 	if !leaderElection.LeaderElect {
-		run(healthCheck, debuggingSnapshotter)
+		run(healthCheck, debuggingSnapshotter, adminController)
 	} else {
 		id, err := os.Hostname()
 		if err != nil {
@@ -713,7 +1007,7 @@ func main() {
 				OnStartedLeading: func(_ ctx.Context) {
 					// Since we are committing a suicide after losing
 					// mastership, we can safely ignore the argument.
-					run(healthCheck, debuggingSnapshotter)
+					run(healthCheck, debuggingSnapshotter, adminController)
 				},
 				OnStoppedLeading: func() {
 					klog.Fatalf("lost master")
@@ -790,6 +1084,118 @@ func parseMultipleGpuLimits(flags MultiStringFlag) ([]config.GpuLimits, error) {
 	return parsedFlags, nil
 }
 
+func parseNodeGroupSetResourceLimits(flags MultiStringFlag) ([]config.NodeGroupSetResourceLimits, error) {
+	parsedFlags := make([]config.NodeGroupSetResourceLimits, 0, len(flags))
+	names := make(map[string]bool)
+	for _, flag := range flags {
+		parsedFlag, err := parseSingleNodeGroupSetResourceLimit(flag)
+		if err != nil {
+			return nil, err
+		}
+		key := parsedFlag.Name + "/" + parsedFlag.Resource
+		if names[key] {
+			return nil, fmt.Errorf("duplicated %s limit of node group set %s", parsedFlag.Resource, parsedFlag.Name)
+		}
+		names[key] = true
+		parsedFlags = append(parsedFlags, parsedFlag)
+	}
+	return parsedFlags, nil
+}
+
+// nodeGroupSetResourceLimitSpec is the JSON form of a node group set resource limit, which allows
+// node group id regexes containing ':', e.g. matching URLs.
+type nodeGroupSetResourceLimitSpec struct {
+	Name             string `json:"name"`
+	NodeGroupIdRegex string `json:"nodeGroupIdRegex"`
+	LabelSelector    string `json:"labelSelector"`
+	Resource         string `json:"resource"`
+	Min              int64  `json:"min"`
+	Max              int64  `json:"max"`
+}
+
+func parseSingleNodeGroupSetResourceLimit(limits string) (config.NodeGroupSetResourceLimits, error) {
+	var parsedLimits config.NodeGroupSetResourceLimits
+	if strings.HasPrefix(strings.TrimSpace(limits), "{") {
+		var spec nodeGroupSetResourceLimitSpec
+		decoder := json.NewDecoder(strings.NewReader(limits))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&spec); err != nil {
+			return config.NodeGroupSetResourceLimits{}, fmt.Errorf("incorrect node group set resource limit specification %v: %v", limits, err)
+		}
+		parsedLimits = config.NodeGroupSetResourceLimits(spec)
+	} else {
+		parts := strings.Split(limits, ":")
+		if len(parts) != 6 {
+			return config.NodeGroupSetResourceLimits{}, fmt.Errorf("incorrect node group set resource limit specification: %v", limits)
+		}
+		minVal, err := strconv.ParseInt(parts[4], 10, 64)
+		if err != nil {
+			return config.NodeGroupSetResourceLimits{}, fmt.Errorf("incorrect node group set resource limit - min is not integer: %v", limits)
+		}
+		maxVal, err := strconv.ParseInt(parts[5], 10, 64)
+		if err != nil {
+			return config.NodeGroupSetResourceLimits{}, fmt.Errorf("incorrect node group set resource limit - max is not integer: %v", limits)
+		}
+		parsedLimits = config.NodeGroupSetResourceLimits{
+			Name:             parts[0],
+			NodeGroupIdRegex: parts[1],
+			LabelSelector:    parts[2],
+			Resource:         parts[3],
+			Min:              minVal,
+			Max:              maxVal,
+		}
+	}
+	if parsedLimits.Resource == cloudprovider.ResourceNameMemory {
+		// Convert memory limits to bytes.
+		parsedLimits.Min = parsedLimits.Min * units.GiB
+		parsedLimits.Max = parsedLimits.Max * units.GiB
+	}
+	if _, err := cloudprovider.NewNodeGroupSetLimit(parsedLimits); err != nil {
+		return config.NodeGroupSetResourceLimits{}, fmt.Errorf("incorrect node group set resource limit %v: %v", limits, err)
+	}
+	return parsedLimits, nil
+}
+
+func parsePriorityClassMaxDelays(flags MultiStringFlag) (map[string]time.Duration, error) {
+	delays := make(map[string]time.Duration)
+	for _, flag := range flags {
+		parts := strings.Split(flag, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("incorrect priority class max delay specification: %v", flag)
+		}
+		delay, err := time.ParseDuration(parts[1])
+		if err != nil || delay < 0 {
+			return nil, fmt.Errorf("incorrect priority class max delay - delay is not a non-negative duration: %v", flag)
+		}
+		if _, found := delays[parts[0]]; found {
+			return nil, fmt.Errorf("duplicated max delay of priority class %s", parts[0])
+		}
+		delays[parts[0]] = delay
+	}
+	return delays, nil
+}
+
+func parseDrainCosts(flags MultiStringFlag) (map[string]float64, error) {
+	costs := draincost.DefaultControllerKindCosts()
+	parsed := make(map[string]bool)
+	for _, flag := range flags {
+		parts := strings.Split(flag, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("incorrect drain cost specification: %v", flag)
+		}
+		cost, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || cost < 0 {
+			return nil, fmt.Errorf("incorrect drain cost - cost is not a non-negative number: %v", flag)
+		}
+		if parsed[parts[0]] {
+			return nil, fmt.Errorf("duplicated drain cost of controller kind %s", parts[0])
+		}
+		parsed[parts[0]] = true
+		costs[parts[0]] = cost
+	}
+	return costs, nil
+}
+
 func parseSingleGpuLimit(limits string) (config.GpuLimits, error) {
 	parts := strings.Split(limits, ":")
 	if len(parts) != 3 {
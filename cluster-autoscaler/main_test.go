@@ -18,8 +18,10 @@ package main
 
 import (
 	"testing"
+	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -91,3 +93,174 @@ func TestParseSingleGpuLimit(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSingleNodeGroupSetResourceLimit(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		input      string
+		wantLimits config.NodeGroupSetResourceLimits
+		wantErr    bool
+	}{
+		"gpu limit": {
+			input:      "a100:.*-a100-.*::nvidia-a100:0:512",
+			wantLimits: config.NodeGroupSetResourceLimits{Name: "a100", NodeGroupIdRegex: ".*-a100-.*", Resource: "nvidia-a100", Max: 512},
+		},
+		"memory limit in gigabytes": {
+			input:      "team-x::team=x:memory:1:2048",
+			wantLimits: config.NodeGroupSetResourceLimits{Name: "team-x", LabelSelector: "team=x", Resource: "memory", Min: units.GiB, Max: 2048 * units.GiB},
+		},
+		"too few parts": {
+			input:   "a100:.*:cpu:0:512",
+			wantErr: true,
+		},
+		"min is not integer": {
+			input:   "a100:::cpu:x:512",
+			wantErr: true,
+		},
+		"max is not integer": {
+			input:   "a100:::cpu:0:x",
+			wantErr: true,
+		},
+		"min greater than max": {
+			input:   "a100:::cpu:10:5",
+			wantErr: true,
+		},
+		"invalid regex": {
+			input:   "a100:(::cpu:0:5",
+			wantErr: true,
+		},
+		"json with url node group id": {
+			input: `{"name": "a100", "nodeGroupIdRegex": "https://www.googleapis.com/compute/v1/projects/p/zones/z/instanceGroups/ng-a100-.*", "resource": "nvidia-a100", "max": 512}`,
+			wantLimits: config.NodeGroupSetResourceLimits{
+				Name:             "a100",
+				NodeGroupIdRegex: "https://www.googleapis.com/compute/v1/projects/p/zones/z/instanceGroups/ng-a100-.*",
+				Resource:         "nvidia-a100",
+				Max:              512,
+			},
+		},
+		"json memory limit in gigabytes": {
+			input:      `{"name": "team-x", "labelSelector": "team=x", "resource": "memory", "min": 1, "max": 2048}`,
+			wantLimits: config.NodeGroupSetResourceLimits{Name: "team-x", LabelSelector: "team=x", Resource: "memory", Min: units.GiB, Max: 2048 * units.GiB},
+		},
+		"json unknown field": {
+			input:   `{"name": "a100", "resource": "cpu", "maximum": 5}`,
+			wantErr: true,
+		},
+		"json min is not integer": {
+			input:   `{"name": "a100", "resource": "cpu", "min": "x"}`,
+			wantErr: true,
+		},
+		"json min greater than max": {
+			input:   `{"name": "a100", "resource": "cpu", "min": 10, "max": 5}`,
+			wantErr: true,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			limits, err := parseSingleNodeGroupSetResourceLimit(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantLimits, limits)
+			}
+		})
+	}
+}
+
+func TestParseNodeGroupSetResourceLimitsDuplicates(t *testing.T) {
+	_, err := parseNodeGroupSetResourceLimits(MultiStringFlag{"a100:::cpu:0:5", "a100:::memory:0:5"})
+	assert.NoError(t, err)
+	_, err = parseNodeGroupSetResourceLimits(MultiStringFlag{"a100:::cpu:0:5", "a100:::cpu:0:10"})
+	assert.Error(t, err)
+}
+
+func TestParsePriorityClassMaxDelays(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		input      MultiStringFlag
+		wantDelays map[string]time.Duration
+		wantErr    bool
+	}{
+		"no overrides": {
+			wantDelays: map[string]time.Duration{},
+		},
+		"valid overrides": {
+			input:      MultiStringFlag{"system-cluster-critical=0", "batch=1m"},
+			wantDelays: map[string]time.Duration{"system-cluster-critical": 0, "batch": time.Minute},
+		},
+		"missing delay": {
+			input:   MultiStringFlag{"critical"},
+			wantErr: true,
+		},
+		"empty priority class": {
+			input:   MultiStringFlag{"=1s"},
+			wantErr: true,
+		},
+		"invalid delay": {
+			input:   MultiStringFlag{"critical=x"},
+			wantErr: true,
+		},
+		"negative delay": {
+			input:   MultiStringFlag{"critical=-1s"},
+			wantErr: true,
+		},
+		"duplicated priority class": {
+			input:   MultiStringFlag{"critical=0", "critical=1s"},
+			wantErr: true,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			delays, err := parsePriorityClassMaxDelays(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantDelays, delays)
+			}
+		})
+	}
+}
+
+func TestParseDrainCosts(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		input     MultiStringFlag
+		wantCosts map[string]float64
+		wantErr   bool
+	}{
+		"defaults": {
+			wantCosts: map[string]float64{"StatefulSet": 2},
+		},
+		"valid costs": {
+			input:     MultiStringFlag{"StatefulSet=5", "Job=0.5"},
+			wantCosts: map[string]float64{"StatefulSet": 5, "Job": 0.5},
+		},
+		"missing cost": {
+			input:   MultiStringFlag{"Job"},
+			wantErr: true,
+		},
+		"empty controller kind": {
+			input:   MultiStringFlag{"=1"},
+			wantErr: true,
+		},
+		"invalid cost": {
+			input:   MultiStringFlag{"Job=x"},
+			wantErr: true,
+		},
+		"negative cost": {
+			input:   MultiStringFlag{"Job=-1"},
+			wantErr: true,
+		},
+		"duplicated controller kind": {
+			input:   MultiStringFlag{"Job=1", "Job=2"},
+			wantErr: true,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			costs, err := parseDrainCosts(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantCosts, costs)
+			}
+		})
+	}
+}
@@ -47,6 +47,15 @@ type NodeGroupType string
 // PodEvictionResult describes result of the pod eviction attempt
 type PodEvictionResult string
 
+// OptionsReloadResult describes result of an attempt to apply reloaded autoscaling options
+type OptionsReloadResult string
+
+// NodeRotationResult describes a step of rotating a node
+type NodeRotationResult string
+
+// AdminOperationResult describes result of applying an operation requested through the admin API
+type AdminOperationResult string
+
 const (
 	caNamespace           = "cluster_autoscaler"
 	readyLabel            = "ready"
@@ -94,6 +103,20 @@ const (
 	PodEvictionSucceed PodEvictionResult = "succeeded"
 	// PodEvictionFailed means creation of the pod eviction object failed
 	PodEvictionFailed PodEvictionResult = "failed"
+	// OptionsReloadApplied means reloaded autoscaling options were applied
+	OptionsReloadApplied OptionsReloadResult = "applied"
+	// OptionsReloadRejected means reloaded autoscaling options were invalid and the previous ones were kept
+	OptionsReloadRejected OptionsReloadResult = "rejected"
+	// NodeRotationStarted means a replacement of an old or outdated node was requested
+	NodeRotationStarted NodeRotationResult = "started"
+	// NodeRotationDrained means an old or outdated node was drained after its replacement became ready
+	NodeRotationDrained NodeRotationResult = "drained"
+	// NodeRotationFailed means a replacement didn't become ready in time or the node couldn't be drained
+	NodeRotationFailed NodeRotationResult = "failed"
+	// AdminOperationApplied means an operation requested through the admin API was applied
+	AdminOperationApplied AdminOperationResult = "applied"
+	// AdminOperationFailed means an operation requested through the admin API couldn't be applied
+	AdminOperationFailed AdminOperationResult = "failed"
 )
 
 // Names of Cluster Autoscaler operations
@@ -427,6 +450,75 @@ var (
 			Help:      "Number of migs where instance count according to InstanceGroupManagers.List() differs from the results of Instances.List(). This can happen when some instances are abandoned or a user edits instance 'created-by' metadata.",
 		},
 	)
+
+	/**** Metrics related to options reloading ****/
+	optionsReloadsCount = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "options_reloads_total",
+			Help:      "Number of attempts to apply reloaded autoscaling options, by result.",
+		},
+		[]string{"result"},
+	)
+
+	/**** Metrics related to dry-run mode ****/
+	dryRunActionsCount = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "dry_run_actions_total",
+			Help:      "Number of actions not executed because Cluster Autoscaler runs in dry-run mode, by action.",
+		},
+		[]string{"action"},
+	)
+
+	/**** Metrics related to admin API ****/
+	adminOperationsCount = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "admin_operations_total",
+			Help:      "Number of operations requested through the admin API, by operation and result.",
+		},
+		[]string{"operation", "result"},
+	)
+
+	/**** Metrics related to node rotation ****/
+	nodeRotationsCount = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "node_rotations_total",
+			Help:      "Number of steps of rotations of old or outdated nodes, by result.",
+		},
+		[]string{"result"},
+	)
+
+	/**** Metrics related to extended resources ****/
+	extendedResourceReadinessDelay = k8smetrics.NewHistogramVec(
+		&k8smetrics.HistogramOpts{
+			Namespace: caNamespace,
+			Name:      "extended_resource_readiness_delay_seconds",
+			Help:      "Time from node creation until an extended resource expected from its node group template became allocatable, by resource.",
+			Buckets:   k8smetrics.ExponentialBuckets(5, 1.5, 15), // 5, 7.5, 11.25, ..., 1459.6
+		}, []string{"resource"},
+	)
+
+	/**** Metrics related to loop triggering ****/
+	pendingPodBatchDelay = k8smetrics.NewHistogram(
+		&k8smetrics.HistogramOpts{
+			Namespace: caNamespace,
+			Name:      "pending_pod_batch_delay_seconds",
+			Help:      "Time from the first unschedulable pod of a batch until the autoscaling iteration was triggered.",
+			Buckets:   k8smetrics.ExponentialBuckets(0.1, 1.5, 15), // 0.1, 0.15, 0.225, ..., 29.2
+		},
+	)
+
+	pendingPodBatchSize = k8smetrics.NewHistogram(
+		&k8smetrics.HistogramOpts{
+			Namespace: caNamespace,
+			Name:      "pending_pod_batch_size",
+			Help:      "Number of unschedulable pods observed in a batch triggering an autoscaling iteration.",
+			Buckets:   k8smetrics.ExponentialBuckets(1, 2, 12), // 1, 2, 4, ..., 2048
+		},
+	)
 )
 
 // RegisterAll registers all metrics.
@@ -463,6 +555,13 @@ func RegisterAll(emitPerNodeGroupMetrics bool) {
 	legacyregistry.MustRegister(pendingNodeDeletions)
 	legacyregistry.MustRegister(nodeTaintsCount)
 	legacyregistry.MustRegister(inconsistentInstancesMigsCount)
+	legacyregistry.MustRegister(optionsReloadsCount)
+	legacyregistry.MustRegister(nodeRotationsCount)
+	legacyregistry.MustRegister(dryRunActionsCount)
+	legacyregistry.MustRegister(adminOperationsCount)
+	legacyregistry.MustRegister(extendedResourceReadinessDelay)
+	legacyregistry.MustRegister(pendingPodBatchDelay)
+	legacyregistry.MustRegister(pendingPodBatchSize)
 
 	if emitPerNodeGroupMetrics {
 		legacyregistry.MustRegister(nodesGroupMinNodes)
@@ -717,6 +816,26 @@ func RegisterSkippedScaleUpMemory() {
 	skippedScaleEventsCount.WithLabelValues(DirectionScaleUp, MemoryResourceLimit).Add(1.0)
 }
 
+// RegisterOptionsReload records an attempt to apply reloaded autoscaling options
+func RegisterOptionsReload(result OptionsReloadResult) {
+	optionsReloadsCount.WithLabelValues(string(result)).Inc()
+}
+
+// RegisterDryRunAction records an action not executed in dry-run mode
+func RegisterDryRunAction(action string) {
+	dryRunActionsCount.WithLabelValues(action).Inc()
+}
+
+// RegisterAdminOperation records an operation requested through the admin API
+func RegisterAdminOperation(operation string, result AdminOperationResult) {
+	adminOperationsCount.WithLabelValues(operation, string(result)).Inc()
+}
+
+// RegisterNodeRotation records a step of rotating an old or outdated node
+func RegisterNodeRotation(result NodeRotationResult) {
+	nodeRotationsCount.WithLabelValues(string(result)).Inc()
+}
+
 // ObservePendingNodeDeletions records the current value of nodes_pending_deletion metric
 func ObservePendingNodeDeletions(value int) {
 	pendingNodeDeletions.Set(float64(value))
@@ -727,6 +846,17 @@ func ObserveNodeTaintsCount(taintType string, count float64) {
 	nodeTaintsCount.WithLabelValues(taintType).Set(count)
 }
 
+// ObserveExtendedResourceReadinessDelay records the time it took for an extended resource to become allocatable on a new node.
+func ObserveExtendedResourceReadinessDelay(resource string, delay time.Duration) {
+	extendedResourceReadinessDelay.WithLabelValues(resource).Observe(delay.Seconds())
+}
+
+// ObservePendingPodBatch records the size of a batch of unschedulable pods and the time it was collected for.
+func ObservePendingPodBatch(size int, delay time.Duration) {
+	pendingPodBatchSize.Observe(float64(size))
+	pendingPodBatchDelay.Observe(delay.Seconds())
+}
+
 // UpdateInconsistentInstancesMigsCount records the observed number of migs where instance count
 // according to InstanceGroupManagers.List() differs from the results of Instances.List().
 // This can happen when some instances are abandoned or a user edits instance 'created-by' metadata.
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresources
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/klog/v2"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// TemplateNodeInfosObserver may be implemented by CustomResourcesProcessors which need template
+// NodeInfos of node groups. They are passed after being built in each autoscaling loop, so they
+// include templates learned from real nodes and template overlays.
+type TemplateNodeInfosObserver interface {
+	UpdateTemplateNodeInfos(nodeInfos map[string]*schedulerframework.NodeInfo)
+}
+
+// ExtendedResourcesProcessor handles resources exposed by device plugins, e.g. FPGAs, SR-IOV NICs
+// or hugepages, which may not become allocatable immediately after the node creation. Nodes which
+// don't have some of the resources expected for their node group are treated as unready, so that
+// they don't trigger another scale-up while the device plugin starts. Other custom resources are
+// handled by the wrapped CustomResourcesProcessor.
+type ExtendedResourcesProcessor struct {
+	resources                []apiv1.ResourceName
+	customResourcesProcessor CustomResourcesProcessor
+	// unreadyResources contains resources which were missing on nodes when they were last seen ready, keyed by node name.
+	unreadyResources map[string]map[apiv1.ResourceName]bool
+	// expected contains handled resources expected on nodes, keyed by node group ID. It is taken from
+	// template NodeInfos of the last loop, or from the cloud provider template if there were none.
+	expected map[string]apiv1.ResourceList
+}
+
+// NewExtendedResourcesProcessor returns ExtendedResourcesProcessor handling the given resources and
+// wrapping the given CustomResourcesProcessor.
+func NewExtendedResourcesProcessor(resources []string, customResourcesProcessor CustomResourcesProcessor) *ExtendedResourcesProcessor {
+	var resourceNames []apiv1.ResourceName
+	for _, resource := range resources {
+		resourceNames = append(resourceNames, apiv1.ResourceName(resource))
+	}
+	return &ExtendedResourcesProcessor{
+		resources:                resourceNames,
+		customResourcesProcessor: customResourcesProcessor,
+		unreadyResources:         make(map[string]map[apiv1.ResourceName]bool),
+		expected:                 make(map[string]apiv1.ResourceList),
+	}
+}
+
+// UpdateTemplateNodeInfos sets resources expected on nodes of node groups from their template NodeInfos.
+func (p *ExtendedResourcesProcessor) UpdateTemplateNodeInfos(nodeInfos map[string]*schedulerframework.NodeInfo) {
+	for id, nodeInfo := range nodeInfos {
+		if nodeInfo != nil && nodeInfo.Node() != nil {
+			p.expected[id] = p.handledResources(nodeInfo.Node())
+		}
+	}
+}
+
+// FilterOutNodesWithUnreadyResources removes nodes that should have an extended resource, but don't have
+// it in allocatable from ready nodes list and updates their status to unready on all nodes list.
+// The expected resources are learned from the template NodeInfo of the node group, or from other nodes
+// of the node group if there is no template.
+func (p *ExtendedResourcesProcessor) FilterOutNodesWithUnreadyResources(context *context.AutoscalingContext, allNodes, readyNodes []*apiv1.Node) ([]*apiv1.Node, []*apiv1.Node) {
+	allNodes, readyNodes = p.customResourcesProcessor.FilterOutNodesWithUnreadyResources(context, allNodes, readyNodes)
+	if len(p.resources) == 0 {
+		return allNodes, readyNodes
+	}
+
+	nodeGroups := make(map[string]cloudprovider.NodeGroup)
+	nodesByGroup := make(map[string][]*apiv1.Node)
+	existingNodes := make(map[string]bool)
+	for _, node := range allNodes {
+		existingNodes[node.Name] = true
+		nodeGroup, err := context.CloudProvider.NodeGroupForNode(node)
+		if err != nil || nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+			continue
+		}
+		nodeGroups[node.Name] = nodeGroup
+		nodesByGroup[nodeGroup.Id()] = append(nodesByGroup[nodeGroup.Id()], node)
+	}
+	// Forget nodes which were removed, and node groups without nodes.
+	for name := range p.unreadyResources {
+		if !existingNodes[name] {
+			delete(p.unreadyResources, name)
+		}
+	}
+	for id := range p.expected {
+		if _, found := nodesByGroup[id]; !found {
+			delete(p.expected, id)
+		}
+	}
+
+	now := time.Now()
+	expectedByGroup := make(map[string]apiv1.ResourceList)
+	newReadyNodes := make([]*apiv1.Node, 0, len(readyNodes))
+	nodesWithUnreadyResources := make(map[string]*apiv1.Node)
+	for _, node := range readyNodes {
+		nodeGroup, found := nodeGroups[node.Name]
+		if !found {
+			newReadyNodes = append(newReadyNodes, node)
+			continue
+		}
+		id := nodeGroup.Id()
+		expected, found := expectedByGroup[id]
+		if !found {
+			expected = p.expectedResources(nodeGroup, nodesByGroup[id])
+			expectedByGroup[id] = expected
+		}
+		missing := missingResources(node, expected)
+		for resource := range p.unreadyResources[node.Name] {
+			if !missing[resource] {
+				metrics.ObserveExtendedResourceReadinessDelay(string(resource), now.Sub(node.CreationTimestamp.Time))
+			}
+		}
+		if len(missing) == 0 {
+			delete(p.unreadyResources, node.Name)
+			newReadyNodes = append(newReadyNodes, node)
+			continue
+		}
+		p.unreadyResources[node.Name] = missing
+		klog.V(3).Infof("Overriding status of node %v, which seems to have unready resources %v", node.Name, resourceNames(missing))
+		nodesWithUnreadyResources[node.Name] = kubernetes.GetUnreadyNodeCopy(node, kubernetes.ResourceUnready)
+	}
+
+	newAllNodes := make([]*apiv1.Node, 0, len(allNodes))
+	for _, node := range allNodes {
+		if newNode, found := nodesWithUnreadyResources[node.Name]; found {
+			newAllNodes = append(newAllNodes, newNode)
+		} else {
+			newAllNodes = append(newAllNodes, node)
+		}
+	}
+	return newAllNodes, newReadyNodes
+}
+
+// expectedResources returns allocatable amounts of handled resources expected on nodes of the node group.
+func (p *ExtendedResourcesProcessor) expectedResources(nodeGroup cloudprovider.NodeGroup, nodes []*apiv1.Node) apiv1.ResourceList {
+	if expected, found := p.expected[nodeGroup.Id()]; found {
+		return expected
+	}
+	// Template NodeInfos weren't built yet, e.g. in the first loop.
+	template, err := nodeGroup.TemplateNodeInfo()
+	if err == nil {
+		expected := p.handledResources(template.Node())
+		p.expected[nodeGroup.Id()] = expected
+		return expected
+	}
+	if err != cloudprovider.ErrNotImplemented {
+		klog.Warningf("Failed to get template of node group %s, using its nodes to get expected extended resources: %v", nodeGroup.Id(), err)
+	}
+	// The resource is expected if any other node of the node group has it.
+	expected := apiv1.ResourceList{}
+	for _, node := range nodes {
+		for _, resource := range p.resources {
+			quantity, found := node.Status.Allocatable[resource]
+			if !found || quantity.IsZero() {
+				continue
+			}
+			if current, found := expected[resource]; !found || quantity.Cmp(current) > 0 {
+				expected[resource] = quantity
+			}
+		}
+	}
+	return expected
+}
+
+// handledResources returns handled resources allocatable on the node.
+func (p *ExtendedResourcesProcessor) handledResources(node *apiv1.Node) apiv1.ResourceList {
+	resources := apiv1.ResourceList{}
+	for _, resource := range p.resources {
+		if quantity, found := node.Status.Allocatable[resource]; found && !quantity.IsZero() {
+			resources[resource] = quantity
+		}
+	}
+	return resources
+}
+
+// missingResources returns expected resources which aren't allocatable on the node.
+func missingResources(node *apiv1.Node, expected apiv1.ResourceList) map[apiv1.ResourceName]bool {
+	missing := make(map[apiv1.ResourceName]bool)
+	for resource := range expected {
+		if quantity, found := node.Status.Allocatable[resource]; !found || quantity.IsZero() {
+			missing[resource] = true
+		}
+	}
+	return missing
+}
+
+func resourceNames(resources map[apiv1.ResourceName]bool) []string {
+	var names []string
+	for resource := range resources {
+		names = append(names, string(resource))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetNodeResourceTargets returns mapping of resource names to their targets.
+func (p *ExtendedResourcesProcessor) GetNodeResourceTargets(context *context.AutoscalingContext, node *apiv1.Node, nodeGroup cloudprovider.NodeGroup) ([]CustomResourceTarget, errors.AutoscalerError) {
+	return p.customResourcesProcessor.GetNodeResourceTargets(context, node, nodeGroup)
+}
+
+// CleanUp cleans up processor's internal structures.
+func (p *ExtendedResourcesProcessor) CleanUp() {
+	p.customResourcesProcessor.CleanUp()
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const testFpga = "example.com/fpga"
+
+func buildExtendedResourceNode(name string, fpgas int64) *apiv1.Node {
+	node := BuildTestNode(name, 1000, 1000)
+	SetNodeReadyState(node, true, time.Now().Add(-time.Minute))
+	if fpgas > 0 {
+		node.Status.Capacity[testFpga] = *resource.NewQuantity(fpgas, resource.DecimalSI)
+		node.Status.Allocatable[testFpga] = *resource.NewQuantity(fpgas, resource.DecimalSI)
+	}
+	return node
+}
+
+func nodeNames(nodes []*apiv1.Node) []string {
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+func TestExtendedResourcesFilterOutNodesWithUnreadyResources(t *testing.T) {
+	fpgaTemplate := schedulerframework.NewNodeInfo()
+	fpgaTemplate.SetNode(buildExtendedResourceNode("fpga-template", 2))
+	plainTemplate := schedulerframework.NewNodeInfo()
+	plainTemplate.SetNode(buildExtendedResourceNode("plain-template", 0))
+
+	fpgaReady := buildExtendedResourceNode("fpga-ready", 2)
+	fpgaUnready := buildExtendedResourceNode("fpga-unready", 0)
+	plain := buildExtendedResourceNode("plain", 0)
+	notAutoscaled := buildExtendedResourceNode("not-autoscaled", 0)
+
+	provider := testprovider.NewTestAutoprovisioningCloudProvider(nil, nil, nil, nil, nil,
+		map[string]*schedulerframework.NodeInfo{"ng-fpga": fpgaTemplate, "ng-plain": plainTemplate})
+	provider.AddNodeGroup("ng-fpga", 0, 10, 2)
+	provider.AddNode("ng-fpga", fpgaReady)
+	provider.AddNode("ng-fpga", fpgaUnready)
+	provider.AddNodeGroup("ng-plain", 0, 10, 1)
+	provider.AddNode("ng-plain", plain)
+	ctx := &context.AutoscalingContext{CloudProvider: provider}
+
+	processor := NewExtendedResourcesProcessor([]string{testFpga}, NewDefaultCustomResourcesProcessor())
+	nodes := []*apiv1.Node{fpgaReady, fpgaUnready, plain, notAutoscaled}
+	allNodes, readyNodes := processor.FilterOutNodesWithUnreadyResources(ctx, nodes, nodes)
+	assert.Equal(t, []string{"fpga-ready", "plain", "not-autoscaled"}, nodeNames(readyNodes))
+	assert.Equal(t, []string{"fpga-ready", "fpga-unready", "plain", "not-autoscaled"}, nodeNames(allNodes))
+	ready, _, err := kubernetes.GetReadinessState(allNodes[1])
+	assert.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, map[apiv1.ResourceName]bool{testFpga: true}, processor.unreadyResources["fpga-unready"])
+
+	// The device plugin started.
+	fpgaNowReady := buildExtendedResourceNode("fpga-unready", 2)
+	nodes = []*apiv1.Node{fpgaReady, fpgaNowReady, plain, notAutoscaled}
+	_, readyNodes = processor.FilterOutNodesWithUnreadyResources(ctx, nodes, nodes)
+	assert.Equal(t, []string{"fpga-ready", "fpga-unready", "plain", "not-autoscaled"}, nodeNames(readyNodes))
+	assert.Empty(t, processor.unreadyResources)
+}
+
+func TestExtendedResourcesLearnedFromNodes(t *testing.T) {
+	peerReady := buildExtendedResourceNode("peer-ready", 1)
+	peerUnready := buildExtendedResourceNode("peer-unready", 0)
+	plain := buildExtendedResourceNode("plain", 0)
+
+	// The cloud provider doesn't provide templates.
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng-fpga", 0, 10, 2)
+	provider.AddNode("ng-fpga", peerReady)
+	provider.AddNode("ng-fpga", peerUnready)
+	provider.AddNodeGroup("ng-plain", 0, 10, 1)
+	provider.AddNode("ng-plain", plain)
+	ctx := &context.AutoscalingContext{CloudProvider: provider}
+
+	processor := NewExtendedResourcesProcessor([]string{testFpga}, NewDefaultCustomResourcesProcessor())
+	nodes := []*apiv1.Node{peerReady, peerUnready, plain}
+	_, readyNodes := processor.FilterOutNodesWithUnreadyResources(ctx, nodes, nodes)
+	assert.Equal(t, []string{"peer-ready", "plain"}, nodeNames(readyNodes))
+
+	// Removed nodes are forgotten.
+	nodes = []*apiv1.Node{peerReady, plain}
+	processor.FilterOutNodesWithUnreadyResources(ctx, nodes, nodes)
+	assert.Empty(t, processor.unreadyResources)
+}
+
+func TestExtendedResourcesFromTemplateNodeInfos(t *testing.T) {
+	unready := buildExtendedResourceNode("unready", 0)
+
+	// The cloud provider doesn't provide templates and no other node has the resource.
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng-fpga", 0, 10, 1)
+	provider.AddNode("ng-fpga", unready)
+	ctx := &context.AutoscalingContext{CloudProvider: provider}
+
+	processor := NewExtendedResourcesProcessor([]string{testFpga}, NewDefaultCustomResourcesProcessor())
+	nodes := []*apiv1.Node{unready}
+	_, readyNodes := processor.FilterOutNodesWithUnreadyResources(ctx, nodes, nodes)
+	assert.Equal(t, []string{"unready"}, nodeNames(readyNodes))
+
+	// Template built in the loop, e.g. learned from a previous node or declared by an overlay.
+	template := schedulerframework.NewNodeInfo()
+	template.SetNode(buildExtendedResourceNode("template", 2))
+	processor.UpdateTemplateNodeInfos(map[string]*schedulerframework.NodeInfo{"ng-fpga": template})
+	_, readyNodes = processor.FilterOutNodesWithUnreadyResources(ctx, nodes, nodes)
+	assert.Empty(t, readyNodes)
+
+	// Node groups without nodes are forgotten.
+	processor.FilterOutNodesWithUnreadyResources(ctx, nil, nil)
+	assert.Empty(t, processor.expected)
+}
+
+func TestExtendedResourcesCachesCloudProviderTemplates(t *testing.T) {
+	template := schedulerframework.NewNodeInfo()
+	template.SetNode(buildExtendedResourceNode("template", 2))
+	node := buildExtendedResourceNode("node", 2)
+	provider := testprovider.NewTestAutoprovisioningCloudProvider(nil, nil, nil, nil, nil,
+		map[string]*schedulerframework.NodeInfo{"ng-fpga": template})
+	provider.AddNodeGroup("ng-fpga", 0, 10, 1)
+	provider.AddNode("ng-fpga", node)
+	ctx := &context.AutoscalingContext{CloudProvider: provider}
+
+	processor := NewExtendedResourcesProcessor([]string{testFpga}, NewDefaultCustomResourcesProcessor())
+	nodes := []*apiv1.Node{node}
+	processor.FilterOutNodesWithUnreadyResources(ctx, nodes, nodes)
+	assert.Equal(t, apiv1.ResourceList{testFpga: *resource.NewQuantity(2, resource.DecimalSI)}, processor.expected["ng-fpga"])
+}
@@ -128,6 +128,22 @@ func TestFilterOutNodesWithUnreadyResources(t *testing.T) {
 	}
 	expectedReadiness[nodeGpuUnready2.Name] = false
 
+	nodeMigReady := &apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "nodeMigReady",
+			Labels:            gpuLabels,
+			CreationTimestamp: metav1.NewTime(start),
+		},
+		Status: apiv1.NodeStatus{
+			Capacity:    apiv1.ResourceList{},
+			Allocatable: apiv1.ResourceList{},
+			Conditions:  []apiv1.NodeCondition{readyCondition},
+		},
+	}
+	nodeMigReady.Status.Allocatable["nvidia.com/mig-1g.10gb"] = *resource.NewQuantity(7, resource.DecimalSI)
+	nodeMigReady.Status.Capacity["nvidia.com/mig-1g.10gb"] = *resource.NewQuantity(7, resource.DecimalSI)
+	expectedReadiness[nodeMigReady.Name] = true
+
 	nodeNoGpuReady := &apiv1.Node{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:              "nodeNoGpuReady",
@@ -158,6 +174,7 @@ func TestFilterOutNodesWithUnreadyResources(t *testing.T) {
 		nodeGpuUnready2,
 		nodeDirectXReady,
 		nodeDirectXUnready,
+		nodeMigReady,
 		nodeNoGpuReady,
 	}
 	initialAllNodes := []*apiv1.Node{
@@ -166,6 +183,7 @@ func TestFilterOutNodesWithUnreadyResources(t *testing.T) {
 		nodeGpuUnready2,
 		nodeDirectXReady,
 		nodeDirectXUnready,
+		nodeMigReady,
 		nodeNoGpuReady,
 		nodeNoGpuUnready,
 	}
@@ -21,6 +21,8 @@ import (
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	klog "k8s.io/klog/v2"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
 )
 
 // NodeGroupConfigProcessor provides config values for a particular NodeGroup.
@@ -37,10 +39,45 @@ type NodeGroupConfigProcessor interface {
 	GetMaxNodeProvisionTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
 	// GetIgnoreDaemonSetsUtilization returns IgnoreDaemonSetsUtilization value that should be used for a given NodeGroup.
 	GetIgnoreDaemonSetsUtilization(nodeGroup cloudprovider.NodeGroup) (bool, error)
+	// GetMaxScaleUpNodesPerMinute returns MaxScaleUpNodesPerMinute value that should be used for a given NodeGroup.
+	GetMaxScaleUpNodesPerMinute(nodeGroup cloudprovider.NodeGroup) (int, error)
+	// GetMaxScaleUpGrowthPercent returns MaxScaleUpGrowthPercent value that should be used for a given NodeGroup.
+	GetMaxScaleUpGrowthPercent(nodeGroup cloudprovider.NodeGroup) (int, error)
+	// GetMinSize returns the minimum size that should be used for a given NodeGroup.
+	GetMinSize(nodeGroup cloudprovider.NodeGroup) (int, error)
+	// GetMaxSize returns the maximum size that should be used for a given NodeGroup.
+	GetMaxSize(nodeGroup cloudprovider.NodeGroup) (int, error)
+	// Refresh is called once per loop, before any other method in that loop, with template
+	// NodeInfos of the node groups. It allows the processor to update its view of the node groups.
+	Refresh(nodeGroups []cloudprovider.NodeGroup, nodeInfos map[string]*schedulerframework.NodeInfo) error
 	// CleanUp cleans up processor's internal structures.
 	CleanUp()
 }
 
+// NodeGroupSizeGetter provides min and max size of a NodeGroup.
+type NodeGroupSizeGetter interface {
+	// GetMinSize returns the minimum size that should be used for a given NodeGroup.
+	GetMinSize(nodeGroup cloudprovider.NodeGroup) (int, error)
+	// GetMaxSize returns the maximum size that should be used for a given NodeGroup.
+	GetMaxSize(nodeGroup cloudprovider.NodeGroup) (int, error)
+}
+
+// GetSizeRange returns min and max size of the node group, as returned by getter. Falls back
+// to the values reported by the cloud provider in case of an error.
+func GetSizeRange(getter NodeGroupSizeGetter, nodeGroup cloudprovider.NodeGroup) (int, int) {
+	minSize, err := getter.GetMinSize(nodeGroup)
+	if err != nil {
+		klog.Warningf("Failed to get min size of node group %s: %v", nodeGroup.Id(), err)
+		minSize = nodeGroup.MinSize()
+	}
+	maxSize, err := getter.GetMaxSize(nodeGroup)
+	if err != nil {
+		klog.Warningf("Failed to get max size of node group %s: %v", nodeGroup.Id(), err)
+		maxSize = nodeGroup.MaxSize()
+	}
+	return minSize, maxSize
+}
+
 // DelegatingNodeGroupConfigProcessor calls NodeGroup.GetOptions to get config
 // for each NodeGroup. If NodeGroup doesn't return a value default config is
 // used instead.
@@ -120,10 +157,54 @@ func (p *DelegatingNodeGroupConfigProcessor) GetIgnoreDaemonSetsUtilization(node
 	return ngConfig.IgnoreDaemonSetsUtilization, nil
 }
 
+// GetMaxScaleUpNodesPerMinute returns MaxScaleUpNodesPerMinute value that should be used for a given NodeGroup.
+func (p *DelegatingNodeGroupConfigProcessor) GetMaxScaleUpNodesPerMinute(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	ngConfig, err := nodeGroup.GetOptions(p.nodeGroupDefaults)
+	if err != nil && err != cloudprovider.ErrNotImplemented {
+		return 0, err
+	}
+	if ngConfig == nil || err == cloudprovider.ErrNotImplemented {
+		return p.nodeGroupDefaults.MaxScaleUpNodesPerMinute, nil
+	}
+	return ngConfig.MaxScaleUpNodesPerMinute, nil
+}
+
+// GetMaxScaleUpGrowthPercent returns MaxScaleUpGrowthPercent value that should be used for a given NodeGroup.
+func (p *DelegatingNodeGroupConfigProcessor) GetMaxScaleUpGrowthPercent(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	ngConfig, err := nodeGroup.GetOptions(p.nodeGroupDefaults)
+	if err != nil && err != cloudprovider.ErrNotImplemented {
+		return 0, err
+	}
+	if ngConfig == nil || err == cloudprovider.ErrNotImplemented {
+		return p.nodeGroupDefaults.MaxScaleUpGrowthPercent, nil
+	}
+	return ngConfig.MaxScaleUpGrowthPercent, nil
+}
+
+// GetMinSize returns the minimum size of a given NodeGroup, as reported by the cloud provider.
+func (p *DelegatingNodeGroupConfigProcessor) GetMinSize(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	return nodeGroup.MinSize(), nil
+}
+
+// GetMaxSize returns the maximum size of a given NodeGroup, as reported by the cloud provider.
+func (p *DelegatingNodeGroupConfigProcessor) GetMaxSize(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	return nodeGroup.MaxSize(), nil
+}
+
+// Refresh is a no-op, all the config comes from the node groups themselves.
+func (p *DelegatingNodeGroupConfigProcessor) Refresh([]cloudprovider.NodeGroup, map[string]*schedulerframework.NodeInfo) error {
+	return nil
+}
+
 // CleanUp cleans up processor's internal structures.
 func (p *DelegatingNodeGroupConfigProcessor) CleanUp() {
 }
 
+// UpdateOptions replaces node group defaults with the ones from reloaded options.
+func (p *DelegatingNodeGroupConfigProcessor) UpdateOptions(options config.AutoscalingOptions) {
+	p.nodeGroupDefaults = options.NodeGroupDefaults
+}
+
 // NewDefaultNodeGroupConfigProcessor returns a default instance of NodeGroupConfigProcessor.
 func NewDefaultNodeGroupConfigProcessor(nodeGroupDefaults config.NodeGroupAutoscalingOptions) NodeGroupConfigProcessor {
 	return &DelegatingNodeGroupConfigProcessor{
@@ -48,6 +48,8 @@ func TestDelegatingNodeGroupConfigProcessor(t *testing.T) {
 		ScaleDownUtilizationThreshold:    0.5,
 		MaxNodeProvisionTime:             15 * time.Minute,
 		IgnoreDaemonSetsUtilization:      true,
+		MaxScaleUpNodesPerMinute:         20,
+		MaxScaleUpGrowthPercent:          50,
 	}
 	ngOpts := &config.NodeGroupAutoscalingOptions{
 		ScaleDownUnneededTime:            10 * time.Minute,
@@ -56,6 +58,8 @@ func TestDelegatingNodeGroupConfigProcessor(t *testing.T) {
 		ScaleDownUtilizationThreshold:    0.75,
 		MaxNodeProvisionTime:             60 * time.Minute,
 		IgnoreDaemonSetsUtilization:      false,
+		MaxScaleUpNodesPerMinute:         5,
+		MaxScaleUpGrowthPercent:          10,
 	}
 
 	testUnneededTime := func(t *testing.T, p NodeGroupConfigProcessor, ng cloudprovider.NodeGroup, w Want, we error) {
@@ -121,6 +125,27 @@ func TestDelegatingNodeGroupConfigProcessor(t *testing.T) {
 		assert.Equal(t, res, results[w])
 	}
 
+	testMaxScaleUpNodesPerMinute := func(t *testing.T, p NodeGroupConfigProcessor, ng cloudprovider.NodeGroup, w Want, we error) {
+		res, err := p.GetMaxScaleUpNodesPerMinute(ng)
+		assert.Equal(t, err, we)
+		results := map[Want]int{
+			NIL:    0,
+			GLOBAL: 20,
+			NG:     5,
+		}
+		assert.Equal(t, res, results[w])
+	}
+	testMaxScaleUpGrowthPercent := func(t *testing.T, p NodeGroupConfigProcessor, ng cloudprovider.NodeGroup, w Want, we error) {
+		res, err := p.GetMaxScaleUpGrowthPercent(ng)
+		assert.Equal(t, err, we)
+		results := map[Want]int{
+			NIL:    0,
+			GLOBAL: 50,
+			NG:     10,
+		}
+		assert.Equal(t, res, results[w])
+	}
+
 	funcs := map[string]func(*testing.T, NodeGroupConfigProcessor, cloudprovider.NodeGroup, Want, error){
 		"ScaleDownUnneededTime":            testUnneededTime,
 		"ScaleDownUnreadyTime":             testUnreadyTime,
@@ -128,6 +153,8 @@ func TestDelegatingNodeGroupConfigProcessor(t *testing.T) {
 		"ScaleDownGpuUtilizationThreshold": testGpuThreshold,
 		"MaxNodeProvisionTime":             testMaxNodeProvisionTime,
 		"IgnoreDaemonSetsUtilization":      testIgnoreDSUtilization,
+		"MaxScaleUpNodesPerMinute":         testMaxScaleUpNodesPerMinute,
+		"MaxScaleUpGrowthPercent":          testMaxScaleUpGrowthPercent,
 		"MultipleOptions": func(t *testing.T, p NodeGroupConfigProcessor, ng cloudprovider.NodeGroup, w Want, we error) {
 			testUnneededTime(t, p, ng, w, we)
 			testUnreadyTime(t, p, ng, w, we)
@@ -135,6 +162,8 @@ func TestDelegatingNodeGroupConfigProcessor(t *testing.T) {
 			testGpuThreshold(t, p, ng, w, we)
 			testMaxNodeProvisionTime(t, p, ng, w, we)
 			testIgnoreDSUtilization(t, p, ng, w, we)
+			testMaxScaleUpNodesPerMinute(t, p, ng, w, we)
+			testMaxScaleUpGrowthPercent(t, p, ng, w, we)
 		},
 		"RepeatingTheSameCallGivesConsistentResults": func(t *testing.T, p NodeGroupConfigProcessor, ng cloudprovider.NodeGroup, w Want, we error) {
 			testUnneededTime(t, p, ng, w, we)
@@ -0,0 +1,474 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegroupconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	klog "k8s.io/klog/v2"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/client/clientset/versioned"
+	"k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/client/informers/externalversions"
+	listers "k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/client/listers/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+)
+
+const nodeGroupPolicyClientCallTimeout = 4 * time.Second
+
+// nodeGroupOverrides contains option values merged from all policies selecting a node group.
+// Nil fields aren't overridden by any policy.
+type nodeGroupOverrides struct {
+	minSize                          *int
+	maxSize                          *int
+	scaleDownUtilizationThreshold    *float64
+	scaleDownGpuUtilizationThreshold *float64
+	scaleDownUnneededTime            *time.Duration
+	scaleDownUnreadyTime             *time.Duration
+	maxNodeProvisionTime             *time.Duration
+	ignoreDaemonSetsUtilization      *bool
+	maxScaleUpNodesPerMinute         *int
+	maxScaleUpGrowthPercent          *int
+}
+
+// compiledPolicy is a validated NodeGroupPolicy, ready to be matched against node groups.
+type compiledPolicy struct {
+	idRegex        *regexp.Regexp
+	templateLabels labels.Selector
+	overrides      nodeGroupOverrides
+}
+
+// NodeGroupPolicyProcessor overrides config values returned by another NodeGroupConfigProcessor
+// with values set in NodeGroupPolicy objects selecting the node group.
+type NodeGroupPolicyProcessor struct {
+	delegate  NodeGroupConfigProcessor
+	client    versioned.Interface
+	lister    listers.NodeGroupPolicyLister
+	overrides map[string]*nodeGroupOverrides
+	// writtenStatuses remembers the last status written for each policy, to avoid writing it
+	// again before the lister observes the update.
+	writtenStatuses map[string]writtenStatus
+	// skipStatusUpdates is true if statuses of policies aren't written.
+	skipStatusUpdates bool
+}
+
+type writtenStatus struct {
+	baseResourceVersion string
+	status              v1alpha1.NodeGroupPolicyStatus
+}
+
+// NewNodeGroupPolicyProcessor returns a NodeGroupPolicyProcessor applying policies listed by lister
+// on top of config values returned by delegate. Status of the policies is written using client.
+func NewNodeGroupPolicyProcessor(delegate NodeGroupConfigProcessor, client versioned.Interface, lister listers.NodeGroupPolicyLister) *NodeGroupPolicyProcessor {
+	return &NodeGroupPolicyProcessor{
+		delegate:        delegate,
+		client:          client,
+		lister:          lister,
+		overrides:       map[string]*nodeGroupOverrides{},
+		writtenStatuses: map[string]writtenStatus{},
+	}
+}
+
+// WithoutStatusUpdates makes the processor only read NodeGroupPolicies, e.g. in dry-run mode.
+func (p *NodeGroupPolicyProcessor) WithoutStatusUpdates() *NodeGroupPolicyProcessor {
+	p.skipStatusUpdates = true
+	return p
+}
+
+// NewNodeGroupPolicyLister creates a lister for the NodeGroupPolicies in the cluster.
+func NewNodeGroupPolicyLister(client versioned.Interface, stopChannel <-chan struct{}) (listers.NodeGroupPolicyLister, error) {
+	factory := externalversions.NewSharedInformerFactory(client, 1*time.Hour)
+	lister := factory.Autoscaling().V1alpha1().NodeGroupPolicies().Lister()
+	factory.Start(stopChannel)
+	informersSynced := factory.WaitForCacheSync(stopChannel)
+	for _, synced := range informersSynced {
+		if !synced {
+			return nil, fmt.Errorf("can't create NodeGroupPolicy lister")
+		}
+	}
+	klog.V(2).Info("Successful initial NodeGroupPolicy sync")
+	return lister, nil
+}
+
+// Refresh matches NodeGroupPolicies against node groups and merges options of the policies
+// selecting each node group. Status of the policies is updated if it changed.
+func (p *NodeGroupPolicyProcessor) Refresh(nodeGroups []cloudprovider.NodeGroup, nodeInfos map[string]*schedulerframework.NodeInfo) error {
+	if err := p.delegate.Refresh(nodeGroups, nodeInfos); err != nil {
+		return err
+	}
+	policies, err := p.lister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list NodeGroupPolicies: %v", err)
+	}
+	// Highest priority first, so that the first policy setting an option wins.
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Spec.Priority != policies[j].Spec.Priority {
+			return policies[i].Spec.Priority > policies[j].Spec.Priority
+		}
+		return policies[i].Name < policies[j].Name
+	})
+
+	overrides := map[string]*nodeGroupOverrides{}
+	for _, policy := range policies {
+		compiled, err := compilePolicy(policy)
+		if err != nil {
+			klog.Warningf("Ignoring invalid NodeGroupPolicy %s: %v", policy.Name, err)
+			p.updateStatus(policy, nil, err)
+			continue
+		}
+		var matched []string
+		for _, nodeGroup := range nodeGroups {
+			if !compiled.matches(nodeGroup.Id(), nodeInfos[nodeGroup.Id()]) {
+				continue
+			}
+			matched = append(matched, nodeGroup.Id())
+			if overrides[nodeGroup.Id()] == nil {
+				overrides[nodeGroup.Id()] = &nodeGroupOverrides{}
+			}
+			overrides[nodeGroup.Id()].merge(&compiled.overrides)
+		}
+		sort.Strings(matched)
+		p.updateStatus(policy, matched, nil)
+	}
+	p.overrides = overrides
+	return nil
+}
+
+func (p *NodeGroupPolicyProcessor) updateStatus(policy *v1alpha1.NodeGroupPolicy, matched []string, validationErr error) {
+	if p.skipStatusUpdates {
+		return
+	}
+	current := policy.Status
+	// The lister may not have observed the last status update yet.
+	if written, found := p.writtenStatuses[policy.Name]; found && written.baseResourceVersion == policy.ResourceVersion {
+		current = written.status
+	}
+	updated := policy.DeepCopy()
+	updated.Status = *current.DeepCopy()
+	updated.Status.ObservedGeneration = policy.Generation
+	updated.Status.MatchedNodeGroups = matched
+	condition := metav1.Condition{
+		Type:               v1alpha1.PolicyValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.PolicyValidReason,
+		ObservedGeneration: policy.Generation,
+	}
+	if validationErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1alpha1.PolicyInvalidReason
+		condition.Message = validationErr.Error()
+	}
+	meta.SetStatusCondition(&updated.Status.Conditions, condition)
+	if reflect.DeepEqual(current, updated.Status) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), nodeGroupPolicyClientCallTimeout)
+	defer cancel()
+	if _, err := p.client.AutoscalingV1alpha1().NodeGroupPolicies().UpdateStatus(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		klog.Warningf("Failed to update status of NodeGroupPolicy %s: %v", policy.Name, err)
+		return
+	}
+	p.writtenStatuses[policy.Name] = writtenStatus{baseResourceVersion: policy.ResourceVersion, status: updated.Status}
+}
+
+func compilePolicy(policy *v1alpha1.NodeGroupPolicy) (*compiledPolicy, error) {
+	spec := policy.Spec
+	compiled := &compiledPolicy{}
+	if spec.Selector.NodeGroupIDRegex == "" && spec.Selector.TemplateLabels == nil {
+		return nil, fmt.Errorf("selector must set nodeGroupIdRegex or templateLabels")
+	}
+	if spec.Selector.NodeGroupIDRegex != "" {
+		idRegex, err := regexp.Compile("^(?:" + spec.Selector.NodeGroupIDRegex + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid nodeGroupIdRegex: %v", err)
+		}
+		compiled.idRegex = idRegex
+	}
+	if spec.Selector.TemplateLabels != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.Selector.TemplateLabels)
+		if err != nil {
+			return nil, fmt.Errorf("invalid templateLabels: %v", err)
+		}
+		compiled.templateLabels = selector
+	}
+
+	if spec.MinSize != nil && spec.MaxSize != nil && *spec.MinSize > *spec.MaxSize {
+		return nil, fmt.Errorf("minSize %d is greater than maxSize %d", *spec.MinSize, *spec.MaxSize)
+	}
+	if spec.MinSize != nil {
+		minSize := int(*spec.MinSize)
+		compiled.overrides.minSize = &minSize
+	}
+	if spec.MaxSize != nil {
+		maxSize := int(*spec.MaxSize)
+		compiled.overrides.maxSize = &maxSize
+	}
+	var err error
+	if compiled.overrides.scaleDownUtilizationThreshold, err = parseThreshold(spec.ScaleDownUtilizationThreshold); err != nil {
+		return nil, fmt.Errorf("invalid scaleDownUtilizationThreshold: %v", err)
+	}
+	if compiled.overrides.scaleDownGpuUtilizationThreshold, err = parseThreshold(spec.ScaleDownGpuUtilizationThreshold); err != nil {
+		return nil, fmt.Errorf("invalid scaleDownGpuUtilizationThreshold: %v", err)
+	}
+	if compiled.overrides.scaleDownUnneededTime, err = parseDuration(spec.ScaleDownUnneededTime); err != nil {
+		return nil, fmt.Errorf("invalid scaleDownUnneededTime: %v", err)
+	}
+	if compiled.overrides.scaleDownUnreadyTime, err = parseDuration(spec.ScaleDownUnreadyTime); err != nil {
+		return nil, fmt.Errorf("invalid scaleDownUnreadyTime: %v", err)
+	}
+	if compiled.overrides.maxNodeProvisionTime, err = parseDuration(spec.MaxNodeProvisionTime); err != nil {
+		return nil, fmt.Errorf("invalid maxNodeProvisionTime: %v", err)
+	}
+	compiled.overrides.ignoreDaemonSetsUtilization = spec.IgnoreDaemonSetsUtilization
+	if compiled.overrides.maxScaleUpNodesPerMinute, err = parseNonNegative(spec.MaxScaleUpNodesPerMinute); err != nil {
+		return nil, fmt.Errorf("invalid maxScaleUpNodesPerMinute: %v", err)
+	}
+	if compiled.overrides.maxScaleUpGrowthPercent, err = parseNonNegative(spec.MaxScaleUpGrowthPercent); err != nil {
+		return nil, fmt.Errorf("invalid maxScaleUpGrowthPercent: %v", err)
+	}
+	return compiled, nil
+}
+
+func parseNonNegative(value *int32) (*int, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if *value < 0 {
+		return nil, fmt.Errorf("%d is negative", *value)
+	}
+	result := int(*value)
+	return &result, nil
+}
+
+func parseThreshold(value *string) (*float64, error) {
+	if value == nil {
+		return nil, nil
+	}
+	threshold, err := strconv.ParseFloat(*value, 64)
+	if err != nil {
+		return nil, err
+	}
+	if threshold < 0 || threshold > 1 {
+		return nil, fmt.Errorf("%v is not between 0 and 1", threshold)
+	}
+	return &threshold, nil
+}
+
+func parseDuration(value *metav1.Duration) (*time.Duration, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if value.Duration < 0 {
+		return nil, fmt.Errorf("%v is negative", value.Duration)
+	}
+	return &value.Duration, nil
+}
+
+// matches tells if the policy selects a node group. Template labels can only be
+// matched if a template NodeInfo is available for the node group.
+func (c *compiledPolicy) matches(nodeGroupId string, nodeInfo *schedulerframework.NodeInfo) bool {
+	if c.idRegex != nil && !c.idRegex.MatchString(nodeGroupId) {
+		return false
+	}
+	if c.templateLabels != nil {
+		if nodeInfo == nil || nodeInfo.Node() == nil {
+			return false
+		}
+		if !c.templateLabels.Matches(labels.Set(nodeInfo.Node().Labels)) {
+			return false
+		}
+	}
+	return true
+}
+
+// merge sets options which are not set yet to values from other.
+func (o *nodeGroupOverrides) merge(other *nodeGroupOverrides) {
+	if o.minSize == nil {
+		o.minSize = other.minSize
+	}
+	if o.maxSize == nil {
+		o.maxSize = other.maxSize
+	}
+	if o.scaleDownUtilizationThreshold == nil {
+		o.scaleDownUtilizationThreshold = other.scaleDownUtilizationThreshold
+	}
+	if o.scaleDownGpuUtilizationThreshold == nil {
+		o.scaleDownGpuUtilizationThreshold = other.scaleDownGpuUtilizationThreshold
+	}
+	if o.scaleDownUnneededTime == nil {
+		o.scaleDownUnneededTime = other.scaleDownUnneededTime
+	}
+	if o.scaleDownUnreadyTime == nil {
+		o.scaleDownUnreadyTime = other.scaleDownUnreadyTime
+	}
+	if o.maxNodeProvisionTime == nil {
+		o.maxNodeProvisionTime = other.maxNodeProvisionTime
+	}
+	if o.ignoreDaemonSetsUtilization == nil {
+		o.ignoreDaemonSetsUtilization = other.ignoreDaemonSetsUtilization
+	}
+	if o.maxScaleUpNodesPerMinute == nil {
+		o.maxScaleUpNodesPerMinute = other.maxScaleUpNodesPerMinute
+	}
+	if o.maxScaleUpGrowthPercent == nil {
+		o.maxScaleUpGrowthPercent = other.maxScaleUpGrowthPercent
+	}
+}
+
+func (p *NodeGroupPolicyProcessor) overridesFor(nodeGroup cloudprovider.NodeGroup) *nodeGroupOverrides {
+	if o, found := p.overrides[nodeGroup.Id()]; found {
+		return o
+	}
+	return &nodeGroupOverrides{}
+}
+
+// GetScaleDownUnneededTime returns ScaleDownUnneededTime value that should be used for a given NodeGroup.
+func (p *NodeGroupPolicyProcessor) GetScaleDownUnneededTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error) {
+	if o := p.overridesFor(nodeGroup); o.scaleDownUnneededTime != nil {
+		return *o.scaleDownUnneededTime, nil
+	}
+	return p.delegate.GetScaleDownUnneededTime(nodeGroup)
+}
+
+// GetScaleDownUnreadyTime returns ScaleDownUnreadyTime value that should be used for a given NodeGroup.
+func (p *NodeGroupPolicyProcessor) GetScaleDownUnreadyTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error) {
+	if o := p.overridesFor(nodeGroup); o.scaleDownUnreadyTime != nil {
+		return *o.scaleDownUnreadyTime, nil
+	}
+	return p.delegate.GetScaleDownUnreadyTime(nodeGroup)
+}
+
+// GetScaleDownUtilizationThreshold returns ScaleDownUtilizationThreshold value that should be used for a given NodeGroup.
+func (p *NodeGroupPolicyProcessor) GetScaleDownUtilizationThreshold(nodeGroup cloudprovider.NodeGroup) (float64, error) {
+	if o := p.overridesFor(nodeGroup); o.scaleDownUtilizationThreshold != nil {
+		return *o.scaleDownUtilizationThreshold, nil
+	}
+	return p.delegate.GetScaleDownUtilizationThreshold(nodeGroup)
+}
+
+// GetScaleDownGpuUtilizationThreshold returns ScaleDownGpuUtilizationThreshold value that should be used for a given NodeGroup.
+func (p *NodeGroupPolicyProcessor) GetScaleDownGpuUtilizationThreshold(nodeGroup cloudprovider.NodeGroup) (float64, error) {
+	if o := p.overridesFor(nodeGroup); o.scaleDownGpuUtilizationThreshold != nil {
+		return *o.scaleDownGpuUtilizationThreshold, nil
+	}
+	return p.delegate.GetScaleDownGpuUtilizationThreshold(nodeGroup)
+}
+
+// GetMaxNodeProvisionTime returns MaxNodeProvisionTime value that should be used for a given NodeGroup.
+func (p *NodeGroupPolicyProcessor) GetMaxNodeProvisionTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error) {
+	if o := p.overridesFor(nodeGroup); o.maxNodeProvisionTime != nil {
+		return *o.maxNodeProvisionTime, nil
+	}
+	return p.delegate.GetMaxNodeProvisionTime(nodeGroup)
+}
+
+// GetIgnoreDaemonSetsUtilization returns IgnoreDaemonSetsUtilization value that should be used for a given NodeGroup.
+func (p *NodeGroupPolicyProcessor) GetIgnoreDaemonSetsUtilization(nodeGroup cloudprovider.NodeGroup) (bool, error) {
+	if o := p.overridesFor(nodeGroup); o.ignoreDaemonSetsUtilization != nil {
+		return *o.ignoreDaemonSetsUtilization, nil
+	}
+	return p.delegate.GetIgnoreDaemonSetsUtilization(nodeGroup)
+}
+
+// GetMaxScaleUpNodesPerMinute returns MaxScaleUpNodesPerMinute value that should be used for a given NodeGroup.
+func (p *NodeGroupPolicyProcessor) GetMaxScaleUpNodesPerMinute(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	if o := p.overridesFor(nodeGroup); o.maxScaleUpNodesPerMinute != nil {
+		return *o.maxScaleUpNodesPerMinute, nil
+	}
+	return p.delegate.GetMaxScaleUpNodesPerMinute(nodeGroup)
+}
+
+// GetMaxScaleUpGrowthPercent returns MaxScaleUpGrowthPercent value that should be used for a given NodeGroup.
+func (p *NodeGroupPolicyProcessor) GetMaxScaleUpGrowthPercent(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	if o := p.overridesFor(nodeGroup); o.maxScaleUpGrowthPercent != nil {
+		return *o.maxScaleUpGrowthPercent, nil
+	}
+	return p.delegate.GetMaxScaleUpGrowthPercent(nodeGroup)
+}
+
+// GetMinSize returns the minimum size that should be used for a given NodeGroup. Policies can
+// only narrow the size range of the node group, so the result is kept within the range returned
+// by the delegate and never exceeds the max size.
+func (p *NodeGroupPolicyProcessor) GetMinSize(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	minSize, err := p.delegate.GetMinSize(nodeGroup)
+	if err != nil {
+		return 0, err
+	}
+	o := p.overridesFor(nodeGroup)
+	if o.minSize == nil || *o.minSize <= minSize {
+		return minSize, nil
+	}
+	maxSize, err := p.GetMaxSize(nodeGroup)
+	if err != nil {
+		return 0, err
+	}
+	if *o.minSize > maxSize {
+		return maxSize, nil
+	}
+	return *o.minSize, nil
+}
+
+// GetMaxSize returns the maximum size that should be used for a given NodeGroup. Policies can
+// only narrow the size range of the node group, so the result is kept within the range returned
+// by the delegate.
+func (p *NodeGroupPolicyProcessor) GetMaxSize(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	maxSize, err := p.delegate.GetMaxSize(nodeGroup)
+	if err != nil {
+		return 0, err
+	}
+	o := p.overridesFor(nodeGroup)
+	if o.maxSize == nil || *o.maxSize >= maxSize {
+		return maxSize, nil
+	}
+	minSize, err := p.delegate.GetMinSize(nodeGroup)
+	if err != nil {
+		return 0, err
+	}
+	if *o.maxSize < minSize {
+		return minSize, nil
+	}
+	return *o.maxSize, nil
+}
+
+// CleanUp cleans up processor's internal structures.
+func (p *NodeGroupPolicyProcessor) CleanUp() {
+	p.delegate.CleanUp()
+}
+
+// optionsUpdater matches processors.OptionsUpdater, which can't be imported here.
+type optionsUpdater interface {
+	UpdateOptions(options config.AutoscalingOptions)
+}
+
+// UpdateOptions passes reloaded options to the delegate, policies keep overriding them.
+func (p *NodeGroupPolicyProcessor) UpdateOptions(options config.AutoscalingOptions) {
+	if updater, ok := p.delegate.(optionsUpdater); ok {
+		updater.UpdateOptions(options)
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegroupconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/autoscaler/cluster-autoscaler/apis/nodegrouppolicy/client/clientset/versioned/fake"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestNodeGroupPolicyProcessor(t *testing.T) {
+	defaults := config.NodeGroupAutoscalingOptions{
+		ScaleDownUnneededTime:         10 * time.Minute,
+		ScaleDownUtilizationThreshold: 0.5,
+	}
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng-1", 0, 10, 1)
+	provider.AddNodeGroup("ng-2", 1, 10, 1)
+	provider.AddNodeGroup("other", 0, 10, 1)
+	nodeInfos := map[string]*schedulerframework.NodeInfo{}
+	for id, nodeLabels := range map[string]map[string]string{
+		"ng-1":  {"pool": "gpu"},
+		"ng-2":  {},
+		"other": {"pool": "gpu"},
+	} {
+		node := BuildTestNode(id+"-template", 1000, 1000)
+		node.Labels = nodeLabels
+		nodeInfo := schedulerframework.NewNodeInfo()
+		nodeInfo.SetNode(node)
+		nodeInfos[id] = nodeInfo
+	}
+
+	client := fake.NewSimpleClientset(
+		&v1alpha1.NodeGroupPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "high", Generation: 1},
+			Spec: v1alpha1.NodeGroupPolicySpec{
+				Selector:              v1alpha1.NodeGroupSelector{NodeGroupIDRegex: "ng-.*"},
+				Priority:              10,
+				MaxSize:               ptr.To[int32](3),
+				ScaleDownUnneededTime: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		&v1alpha1.NodeGroupPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "low", Generation: 1},
+			Spec: v1alpha1.NodeGroupPolicySpec{
+				Selector: v1alpha1.NodeGroupSelector{TemplateLabels: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"pool": "gpu"},
+				}},
+				MinSize:                       ptr.To[int32](20),
+				ScaleDownUnneededTime:         &metav1.Duration{Duration: 20 * time.Minute},
+				ScaleDownUtilizationThreshold: ptr.To("0.3"),
+				MaxScaleUpNodesPerMinute:      ptr.To[int32](4),
+			},
+		},
+		&v1alpha1.NodeGroupPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid", Generation: 1},
+			Spec: v1alpha1.NodeGroupPolicySpec{
+				Selector:                      v1alpha1.NodeGroupSelector{NodeGroupIDRegex: ".*"},
+				ScaleDownUtilizationThreshold: ptr.To("1.5"),
+			},
+		},
+	)
+	lister, err := NewNodeGroupPolicyLister(client, make(chan struct{}))
+	assert.NoError(t, err)
+	p := NewNodeGroupPolicyProcessor(NewDefaultNodeGroupConfigProcessor(defaults), client, lister)
+	assert.NoError(t, p.Refresh(provider.NodeGroups(), nodeInfos))
+
+	for _, tc := range []struct {
+		nodeGroup     string
+		unneededTime  time.Duration
+		threshold     float64
+		minSize       int
+		maxSize       int
+		unreadyTime   time.Duration
+		provisionTime time.Duration
+		nodesPerMin   int
+	}{
+		// Unneeded time from the policy with higher priority, min size capped to max size.
+		{nodeGroup: "ng-1", unneededTime: 5 * time.Minute, threshold: 0.3, minSize: 3, maxSize: 3, nodesPerMin: 4},
+		{nodeGroup: "ng-2", unneededTime: 5 * time.Minute, threshold: 0.5, minSize: 1, maxSize: 3},
+		// Min size can't exceed the cloud provider max size.
+		{nodeGroup: "other", unneededTime: 20 * time.Minute, threshold: 0.3, minSize: 10, maxSize: 10, nodesPerMin: 4},
+	} {
+		t.Run(tc.nodeGroup, func(t *testing.T) {
+			nodeGroup := provider.GetNodeGroup(tc.nodeGroup)
+			unneededTime, err := p.GetScaleDownUnneededTime(nodeGroup)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.unneededTime, unneededTime)
+			threshold, err := p.GetScaleDownUtilizationThreshold(nodeGroup)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.threshold, threshold)
+			minSize, maxSize := GetSizeRange(p, nodeGroup)
+			assert.Equal(t, tc.minSize, minSize)
+			assert.Equal(t, tc.maxSize, maxSize)
+			nodesPerMin, err := p.GetMaxScaleUpNodesPerMinute(nodeGroup)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.nodesPerMin, nodesPerMin)
+		})
+	}
+
+	wantStatus := map[string]struct {
+		valid   metav1.ConditionStatus
+		matched []string
+	}{
+		"high":    {valid: metav1.ConditionTrue, matched: []string{"ng-1", "ng-2"}},
+		"low":     {valid: metav1.ConditionTrue, matched: []string{"ng-1", "other"}},
+		"invalid": {valid: metav1.ConditionFalse},
+	}
+	for name, want := range wantStatus {
+		policy, err := client.AutoscalingV1alpha1().NodeGroupPolicies().Get(context.TODO(), name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, want.matched, policy.Status.MatchedNodeGroups, name)
+		assert.Equal(t, int64(1), policy.Status.ObservedGeneration, name)
+		condition := meta.FindStatusCondition(policy.Status.Conditions, v1alpha1.PolicyValid)
+		if assert.NotNil(t, condition, name) {
+			assert.Equal(t, want.valid, condition.Status, name)
+		}
+	}
+	// Unchanged status is not written again.
+	client.ClearActions()
+	assert.NoError(t, p.Refresh(provider.NodeGroups(), nodeInfos))
+	for _, action := range client.Actions() {
+		assert.NotEqual(t, "update", action.GetVerb(), "unexpected action %v", action)
+	}
+}
+
+func TestNodeGroupPolicyProcessorWithoutStatusUpdates(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng-1", 0, 10, 1)
+	client := fake.NewSimpleClientset(&v1alpha1.NodeGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Generation: 1},
+		Spec: v1alpha1.NodeGroupPolicySpec{
+			Selector: v1alpha1.NodeGroupSelector{NodeGroupIDRegex: "ng-.*"},
+			MaxSize:  ptr.To[int32](3),
+		},
+	})
+	lister, err := NewNodeGroupPolicyLister(client, make(chan struct{}))
+	assert.NoError(t, err)
+	p := NewNodeGroupPolicyProcessor(NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{}), client, lister).WithoutStatusUpdates()
+	client.ClearActions()
+	assert.NoError(t, p.Refresh(provider.NodeGroups(), map[string]*schedulerframework.NodeInfo{}))
+
+	_, maxSize := GetSizeRange(p, provider.GetNodeGroup("ng-1"))
+	assert.Equal(t, 3, maxSize)
+	for _, action := range client.Actions() {
+		assert.NotEqual(t, "update", action.GetVerb(), "unexpected action %v", action)
+	}
+}
+
+func TestCompilePolicy(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		spec    v1alpha1.NodeGroupPolicySpec
+		wantErr bool
+	}{
+		"empty selector": {
+			spec:    v1alpha1.NodeGroupPolicySpec{},
+			wantErr: true,
+		},
+		"invalid regex": {
+			spec:    v1alpha1.NodeGroupPolicySpec{Selector: v1alpha1.NodeGroupSelector{NodeGroupIDRegex: "("}},
+			wantErr: true,
+		},
+		"min size greater than max size": {
+			spec: v1alpha1.NodeGroupPolicySpec{
+				Selector: v1alpha1.NodeGroupSelector{NodeGroupIDRegex: "ng"},
+				MinSize:  ptr.To[int32](5),
+				MaxSize:  ptr.To[int32](4),
+			},
+			wantErr: true,
+		},
+		"threshold out of range": {
+			spec: v1alpha1.NodeGroupPolicySpec{
+				Selector:                         v1alpha1.NodeGroupSelector{NodeGroupIDRegex: "ng"},
+				ScaleDownGpuUtilizationThreshold: ptr.To("-0.1"),
+			},
+			wantErr: true,
+		},
+		"negative duration": {
+			spec: v1alpha1.NodeGroupPolicySpec{
+				Selector:             v1alpha1.NodeGroupSelector{NodeGroupIDRegex: "ng"},
+				MaxNodeProvisionTime: &metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: true,
+		},
+		"negative scale-up rate limit": {
+			spec: v1alpha1.NodeGroupPolicySpec{
+				Selector:                 v1alpha1.NodeGroupSelector{NodeGroupIDRegex: "ng"},
+				MaxScaleUpNodesPerMinute: ptr.To[int32](-1),
+			},
+			wantErr: true,
+		},
+		"valid": {
+			spec: v1alpha1.NodeGroupPolicySpec{
+				Selector:                    v1alpha1.NodeGroupSelector{NodeGroupIDRegex: "ng"},
+				MinSize:                     ptr.To[int32](1),
+				MaxSize:                     ptr.To[int32](1),
+				ScaleDownUnreadyTime:        &metav1.Duration{Duration: time.Minute},
+				IgnoreDaemonSetsUtilization: ptr.To(true),
+				MaxScaleUpGrowthPercent:     ptr.To[int32](20),
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			_, err := compilePolicy(&v1alpha1.NodeGroupPolicy{Spec: tc.spec})
+			assert.Equal(t, tc.wantErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestNodeGroupPolicyProcessorDelegates(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng", 1, 5, 1)
+	nodeGroup := provider.GetNodeGroup("ng")
+	nodeGroup.(*testprovider.TestNodeGroup).SetOptions(&config.NodeGroupAutoscalingOptions{
+		ScaleDownUnreadyTime: 7 * time.Minute,
+		MaxNodeProvisionTime: 8 * time.Minute,
+	})
+	client := fake.NewSimpleClientset()
+	lister, err := NewNodeGroupPolicyLister(client, make(chan struct{}))
+	assert.NoError(t, err)
+	p := NewNodeGroupPolicyProcessor(NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{}), client, lister).WithoutStatusUpdates()
+	assert.NoError(t, p.Refresh([]cloudprovider.NodeGroup{nodeGroup}, nil))
+
+	unreadyTime, err := p.GetScaleDownUnreadyTime(nodeGroup)
+	assert.NoError(t, err)
+	assert.Equal(t, 7*time.Minute, unreadyTime)
+	provisionTime, err := p.GetMaxNodeProvisionTime(nodeGroup)
+	assert.NoError(t, err)
+	assert.Equal(t, 8*time.Minute, provisionTime)
+	minSize, maxSize := GetSizeRange(p, nodeGroup)
+	assert.Equal(t, 1, minSize)
+	assert.Equal(t, 5, maxSize)
+}
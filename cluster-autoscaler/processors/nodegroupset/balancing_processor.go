@@ -20,7 +20,9 @@ import (
 	"sort"
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
 
@@ -30,6 +32,8 @@ import (
 // BalancingNodeGroupSetProcessor tries to keep similar node groups balanced on scale-up.
 type BalancingNodeGroupSetProcessor struct {
 	Comparator NodeInfoComparator
+	// VolumeListers, if set, are used to avoid balancing scale-ups to zones pods can't run in because of their volumes.
+	VolumeListers *VolumeListers
 }
 
 // FindSimilarNodeGroups returns a list of NodeGroups similar to the given one using the
@@ -73,10 +77,10 @@ func (b *BalancingNodeGroupSetProcessor) FindSimilarNodeGroups(context *context.
 //
 // Returns ScaleUpInfos for groups that need to be resized.
 //
-// MaxSize of each group will be respected. If newNodes > total free capacity
+// MaxSize of each group, as returned by sizeGetter, will be respected. If newNodes > total free capacity
 // of all NodeGroups it will be capped to total capacity. In particular if all
 // group already have MaxSize, empty list will be returned.
-func (b *BalancingNodeGroupSetProcessor) BalanceScaleUpBetweenGroups(context *context.AutoscalingContext, groups []cloudprovider.NodeGroup, newNodes int) ([]ScaleUpInfo, errors.AutoscalerError) {
+func (b *BalancingNodeGroupSetProcessor) BalanceScaleUpBetweenGroups(context *context.AutoscalingContext, groups []cloudprovider.NodeGroup, newNodes int, sizeGetter nodegroupconfig.NodeGroupSizeGetter) ([]ScaleUpInfo, errors.AutoscalerError) {
 	if len(groups) == 0 {
 		return []ScaleUpInfo{}, errors.NewAutoscalerError(
 			errors.InternalError, "Can't balance scale up between 0 groups")
@@ -92,8 +96,8 @@ func (b *BalancingNodeGroupSetProcessor) BalanceScaleUpBetweenGroups(context *co
 				errors.CloudProviderError,
 				"failed to get node group size: %v", err)
 		}
-		maxSize := ng.MaxSize()
-		if currentSize == maxSize {
+		_, maxSize := nodegroupconfig.GetSizeRange(sizeGetter, ng)
+		if currentSize >= maxSize {
 			// group already maxed, ignore it
 			continue
 		}
@@ -182,3 +186,8 @@ func (b *BalancingNodeGroupSetProcessor) BalanceScaleUpBetweenGroups(context *co
 
 // CleanUp performs final clean up of processor state.
 func (b *BalancingNodeGroupSetProcessor) CleanUp() {}
+
+// UpdateOptions rebuilds the comparator using balancing labels from reloaded options.
+func (b *BalancingNodeGroupSetProcessor) UpdateOptions(options config.AutoscalingOptions) {
+	b.Comparator = CreateNodeInfoComparatorFromOptions(options)
+}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
 )
@@ -48,7 +49,8 @@ type NodeGroupSetProcessor interface {
 	FindSimilarNodeGroups(context *context.AutoscalingContext, nodeGroup cloudprovider.NodeGroup,
 		nodeInfosForGroups map[string]*schedulerframework.NodeInfo) ([]cloudprovider.NodeGroup, errors.AutoscalerError)
 
-	BalanceScaleUpBetweenGroups(context *context.AutoscalingContext, groups []cloudprovider.NodeGroup, newNodes int) ([]ScaleUpInfo, errors.AutoscalerError)
+	// BalanceScaleUpBetweenGroups splits newNodes between groups, respecting their max sizes returned by sizeGetter.
+	BalanceScaleUpBetweenGroups(context *context.AutoscalingContext, groups []cloudprovider.NodeGroup, newNodes int, sizeGetter nodegroupconfig.NodeGroupSizeGetter) ([]ScaleUpInfo, errors.AutoscalerError)
 	CleanUp()
 }
 
@@ -63,7 +65,7 @@ func (n *NoOpNodeGroupSetProcessor) FindSimilarNodeGroups(context *context.Autos
 }
 
 // BalanceScaleUpBetweenGroups splits a scale-up between provided NodeGroups.
-func (n *NoOpNodeGroupSetProcessor) BalanceScaleUpBetweenGroups(context *context.AutoscalingContext, groups []cloudprovider.NodeGroup, newNodes int) ([]ScaleUpInfo, errors.AutoscalerError) {
+func (n *NoOpNodeGroupSetProcessor) BalanceScaleUpBetweenGroups(context *context.AutoscalingContext, groups []cloudprovider.NodeGroup, newNodes int, sizeGetter nodegroupconfig.NodeGroupSizeGetter) ([]ScaleUpInfo, errors.AutoscalerError) {
 	return []ScaleUpInfo{}, nil
 }
 
@@ -76,3 +78,21 @@ func NewDefaultNodeGroupSetProcessor(ignoredLabels []string, ratioOpts config.No
 		Comparator: CreateGenericNodeInfoComparator(ignoredLabels, ratioOpts),
 	}
 }
+
+// CreateNodeInfoComparatorFromOptions returns a NodeInfoComparator using the balancing labels from options,
+// or the cloud provider specific comparator ignoring the extra labels if no balancing labels are set.
+func CreateNodeInfoComparatorFromOptions(options config.AutoscalingOptions) NodeInfoComparator {
+	if len(options.BalancingLabels) > 0 {
+		return CreateLabelNodeInfoComparator(options.BalancingLabels)
+	}
+	nodeInfoComparatorBuilder := CreateGenericNodeInfoComparator
+	switch options.CloudProviderName {
+	case cloudprovider.AzureProviderName:
+		nodeInfoComparatorBuilder = CreateAzureNodeInfoComparator
+	case cloudprovider.AwsProviderName:
+		nodeInfoComparatorBuilder = CreateAwsNodeInfoComparator
+	case cloudprovider.GceProviderName:
+		nodeInfoComparatorBuilder = CreateGceNodeInfoComparator
+	}
+	return nodeInfoComparatorBuilder(options.BalancingExtraIgnoredLabels, options.NodeGroupSetRatios)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegroupset
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+
+	klog "k8s.io/klog/v2"
+)
+
+// PodAwareNodeGroupSetProcessor is implemented by NodeGroupSetProcessors which can narrow down
+// similar node groups based on the pods a scale-up is for.
+type PodAwareNodeGroupSetProcessor interface {
+	// FilterSimilarNodeGroups returns similar node groups which new nodes for the pods can be added to.
+	FilterSimilarNodeGroups(context *context.AutoscalingContext, nodeGroup cloudprovider.NodeGroup, similarNodeGroups []cloudprovider.NodeGroup,
+		pods []*apiv1.Pod, nodeInfosForGroups map[string]*schedulerframework.NodeInfo) []cloudprovider.NodeGroup
+}
+
+// VolumeListers provides persistent volumes and claims, used to find zones pods are pinned to.
+type VolumeListers struct {
+	PersistentVolumeClaimLister v1lister.PersistentVolumeClaimLister
+	PersistentVolumeLister      v1lister.PersistentVolumeLister
+}
+
+var zoneLabels = []string{apiv1.LabelTopologyZone, apiv1.LabelFailureDomainBetaZone}
+
+// FilterSimilarNodeGroups drops similar node groups in zones the pods can't run in because they use zonal
+// persistent volumes, so that balancing doesn't add nodes which the pods can't use. Node groups are kept if
+// any pod isn't pinned to a zone, or if the zone of their template is unknown.
+func (b *BalancingNodeGroupSetProcessor) FilterSimilarNodeGroups(context *context.AutoscalingContext, nodeGroup cloudprovider.NodeGroup, similarNodeGroups []cloudprovider.NodeGroup,
+	pods []*apiv1.Pod, nodeInfosForGroups map[string]*schedulerframework.NodeInfo) []cloudprovider.NodeGroup {
+	if b.VolumeListers == nil || len(similarNodeGroups) == 0 || len(pods) == 0 {
+		return similarNodeGroups
+	}
+	allowedZones := make(map[string]bool)
+	for _, pod := range pods {
+		zones := b.podZones(pod)
+		if len(zones) == 0 {
+			return similarNodeGroups
+		}
+		for zone := range zones {
+			allowedZones[zone] = true
+		}
+	}
+	var result []cloudprovider.NodeGroup
+	for _, ng := range similarNodeGroups {
+		zone := ""
+		if nodeInfo, found := nodeInfosForGroups[ng.Id()]; found {
+			zone = nodeZone(nodeInfo.Node())
+		}
+		if zone == "" || allowedZones[zone] {
+			result = append(result, ng)
+		} else {
+			klog.V(4).Infof("Not balancing scale-up of %s to %s, pods use volumes outside of its zone %s", nodeGroup.Id(), ng.Id(), zone)
+		}
+	}
+	return result
+}
+
+// podZones returns zones the pod is pinned to by its bound persistent volumes, or nil if it isn't pinned.
+func (b *BalancingNodeGroupSetProcessor) podZones(pod *apiv1.Pod) map[string]bool {
+	var zones map[string]bool
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc, err := b.VolumeListers.PersistentVolumeClaimLister.PersistentVolumeClaims(pod.Namespace).Get(volume.PersistentVolumeClaim.ClaimName)
+		if err != nil || pvc.Spec.VolumeName == "" {
+			continue
+		}
+		pv, err := b.VolumeListers.PersistentVolumeLister.Get(pvc.Spec.VolumeName)
+		if err != nil {
+			continue
+		}
+		volumeZones := persistentVolumeZones(pv)
+		if len(volumeZones) == 0 {
+			continue
+		}
+		if zones == nil {
+			zones = volumeZones
+			continue
+		}
+		for zone := range zones {
+			if !volumeZones[zone] {
+				delete(zones, zone)
+			}
+		}
+	}
+	return zones
+}
+
+// persistentVolumeZones returns zones the persistent volume is available in, based on its node affinity and labels.
+func persistentVolumeZones(pv *apiv1.PersistentVolume) map[string]bool {
+	zones := make(map[string]bool)
+	if pv.Spec.NodeAffinity != nil && pv.Spec.NodeAffinity.Required != nil {
+		for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+			for _, expression := range term.MatchExpressions {
+				if isZoneLabel(expression.Key) && expression.Operator == apiv1.NodeSelectorOpIn {
+					for _, zone := range expression.Values {
+						zones[zone] = true
+					}
+				}
+			}
+		}
+	}
+	for _, label := range zoneLabels {
+		if zone, found := pv.Labels[label]; found && zone != "" {
+			zones[zone] = true
+		}
+	}
+	return zones
+}
+
+func nodeZone(node *apiv1.Node) string {
+	for _, label := range zoneLabels {
+		if zone, found := node.Labels[label]; found {
+			return zone
+		}
+	}
+	return ""
+}
+
+func isZoneLabel(key string) bool {
+	for _, label := range zoneLabels {
+		if key == label {
+			return true
+		}
+	}
+	return false
+}
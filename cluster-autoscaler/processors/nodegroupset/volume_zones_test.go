@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegroupset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func buildZonalVolume(name, zone string, useAffinity bool) (*apiv1.PersistentVolumeClaim, *apiv1.PersistentVolume) {
+	pvc := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       apiv1.PersistentVolumeClaimSpec{VolumeName: "pv-" + name},
+	}
+	pv := &apiv1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv-" + name}}
+	if useAffinity {
+		pv.Spec.NodeAffinity = &apiv1.VolumeNodeAffinity{
+			Required: &apiv1.NodeSelector{
+				NodeSelectorTerms: []apiv1.NodeSelectorTerm{{
+					MatchExpressions: []apiv1.NodeSelectorRequirement{{
+						Key:      apiv1.LabelTopologyZone,
+						Operator: apiv1.NodeSelectorOpIn,
+						Values:   []string{zone},
+					}},
+				}},
+			},
+		}
+	} else {
+		pv.Labels = map[string]string{apiv1.LabelFailureDomainBetaZone: zone}
+	}
+	return pvc, pv
+}
+
+func buildPodWithClaim(name, claim string) *apiv1.Pod {
+	pod := BuildTestPod(name, 100, 100)
+	if claim != "" {
+		pod.Spec.Volumes = []apiv1.Volume{{
+			Name: "data",
+			VolumeSource: apiv1.VolumeSource{
+				PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+			},
+		}}
+	}
+	return pod
+}
+
+func TestFilterSimilarNodeGroupsByVolumeZones(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	nodeInfos := map[string]*schedulerframework.NodeInfo{}
+	for _, ng := range []struct{ id, zone string }{{"ng-a", "zone-a"}, {"ng-b", "zone-b"}, {"ng-c", "zone-c"}, {"ng-unknown", ""}} {
+		provider.AddNodeGroup(ng.id, 0, 10, 0)
+		node := BuildTestNode(ng.id+"-template", 1000, 1000)
+		if ng.zone != "" {
+			node.Labels[apiv1.LabelTopologyZone] = ng.zone
+		}
+		nodeInfo := schedulerframework.NewNodeInfo()
+		nodeInfo.SetNode(node)
+		nodeInfos[ng.id] = nodeInfo
+	}
+	similar := []cloudprovider.NodeGroup{provider.GetNodeGroup("ng-b"), provider.GetNodeGroup("ng-c"), provider.GetNodeGroup("ng-unknown")}
+
+	pvcIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	pvIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, volume := range []struct {
+		name, zone  string
+		useAffinity bool
+	}{{"data-a", "zone-a", true}, {"data-b", "zone-b", false}} {
+		pvc, pv := buildZonalVolume(volume.name, volume.zone, volume.useAffinity)
+		assert.NoError(t, pvcIndexer.Add(pvc))
+		assert.NoError(t, pvIndexer.Add(pv))
+	}
+	unbound := &apiv1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "unbound", Namespace: "default"}}
+	assert.NoError(t, pvcIndexer.Add(unbound))
+
+	processor := &BalancingNodeGroupSetProcessor{
+		VolumeListers: &VolumeListers{
+			PersistentVolumeClaimLister: v1lister.NewPersistentVolumeClaimLister(pvcIndexer),
+			PersistentVolumeLister:      v1lister.NewPersistentVolumeLister(pvIndexer),
+		},
+	}
+	testCases := map[string]struct {
+		pods []*apiv1.Pod
+		want []string
+	}{
+		"pods pinned to zone a": {
+			pods: []*apiv1.Pod{buildPodWithClaim("p1", "data-a")},
+			want: []string{"ng-unknown"},
+		},
+		"pods pinned to zones a and b": {
+			pods: []*apiv1.Pod{buildPodWithClaim("p1", "data-a"), buildPodWithClaim("p2", "data-b")},
+			want: []string{"ng-b", "ng-unknown"},
+		},
+		"some pods not pinned": {
+			pods: []*apiv1.Pod{buildPodWithClaim("p1", "data-a"), buildPodWithClaim("p2", "")},
+			want: []string{"ng-b", "ng-c", "ng-unknown"},
+		},
+		"unbound claim": {
+			pods: []*apiv1.Pod{buildPodWithClaim("p1", "unbound")},
+			want: []string{"ng-b", "ng-c", "ng-unknown"},
+		},
+		"missing claim": {
+			pods: []*apiv1.Pod{buildPodWithClaim("p1", "missing")},
+			want: []string{"ng-b", "ng-c", "ng-unknown"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := &context.AutoscalingContext{CloudProvider: provider}
+			var got []string
+			for _, ng := range processor.FilterSimilarNodeGroups(ctx, provider.GetNodeGroup("ng-a"), similar, tc.pods, nodeInfos) {
+				got = append(got, ng.Id())
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
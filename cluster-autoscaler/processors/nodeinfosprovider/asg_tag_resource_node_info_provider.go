@@ -39,6 +39,14 @@ func NewAsgTagResourceNodeInfoProvider(t *time.Duration, forceDaemonSets bool) *
 	}
 }
 
+// NewCustomAsgTagResourceNodeInfoProvider returns AsgTagResourceNodeInfoProvider wrapping
+// the given MixedTemplateNodeInfoProvider.
+func NewCustomAsgTagResourceNodeInfoProvider(mixedTemplateNodeInfoProvider *MixedTemplateNodeInfoProvider) *AsgTagResourceNodeInfoProvider {
+	return &AsgTagResourceNodeInfoProvider{
+		mixedTemplateNodeInfoProvider: mixedTemplateNodeInfoProvider,
+	}
+}
+
 // Process returns the nodeInfos set for this cluster.
 func (p *AsgTagResourceNodeInfoProvider) Process(ctx *context.AutoscalingContext, nodes []*apiv1.Node, daemonsets []*appsv1.DaemonSet, taintConfig taints.TaintConfig, currentTime time.Time) (map[string]*schedulerframework.NodeInfo, errors.AutoscalerError) {
 	nodeInfos, err := p.mixedTemplateNodeInfoProvider.Process(ctx, nodes, daemonsets, taintConfig, currentTime)
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeinfosprovider
+
+import (
+	"reflect"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+	volumeutil "k8s.io/kubernetes/pkg/volume/util"
+)
+
+// CSILimitsNodeInfoProvider is a wrapper for TemplateNodeInfoProvider adding CSI volume attach limits
+// of nodes of node groups to their template NodeInfos. Template nodes don't have CSINode objects,
+// so without the limits scheduler simulations assume they can attach any number of volumes. The limits
+// are learned from CSINode objects of nodes of the node group and kept after it scales down to zero.
+type CSILimitsNodeInfoProvider struct {
+	templateNodeInfoProvider TemplateNodeInfoProvider
+	csiNodeLister            storagelisters.CSINodeLister
+	// limits contains attach limits by node group ID, keyed by the attach limit resource name of the CSI driver.
+	limits map[string]map[apiv1.ResourceName]int64
+}
+
+// NewCSILimitsNodeInfoProvider returns CSILimitsNodeInfoProvider wrapping TemplateNodeInfoProvider.
+func NewCSILimitsNodeInfoProvider(templateNodeInfoProvider TemplateNodeInfoProvider, csiNodeLister storagelisters.CSINodeLister) *CSILimitsNodeInfoProvider {
+	return &CSILimitsNodeInfoProvider{
+		templateNodeInfoProvider: templateNodeInfoProvider,
+		csiNodeLister:            csiNodeLister,
+		limits:                   make(map[string]map[apiv1.ResourceName]int64),
+	}
+}
+
+// Process returns the nodeInfos set for this cluster.
+func (p *CSILimitsNodeInfoProvider) Process(ctx *context.AutoscalingContext, nodes []*apiv1.Node, daemonsets []*appsv1.DaemonSet, taintConfig taints.TaintConfig, currentTime time.Time) (map[string]*schedulerframework.NodeInfo, errors.AutoscalerError) {
+	nodeInfos, err := p.templateNodeInfoProvider.Process(ctx, nodes, daemonsets, taintConfig, currentTime)
+	if err != nil {
+		return nil, err
+	}
+	p.learnLimits(ctx, nodes)
+	for id, nodeInfo := range nodeInfos {
+		limits := p.limits[id]
+		if len(limits) == 0 {
+			continue
+		}
+		var node *apiv1.Node
+		for resourceName, count := range limits {
+			if _, found := nodeInfo.Node().Status.Allocatable[resourceName]; found {
+				continue
+			}
+			if node == nil {
+				node = nodeInfo.Node().DeepCopy()
+				if node.Status.Allocatable == nil {
+					node.Status.Allocatable = apiv1.ResourceList{}
+				}
+			}
+			node.Status.Allocatable[resourceName] = *resource.NewQuantity(count, resource.DecimalSI)
+		}
+		if node != nil {
+			// SetNode recomputes allocatable resources of the NodeInfo, including volume limits.
+			nodeInfo.SetNode(node)
+		}
+	}
+	return nodeInfos, nil
+}
+
+// learnLimits updates attach limits of node groups which have nodes with CSINode objects. If nodes of a node
+// group have different limits, e.g. because a driver isn't registered yet on some of them, the lowest positive
+// limit of every driver is used.
+func (p *CSILimitsNodeInfoProvider) learnLimits(ctx *context.AutoscalingContext, nodes []*apiv1.Node) {
+	learned := make(map[string]map[apiv1.ResourceName]int64)
+	for _, node := range nodes {
+		nodeGroup, err := ctx.CloudProvider.NodeGroupForNode(node)
+		if err != nil || nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+			continue
+		}
+		csiNode, err := p.csiNodeLister.Get(node.Name)
+		if err != nil {
+			continue
+		}
+		id := nodeGroup.Id()
+		for _, driver := range csiNode.Spec.Drivers {
+			if driver.Allocatable == nil || driver.Allocatable.Count == nil || *driver.Allocatable.Count <= 0 {
+				continue
+			}
+			if learned[id] == nil {
+				learned[id] = make(map[apiv1.ResourceName]int64)
+			}
+			resourceName := apiv1.ResourceName(volumeutil.GetCSIAttachLimitKey(driver.Name))
+			count := int64(*driver.Allocatable.Count)
+			if current, found := learned[id][resourceName]; !found || count < current {
+				learned[id][resourceName] = count
+			}
+		}
+	}
+	for id, limits := range learned {
+		p.limits[id] = limits
+	}
+	// Forget node groups which no longer exist.
+	existing := make(map[string]bool)
+	for _, nodeGroup := range ctx.CloudProvider.NodeGroups() {
+		existing[nodeGroup.Id()] = true
+	}
+	for id := range p.limits {
+		if !existing[id] {
+			delete(p.limits, id)
+		}
+	}
+}
+
+// CleanUp cleans up processor's internal structures.
+func (p *CSILimitsNodeInfoProvider) CleanUp() {
+	p.templateNodeInfoProvider.CleanUp()
+}
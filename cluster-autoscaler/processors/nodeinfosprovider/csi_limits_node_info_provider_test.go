@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeinfosprovider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
+	"k8s.io/client-go/tools/cache"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+	volumeutil "k8s.io/kubernetes/pkg/volume/util"
+	"k8s.io/utils/ptr"
+)
+
+func buildCSINode(nodeName string, limits map[string]int32) *storagev1.CSINode {
+	csiNode := &storagev1.CSINode{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+	for driver, count := range limits {
+		csiNode.Spec.Drivers = append(csiNode.Spec.Drivers, storagev1.CSINodeDriver{
+			Name:        driver,
+			NodeID:      nodeName,
+			Allocatable: &storagev1.VolumeNodeResources{Count: ptr.To(count)},
+		})
+	}
+	return csiNode
+}
+
+func TestCSILimitsNodeInfoProvider(t *testing.T) {
+	now := time.Now()
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, now.Add(-2*time.Minute))
+	n2 := BuildTestNode("n2", 1000, 1000)
+	SetNodeReadyState(n2, true, now.Add(-2*time.Minute))
+	n3 := BuildTestNode("n3", 1000, 1000)
+	SetNodeReadyState(n3, true, now.Add(-2*time.Minute))
+
+	tn := BuildTestNode("tn", 1000, 1000)
+	tni := schedulerframework.NewNodeInfo()
+	tni.SetNode(tn)
+
+	provider := testprovider.NewTestAutoprovisioningCloudProvider(nil, nil, nil, nil, nil,
+		map[string]*schedulerframework.NodeInfo{"ng1": tni, "ng2": tni, "ng3": tni})
+	provider.AddNodeGroup("ng1", 0, 10, 2)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	provider.AddNode("ng2", n3)
+	provider.AddNodeGroup("ng3", 0, 10, 0)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(buildCSINode("n1", map[string]int32{"ebs.csi.aws.com": 25, "efs.csi.aws.com": 100})))
+	assert.NoError(t, indexer.Add(buildCSINode("n2", map[string]int32{"ebs.csi.aws.com": 20})))
+	// n3 has no CSINode object, e.g. because the CSI driver isn't registered yet.
+
+	podLister := kube_util.NewTestPodLister([]*apiv1.Pod{})
+	registry := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+	ctx := context.AutoscalingContext{
+		CloudProvider: provider,
+		AutoscalingKubeClients: context.AutoscalingKubeClients{
+			ListerRegistry: registry,
+		},
+	}
+	ebsLimit := apiv1.ResourceName(volumeutil.GetCSIAttachLimitKey("ebs.csi.aws.com"))
+	efsLimit := apiv1.ResourceName(volumeutil.GetCSIAttachLimitKey("efs.csi.aws.com"))
+
+	p := NewCSILimitsNodeInfoProvider(NewMixedTemplateNodeInfoProvider(nil, false), storagelisters.NewCSINodeLister(indexer))
+	res, err := p.Process(&ctx, []*apiv1.Node{n1, n2, n3}, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+	assert.NoError(t, err)
+	assert.Len(t, res, 3)
+	// The lowest limit among nodes of the node group is used.
+	ebs := res["ng1"].Node().Status.Allocatable[ebsLimit]
+	assert.Equal(t, int64(20), ebs.Value())
+	efs := res["ng1"].Node().Status.Allocatable[efsLimit]
+	assert.Equal(t, int64(100), efs.Value())
+	assert.Equal(t, int64(20), res["ng1"].Allocatable.ScalarResources[ebsLimit])
+	assert.NotContains(t, res["ng2"].Node().Status.Allocatable, ebsLimit)
+	assert.NotContains(t, res["ng3"].Node().Status.Allocatable, ebsLimit)
+
+	// Limits are kept after the node group scales down to zero.
+	provider.DeleteNode(n1)
+	provider.DeleteNode(n2)
+	res, err = p.Process(&ctx, []*apiv1.Node{n3}, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+	assert.NoError(t, err)
+	ebs = res["ng1"].Node().Status.Allocatable[ebsLimit]
+	assert.Equal(t, int64(20), ebs.Value())
+	assert.NotContains(t, tn.Status.Allocatable, ebsLimit)
+}
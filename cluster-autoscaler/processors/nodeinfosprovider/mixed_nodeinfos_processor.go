@@ -37,6 +37,10 @@ import (
 const stabilizationDelay = 1 * time.Minute
 const maxCacheExpireTime = 87660 * time.Hour
 
+// templateSaveInterval is how often cached templates are saved to the TemplateStore
+// when the set of cached node groups doesn't change.
+const templateSaveInterval = 10 * time.Minute
+
 type cacheItem struct {
 	*schedulerframework.NodeInfo
 	added time.Time
@@ -47,6 +51,14 @@ type MixedTemplateNodeInfoProvider struct {
 	nodeInfoCache   map[string]cacheItem
 	ttl             time.Duration
 	forceDaemonSets bool
+
+	// store persists nodeInfoCache across restarts, nil if disabled.
+	store TemplateStore
+	// maxAge is the maximum age of templates loaded from the store.
+	maxAge      time.Duration
+	storeLoaded bool
+	lastSave    time.Time
+	savedGroups map[string]bool
 }
 
 // NewMixedTemplateNodeInfoProvider returns a NodeInfoProvider processor building
@@ -63,6 +75,16 @@ func NewMixedTemplateNodeInfoProvider(t *time.Duration, forceDaemonSets bool) *M
 	}
 }
 
+// WithTemplateStore makes the provider save templates built from real nodes to the store and
+// load them after a restart, so that node groups scaled to zero don't fall back to cloud provider
+// templates. Templates older than maxAge, or saved for a different cloud provider template of
+// the node group, are not loaded. Zero maxAge means no limit.
+func (p *MixedTemplateNodeInfoProvider) WithTemplateStore(store TemplateStore, maxAge time.Duration) *MixedTemplateNodeInfoProvider {
+	p.store = store
+	p.maxAge = maxAge
+	return p
+}
+
 func (p *MixedTemplateNodeInfoProvider) isCacheItemExpired(added time.Time) bool {
 	return time.Now().Sub(added) > p.ttl
 }
@@ -82,6 +104,9 @@ func (p *MixedTemplateNodeInfoProvider) Process(ctx *context.AutoscalingContext,
 	if err != nil {
 		return map[string]*schedulerframework.NodeInfo{}, err
 	}
+	if p.store != nil && !p.storeLoaded {
+		p.loadTemplates(ctx.CloudProvider, now)
+	}
 
 	// processNode returns information whether the nodeTemplate was generated and if there was an error.
 	processNode := func(node *apiv1.Node) (bool, string, errors.AutoscalerError) {
@@ -171,6 +196,10 @@ func (p *MixedTemplateNodeInfoProvider) Process(ctx *context.AutoscalingContext,
 		}
 	}
 
+	if p.store != nil && p.storeLoaded {
+		p.saveTemplates(ctx.CloudProvider, now)
+	}
+
 	// Last resort - unready/unschedulable nodes.
 	for _, node := range nodes {
 		// Allowing broken nodes
@@ -194,6 +223,84 @@ func (p *MixedTemplateNodeInfoProvider) Process(ctx *context.AutoscalingContext,
 	return result, nil
 }
 
+// loadTemplates fills the cache with valid templates from the store. Loading is retried in the
+// next loop on error, and templates aren't saved until they are loaded, so that a transient
+// error doesn't wipe saved templates.
+func (p *MixedTemplateNodeInfoProvider) loadTemplates(provider cloudprovider.CloudProvider, now time.Time) {
+	templates, err := p.store.Load()
+	if err != nil {
+		klog.Errorf("Failed to load template node infos: %v", err)
+		return
+	}
+	p.storeLoaded = true
+	p.savedGroups = make(map[string]bool)
+	for _, nodeGroup := range provider.NodeGroups() {
+		id := nodeGroup.Id()
+		template, found := templates[id]
+		if !found || template.Node == nil {
+			continue
+		}
+		if p.maxAge > 0 && now.Sub(template.Added) > p.maxAge {
+			klog.V(2).Infof("Not loading template node info for %s, it is older than %v", id, p.maxAge)
+			continue
+		}
+		if template.Fingerprint != "" {
+			fingerprint, err := templateFingerprint(nodeGroup)
+			if err != nil {
+				klog.Warningf("Not loading template node info for %s, failed to get node group template: %v", id, err)
+				continue
+			}
+			if fingerprint != template.Fingerprint {
+				klog.V(2).Infof("Not loading template node info for %s, node group template changed", id)
+				continue
+			}
+		}
+		if p.nodeInfoCache == nil {
+			p.nodeInfoCache = make(map[string]cacheItem)
+		}
+		p.nodeInfoCache[id] = cacheItem{NodeInfo: template.toNodeInfo(), added: template.Added}
+		p.savedGroups[id] = true
+	}
+	klog.V(1).Infof("Loaded %d template node infos", len(p.savedGroups))
+}
+
+// saveTemplates saves the cache to the store when the set of cached node groups
+// changed or templateSaveInterval passed since the last save.
+func (p *MixedTemplateNodeInfoProvider) saveTemplates(provider cloudprovider.CloudProvider, now time.Time) {
+	changed := len(p.savedGroups) != len(p.nodeInfoCache)
+	for id := range p.nodeInfoCache {
+		if !p.savedGroups[id] {
+			changed = true
+		}
+	}
+	if !changed && now.Sub(p.lastSave) < templateSaveInterval {
+		return
+	}
+	fingerprints := make(map[string]string)
+	for _, nodeGroup := range provider.NodeGroups() {
+		if _, found := p.nodeInfoCache[nodeGroup.Id()]; !found {
+			continue
+		}
+		fingerprint, err := templateFingerprint(nodeGroup)
+		if err != nil {
+			klog.Warningf("Failed to get template of node group %s: %v", nodeGroup.Id(), err)
+		}
+		fingerprints[nodeGroup.Id()] = fingerprint
+	}
+	templates := make(map[string]PersistedTemplate)
+	savedGroups := make(map[string]bool)
+	for id, item := range p.nodeInfoCache {
+		templates[id] = toPersistedTemplate(item.NodeInfo, fingerprints[id], item.added)
+		savedGroups[id] = true
+	}
+	if err := p.store.Save(templates); err != nil {
+		klog.Errorf("Failed to save template node infos: %v", err)
+		return
+	}
+	p.lastSave = now
+	p.savedGroups = savedGroups
+}
+
 func getPodsForNodes(listers kube_util.ListerRegistry) (map[string][]*apiv1.Pod, errors.AutoscalerError) {
 	pods, err := listers.AllPodLister().List()
 	if err != nil {
@@ -17,6 +17,7 @@ limitations under the License.
 package nodeinfosprovider
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -310,3 +311,77 @@ func getNodeResource(node *apiv1.Node, resource apiv1.ResourceName) int64 {
 
 	return nodeCapacityValue
 }
+
+func TestGetNodeInfosPersisted(t *testing.T) {
+	now := time.Now()
+	ready1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(ready1, true, now.Add(-2*time.Minute))
+	ready2 := BuildTestNode("n2", 2000, 2000)
+	SetNodeReadyState(ready2, true, now.Add(-2*time.Minute))
+	ready3 := BuildTestNode("n3", 3000, 3000)
+	SetNodeReadyState(ready3, true, now.Add(-2*time.Minute))
+
+	tn := BuildTestNode("tn", 10000, 10000)
+	tni := schedulerframework.NewNodeInfo()
+	tni.SetNode(tn)
+	changedTn := BuildTestNode("tn", 20000, 20000)
+	changedTni := schedulerframework.NewNodeInfo()
+	changedTni.SetNode(changedTn)
+
+	podLister := kube_util.NewTestPodLister([]*apiv1.Pod{})
+	registry := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+	predicateChecker, err := predicatechecker.NewTestPredicateChecker()
+	assert.NoError(t, err)
+	store := NewFileTemplateStore(filepath.Join(t.TempDir(), "templates.json"))
+	maxAge := time.Hour
+
+	// Learn templates from real nodes and save them.
+	provider1 := testprovider.NewTestAutoprovisioningCloudProvider(
+		nil, nil, nil, nil, nil,
+		map[string]*schedulerframework.NodeInfo{"ng1": tni, "ng2": tni, "ng3": tni})
+	provider1.AddNodeGroup("ng1", 0, 10, 1)
+	provider1.AddNode("ng1", ready1)
+	provider1.AddNodeGroup("ng2", 0, 10, 1)
+	provider1.AddNode("ng2", ready2)
+	provider1.AddNodeGroup("ng3", 0, 10, 1)
+	provider1.AddNode("ng3", ready3)
+	ctx := context.AutoscalingContext{
+		CloudProvider:    provider1,
+		PredicateChecker: predicateChecker,
+		AutoscalingKubeClients: context.AutoscalingKubeClients{
+			ListerRegistry: registry,
+		},
+	}
+	_, err = NewMixedTemplateNodeInfoProvider(nil, false).WithTemplateStore(store, maxAge).Process(&ctx, []*apiv1.Node{ready1, ready2, ready3}, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+	assert.NoError(t, err)
+	templates, loadErr := store.Load()
+	assert.NoError(t, loadErr)
+	assert.Len(t, templates, 3)
+
+	// Make the ng3 template too old to be loaded.
+	ng3 := templates["ng3"]
+	ng3.Added = now.Add(-2 * maxAge)
+	templates["ng3"] = ng3
+	assert.NoError(t, store.Save(templates))
+
+	// After a restart all node groups are at zero and the ng2 template has changed.
+	provider2 := testprovider.NewTestAutoprovisioningCloudProvider(
+		nil, nil, nil, nil, nil,
+		map[string]*schedulerframework.NodeInfo{"ng1": tni, "ng2": changedTni, "ng3": tni})
+	provider2.AddNodeGroup("ng1", 0, 10, 0)
+	provider2.AddNodeGroup("ng2", 0, 10, 0)
+	provider2.AddNodeGroup("ng3", 0, 10, 0)
+	ctx.CloudProvider = provider2
+	res, err := NewMixedTemplateNodeInfoProvider(nil, false).WithTemplateStore(store, maxAge).Process(&ctx, []*apiv1.Node{}, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(res))
+	assertEqualNodeCapacities(t, ready1, res["ng1"].Node())
+	assertEqualNodeCapacities(t, changedTn, res["ng2"].Node())
+	assertEqualNodeCapacities(t, tn, res["ng3"].Node())
+
+	// Templates which weren't loaded are dropped from the store.
+	templates, loadErr = store.Load()
+	assert.NoError(t, loadErr)
+	assert.Len(t, templates, 1)
+	assert.Contains(t, templates, "ng1")
+}
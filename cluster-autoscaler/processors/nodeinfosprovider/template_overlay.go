@@ -0,0 +1,317 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeinfosprovider
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	kube_client "k8s.io/client-go/kubernetes"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+	"sigs.k8s.io/yaml"
+
+	klog "k8s.io/klog/v2"
+)
+
+// TemplateOverlay declares properties of nodes of matching node groups, which are added to
+// templates of node groups without nodes.
+type TemplateOverlay struct {
+	// NodeGroupIdRegex has to fully match IDs of node groups the overlay applies to.
+	NodeGroupIdRegex string `json:"nodeGroupIdRegex"`
+	// Labels are added to template nodes, replacing labels with the same keys.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are added to template nodes, replacing annotations with the same keys.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Taints are added to template nodes, replacing taints with the same key and effect.
+	Taints []apiv1.Taint `json:"taints,omitempty"`
+	// Resources set both capacity and allocatable of template nodes, e.g. extended resources or ephemeral-storage.
+	Resources apiv1.ResourceList `json:"resources,omitempty"`
+
+	nodeGroupIdRegex *regexp.Regexp
+}
+
+// Matches returns true if the overlay applies to the node group.
+func (o *TemplateOverlay) Matches(nodeGroupId string) bool {
+	return o.nodeGroupIdRegex != nil && o.nodeGroupIdRegex.MatchString(nodeGroupId)
+}
+
+// apply adds properties declared by the overlay to the node.
+func (o *TemplateOverlay) apply(node *apiv1.Node) {
+	if len(o.Labels) > 0 && node.Labels == nil {
+		node.Labels = make(map[string]string)
+	}
+	for k, v := range o.Labels {
+		node.Labels[k] = v
+	}
+	if len(o.Annotations) > 0 && node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	for k, v := range o.Annotations {
+		node.Annotations[k] = v
+	}
+	for _, taint := range o.Taints {
+		replaced := false
+		for i := range node.Spec.Taints {
+			if node.Spec.Taints[i].Key == taint.Key && node.Spec.Taints[i].Effect == taint.Effect {
+				node.Spec.Taints[i] = taint
+				replaced = true
+			}
+		}
+		if !replaced {
+			node.Spec.Taints = append(node.Spec.Taints, taint)
+		}
+	}
+	if len(o.Resources) > 0 {
+		if node.Status.Capacity == nil {
+			node.Status.Capacity = apiv1.ResourceList{}
+		}
+		if node.Status.Allocatable == nil {
+			node.Status.Allocatable = apiv1.ResourceList{}
+		}
+	}
+	for name, quantity := range o.Resources {
+		node.Status.Capacity[name] = quantity.DeepCopy()
+		node.Status.Allocatable[name] = quantity.DeepCopy()
+	}
+}
+
+// mismatches returns descriptions of properties declared by the overlay which the real node doesn't have.
+func (o *TemplateOverlay) mismatches(node *apiv1.Node) []string {
+	var result []string
+	for k, v := range o.Labels {
+		if actual, found := node.Labels[k]; !found || actual != v {
+			result = append(result, fmt.Sprintf("label %s=%s", k, v))
+		}
+	}
+	for _, taint := range o.Taints {
+		found := false
+		for _, actual := range node.Spec.Taints {
+			if actual.Key == taint.Key && actual.Effect == taint.Effect && actual.Value == taint.Value {
+				found = true
+			}
+		}
+		if !found {
+			result = append(result, fmt.Sprintf("taint %s", taint.ToString()))
+		}
+	}
+	for name, quantity := range o.Resources {
+		if actual, found := node.Status.Capacity[name]; !found || actual.Cmp(quantity) != 0 {
+			result = append(result, fmt.Sprintf("resource %s=%s", name, quantity.String()))
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ParseTemplateOverlays parses a YAML or JSON list of overlays.
+func ParseTemplateOverlays(content []byte) ([]TemplateOverlay, error) {
+	var overlays []TemplateOverlay
+	if err := yaml.Unmarshal(content, &overlays); err != nil {
+		return nil, fmt.Errorf("failed to parse template overlays: %v", err)
+	}
+	for i := range overlays {
+		overlay := &overlays[i]
+		if overlay.NodeGroupIdRegex == "" {
+			return nil, fmt.Errorf("template overlay %d: nodeGroupIdRegex is required", i)
+		}
+		regex, err := regexp.Compile("^(?:" + overlay.NodeGroupIdRegex + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("template overlay %d: invalid nodeGroupIdRegex %q: %v", i, overlay.NodeGroupIdRegex, err)
+		}
+		overlay.nodeGroupIdRegex = regex
+		for _, taint := range overlay.Taints {
+			switch taint.Effect {
+			case apiv1.TaintEffectNoSchedule, apiv1.TaintEffectPreferNoSchedule, apiv1.TaintEffectNoExecute:
+			default:
+				return nil, fmt.Errorf("template overlay %d: invalid effect %q of taint %s", i, taint.Effect, taint.Key)
+			}
+		}
+	}
+	return overlays, nil
+}
+
+// TemplateOverlaySource provides template overlays.
+type TemplateOverlaySource interface {
+	// Load returns the current overlays, in the order they are applied.
+	Load() ([]TemplateOverlay, error)
+}
+
+type fileTemplateOverlaySource struct {
+	path string
+}
+
+// NewFileTemplateOverlaySource returns a TemplateOverlaySource reading a YAML or JSON list of
+// overlays from a file, e.g. a file mounted from a ConfigMap.
+func NewFileTemplateOverlaySource(path string) TemplateOverlaySource {
+	return &fileTemplateOverlaySource{path: path}
+}
+
+func (s *fileTemplateOverlaySource) Load() ([]TemplateOverlay, error) {
+	content, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseTemplateOverlays(content)
+}
+
+type configMapTemplateOverlaySource struct {
+	lister    v1lister.ConfigMapNamespaceLister
+	name      string
+	namespace string
+}
+
+// NewConfigMapTemplateOverlaySource returns a TemplateOverlaySource reading overlays from a ConfigMap.
+// Every data entry holds a YAML or JSON list of overlays, entries are applied in the order of their keys.
+// The ConfigMap is watched until stopCh is closed.
+func NewConfigMapTemplateOverlaySource(kubeClient kube_client.Interface, namespace, name string, stopCh <-chan struct{}) TemplateOverlaySource {
+	return &configMapTemplateOverlaySource{
+		lister:    kube_util.NewConfigMapListerForNamespace(kubeClient, stopCh, namespace).ConfigMaps(namespace),
+		name:      name,
+		namespace: namespace,
+	}
+}
+
+func (s *configMapTemplateOverlaySource) Load() ([]TemplateOverlay, error) {
+	configMap, err := s.lister.Get(s.name)
+	if kube_errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	var keys []string
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var overlays []TemplateOverlay
+	for _, key := range keys {
+		parsed, err := ParseTemplateOverlays([]byte(configMap.Data[key]))
+		if err != nil {
+			return nil, fmt.Errorf("ConfigMap %s/%s key %s: %v", s.namespace, s.name, key, err)
+		}
+		overlays = append(overlays, parsed...)
+	}
+	return overlays, nil
+}
+
+// TemplateOverlayNodeInfoProvider is a wrapper for TemplateNodeInfoProvider applying template overlays
+// to NodeInfos of node groups without nodes. Overlays of node groups with nodes are validated against
+// their nodes instead, and mismatches are logged.
+type TemplateOverlayNodeInfoProvider struct {
+	templateNodeInfoProvider TemplateNodeInfoProvider
+	source                   TemplateOverlaySource
+	overlays                 []TemplateOverlay
+	// reportedMismatches contains the last mismatch reported for every node group, so that it isn't logged in every loop.
+	reportedMismatches map[string]string
+}
+
+// NewTemplateOverlayNodeInfoProvider returns TemplateOverlayNodeInfoProvider wrapping TemplateNodeInfoProvider.
+func NewTemplateOverlayNodeInfoProvider(templateNodeInfoProvider TemplateNodeInfoProvider, source TemplateOverlaySource) *TemplateOverlayNodeInfoProvider {
+	return &TemplateOverlayNodeInfoProvider{
+		templateNodeInfoProvider: templateNodeInfoProvider,
+		source:                   source,
+		reportedMismatches:       make(map[string]string),
+	}
+}
+
+// Process returns the nodeInfos set for this cluster.
+func (p *TemplateOverlayNodeInfoProvider) Process(ctx *context.AutoscalingContext, nodes []*apiv1.Node, daemonsets []*appsv1.DaemonSet, taintConfig taints.TaintConfig, currentTime time.Time) (map[string]*schedulerframework.NodeInfo, errors.AutoscalerError) {
+	nodeInfos, err := p.templateNodeInfoProvider.Process(ctx, nodes, daemonsets, taintConfig, currentTime)
+	if err != nil {
+		return nil, err
+	}
+	overlays, loadErr := p.source.Load()
+	if loadErr != nil {
+		// Keep using the last valid overlays.
+		klog.Errorf("Failed to load template overlays: %v", loadErr)
+	} else {
+		p.overlays = overlays
+	}
+	if len(p.overlays) == 0 {
+		return nodeInfos, nil
+	}
+
+	nodesByGroup := make(map[string]*apiv1.Node)
+	for _, node := range nodes {
+		nodeGroup, err := ctx.CloudProvider.NodeGroupForNode(node)
+		if err != nil || nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+			continue
+		}
+		if _, found := nodesByGroup[nodeGroup.Id()]; !found {
+			nodesByGroup[nodeGroup.Id()] = node
+		}
+	}
+
+	for id, nodeInfo := range nodeInfos {
+		if node, found := nodesByGroup[id]; found {
+			p.validate(id, node)
+			continue
+		}
+		var node *apiv1.Node
+		for i := range p.overlays {
+			if !p.overlays[i].Matches(id) {
+				continue
+			}
+			if node == nil {
+				node = nodeInfo.Node().DeepCopy()
+			}
+			p.overlays[i].apply(node)
+		}
+		if node != nil {
+			// SetNode recomputes allocatable resources of the NodeInfo.
+			nodeInfo.SetNode(node)
+		}
+	}
+	return nodeInfos, nil
+}
+
+// validate logs properties declared by overlays of the node group which its real node doesn't have.
+func (p *TemplateOverlayNodeInfoProvider) validate(nodeGroupId string, node *apiv1.Node) {
+	var mismatches []string
+	for i := range p.overlays {
+		if p.overlays[i].Matches(nodeGroupId) {
+			mismatches = append(mismatches, p.overlays[i].mismatches(node)...)
+		}
+	}
+	message := strings.Join(mismatches, ", ")
+	if message != "" && p.reportedMismatches[nodeGroupId] != message {
+		klog.Warningf("Node %s of node group %s doesn't match its template overlays, missing: %s", node.Name, nodeGroupId, message)
+	}
+	p.reportedMismatches[nodeGroupId] = message
+}
+
+// CleanUp cleans up processor's internal structures.
+func (p *TemplateOverlayNodeInfoProvider) CleanUp() {
+	p.templateNodeInfoProvider.CleanUp()
+}
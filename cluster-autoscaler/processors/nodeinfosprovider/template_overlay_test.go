@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeinfosprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const testOverlays = `
+- nodeGroupIdRegex: ng-gpu-.*
+  labels:
+    accelerator: a100
+  taints:
+  - key: gpu
+    value: "true"
+    effect: NoSchedule
+  resources:
+    nvidia.com/gpu: "4"
+    ephemeral-storage: 100Gi
+- nodeGroupIdRegex: ng-gpu-2
+  labels:
+    accelerator: h100
+`
+
+func TestParseTemplateOverlays(t *testing.T) {
+	testCases := map[string]struct {
+		content   string
+		wantCount int
+		wantErr   bool
+	}{
+		"valid": {
+			content:   testOverlays,
+			wantCount: 2,
+		},
+		"empty": {
+			content: "",
+		},
+		"missing regex": {
+			content: "- labels:\n    a: b\n",
+			wantErr: true,
+		},
+		"invalid regex": {
+			content: "- nodeGroupIdRegex: \"(\"\n",
+			wantErr: true,
+		},
+		"invalid taint effect": {
+			content: "- nodeGroupIdRegex: ng\n  taints:\n  - key: a\n    effect: Sometimes\n",
+			wantErr: true,
+		},
+		"not a list": {
+			content: "nodeGroupIdRegex: ng\n",
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			overlays, err := ParseTemplateOverlays([]byte(tc.content))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, overlays, tc.wantCount)
+		})
+	}
+}
+
+func TestTemplateOverlayNodeInfoProvider(t *testing.T) {
+	now := time.Now()
+	ready := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(ready, true, now.Add(-2*time.Minute))
+
+	tn := BuildTestNode("tn", 1000, 1000)
+	tn.Spec.Taints = []apiv1.Taint{{Key: "gpu", Value: "false", Effect: apiv1.TaintEffectNoSchedule}}
+	tni := schedulerframework.NewNodeInfo()
+	tni.SetNode(tn)
+
+	provider := testprovider.NewTestAutoprovisioningCloudProvider(
+		nil, nil, nil, nil, nil,
+		map[string]*schedulerframework.NodeInfo{"ng-gpu-1": tni, "ng-gpu-2": tni, "ng-gpu-3": tni, "ng-cpu": tni})
+	provider.AddNodeGroup("ng-gpu-1", 0, 10, 0)
+	provider.AddNodeGroup("ng-gpu-2", 0, 10, 0)
+	provider.AddNodeGroup("ng-gpu-3", 0, 10, 1)
+	provider.AddNode("ng-gpu-3", ready)
+	provider.AddNodeGroup("ng-cpu", 0, 10, 0)
+
+	path := filepath.Join(t.TempDir(), "overlays.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(testOverlays), 0644))
+
+	podLister := kube_util.NewTestPodLister([]*apiv1.Pod{})
+	registry := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+	ctx := context.AutoscalingContext{
+		CloudProvider: provider,
+		AutoscalingKubeClients: context.AutoscalingKubeClients{
+			ListerRegistry: registry,
+		},
+	}
+	p := NewTemplateOverlayNodeInfoProvider(NewMixedTemplateNodeInfoProvider(nil, false), NewFileTemplateOverlaySource(path))
+	res, err := p.Process(&ctx, []*apiv1.Node{ready}, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+	assert.NoError(t, err)
+	assert.Len(t, res, 4)
+
+	gpu1 := res["ng-gpu-1"]
+	assert.Equal(t, "a100", gpu1.Node().Labels["accelerator"])
+	assert.Equal(t, []apiv1.Taint{{Key: "gpu", Value: "true", Effect: apiv1.TaintEffectNoSchedule}}, gpu1.Node().Spec.Taints)
+	gpus := gpu1.Node().Status.Allocatable["nvidia.com/gpu"]
+	assert.Equal(t, int64(4), gpus.Value())
+	assert.Equal(t, int64(4), gpu1.Allocatable.ScalarResources["nvidia.com/gpu"])
+	storage := resource.MustParse("100Gi")
+	assert.Equal(t, storage.Value(), gpu1.Allocatable.EphemeralStorage)
+
+	// Later overlays take precedence.
+	assert.Equal(t, "h100", res["ng-gpu-2"].Node().Labels["accelerator"])
+
+	// Node groups with nodes use their nodes, node groups not matching any overlay are unchanged.
+	assert.NotContains(t, res["ng-gpu-3"].Node().Labels, "accelerator")
+	assert.Equal(t, "label accelerator=a100, resource ephemeral-storage=100Gi, resource nvidia.com/gpu=4, taint gpu=true:NoSchedule", p.reportedMismatches["ng-gpu-3"])
+	assert.NotContains(t, res["ng-cpu"].Node().Labels, "accelerator")
+	assert.Equal(t, "false", res["ng-cpu"].Node().Spec.Taints[0].Value)
+
+	// The template returned by the cloud provider isn't modified.
+	assert.NotContains(t, tn.Labels, "accelerator")
+}
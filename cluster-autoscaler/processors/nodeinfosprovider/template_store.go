@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeinfosprovider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	kube_client "k8s.io/client-go/kubernetes"
+	klog "k8s.io/klog/v2"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const (
+	// templatesKey is the key of ConfigMap data under which templates are stored.
+	templatesKey = "templates"
+	// configMapCallTimeout bounds calls to the API server, so that a slow API server doesn't block the loop.
+	configMapCallTimeout = 10 * time.Second
+	// maxConfigMapDataSize is the maximum size of templates saved in a ConfigMap. ConfigMaps are
+	// limited to 1 MiB, some room is left for metadata.
+	maxConfigMapDataSize = 1000 * 1024
+)
+
+// PersistedTemplate is a template NodeInfo built from a real node, as saved in a TemplateStore.
+type PersistedTemplate struct {
+	// Node is the sanitized node.
+	Node *apiv1.Node `json:"node"`
+	// Pods are the sanitized pods expected on every node, e.g. DaemonSet and static pods.
+	Pods []*apiv1.Pod `json:"pods,omitempty"`
+	// Fingerprint of the cloud provider template of the node group when the template was saved,
+	// empty if the cloud provider doesn't provide templates.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Added is the last time a real node of the node group was seen.
+	Added time.Time `json:"added"`
+}
+
+// TemplateStore saves and loads templates built from real nodes, so that they survive restarts.
+type TemplateStore interface {
+	// Load returns saved templates keyed by node group ID, or nil if nothing was saved.
+	Load() (map[string]PersistedTemplate, error)
+	// Save replaces the saved templates.
+	Save(templates map[string]PersistedTemplate) error
+}
+
+type configMapTemplateStore struct {
+	kubeClient kube_client.Interface
+	namespace  string
+	name       string
+}
+
+// NewConfigMapTemplateStore returns a TemplateStore keeping templates as JSON in a ConfigMap.
+// The ConfigMap is created on first save.
+func NewConfigMapTemplateStore(kubeClient kube_client.Interface, namespace, name string) TemplateStore {
+	return &configMapTemplateStore{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		name:       name,
+	}
+}
+
+func (s *configMapTemplateStore) Load() (map[string]PersistedTemplate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), configMapCallTimeout)
+	defer cancel()
+	configMap, err := s.kubeClient.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if kube_errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	data, found := configMap.Data[templatesKey]
+	if !found {
+		return nil, nil
+	}
+	templates := map[string]PersistedTemplate{}
+	if err := json.Unmarshal([]byte(data), &templates); err != nil {
+		return nil, fmt.Errorf("failed to parse template ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	return templates, nil
+}
+
+func (s *configMapTemplateStore) Save(templates map[string]PersistedTemplate) error {
+	data, dropped, err := marshalTemplates(templates, maxConfigMapDataSize)
+	if err != nil {
+		return err
+	}
+	if len(dropped) > 0 {
+		klog.Warningf("Templates don't fit in ConfigMap %s/%s, not saving templates of node groups %v", s.namespace, s.name, dropped)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), configMapCallTimeout)
+	defer cancel()
+	maps := s.kubeClient.CoreV1().ConfigMaps(s.namespace)
+	configMap, err := maps.Get(ctx, s.name, metav1.GetOptions{})
+	if kube_errors.IsNotFound(err) {
+		configMap = &apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.namespace,
+				Name:      s.name,
+			},
+			Data: map[string]string{templatesKey: string(data)},
+		}
+		_, err = maps.Create(ctx, configMap, metav1.CreateOptions{})
+	} else if err == nil {
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		configMap.Data[templatesKey] = string(data)
+		_, err = maps.Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write template ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	return nil
+}
+
+// marshalTemplates returns templates as JSON of at most maxSize bytes. If they don't fit, templates
+// added longest ago are left out and their node group IDs are returned.
+func marshalTemplates(templates map[string]PersistedTemplate, maxSize int) ([]byte, []string, error) {
+	// JSON of a map is the sum of its entries, separated by commas and enclosed in braces.
+	entrySizes := make(map[string]int, len(templates))
+	size := 2
+	for id, template := range templates {
+		key, err := json.Marshal(id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal templates: %v", err)
+		}
+		value, err := json.Marshal(template)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal template of node group %s: %v", id, err)
+		}
+		entrySizes[id] = len(key) + 1 + len(value) + 1
+		size += entrySizes[id]
+	}
+	ids := make([]string, 0, len(templates))
+	for id := range templates {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if !templates[ids[i]].Added.Equal(templates[ids[j]].Added) {
+			return templates[ids[i]].Added.Before(templates[ids[j]].Added)
+		}
+		return ids[i] < ids[j]
+	})
+	kept := make(map[string]PersistedTemplate, len(templates))
+	for id, template := range templates {
+		kept[id] = template
+	}
+	var dropped []string
+	for _, id := range ids {
+		if size <= maxSize {
+			break
+		}
+		delete(kept, id)
+		size -= entrySizes[id]
+		dropped = append(dropped, id)
+	}
+	data, err := json.Marshal(kept)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal templates: %v", err)
+	}
+	return data, dropped, nil
+}
+
+type fileTemplateStore struct {
+	path string
+}
+
+// NewFileTemplateStore returns a TemplateStore keeping templates as JSON in a local file.
+func NewFileTemplateStore(path string) TemplateStore {
+	return &fileTemplateStore{path: path}
+}
+
+func (s *fileTemplateStore) Load() (map[string]PersistedTemplate, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file %s: %v", s.path, err)
+	}
+	templates := map[string]PersistedTemplate{}
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("failed to parse template file %s: %v", s.path, err)
+	}
+	return templates, nil
+}
+
+func (s *fileTemplateStore) Save(templates map[string]PersistedTemplate) error {
+	data, err := json.Marshal(templates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal templates: %v", err)
+	}
+	// Write to a temporary file first, so that a crash doesn't leave a partially written file behind.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write template file %s: %v", s.path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write template file %s: %v", s.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write template file %s: %v", s.path, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write template file %s: %v", s.path, err)
+	}
+	return nil
+}
+
+// toPersistedTemplate converts a cached NodeInfo to a PersistedTemplate, dropping fields which
+// are irrelevant for simulations and only make the stored template bigger.
+func toPersistedTemplate(nodeInfo *schedulerframework.NodeInfo, fingerprint string, added time.Time) PersistedTemplate {
+	node := nodeInfo.Node().DeepCopy()
+	node.ManagedFields = nil
+	node.Status.Images = nil
+	var pods []*apiv1.Pod
+	for _, podInfo := range nodeInfo.Pods {
+		pod := podInfo.Pod.DeepCopy()
+		pod.ManagedFields = nil
+		pods = append(pods, pod)
+	}
+	return PersistedTemplate{Node: node, Pods: pods, Fingerprint: fingerprint, Added: added}
+}
+
+// toNodeInfo converts a PersistedTemplate back to a NodeInfo.
+func (t PersistedTemplate) toNodeInfo() *schedulerframework.NodeInfo {
+	nodeInfo := schedulerframework.NewNodeInfo(t.Pods...)
+	nodeInfo.SetNode(t.Node)
+	return nodeInfo
+}
+
+// templateFingerprint returns a hash of the parts of the cloud provider template of the node group
+// which describe the node, used to invalidate saved templates after the node group configuration
+// changes. Empty string is returned if the cloud provider doesn't provide templates.
+func templateFingerprint(nodeGroup cloudprovider.NodeGroup) (string, error) {
+	template, err := nodeGroup.TemplateNodeInfo()
+	if err == cloudprovider.ErrNotImplemented {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	node := template.Node()
+	var labels []string
+	for k, v := range node.Labels {
+		// Templates often have random host names.
+		if k != apiv1.LabelHostname {
+			labels = append(labels, k+"="+v)
+		}
+	}
+	sort.Strings(labels)
+	data, err := json.Marshal(struct {
+		Labels      []string
+		Taints      []apiv1.Taint
+		Capacity    apiv1.ResourceList
+		Allocatable apiv1.ResourceList
+	}{labels, node.Spec.Taints, node.Status.Capacity, node.Status.Allocatable})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
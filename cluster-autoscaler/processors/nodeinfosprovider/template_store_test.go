@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeinfosprovider
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestTemplateStores(t *testing.T) {
+	node := BuildTestNode("template-node", 1000, 1000)
+	pod := BuildTestPod("ds-pod", 100, 100)
+	templates := map[string]PersistedTemplate{
+		"ng1": {
+			Node:        node,
+			Pods:        []*apiv1.Pod{pod},
+			Fingerprint: "abc",
+			Added:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	stores := map[string]TemplateStore{
+		"configmap": NewConfigMapTemplateStore(fake.NewSimpleClientset(), "kube-system", "templates"),
+		"file":      NewFileTemplateStore(filepath.Join(t.TempDir(), "templates.json")),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			loaded, err := store.Load()
+			assert.NoError(t, err)
+			assert.Nil(t, loaded)
+
+			assert.NoError(t, store.Save(templates))
+			// Second save updates the existing object.
+			assert.NoError(t, store.Save(templates))
+			loaded, err = store.Load()
+			assert.NoError(t, err)
+			assert.Len(t, loaded, 1)
+			assert.Equal(t, "abc", loaded["ng1"].Fingerprint)
+			assert.True(t, templates["ng1"].Added.Equal(loaded["ng1"].Added))
+			nodeInfo := loaded["ng1"].toNodeInfo()
+			assert.Equal(t, node.Name, nodeInfo.Node().Name)
+			assert.Equal(t, node.Status.Allocatable.Cpu().MilliValue(), nodeInfo.Node().Status.Allocatable.Cpu().MilliValue())
+			assert.Len(t, nodeInfo.Pods, 1)
+			assert.Equal(t, pod.Name, nodeInfo.Pods[0].Pod.Name)
+		})
+	}
+}
+
+func TestMarshalTemplates(t *testing.T) {
+	templates := map[string]PersistedTemplate{}
+	for id, added := range map[string]time.Time{
+		"newest": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"middle": time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		"oldest": time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		templates[id] = PersistedTemplate{Node: BuildTestNode(id+"-node", 1000, 1000), Added: added}
+	}
+	all, err := json.Marshal(templates)
+	assert.NoError(t, err)
+
+	data, dropped, err := marshalTemplates(templates, len(all)+1)
+	assert.NoError(t, err)
+	assert.Empty(t, dropped)
+	assert.Equal(t, all, data)
+
+	data, dropped, err = marshalTemplates(templates, len(all)-1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"oldest"}, dropped)
+	assert.LessOrEqual(t, len(data), len(all)-1)
+	saved := map[string]PersistedTemplate{}
+	assert.NoError(t, json.Unmarshal(data, &saved))
+	assert.Len(t, saved, 2)
+	assert.NotContains(t, saved, "oldest")
+
+	data, dropped, err = marshalTemplates(templates, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"oldest", "middle", "newest"}, dropped)
+	assert.Equal(t, "{}", string(data))
+}
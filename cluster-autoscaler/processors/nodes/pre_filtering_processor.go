@@ -23,6 +23,7 @@ import (
 	klog "k8s.io/klog/v2"
 
 	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 )
@@ -31,6 +32,7 @@ import (
 // size <= minimum number of nodes for that nodegroup and filters out node from non-autoscaled
 // nodegroups
 type PreFilteringScaleDownNodeProcessor struct {
+	sizeGetter nodegroupconfig.NodeGroupSizeGetter
 }
 
 // GetPodDestinationCandidates returns nodes that potentially could act as destinations for pods
@@ -62,7 +64,7 @@ func (n *PreFilteringScaleDownNodeProcessor) GetScaleDownCandidates(ctx *context
 			klog.Errorf("Error while checking node group size %s: group size not found", nodeGroup.Id())
 			continue
 		}
-		minSize := nodeGroup.MinSize()
+		minSize, _ := nodegroupconfig.GetSizeRange(n.sizeGetter, nodeGroup)
 		if size <= minSize {
 			klog.V(1).Infof("Skipping %s - node group min size reached (current: %d, min: %d)", node.Name, size, minSize)
 			continue
@@ -77,6 +79,7 @@ func (n *PreFilteringScaleDownNodeProcessor) CleanUp() {
 }
 
 // NewPreFilteringScaleDownNodeProcessor returns a new PreFilteringScaleDownNodeProcessor.
-func NewPreFilteringScaleDownNodeProcessor() *PreFilteringScaleDownNodeProcessor {
-	return &PreFilteringScaleDownNodeProcessor{}
+// Min sizes of the node groups are provided by sizeGetter.
+func NewPreFilteringScaleDownNodeProcessor(sizeGetter nodegroupconfig.NodeGroupSizeGetter) *PreFilteringScaleDownNodeProcessor {
+	return &PreFilteringScaleDownNodeProcessor{sizeGetter: sizeGetter}
 }
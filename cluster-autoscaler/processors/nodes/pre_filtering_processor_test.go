@@ -22,16 +22,33 @@ import (
 	"github.com/stretchr/testify/assert"
 	apiv1 "k8s.io/api/core/v1"
 
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 )
 
+// fakeSizeGetter overrides min sizes of node groups found in minSizes.
+type fakeSizeGetter struct {
+	minSizes map[string]int
+}
+
+func (f *fakeSizeGetter) GetMinSize(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	if minSize, found := f.minSizes[nodeGroup.Id()]; found {
+		return minSize, nil
+	}
+	return nodeGroup.MinSize(), nil
+}
+
+func (f *fakeSizeGetter) GetMaxSize(nodeGroup cloudprovider.NodeGroup) (int, error) {
+	return nodeGroup.MaxSize(), nil
+}
+
 func TestPreFilteringScaleDownNodeProcessor_GetPodDestinationCandidates(t *testing.T) {
 	n1 := BuildTestNode("n1", 100, 1000)
 	n2 := BuildTestNode("n2", 100, 1000)
 	ctx := &context.AutoscalingContext{}
-	defaultProcessor := NewPreFilteringScaleDownNodeProcessor()
+	defaultProcessor := NewPreFilteringScaleDownNodeProcessor(&fakeSizeGetter{})
 	expectedNodes := []*apiv1.Node{n1, n2}
 	nodes := []*apiv1.Node{n1, n2}
 	nodes, err := defaultProcessor.GetPodDestinationCandidates(ctx, nodes)
@@ -57,10 +74,17 @@ func TestPreFilteringScaleDownNodeProcessor_GetScaleDownCandidateNodes(t *testin
 	}
 
 	expectedNodes := []*apiv1.Node{ng1_1, ng1_2}
-	defaultProcessor := NewPreFilteringScaleDownNodeProcessor()
+	defaultProcessor := NewPreFilteringScaleDownNodeProcessor(&fakeSizeGetter{})
 	inputNodes := []*apiv1.Node{ng1_1, ng1_2, ng2_1, noNg}
 	result, err := defaultProcessor.GetScaleDownCandidates(ctx, inputNodes)
 
 	assert.NoError(t, err)
 	assert.Equal(t, result, expectedNodes)
+
+	// Min sizes provided by the size getter take precedence over the cloud provider ones.
+	overridingProcessor := NewPreFilteringScaleDownNodeProcessor(&fakeSizeGetter{minSizes: map[string]int{"ng1": 2, "ng2": 0}})
+	result, err = overridingProcessor.GetScaleDownCandidates(ctx, inputNodes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*apiv1.Node{ng2_1}, result)
 }
@@ -73,8 +73,15 @@ type AutoscalingProcessors struct {
 	ScaleStateNotifier *nodegroupchange.NodeGroupChangeObserversList
 }
 
+// OptionsUpdater is implemented by processors which capture AutoscalingOptions when they are
+// built and need to pick up options reloaded at runtime.
+type OptionsUpdater interface {
+	UpdateOptions(options config.AutoscalingOptions)
+}
+
 // DefaultProcessors returns default set of processors.
 func DefaultProcessors(options config.AutoscalingOptions) *AutoscalingProcessors {
+	nodeGroupConfigProcessor := nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults)
 	return &AutoscalingProcessors{
 		PodListProcessor:       pods.NewDefaultPodListProcessor(),
 		NodeGroupListProcessor: nodegroups.NewDefaultNodeGroupListProcessor(),
@@ -84,8 +91,8 @@ func DefaultProcessors(options config.AutoscalingOptions) *AutoscalingProcessors
 			MaxCapacityMemoryDifferenceRatio: config.DefaultMaxCapacityMemoryDifferenceRatio,
 			MaxFreeDifferenceRatio:           config.DefaultMaxFreeDifferenceRatio,
 		}),
-		ScaleUpStatusProcessor: status.NewDefaultScaleUpStatusProcessor(),
-		ScaleDownNodeProcessor: nodes.NewPreFilteringScaleDownNodeProcessor(),
+		ScaleUpStatusProcessor: status.NewEventingScaleUpStatusProcessor(options.PodScaleUpExplanations),
+		ScaleDownNodeProcessor: nodes.NewPreFilteringScaleDownNodeProcessor(nodeGroupConfigProcessor),
 		ScaleDownSetProcessor: nodes.NewCompositeScaleDownSetProcessor(
 			[]nodes.ScaleDownSetProcessor{
 				nodes.NewMaxNodesProcessor(),
@@ -95,8 +102,8 @@ func DefaultProcessors(options config.AutoscalingOptions) *AutoscalingProcessors
 		ScaleDownStatusProcessor:    status.NewDefaultScaleDownStatusProcessor(),
 		AutoscalingStatusProcessor:  status.NewDefaultAutoscalingStatusProcessor(),
 		NodeGroupManager:            nodegroups.NewDefaultNodeGroupManager(),
-		NodeGroupConfigProcessor:    nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults),
-		CustomResourcesProcessor:    customresources.NewDefaultCustomResourcesProcessor(),
+		NodeGroupConfigProcessor:    nodeGroupConfigProcessor,
+		CustomResourcesProcessor:    customResourcesProcessor(options),
 		ActionableClusterProcessor:  actionablecluster.NewDefaultActionableClusterProcessor(),
 		TemplateNodeInfoProvider:    nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false),
 		ScaleDownCandidatesNotifier: scaledowncandidates.NewObserversList(),
@@ -120,3 +127,36 @@ func (ap *AutoscalingProcessors) CleanUp() {
 	ap.TemplateNodeInfoProvider.CleanUp()
 	ap.ActionableClusterProcessor.CleanUp()
 }
+
+// UpdateOptions passes reloaded options to all processors implementing OptionsUpdater.
+// Processors are updated in place, as they are shared with other components.
+func (ap *AutoscalingProcessors) UpdateOptions(options config.AutoscalingOptions) {
+	for _, processor := range []interface{}{
+		ap.PodListProcessor,
+		ap.NodeGroupListProcessor,
+		ap.BinpackingLimiter,
+		ap.NodeGroupSetProcessor,
+		ap.ScaleUpStatusProcessor,
+		ap.ScaleDownNodeProcessor,
+		ap.ScaleDownSetProcessor,
+		ap.ScaleDownStatusProcessor,
+		ap.AutoscalingStatusProcessor,
+		ap.NodeGroupManager,
+		ap.TemplateNodeInfoProvider,
+		ap.NodeGroupConfigProcessor,
+		ap.CustomResourcesProcessor,
+		ap.ActionableClusterProcessor,
+	} {
+		if updater, ok := processor.(OptionsUpdater); ok {
+			updater.UpdateOptions(options)
+		}
+	}
+}
+
+// customResourcesProcessor returns the CustomResourcesProcessor handling extended resources from options, if any.
+func customResourcesProcessor(options config.AutoscalingOptions) customresources.CustomResourcesProcessor {
+	if len(options.ExtendedResources) == 0 {
+		return customresources.NewDefaultCustomResourcesProcessor()
+	}
+	return customresources.NewExtendedResourcesProcessor(options.ExtendedResources, customresources.NewDefaultCustomResourcesProcessor())
+}
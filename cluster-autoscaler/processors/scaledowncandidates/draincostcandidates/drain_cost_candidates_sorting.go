@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package draincostcandidates
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/draincost"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+type nodeInfoGetter interface {
+	GetNodeInfo(nodeName string) (*schedulerframework.NodeInfo, error)
+}
+
+// DrainCostSorting is sorting scale down candidates so that nodes whose pods are cheaper to move appear first.
+type DrainCostSorting struct {
+	nodeInfoGetter
+	calculator *draincost.Calculator
+}
+
+// NewDrainCostSortingProcessor return DrainCostSorting struct.
+func NewDrainCostSortingProcessor(n nodeInfoGetter, calculator *draincost.Calculator) *DrainCostSorting {
+	return &DrainCostSorting{
+		nodeInfoGetter: n,
+		calculator:     calculator,
+	}
+}
+
+// ScaleDownEarlierThan return true if pods of node1 are cheaper to move than pods of node2.
+func (p *DrainCostSorting) ScaleDownEarlierThan(node1, node2 *apiv1.Node) bool {
+	cost1, found1 := p.nodeDrainCost(node1)
+	cost2, found2 := p.nodeDrainCost(node2)
+	return found1 && found2 && cost1 < cost2
+}
+
+func (p *DrainCostSorting) nodeDrainCost(node *apiv1.Node) (float64, bool) {
+	nodeInfo, err := p.nodeInfoGetter.GetNodeInfo(node.Name)
+	if err != nil {
+		return 0, false
+	}
+	cost := 0.0
+	for _, podInfo := range nodeInfo.Pods {
+		pod := podInfo.Pod
+		if pod_util.IsMirrorPod(pod) || pod_util.IsDaemonSetPod(pod) {
+			continue
+		}
+		cost += p.calculator.PodCost(pod)
+	}
+	return cost, true
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package draincostcandidates
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/draincost"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+type testNodeInfoGetter struct {
+	m map[string]*schedulerframework.NodeInfo
+}
+
+func (t *testNodeInfoGetter) GetNodeInfo(nodeName string) (*schedulerframework.NodeInfo, error) {
+	if nodeInfo, ok := t.m[nodeName]; ok {
+		return nodeInfo, nil
+	}
+	return nil, fmt.Errorf("node %s not found", nodeName)
+}
+
+func TestScaleDownEarlierThan(t *testing.T) {
+	replicated := func(pod *apiv1.Pod) {
+		pod.OwnerReferences = GenerateOwnerReferences("rs", "ReplicaSet", "apps/v1", "")
+	}
+	buildNodeInfo := func(name string, pods ...*apiv1.Pod) *schedulerframework.NodeInfo {
+		nodeInfo := schedulerframework.NewNodeInfo(pods...)
+		nodeInfo.SetNode(BuildTestNode(name, 1000, 1000))
+		return nodeInfo
+	}
+	nodeInfos := map[string]*schedulerframework.NodeInfo{
+		"empty": buildNodeInfo("empty",
+			SetDSPodSpec(BuildTestPod("ds", 100, 0)),
+			SetMirrorPodSpec(BuildTestPod("mirror", 100, 0))),
+		"replicated": buildNodeInfo("replicated",
+			BuildTestPod("r1", 100, 0, replicated),
+			BuildTestPod("r2", 100, 0, replicated)),
+		"singleton": buildNodeInfo("singleton",
+			BuildTestPod("s", 100, 0)),
+		"singleton2": buildNodeInfo("singleton2",
+			BuildTestPod("s2", 100, 0)),
+	}
+	p := NewDrainCostSortingProcessor(&testNodeInfoGetter{nodeInfos}, draincost.NewCalculator(nil, draincost.DefaultControllerKindCosts(), draincost.DefaultSingletonCost))
+
+	for tn, tc := range map[string]struct {
+		node1       string
+		node2       string
+		wantEarlier bool
+	}{
+		"empty node before replicated pods": {
+			node1:       "empty",
+			node2:       "replicated",
+			wantEarlier: true,
+		},
+		"replicated pods before singleton": {
+			node1:       "replicated",
+			node2:       "singleton",
+			wantEarlier: true,
+		},
+		"singleton after replicated pods": {
+			node1:       "singleton",
+			node2:       "replicated",
+			wantEarlier: false,
+		},
+		"equal costs": {
+			node1:       "singleton",
+			node2:       "singleton2",
+			wantEarlier: false,
+		},
+		"unknown node": {
+			node1:       "missing",
+			node2:       "singleton",
+			wantEarlier: false,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			node1 := BuildTestNode(tc.node1, 1000, 1000)
+			node2 := BuildTestNode(tc.node2, 1000, 1000)
+			assert.Equal(t, tc.wantEarlier, p.ScaleDownEarlierThan(node1, node2))
+		})
+	}
+}
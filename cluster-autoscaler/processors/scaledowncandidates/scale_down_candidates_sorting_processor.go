@@ -20,6 +20,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 
 	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodes"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 )
@@ -54,6 +55,6 @@ func (p *ScaleDownCandidatesSortingProcessor) CleanUp() {
 }
 
 // NewScaleDownCandidatesSortingProcessor returns a new PreFilteringScaleDownNodeProcessor.
-func NewScaleDownCandidatesSortingProcessor(sorting []CandidatesComparer) *ScaleDownCandidatesSortingProcessor {
-	return &ScaleDownCandidatesSortingProcessor{preFilter: nodes.NewPreFilteringScaleDownNodeProcessor(), sorting: sorting}
+func NewScaleDownCandidatesSortingProcessor(sizeGetter nodegroupconfig.NodeGroupSizeGetter, sorting []CandidatesComparer) *ScaleDownCandidatesSortingProcessor {
+	return &ScaleDownCandidatesSortingProcessor{preFilter: nodes.NewPreFilteringScaleDownNodeProcessor(sizeGetter), sorting: sorting}
 }
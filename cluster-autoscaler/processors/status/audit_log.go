@@ -0,0 +1,484 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	klog "k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+)
+
+// AuditLogStdout is the audit log path which makes the log be written to stdout.
+const AuditLogStdout = "-"
+
+// AuditRecord is a structured record of decisions made in a single autoscaling iteration.
+type AuditRecord struct {
+	Timestamp time.Time             `json:"timestamp"`
+	ScaleUp   []ScaleUpAuditRecord  `json:"scaleUp,omitempty"`
+	ScaleDown *ScaleDownAuditRecord `json:"scaleDown,omitempty"`
+}
+
+// ScaleUpAuditRecord describes a single scale-up attempt.
+type ScaleUpAuditRecord struct {
+	Result                  string                 `json:"result"`
+	Error                   string                 `json:"error,omitempty"`
+	Options                 []OptionAuditRecord    `json:"options,omitempty"`
+	BestOption              *OptionAuditRecord     `json:"bestOption,omitempty"`
+	ScaleUps                []ScaleUpInfoRecord    `json:"scaleUps,omitempty"`
+	PodsTriggeredScaleUp    []string               `json:"podsTriggeredScaleUp,omitempty"`
+	PodsRemainUnschedulable []NoScaleUpAuditRecord `json:"podsRemainUnschedulable,omitempty"`
+	FailedResizeNodeGroups  []string               `json:"failedResizeNodeGroups,omitempty"`
+	FailedCreateNodeGroups  []string               `json:"failedCreateNodeGroups,omitempty"`
+}
+
+// OptionAuditRecord describes an expansion option considered during scale-up.
+type OptionAuditRecord struct {
+	NodeGroup         string   `json:"nodeGroup"`
+	SimilarNodeGroups []string `json:"similarNodeGroups,omitempty"`
+	NodeCount         int      `json:"nodeCount"`
+	PodCount          int      `json:"podCount"`
+	Debug             string   `json:"debug,omitempty"`
+}
+
+// ScaleUpInfoRecord describes a resize of a single node group.
+type ScaleUpInfoRecord struct {
+	NodeGroup   string `json:"nodeGroup"`
+	CurrentSize int    `json:"currentSize"`
+	NewSize     int    `json:"newSize"`
+}
+
+// NoScaleUpAuditRecord describes why a pod didn't trigger scale-up, per node group.
+type NoScaleUpAuditRecord struct {
+	Pod      string              `json:"pod"`
+	Rejected map[string][]string `json:"rejected,omitempty"`
+	Skipped  map[string][]string `json:"skipped,omitempty"`
+}
+
+// ScaleDownAuditRecord describes a single scale-down attempt.
+type ScaleDownAuditRecord struct {
+	Result            string                     `json:"result"`
+	UnneededNodes     []string                   `json:"unneededNodes,omitempty"`
+	UnremovableNodes  []UnremovableAuditRecord   `json:"unremovableNodes,omitempty"`
+	ScaledDownNodes   []ScaledDownAuditRecord    `json:"scaledDownNodes,omitempty"`
+	RemovedNodeGroups []string                   `json:"removedNodeGroups,omitempty"`
+	NodeDeleteResults map[string]NodeDeleteAudit `json:"nodeDeleteResults,omitempty"`
+}
+
+// UnremovableAuditRecord describes why a node couldn't be removed.
+type UnremovableAuditRecord struct {
+	Node              string `json:"node"`
+	NodeGroup         string `json:"nodeGroup,omitempty"`
+	Reason            string `json:"reason"`
+	BlockingPod       string `json:"blockingPod,omitempty"`
+	BlockingPodReason string `json:"blockingPodReason,omitempty"`
+}
+
+// ScaledDownAuditRecord describes a node for which deletion was started.
+type ScaledDownAuditRecord struct {
+	Node        string   `json:"node"`
+	NodeGroup   string   `json:"nodeGroup,omitempty"`
+	EvictedPods []string `json:"evictedPods,omitempty"`
+}
+
+// NodeDeleteAudit describes the result of a finished node deletion.
+type NodeDeleteAudit struct {
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// AuditLog writes one AuditRecord per autoscaling iteration as a JSON line.
+// Scale-up statuses are buffered until the scale-down status, which is
+// processed last in each iteration, is recorded.
+type AuditLog struct {
+	mutex   sync.Mutex
+	writer  io.Writer
+	closer  io.Closer
+	pending *AuditRecord
+	closed  bool
+	now     func() time.Time
+}
+
+// NewAuditLog creates an AuditLog writing to the given writer.
+func NewAuditLog(writer io.Writer) *AuditLog {
+	return &AuditLog{
+		writer: writer,
+		now:    time.Now,
+	}
+}
+
+// OpenAuditLog creates an AuditLog appending to a file under the given path,
+// or writing to stdout if the path is AuditLogStdout.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	if path == AuditLogStdout {
+		return NewAuditLog(os.Stdout), nil
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %q: %v", path, err)
+	}
+	l := NewAuditLog(file)
+	l.closer = file
+	return l, nil
+}
+
+// RecordScaleUp adds a scale-up status to the record of the current iteration.
+func (l *AuditLog) RecordScaleUp(status *ScaleUpStatus) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.closed {
+		return
+	}
+	record := l.pendingRecord()
+	record.ScaleUp = append(record.ScaleUp, scaleUpAuditRecord(status))
+}
+
+// RecordScaleDown adds a scale-down status to the record of the current
+// iteration and writes the record out.
+func (l *AuditLog) RecordScaleDown(status *scaledownstatus.ScaleDownStatus) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.closed {
+		return
+	}
+	record := l.pendingRecord()
+	record.ScaleDown = scaleDownAuditRecord(status)
+	l.flush()
+}
+
+// Close writes out any buffered record and closes the underlying file, if any.
+// Statuses recorded after Close, e.g. by an iteration still running during
+// shutdown, are dropped. Closing an already closed log is a no-op.
+func (l *AuditLog) Close() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.closed {
+		return
+	}
+	l.closed = true
+	if l.pending != nil {
+		l.flush()
+	}
+	if l.closer != nil {
+		if err := l.closer.Close(); err != nil {
+			klog.Errorf("Failed to close audit log: %v", err)
+		}
+		l.closer = nil
+	}
+}
+
+func (l *AuditLog) pendingRecord() *AuditRecord {
+	if l.pending == nil {
+		l.pending = &AuditRecord{Timestamp: l.now()}
+	}
+	return l.pending
+}
+
+func (l *AuditLog) flush() {
+	record := l.pending
+	l.pending = nil
+	line, err := json.Marshal(record)
+	if err != nil {
+		klog.Errorf("Failed to marshal audit record: %v", err)
+		return
+	}
+	if _, err := l.writer.Write(append(line, '\n')); err != nil {
+		klog.Errorf("Failed to write audit record: %v", err)
+	}
+}
+
+func scaleUpAuditRecord(status *ScaleUpStatus) ScaleUpAuditRecord {
+	record := ScaleUpAuditRecord{
+		Result:               scaleUpResultName(status.Result),
+		PodsTriggeredScaleUp: podNames(status.PodsTriggeredScaleUp),
+	}
+	if status.ScaleUpError != nil && *status.ScaleUpError != nil {
+		record.Error = (*status.ScaleUpError).Error()
+	}
+	for _, option := range status.ExpansionOptions {
+		record.Options = append(record.Options, optionAuditRecord(option))
+	}
+	if status.BestOption != nil {
+		best := optionAuditRecord(*status.BestOption)
+		record.BestOption = &best
+	}
+	for _, info := range status.ScaleUpInfos {
+		record.ScaleUps = append(record.ScaleUps, ScaleUpInfoRecord{
+			NodeGroup:   info.Group.Id(),
+			CurrentSize: info.CurrentSize,
+			NewSize:     info.NewSize,
+		})
+	}
+	for _, noScaleUpInfo := range status.PodsRemainUnschedulable {
+		record.PodsRemainUnschedulable = append(record.PodsRemainUnschedulable, NoScaleUpAuditRecord{
+			Pod:      podName(noScaleUpInfo.Pod),
+			Rejected: reasonsByNodeGroup(noScaleUpInfo.RejectedNodeGroups),
+			Skipped:  reasonsByNodeGroup(noScaleUpInfo.SkippedNodeGroups),
+		})
+	}
+	for _, nodeGroup := range status.FailedResizeNodeGroups {
+		record.FailedResizeNodeGroups = append(record.FailedResizeNodeGroups, nodeGroup.Id())
+	}
+	for _, nodeGroup := range status.FailedCreationNodeGroups {
+		record.FailedCreateNodeGroups = append(record.FailedCreateNodeGroups, nodeGroup.Id())
+	}
+	return record
+}
+
+func optionAuditRecord(option expander.Option) OptionAuditRecord {
+	record := OptionAuditRecord{
+		NodeGroup: option.NodeGroup.Id(),
+		NodeCount: option.NodeCount,
+		PodCount:  len(option.Pods),
+		Debug:     option.Debug,
+	}
+	for _, nodeGroup := range option.SimilarNodeGroups {
+		record.SimilarNodeGroups = append(record.SimilarNodeGroups, nodeGroup.Id())
+	}
+	return record
+}
+
+func reasonsByNodeGroup(reasons map[string]Reasons) map[string][]string {
+	if len(reasons) == 0 {
+		return nil
+	}
+	result := make(map[string][]string, len(reasons))
+	for nodeGroupId, r := range reasons {
+		result[nodeGroupId] = r.Reasons()
+	}
+	return result
+}
+
+func scaleDownAuditRecord(status *scaledownstatus.ScaleDownStatus) *ScaleDownAuditRecord {
+	record := &ScaleDownAuditRecord{
+		Result: scaleDownResultName(status.Result),
+	}
+	for _, node := range status.UnneededNodes {
+		record.UnneededNodes = append(record.UnneededNodes, node.Name)
+	}
+	for _, unremovable := range status.UnremovableNodes {
+		r := UnremovableAuditRecord{
+			Node:   unremovable.Node.Name,
+			Reason: unremovableReasonName(unremovable.Reason),
+		}
+		if unremovable.NodeGroup != nil {
+			r.NodeGroup = unremovable.NodeGroup.Id()
+		}
+		if unremovable.BlockingPod != nil {
+			r.BlockingPod = podName(unremovable.BlockingPod.Pod)
+			r.BlockingPodReason = unremovable.BlockingPod.Reason.String()
+		}
+		record.UnremovableNodes = append(record.UnremovableNodes, r)
+	}
+	for _, scaledDown := range status.ScaledDownNodes {
+		r := ScaledDownAuditRecord{
+			Node:        scaledDown.Node.Name,
+			EvictedPods: podNames(scaledDown.EvictedPods),
+		}
+		if scaledDown.NodeGroup != nil {
+			r.NodeGroup = scaledDown.NodeGroup.Id()
+		}
+		record.ScaledDownNodes = append(record.ScaledDownNodes, r)
+	}
+	for _, nodeGroup := range status.RemovedNodeGroups {
+		record.RemovedNodeGroups = append(record.RemovedNodeGroups, nodeGroup.Id())
+	}
+	if len(status.NodeDeleteResults) > 0 {
+		record.NodeDeleteResults = make(map[string]NodeDeleteAudit, len(status.NodeDeleteResults))
+		for nodeName, result := range status.NodeDeleteResults {
+			audit := NodeDeleteAudit{Result: nodeDeleteResultName(result.ResultType)}
+			if result.Err != nil {
+				audit.Error = result.Err.Error()
+			}
+			record.NodeDeleteResults[nodeName] = audit
+		}
+	}
+	return record
+}
+
+func podName(pod *apiv1.Pod) string {
+	if pod == nil {
+		return ""
+	}
+	return pod.Namespace + "/" + pod.Name
+}
+
+func podNames(pods []*apiv1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		names = append(names, podName(pod))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func scaleUpResultName(result ScaleUpResult) string {
+	switch result {
+	case ScaleUpSuccessful:
+		return "Successful"
+	case ScaleUpError:
+		return "Error"
+	case ScaleUpNoOptionsAvailable:
+		return "NoOptionsAvailable"
+	case ScaleUpNotNeeded:
+		return "NotNeeded"
+	case ScaleUpNotTried:
+		return "NotTried"
+	case ScaleUpInCooldown:
+		return "InCooldown"
+	default:
+		return fmt.Sprintf("unrecognized result: %d", int(result))
+	}
+}
+
+func scaleDownResultName(result scaledownstatus.ScaleDownResult) string {
+	switch result {
+	case scaledownstatus.ScaleDownError:
+		return "Error"
+	case scaledownstatus.ScaleDownNoUnneeded:
+		return "NoUnneeded"
+	case scaledownstatus.ScaleDownNoNodeDeleted:
+		return "NoNodeDeleted"
+	case scaledownstatus.ScaleDownNodeDeleteStarted:
+		return "NodeDeleteStarted"
+	case scaledownstatus.ScaleDownNotTried:
+		return "NotTried"
+	case scaledownstatus.ScaleDownInCooldown:
+		return "InCooldown"
+	case scaledownstatus.ScaleDownInProgress:
+		return "InProgress"
+	default:
+		return fmt.Sprintf("unrecognized result: %d", int(result))
+	}
+}
+
+func nodeDeleteResultName(result scaledownstatus.NodeDeleteResultType) string {
+	switch result {
+	case scaledownstatus.NodeDeleteOk:
+		return "Ok"
+	case scaledownstatus.NodeDeleteErrorFailedToMarkToBeDeleted:
+		return "FailedToMarkToBeDeleted"
+	case scaledownstatus.NodeDeleteErrorFailedToEvictPods:
+		return "FailedToEvictPods"
+	case scaledownstatus.NodeDeleteErrorFailedToDelete:
+		return "FailedToDelete"
+	case scaledownstatus.NodeDeleteErrorInternal:
+		return "Internal"
+	default:
+		return fmt.Sprintf("unrecognized result: %d", int(result))
+	}
+}
+
+func unremovableReasonName(reason simulator.UnremovableReason) string {
+	switch reason {
+	case simulator.NoReason:
+		return "NoReason"
+	case simulator.ScaleDownDisabledAnnotation:
+		return "ScaleDownDisabledAnnotation"
+	case simulator.ScaleDownUnreadyDisabled:
+		return "ScaleDownUnreadyDisabled"
+	case simulator.NotAutoscaled:
+		return "NotAutoscaled"
+	case simulator.NotUnneededLongEnough:
+		return "NotUnneededLongEnough"
+	case simulator.NotUnreadyLongEnough:
+		return "NotUnreadyLongEnough"
+	case simulator.NodeGroupMinSizeReached:
+		return "NodeGroupMinSizeReached"
+	case simulator.MinimalResourceLimitExceeded:
+		return "MinimalResourceLimitExceeded"
+	case simulator.CurrentlyBeingDeleted:
+		return "CurrentlyBeingDeleted"
+	case simulator.NotUnderutilized:
+		return "NotUnderutilized"
+	case simulator.NotUnneededOtherReason:
+		return "NotUnneededOtherReason"
+	case simulator.RecentlyUnremovable:
+		return "RecentlyUnremovable"
+	case simulator.NoPlaceToMovePods:
+		return "NoPlaceToMovePods"
+	case simulator.BlockedByPod:
+		return "BlockedByPod"
+	case simulator.UnexpectedError:
+		return "UnexpectedError"
+	case simulator.NodeGroupSetMinimalResourceLimitExceeded:
+		return "NodeGroupSetMinimalResourceLimitExceeded"
+	case simulator.ScaleDownProtected:
+		return "ScaleDownProtected"
+	default:
+		return fmt.Sprintf("unrecognized reason: %d", int(reason))
+	}
+}
+
+// AuditLogScaleUpStatusProcessor records scale-up statuses in an AuditLog
+// before passing them on to the wrapped processor.
+type AuditLogScaleUpStatusProcessor struct {
+	auditLog *AuditLog
+	wrapped  ScaleUpStatusProcessor
+}
+
+// NewAuditLogScaleUpStatusProcessor creates an AuditLogScaleUpStatusProcessor.
+func NewAuditLogScaleUpStatusProcessor(auditLog *AuditLog, wrapped ScaleUpStatusProcessor) *AuditLogScaleUpStatusProcessor {
+	return &AuditLogScaleUpStatusProcessor{auditLog: auditLog, wrapped: wrapped}
+}
+
+// Process records the scale-up status and calls the wrapped processor.
+func (p *AuditLogScaleUpStatusProcessor) Process(context *context.AutoscalingContext, status *ScaleUpStatus) {
+	p.auditLog.RecordScaleUp(status)
+	p.wrapped.Process(context, status)
+}
+
+// CleanUp closes the audit log and cleans up the wrapped processor.
+func (p *AuditLogScaleUpStatusProcessor) CleanUp() {
+	p.auditLog.Close()
+	p.wrapped.CleanUp()
+}
+
+// AuditLogScaleDownStatusProcessor records scale-down statuses in an AuditLog
+// before passing them on to the wrapped processor.
+type AuditLogScaleDownStatusProcessor struct {
+	auditLog *AuditLog
+	wrapped  ScaleDownStatusProcessor
+}
+
+// NewAuditLogScaleDownStatusProcessor creates an AuditLogScaleDownStatusProcessor.
+func NewAuditLogScaleDownStatusProcessor(auditLog *AuditLog, wrapped ScaleDownStatusProcessor) *AuditLogScaleDownStatusProcessor {
+	return &AuditLogScaleDownStatusProcessor{auditLog: auditLog, wrapped: wrapped}
+}
+
+// Process records the scale-down status and calls the wrapped processor.
+func (p *AuditLogScaleDownStatusProcessor) Process(context *context.AutoscalingContext, status *scaledownstatus.ScaleDownStatus) {
+	p.auditLog.RecordScaleDown(status)
+	p.wrapped.Process(context, status)
+}
+
+// CleanUp closes the audit log and cleans up the wrapped processor.
+func (p *AuditLogScaleDownStatusProcessor) CleanUp() {
+	p.auditLog.Close()
+	p.wrapped.CleanUp()
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+
+	cp_test "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestAuditLog(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ng1 := cp_test.NewTestNodeGroup("ng1", 10, 0, 1, true, false, "", nil, nil)
+	ng2 := cp_test.NewTestNodeGroup("ng2", 10, 0, 1, true, false, "", nil, nil)
+	p1 := BuildTestPod("p1", 100, 0)
+	p2 := BuildTestPod("p2", 100, 0)
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n3 := BuildTestNode("n3", 1000, 1000)
+
+	options := []expander.Option{
+		{NodeGroup: ng1, NodeCount: 1, Pods: []*apiv1.Pod{p1}},
+		{NodeGroup: ng2, NodeCount: 2, Pods: []*apiv1.Pod{p1}},
+	}
+	scaleUpStatus := &ScaleUpStatus{
+		Result:               ScaleUpSuccessful,
+		ExpansionOptions:     options,
+		BestOption:           &options[0],
+		ScaleUpInfos:         []nodegroupset.ScaleUpInfo{{Group: ng1, CurrentSize: 1, NewSize: 2, MaxSize: 10}},
+		PodsTriggeredScaleUp: []*apiv1.Pod{p1},
+		PodsRemainUnschedulable: []NoScaleUpInfo{
+			{
+				Pod:                p2,
+				RejectedNodeGroups: map[string]Reasons{"ng1": &testReason{"not schedulable"}},
+				SkippedNodeGroups:  map[string]Reasons{"ng2": &testReason{"max limit reached"}},
+			},
+		},
+	}
+	scaleDownStatus := &scaledownstatus.ScaleDownStatus{
+		Result:        scaledownstatus.ScaleDownNodeDeleteStarted,
+		UnneededNodes: []*apiv1.Node{n1, n2},
+		UnremovableNodes: []*scaledownstatus.UnremovableNode{
+			{Node: n3, NodeGroup: ng2, Reason: simulator.BlockedByPod, BlockingPod: &drain.BlockingPod{Pod: p2, Reason: drain.NotReplicated}},
+		},
+		ScaledDownNodes: []*scaledownstatus.ScaleDownNode{
+			{Node: n1, NodeGroup: ng1, EvictedPods: []*apiv1.Pod{p1}},
+		},
+		NodeDeleteResults: map[string]scaledownstatus.NodeDeleteResult{
+			"n0": {ResultType: scaledownstatus.NodeDeleteErrorFailedToDelete, Err: fmt.Errorf("boom")},
+		},
+	}
+
+	var buf bytes.Buffer
+	auditLog := NewAuditLog(&buf)
+	auditLog.now = func() time.Time { return now }
+	scaleUpProcessor := NewAuditLogScaleUpStatusProcessor(auditLog, &NoOpScaleUpStatusProcessor{})
+	scaleDownProcessor := NewAuditLogScaleDownStatusProcessor(auditLog, &NoOpScaleDownStatusProcessor{})
+
+	scaleUpProcessor.Process(nil, scaleUpStatus)
+	assert.Empty(t, buf.String(), "record shouldn't be written before scale-down status is processed")
+	scaleUpProcessor.Process(nil, &ScaleUpStatus{Result: ScaleUpNotNeeded})
+	scaleDownProcessor.Process(nil, scaleDownStatus)
+	scaleDownProcessor.Process(nil, &scaledownstatus.ScaleDownStatus{Result: scaledownstatus.ScaleDownInCooldown})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	var got AuditRecord
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &got))
+	want := AuditRecord{
+		Timestamp: now,
+		ScaleUp: []ScaleUpAuditRecord{
+			{
+				Result: "Successful",
+				Options: []OptionAuditRecord{
+					{NodeGroup: "ng1", NodeCount: 1, PodCount: 1},
+					{NodeGroup: "ng2", NodeCount: 2, PodCount: 1},
+				},
+				BestOption:           &OptionAuditRecord{NodeGroup: "ng1", NodeCount: 1, PodCount: 1},
+				ScaleUps:             []ScaleUpInfoRecord{{NodeGroup: "ng1", CurrentSize: 1, NewSize: 2}},
+				PodsTriggeredScaleUp: []string{"default/p1"},
+				PodsRemainUnschedulable: []NoScaleUpAuditRecord{
+					{
+						Pod:      "default/p2",
+						Rejected: map[string][]string{"ng1": {"not schedulable"}},
+						Skipped:  map[string][]string{"ng2": {"max limit reached"}},
+					},
+				},
+			},
+			{Result: "NotNeeded"},
+		},
+		ScaleDown: &ScaleDownAuditRecord{
+			Result:        "NodeDeleteStarted",
+			UnneededNodes: []string{"n1", "n2"},
+			UnremovableNodes: []UnremovableAuditRecord{
+				{Node: "n3", NodeGroup: "ng2", Reason: "BlockedByPod", BlockingPod: "default/p2", BlockingPodReason: "NotReplicated"},
+			},
+			ScaledDownNodes: []ScaledDownAuditRecord{
+				{Node: "n1", NodeGroup: "ng1", EvictedPods: []string{"default/p1"}},
+			},
+			NodeDeleteResults: map[string]NodeDeleteAudit{
+				"n0": {Result: "FailedToDelete", Error: "boom"},
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+
+	got = AuditRecord{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &got))
+	assert.Equal(t, AuditRecord{Timestamp: now, ScaleDown: &ScaleDownAuditRecord{Result: "InCooldown"}}, got)
+}
+
+func TestAuditLogCleanUpFlushes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := OpenAuditLog(path)
+	assert.NoError(t, err)
+	scaleUpProcessor := NewAuditLogScaleUpStatusProcessor(auditLog, &NoOpScaleUpStatusProcessor{})
+	scaleDownProcessor := NewAuditLogScaleDownStatusProcessor(auditLog, &NoOpScaleDownStatusProcessor{})
+
+	scaleUpProcessor.Process(nil, &ScaleUpStatus{Result: ScaleUpNotNeeded})
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Empty(t, content, "record shouldn't be written before scale-down status is processed")
+
+	// Clean-up on exit writes out the buffered record, statuses recorded later are dropped.
+	scaleUpProcessor.CleanUp()
+	scaleUpProcessor.Process(nil, &ScaleUpStatus{Result: ScaleUpNoOptionsAvailable})
+	scaleDownProcessor.Process(nil, &scaledownstatus.ScaleDownStatus{Result: scaledownstatus.ScaleDownInCooldown})
+	scaleDownProcessor.CleanUp()
+
+	content, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if assert.Len(t, lines, 1) {
+		var got AuditRecord
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &got))
+		assert.Equal(t, []ScaleUpAuditRecord{{Result: "NotNeeded"}}, got.ScaleUp)
+		assert.Nil(t, got.ScaleDown)
+	}
+}
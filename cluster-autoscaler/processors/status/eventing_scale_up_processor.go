@@ -31,7 +31,16 @@ import (
 // EventingScaleUpStatusProcessor processes the state of the cluster after
 // a scale-up by emitting relevant events for pods depending on their post
 // scale-up status.
-type EventingScaleUpStatusProcessor struct{}
+type EventingScaleUpStatusProcessor struct {
+	// ExplainNodeGroups makes NotTriggerScaleUp events list the reasons per
+	// node group instead of aggregated reason counts.
+	ExplainNodeGroups bool
+}
+
+// NewEventingScaleUpStatusProcessor creates an EventingScaleUpStatusProcessor.
+func NewEventingScaleUpStatusProcessor(explainNodeGroups bool) *EventingScaleUpStatusProcessor {
+	return &EventingScaleUpStatusProcessor{ExplainNodeGroups: explainNodeGroups}
+}
 
 // Process processes the state of the cluster after a scale-up by emitting
 // relevant events for pods depending on their post scale-up status.
@@ -39,9 +48,14 @@ func (p *EventingScaleUpStatusProcessor) Process(context *context.AutoscalingCon
 	consideredNodeGroupsMap := nodeGroupListToMapById(status.ConsideredNodeGroups)
 	if status.Result != ScaleUpSuccessful && status.Result != ScaleUpError {
 		for _, noScaleUpInfo := range status.PodsRemainUnschedulable {
-			context.Recorder.Event(noScaleUpInfo.Pod, apiv1.EventTypeNormal, "NotTriggerScaleUp",
-				fmt.Sprintf("pod didn't trigger scale-up: %s",
-					ReasonsMessage(noScaleUpInfo, consideredNodeGroupsMap)))
+			var message string
+			if p.ExplainNodeGroups {
+				message = BuildNoScaleUpExplanation(noScaleUpInfo, consideredNodeGroupsMap).Message()
+			} else {
+				message = ReasonsMessage(noScaleUpInfo, consideredNodeGroupsMap)
+			}
+			context.Recorder.Event(noScaleUpInfo.Pod, apiv1.EventTypeNormal, NotTriggerScaleUpReason,
+				fmt.Sprintf("pod didn't trigger scale-up: %s", message))
 		}
 	} else {
 		klog.V(4).Infof("Skipping event processing for unschedulable pods since there is a" +
@@ -132,6 +132,52 @@ func TestEventingScaleUpStatusProcessor(t *testing.T) {
 	}
 }
 
+func TestEventingScaleUpStatusProcessorExplainNodeGroups(t *testing.T) {
+	provider := cp_test.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 10)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	status := &ScaleUpStatus{
+		Result:               ScaleUpNoOptionsAvailable,
+		ConsideredNodeGroups: provider.NodeGroups(),
+		PodsRemainUnschedulable: []NoScaleUpInfo{{
+			Pod:                BuildTestPod("p1", 0, 0),
+			SkippedNodeGroups:  map[string]Reasons{"ng1": &testReason{"max node group size reached"}},
+			RejectedNodeGroups: map[string]Reasons{"ng2": &testReason{"not schedulable"}},
+		}},
+	}
+
+	for tn, tc := range map[string]struct {
+		explainNodeGroups bool
+		wantMessage       string
+	}{
+		"aggregated reasons": {
+			wantMessage: "pod didn't trigger scale-up:",
+		},
+		"explained node groups": {
+			explainNodeGroups: true,
+			wantMessage:       "pod didn't trigger scale-up: ng1: max node group size reached; ng2: not schedulable",
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			fakeRecorder := kube_record.NewFakeRecorder(5)
+			context := &context.AutoscalingContext{
+				AutoscalingKubeClients: context.AutoscalingKubeClients{
+					Recorder: fakeRecorder,
+				},
+			}
+			NewEventingScaleUpStatusProcessor(tc.explainNodeGroups).Process(context, status)
+			if assert.Len(t, fakeRecorder.Events, 1) {
+				event := <-fakeRecorder.Events
+				assert.Contains(t, event, NotTriggerScaleUpReason)
+				assert.Contains(t, event, tc.wantMessage)
+				if !tc.explainNodeGroups {
+					assert.NotContains(t, event, "ng1")
+				}
+			}
+		})
+	}
+}
+
 func TestReasonsMessage(t *testing.T) {
 	notSchedulableReason := &testReason{"not schedulable"}
 	alsoNotSchedulableReason := &testReason{"also not schedulable"}
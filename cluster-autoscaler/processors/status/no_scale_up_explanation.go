@@ -0,0 +1,296 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	ctx "context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	klog "k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/predicatechecker"
+)
+
+const (
+	// NoScaleUpExplanationAnnotationKey is the pod annotation holding a JSON encoded
+	// NoScaleUpExplanation for pods that didn't trigger a scale-up.
+	NoScaleUpExplanationAnnotationKey = "cluster-autoscaler.kubernetes.io/no-scale-up-explanation"
+	// TriggeredScaleUpPodCondition is the pod condition telling if the pod triggered a scale-up.
+	TriggeredScaleUpPodCondition apiv1.PodConditionType = "cluster-autoscaler.kubernetes.io/TriggeredScaleUp"
+
+	// NotTriggerScaleUpReason is the condition and event reason used for pods that didn't trigger a scale-up.
+	NotTriggerScaleUpReason = "NotTriggerScaleUp"
+	// TriggeredScaleUpReason is the condition reason used for pods that triggered a scale-up.
+	TriggeredScaleUpReason = "TriggeredScaleUp"
+
+	// maxExplainedNodeGroups limits the size of the annotation in clusters with many node groups.
+	maxExplainedNodeGroups = 50
+	// maxExplanationMessageLength limits the length of condition and event messages.
+	maxExplanationMessageLength = 1024
+)
+
+// NoScaleUpExplanation describes why a pod didn't trigger a scale-up of any node group.
+type NoScaleUpExplanation struct {
+	// NodeGroups lists node groups that couldn't be used for the pod, sorted by id.
+	NodeGroups []NodeGroupExplanation `json:"nodeGroups"`
+	// OmittedNodeGroups is the number of node groups left out to keep the explanation short.
+	OmittedNodeGroups int `json:"omittedNodeGroups,omitempty"`
+}
+
+// NodeGroupExplanation describes why a single node group couldn't be used for a pod.
+type NodeGroupExplanation struct {
+	NodeGroup string `json:"nodeGroup"`
+	// Skipped is true if the node group wasn't considered at all (e.g. max size
+	// reached, resource limits, backoff), false if the pod didn't fit its nodes.
+	Skipped bool `json:"skipped,omitempty"`
+	// Predicate is the name of the scheduler predicate that failed, if any.
+	Predicate string   `json:"predicate,omitempty"`
+	Reasons   []string `json:"reasons"`
+}
+
+// BuildNoScaleUpExplanation builds an explanation from skipped and rejected reasons
+// of a pod. Node groups that don't exist or weren't considered are ignored.
+func BuildNoScaleUpExplanation(noScaleUpInfo NoScaleUpInfo, consideredNodeGroups map[string]cloudprovider.NodeGroup) NoScaleUpExplanation {
+	explanation := NoScaleUpExplanation{NodeGroups: []NodeGroupExplanation{}}
+	add := func(reasonsByNodeGroup map[string]Reasons, skipped bool) {
+		for nodeGroupId, reasons := range reasonsByNodeGroup {
+			if nodeGroup, present := consideredNodeGroups[nodeGroupId]; !present || !nodeGroup.Exist() {
+				continue
+			}
+			explanation.NodeGroups = append(explanation.NodeGroups, explainNodeGroup(nodeGroupId, reasons, skipped))
+		}
+	}
+	add(noScaleUpInfo.SkippedNodeGroups, true)
+	add(noScaleUpInfo.RejectedNodeGroups, false)
+	sort.Slice(explanation.NodeGroups, func(i, j int) bool {
+		return explanation.NodeGroups[i].NodeGroup < explanation.NodeGroups[j].NodeGroup
+	})
+	if len(explanation.NodeGroups) > maxExplainedNodeGroups {
+		explanation.OmittedNodeGroups = len(explanation.NodeGroups) - maxExplainedNodeGroups
+		explanation.NodeGroups = explanation.NodeGroups[:maxExplainedNodeGroups]
+	}
+	return explanation
+}
+
+func explainNodeGroup(nodeGroupId string, reasons Reasons, skipped bool) NodeGroupExplanation {
+	result := NodeGroupExplanation{NodeGroup: nodeGroupId, Skipped: skipped}
+	if predicateError, ok := reasons.(*predicatechecker.PredicateError); ok {
+		result.Predicate = predicateError.PredicateName()
+		result.Reasons = predicateError.Reasons()
+		if len(result.Reasons) == 0 {
+			result.Reasons = []string{predicateError.Message()}
+		}
+		return result
+	}
+	result.Reasons = reasons.Reasons()
+	return result
+}
+
+// Message returns a human readable summary of the explanation, grouping node
+// groups which were excluded for the same reasons.
+func (e NoScaleUpExplanation) Message() string {
+	if len(e.NodeGroups) == 0 {
+		return "no node group could be considered"
+	}
+	var summaries []string
+	nodeGroupsBySummary := map[string][]string{}
+	for _, ng := range e.NodeGroups {
+		summary := strings.Join(ng.Reasons, ", ")
+		if ng.Predicate != "" {
+			summary = fmt.Sprintf("%s (%s)", summary, ng.Predicate)
+		}
+		if _, found := nodeGroupsBySummary[summary]; !found {
+			summaries = append(summaries, summary)
+		}
+		nodeGroupsBySummary[summary] = append(nodeGroupsBySummary[summary], ng.NodeGroup)
+	}
+	parts := make([]string, 0, len(summaries))
+	for _, summary := range summaries {
+		parts = append(parts, fmt.Sprintf("%s: %s", strings.Join(nodeGroupsBySummary[summary], ", "), summary))
+	}
+	message := strings.Join(parts, "; ")
+	if e.OmittedNodeGroups > 0 {
+		message = fmt.Sprintf("%s; %d more node groups omitted", message, e.OmittedNodeGroups)
+	}
+	if len(message) > maxExplanationMessageLength {
+		message = message[:maxExplanationMessageLength-3] + "..."
+	}
+	return message
+}
+
+// NoScaleUpExplanationProcessor exposes the reasons why pods didn't trigger a
+// scale-up as a pod annotation and a pod condition, before passing the status
+// on to the wrapped processor. The NotTriggerScaleUp event is left to
+// EventingScaleUpStatusProcessor, which includes the same explanation when
+// ExplainNodeGroups is set. Pods are updated only when their explanation
+// changes, at most once per minInterval, and the total rate of pod updates is
+// limited.
+type NoScaleUpExplanationProcessor struct {
+	wrapped     ScaleUpStatusProcessor
+	minInterval time.Duration
+	rateLimiter flowcontrol.RateLimiter
+	lastUpdate  map[types.UID]time.Time
+	now         func() time.Time
+}
+
+// NewNoScaleUpExplanationProcessor creates a NoScaleUpExplanationProcessor
+// updating each pod at most once per minInterval and at most qps pods per second.
+func NewNoScaleUpExplanationProcessor(minInterval time.Duration, qps float32, wrapped ScaleUpStatusProcessor) *NoScaleUpExplanationProcessor {
+	burst := int(qps)
+	if burst < 1 {
+		burst = 1
+	}
+	return &NoScaleUpExplanationProcessor{
+		wrapped:     wrapped,
+		minInterval: minInterval,
+		rateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+		lastUpdate:  map[types.UID]time.Time{},
+		now:         time.Now,
+	}
+}
+
+// Process updates pods that remain unschedulable with an explanation and marks
+// previously explained pods that triggered a scale-up, then calls the wrapped processor.
+func (p *NoScaleUpExplanationProcessor) Process(context *context.AutoscalingContext, status *ScaleUpStatus) {
+	now := p.now()
+	p.forgetOldUpdates(now)
+	if status.Result != ScaleUpSuccessful && status.Result != ScaleUpError {
+		consideredNodeGroups := nodeGroupListToMapById(status.ConsideredNodeGroups)
+		for _, noScaleUpInfo := range status.PodsRemainUnschedulable {
+			p.explain(context, noScaleUpInfo.Pod, BuildNoScaleUpExplanation(noScaleUpInfo, consideredNodeGroups), now)
+		}
+	}
+	if len(status.ScaleUpInfos) > 0 {
+		for _, pod := range status.PodsTriggeredScaleUp {
+			p.clear(context, pod, now)
+		}
+	}
+	p.wrapped.Process(context, status)
+}
+
+// CleanUp cleans up the wrapped processor.
+func (p *NoScaleUpExplanationProcessor) CleanUp() {
+	p.wrapped.CleanUp()
+}
+
+func (p *NoScaleUpExplanationProcessor) forgetOldUpdates(now time.Time) {
+	for uid, lastUpdate := range p.lastUpdate {
+		if now.Sub(lastUpdate) >= p.minInterval {
+			delete(p.lastUpdate, uid)
+		}
+	}
+}
+
+func (p *NoScaleUpExplanationProcessor) explain(context *context.AutoscalingContext, pod *apiv1.Pod, explanation NoScaleUpExplanation, now time.Time) {
+	value, err := json.Marshal(explanation)
+	if err != nil {
+		klog.Errorf("Failed to marshal no scale-up explanation for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return
+	}
+	if pod.Annotations[NoScaleUpExplanationAnnotationKey] == string(value) && hasPodCondition(pod, apiv1.ConditionFalse) {
+		return
+	}
+	if _, found := p.lastUpdate[pod.UID]; found || !p.rateLimiter.TryAccept() {
+		return
+	}
+	p.lastUpdate[pod.UID] = now
+	if err := patchPodAnnotation(context, pod, string(value)); err != nil {
+		klog.Warningf("Failed to set no scale-up explanation annotation on pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return
+	}
+	if err := patchPodCondition(context, pod, apiv1.ConditionFalse, NotTriggerScaleUpReason, explanation.Message(), now); err != nil {
+		klog.Warningf("Failed to set %s condition on pod %s/%s: %v", TriggeredScaleUpPodCondition, pod.Namespace, pod.Name, err)
+	}
+}
+
+func (p *NoScaleUpExplanationProcessor) clear(context *context.AutoscalingContext, pod *apiv1.Pod, now time.Time) {
+	if !hasPodCondition(pod, apiv1.ConditionFalse) {
+		return
+	}
+	if !p.rateLimiter.TryAccept() {
+		return
+	}
+	delete(p.lastUpdate, pod.UID)
+	if err := patchPodAnnotation(context, pod, nil); err != nil {
+		klog.Warningf("Failed to remove no scale-up explanation annotation from pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+	if err := patchPodCondition(context, pod, apiv1.ConditionTrue, TriggeredScaleUpReason, "pod triggered scale-up", now); err != nil {
+		klog.Warningf("Failed to set %s condition on pod %s/%s: %v", TriggeredScaleUpPodCondition, pod.Namespace, pod.Name, err)
+	}
+}
+
+func hasPodCondition(pod *apiv1.Pod, status apiv1.ConditionStatus) bool {
+	condition := getPodCondition(pod)
+	return condition != nil && condition.Status == status
+}
+
+func getPodCondition(pod *apiv1.Pod) *apiv1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == TriggeredScaleUpPodCondition {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// patchPodAnnotation sets the explanation annotation to value, nil removes it.
+func patchPodAnnotation(context *context.AutoscalingContext, pod *apiv1.Pod, value interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{NoScaleUpExplanationAnnotationKey: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = context.ClientSet.CoreV1().Pods(pod.Namespace).Patch(ctx.TODO(), pod.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func patchPodCondition(context *context.AutoscalingContext, pod *apiv1.Pod, status apiv1.ConditionStatus, reason, message string, now time.Time) error {
+	condition := apiv1.PodCondition{
+		Type:               TriggeredScaleUpPodCondition,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastProbeTime:      metav1.NewTime(now),
+		LastTransitionTime: metav1.NewTime(now),
+	}
+	if existing := getPodCondition(pod); existing != nil && existing.Status == status {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []apiv1.PodCondition{condition},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = context.ClientSet.CoreV1().Pods(pod.Namespace).Patch(ctx.TODO(), pod.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}, "status")
+	return err
+}
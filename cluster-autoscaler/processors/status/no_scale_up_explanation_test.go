@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	ctx "context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	kube_record "k8s.io/client-go/tools/record"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	cp_test "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/predicatechecker"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestBuildNoScaleUpExplanation(t *testing.T) {
+	provider := cp_test.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 10)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	provider.AddNodeGroup("ng3", 0, 10, 1)
+	provider.AddNodeGroup("ng4", 0, 10, 1)
+	considered := nodeGroupListToMapById(provider.NodeGroups())
+
+	predicateError := predicatechecker.NewPredicateError(predicatechecker.NotSchedulablePredicateError, "NodeResourcesFit", "", []string{"Insufficient cpu"}, nil)
+	noScaleUpInfo := NoScaleUpInfo{
+		Pod: BuildTestPod("p1", 100, 0),
+		SkippedNodeGroups: map[string]Reasons{
+			"ng1": &testReason{"max node group size reached"},
+			"ng4": &testReason{"max node group size reached"},
+			"ng5": &testReason{"in backoff after failed scale-up"},
+		},
+		RejectedNodeGroups: map[string]Reasons{
+			"ng2": predicateError,
+			"ng3": predicatechecker.GenericPredicateError(),
+		},
+	}
+
+	explanation := BuildNoScaleUpExplanation(noScaleUpInfo, considered)
+	assert.Equal(t, NoScaleUpExplanation{NodeGroups: []NodeGroupExplanation{
+		{NodeGroup: "ng1", Skipped: true, Reasons: []string{"max node group size reached"}},
+		{NodeGroup: "ng2", Predicate: "NodeResourcesFit", Reasons: []string{"Insufficient cpu"}},
+		{NodeGroup: "ng3", Reasons: []string{"generic predicate failure"}},
+		{NodeGroup: "ng4", Skipped: true, Reasons: []string{"max node group size reached"}},
+	}}, explanation)
+	assert.Equal(t, "ng1, ng4: max node group size reached; ng2: Insufficient cpu (NodeResourcesFit); ng3: generic predicate failure", explanation.Message())
+	assert.Equal(t, "no node group could be considered", BuildNoScaleUpExplanation(NoScaleUpInfo{}, considered).Message())
+}
+
+func TestNoScaleUpExplanationProcessor(t *testing.T) {
+	provider := cp_test.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 10)
+	nodeGroups := []cloudprovider.NodeGroup{provider.GetNodeGroup("ng1")}
+	maxSizeReached := map[string]Reasons{"ng1": &testReason{"max node group size reached"}}
+	inBackoff := map[string]Reasons{"ng1": &testReason{"in backoff after failed scale-up"}}
+
+	pod := BuildTestPod("p1", 100, 0)
+	pod.Namespace = "default"
+	client := fake.NewSimpleClientset(pod)
+	recorder := kube_record.NewFakeRecorder(10)
+	autoscalingContext := &context.AutoscalingContext{
+		AutoscalingKubeClients: context.AutoscalingKubeClients{
+			ClientSet: client,
+			Recorder:  recorder,
+		},
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := NewNoScaleUpExplanationProcessor(5*time.Minute, 100, &NoOpScaleUpStatusProcessor{})
+	p.now = func() time.Time { return now }
+
+	process := func(result ScaleUpResult, skipped map[string]Reasons) *apiv1.Pod {
+		client.ClearActions()
+		current, err := client.CoreV1().Pods("default").Get(ctx.TODO(), "p1", metav1.GetOptions{})
+		assert.NoError(t, err)
+		status := &ScaleUpStatus{Result: result, ConsideredNodeGroups: nodeGroups}
+		if result == ScaleUpSuccessful {
+			status.ScaleUpInfos = []nodegroupset.ScaleUpInfo{{Group: nodeGroups[0]}}
+			status.PodsTriggeredScaleUp = []*apiv1.Pod{current}
+		} else {
+			status.PodsRemainUnschedulable = []NoScaleUpInfo{{Pod: current, SkippedNodeGroups: skipped}}
+		}
+		p.Process(autoscalingContext, status)
+		updated, err := client.CoreV1().Pods("default").Get(ctx.TODO(), "p1", metav1.GetOptions{})
+		assert.NoError(t, err)
+		return updated
+	}
+	patches := func() int {
+		count := 0
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "patch" {
+				count++
+			}
+		}
+		return count
+	}
+
+	// First explanation is written.
+	updated := process(ScaleUpNoOptionsAvailable, maxSizeReached)
+	assert.Equal(t, 2, patches())
+	var explanation NoScaleUpExplanation
+	assert.NoError(t, json.Unmarshal([]byte(updated.Annotations[NoScaleUpExplanationAnnotationKey]), &explanation))
+	assert.Equal(t, []NodeGroupExplanation{{NodeGroup: "ng1", Skipped: true, Reasons: []string{"max node group size reached"}}}, explanation.NodeGroups)
+	condition := getPodCondition(updated)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, apiv1.ConditionFalse, condition.Status)
+		assert.Equal(t, NotTriggerScaleUpReason, condition.Reason)
+		assert.Equal(t, "ng1: max node group size reached", condition.Message)
+	}
+
+	// Unchanged explanation is not written again.
+	now = now.Add(10 * time.Minute)
+	process(ScaleUpNoOptionsAvailable, maxSizeReached)
+	assert.Equal(t, 0, patches())
+
+	// Changed explanation is written...
+	updated = process(ScaleUpNoOptionsAvailable, inBackoff)
+	assert.Equal(t, 2, patches())
+	assert.Equal(t, "ng1: in backoff after failed scale-up", getPodCondition(updated).Message)
+
+	// ...but not more often than once per interval.
+	now = now.Add(time.Minute)
+	process(ScaleUpNoOptionsAvailable, maxSizeReached)
+	assert.Equal(t, 0, patches())
+	now = now.Add(5 * time.Minute)
+	process(ScaleUpNoOptionsAvailable, maxSizeReached)
+	assert.Equal(t, 2, patches())
+
+	// Scale-up clears the explanation.
+	updated = process(ScaleUpSuccessful, nil)
+	assert.Equal(t, 2, patches())
+	assert.NotContains(t, updated.Annotations, NoScaleUpExplanationAnnotationKey)
+	condition = getPodCondition(updated)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, apiv1.ConditionTrue, condition.Status)
+		assert.Equal(t, TriggeredScaleUpReason, condition.Reason)
+		assert.True(t, now.Equal(condition.LastTransitionTime.Time))
+	}
+
+	// Pods without the condition are left alone.
+	process(ScaleUpSuccessful, nil)
+	assert.Equal(t, 0, patches())
+
+	// Events are left to EventingScaleUpStatusProcessor.
+	assert.Empty(t, recorder.Events)
+}
@@ -22,6 +22,7 @@ import (
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
 )
@@ -40,6 +41,10 @@ type ScaleUpStatus struct {
 	ConsideredNodeGroups     []cloudprovider.NodeGroup
 	FailedCreationNodeGroups []cloudprovider.NodeGroup
 	FailedResizeNodeGroups   []cloudprovider.NodeGroup
+	// ExpansionOptions are the options considered by the expander.
+	ExpansionOptions []expander.Option
+	// BestOption is the option chosen by the expander, if any.
+	BestOption *expander.Option
 }
 
 // NoScaleUpInfo contains information about a pod that didn't trigger scale-up.
@@ -0,0 +1,457 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	ctx "context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	klog "k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	"k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+)
+
+const (
+	// maxUnremovableNodesInStatus is the maximum number of unremovable nodes
+	// listed in a single status object, to keep the objects reasonably small.
+	maxUnremovableNodesInStatus = 100
+)
+
+// StatusCRDWriter exposes the status of Cluster Autoscaler as a ClusterAutoscalerStatus
+// object and a NodeGroupStatus object per node group. NodeGroupStatus objects are owned
+// by the ClusterAutoscalerStatus and are only updated when their status changes.
+type StatusCRDWriter struct {
+	mutex  sync.Mutex
+	client versioned.Interface
+	name   string
+	// scaledUp and scaledDown contain ids of node groups scaled in the current iteration.
+	scaledUp             map[string]bool
+	scaledDown           map[string]bool
+	lastScaleUpTime      map[string]metav1.Time
+	lastScaleDownTime    map[string]metav1.Time
+	clusterScaleUpTime   *metav1.Time
+	clusterScaleDownTime *metav1.Time
+	unremovableNodes     []v1alpha1.UnremovableNode
+	// clusterStatus and nodeGroupStatuses cache objects last written to the API server.
+	// They're reset on write errors, so that the objects are fetched again.
+	clusterStatus     *v1alpha1.ClusterAutoscalerStatus
+	nodeGroupStatuses map[string]*v1alpha1.NodeGroupStatus
+}
+
+// NewStatusCRDWriter creates a StatusCRDWriter writing the ClusterAutoscalerStatus
+// object with the given name.
+func NewStatusCRDWriter(client versioned.Interface, name string) *StatusCRDWriter {
+	return &StatusCRDWriter{
+		client:            client,
+		name:              name,
+		scaledUp:          map[string]bool{},
+		scaledDown:        map[string]bool{},
+		lastScaleUpTime:   map[string]metav1.Time{},
+		lastScaleDownTime: map[string]metav1.Time{},
+	}
+}
+
+// RecordScaleUp records node groups scaled up in the current iteration.
+func (w *StatusCRDWriter) RecordScaleUp(status *ScaleUpStatus) {
+	if status.Result != ScaleUpSuccessful {
+		return
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for _, info := range status.ScaleUpInfos {
+		w.scaledUp[info.Group.Id()] = true
+	}
+}
+
+// RecordScaleDown records node groups scaled down in the current iteration
+// and nodes which couldn't be removed.
+func (w *StatusCRDWriter) RecordScaleDown(status *scaledownstatus.ScaleDownStatus) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for _, scaledDown := range status.ScaledDownNodes {
+		if scaledDown.NodeGroup != nil {
+			w.scaledDown[scaledDown.NodeGroup.Id()] = true
+		}
+	}
+	w.unremovableNodes = make([]v1alpha1.UnremovableNode, 0, len(status.UnremovableNodes))
+	for _, unremovable := range status.UnremovableNodes {
+		node := v1alpha1.UnremovableNode{
+			Name:   unremovable.Node.Name,
+			Reason: unremovableReasonName(unremovable.Reason),
+		}
+		if unremovable.NodeGroup != nil {
+			node.NodeGroup = unremovable.NodeGroup.Id()
+		}
+		if unremovable.BlockingPod != nil {
+			node.BlockingPod = podName(unremovable.BlockingPod.Pod)
+			node.BlockingPodReason = unremovable.BlockingPod.Reason.String()
+		}
+		w.unremovableNodes = append(w.unremovableNodes, node)
+	}
+	sort.Slice(w.unremovableNodes, func(i, j int) bool {
+		return w.unremovableNodes[i].Name < w.unremovableNodes[j].Name
+	})
+}
+
+// Write updates the status objects with the current status of the cluster.
+// NodeGroupStatus objects of node groups which no longer exist are deleted.
+func (w *StatusCRDWriter) Write(csr *clusterstate.ClusterStateRegistry, now time.Time) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	nowTime := metav1.NewTime(now).Rfc3339Copy()
+	for id := range w.scaledUp {
+		w.lastScaleUpTime[id] = nowTime
+		w.clusterScaleUpTime = &nowTime
+	}
+	for id := range w.scaledDown {
+		w.lastScaleDownTime[id] = nowTime
+		w.clusterScaleDownTime = &nowTime
+	}
+	w.scaledUp = map[string]bool{}
+	w.scaledDown = map[string]bool{}
+
+	status := csr.GetStatus(now)
+	owner, err := w.writeClusterStatus(status, nowTime)
+	if err != nil {
+		return err
+	}
+	return w.writeNodeGroupStatuses(status, owner, nowTime)
+}
+
+func (w *StatusCRDWriter) writeClusterStatus(status *api.ClusterAutoscalerStatus, now metav1.Time) (*v1alpha1.ClusterAutoscalerStatus, error) {
+	state := v1alpha1.ClusterAutoscalerStatusState{
+		AutoscalerStatus: string(status.AutoscalerStatus),
+		Message:          status.Message,
+		LastUpdateTime:   now,
+		ClusterWide: v1alpha1.ClusterWideState{
+			Health: v1alpha1.HealthCondition{
+				Status:             string(status.ClusterWide.Health.Status),
+				NodeCounts:         nodeCount(status.ClusterWide.Health.NodeCounts),
+				LastProbeTime:      status.ClusterWide.Health.LastProbeTime.Rfc3339Copy(),
+				LastTransitionTime: status.ClusterWide.Health.LastTransitionTime.Rfc3339Copy(),
+			},
+			ScaleUp: v1alpha1.ScaleUpCondition{
+				Status:             string(status.ClusterWide.ScaleUp.Status),
+				LastProbeTime:      status.ClusterWide.ScaleUp.LastProbeTime.Rfc3339Copy(),
+				LastTransitionTime: status.ClusterWide.ScaleUp.LastTransitionTime.Rfc3339Copy(),
+			},
+			ScaleDown: scaleDownCondition(status.ClusterWide.ScaleDown),
+		},
+		LastScaleUpTime:   w.clusterScaleUpTime,
+		LastScaleDownTime: w.clusterScaleDownTime,
+		NodeGroups:        int32(len(status.NodeGroups)),
+		UnremovableNodes:  truncateUnremovableNodes(w.unremovableNodes),
+	}
+
+	if w.clusterStatus == nil {
+		existing, err := w.client.AutoscalingV1alpha1().ClusterAutoscalerStatuses().Get(ctx.TODO(), w.name, metav1.GetOptions{})
+		if err == nil {
+			w.clusterStatus = existing
+		} else if !kube_errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get ClusterAutoscalerStatus %s: %v", w.name, err)
+		}
+	}
+	var result *v1alpha1.ClusterAutoscalerStatus
+	var err error
+	if w.clusterStatus == nil {
+		obj := &v1alpha1.ClusterAutoscalerStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: w.name},
+			Status:     state,
+		}
+		result, err = w.client.AutoscalingV1alpha1().ClusterAutoscalerStatuses().Create(ctx.TODO(), obj, metav1.CreateOptions{})
+	} else {
+		obj := w.clusterStatus.DeepCopy()
+		obj.Status = state
+		result, err = w.client.AutoscalingV1alpha1().ClusterAutoscalerStatuses().Update(ctx.TODO(), obj, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		w.clusterStatus = nil
+		return nil, fmt.Errorf("failed to write ClusterAutoscalerStatus %s: %v", w.name, err)
+	}
+	w.clusterStatus = result
+	return result, nil
+}
+
+func (w *StatusCRDWriter) writeNodeGroupStatuses(status *api.ClusterAutoscalerStatus, owner *v1alpha1.ClusterAutoscalerStatus, now metav1.Time) error {
+	if w.nodeGroupStatuses == nil {
+		list, err := w.client.AutoscalingV1alpha1().NodeGroupStatuses().List(ctx.TODO(), metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", v1alpha1.ClusterAutoscalerStatusLabelKey, w.name),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list NodeGroupStatuses: %v", err)
+		}
+		w.nodeGroupStatuses = make(map[string]*v1alpha1.NodeGroupStatus, len(list.Items))
+		for i := range list.Items {
+			w.nodeGroupStatuses[list.Items[i].Name] = &list.Items[i]
+		}
+	}
+
+	unremovable := map[string][]v1alpha1.UnremovableNode{}
+	for _, node := range w.unremovableNodes {
+		unremovable[node.NodeGroup] = append(unremovable[node.NodeGroup], node)
+	}
+
+	var errs []error
+	current := make(map[string]bool, len(status.NodeGroups))
+	for _, nodeGroupStatus := range status.NodeGroups {
+		name := NodeGroupStatusName(w.name, nodeGroupStatus.Name)
+		current[name] = true
+		state := w.nodeGroupState(nodeGroupStatus, unremovable[nodeGroupStatus.Name], now)
+
+		existing, found := w.nodeGroupStatuses[name]
+		if found && equality.Semantic.DeepEqual(withoutProbeTimes(existing.Status), withoutProbeTimes(state)) {
+			continue
+		}
+		var result *v1alpha1.NodeGroupStatus
+		var err error
+		if found {
+			obj := existing.DeepCopy()
+			obj.Status = state
+			result, err = w.client.AutoscalingV1alpha1().NodeGroupStatuses().Update(ctx.TODO(), obj, metav1.UpdateOptions{})
+		} else {
+			obj := &v1alpha1.NodeGroupStatus{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{v1alpha1.ClusterAutoscalerStatusLabelKey: w.name},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: v1alpha1.SchemeGroupVersion.String(),
+						Kind:       "ClusterAutoscalerStatus",
+						Name:       owner.Name,
+						UID:        owner.UID,
+					}},
+				},
+				Status: state,
+			}
+			result, err = w.client.AutoscalingV1alpha1().NodeGroupStatuses().Create(ctx.TODO(), obj, metav1.CreateOptions{})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to write NodeGroupStatus %s: %v", name, err))
+			continue
+		}
+		w.nodeGroupStatuses[name] = result
+	}
+
+	for name := range w.nodeGroupStatuses {
+		if current[name] {
+			continue
+		}
+		err := w.client.AutoscalingV1alpha1().NodeGroupStatuses().Delete(ctx.TODO(), name, metav1.DeleteOptions{})
+		if err != nil && !kube_errors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete NodeGroupStatus %s: %v", name, err))
+			continue
+		}
+		delete(w.nodeGroupStatuses, name)
+	}
+
+	if len(errs) > 0 {
+		// Objects may have been modified or removed by someone else, start from scratch next time.
+		w.nodeGroupStatuses = nil
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (w *StatusCRDWriter) nodeGroupState(status api.NodeGroupStatus, unremovable []v1alpha1.UnremovableNode, now metav1.Time) v1alpha1.NodeGroupState {
+	state := v1alpha1.NodeGroupState{
+		NodeGroup:      status.Name,
+		LastUpdateTime: now,
+		Health: v1alpha1.NodeGroupHealthCondition{
+			HealthCondition: v1alpha1.HealthCondition{
+				Status:             string(status.Health.Status),
+				NodeCounts:         nodeCount(status.Health.NodeCounts),
+				LastProbeTime:      status.Health.LastProbeTime.Rfc3339Copy(),
+				LastTransitionTime: status.Health.LastTransitionTime.Rfc3339Copy(),
+			},
+			CloudProviderTarget: int32(status.Health.CloudProviderTarget),
+			MinSize:             int32(status.Health.MinSize),
+			MaxSize:             int32(status.Health.MaxSize),
+		},
+		ScaleUp: v1alpha1.NodeGroupScaleUpCondition{
+			ScaleUpCondition: v1alpha1.ScaleUpCondition{
+				Status:             string(status.ScaleUp.Status),
+				LastProbeTime:      status.ScaleUp.LastProbeTime.Rfc3339Copy(),
+				LastTransitionTime: status.ScaleUp.LastTransitionTime.Rfc3339Copy(),
+			},
+		},
+		ScaleDown:        scaleDownCondition(status.ScaleDown),
+		UnremovableNodes: truncateUnremovableNodes(unremovable),
+	}
+	if status.ScaleUp.Status == api.ClusterAutoscalerBackoff {
+		state.ScaleUp.Backoff = &v1alpha1.BackoffInfo{
+			ErrorCode:    status.ScaleUp.BackoffInfo.ErrorCode,
+			ErrorMessage: status.ScaleUp.BackoffInfo.ErrorMessage,
+		}
+	}
+	if t, found := w.lastScaleUpTime[status.Name]; found {
+		state.LastScaleUpTime = &t
+	}
+	if t, found := w.lastScaleDownTime[status.Name]; found {
+		state.LastScaleDownTime = &t
+	}
+	return state
+}
+
+// NodeGroupStatusName returns the name of the NodeGroupStatus object of a given
+// node group. Node group ids are sanitized to form a valid object name, and a hash
+// of the id is appended whenever the sanitized id differs from the original one.
+func NodeGroupStatusName(statusName, nodeGroupId string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '.' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r - 'A' + 'a'
+		}
+		return '-'
+	}, nodeGroupId)
+	name := statusName + "-" + strings.Trim(sanitized, "-.")
+	if sanitized == nodeGroupId && len(validation.IsDNS1123Subdomain(name)) == 0 {
+		return name
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(nodeGroupId))
+	suffix := fmt.Sprintf("-%08x", hash.Sum32())
+	if maxLen := validation.DNS1123SubdomainMaxLength - len(suffix); len(name) > maxLen {
+		name = strings.TrimRight(name[:maxLen], "-.")
+	}
+	return name + suffix
+}
+
+func nodeCount(counts api.NodeCount) v1alpha1.NodeCount {
+	return v1alpha1.NodeCount{
+		Registered:       int32(counts.Registered.Total),
+		Ready:            int32(counts.Registered.Ready),
+		NotStarted:       int32(counts.Registered.NotStarted),
+		BeingDeleted:     int32(counts.Registered.BeingDeleted),
+		Unready:          int32(counts.Registered.Unready.Total),
+		ResourceUnready:  int32(counts.Registered.Unready.ResourceUnready),
+		Unregistered:     int32(counts.Unregistered),
+		LongUnregistered: int32(counts.LongUnregistered),
+	}
+}
+
+func scaleDownCondition(condition api.ScaleDownCondition) v1alpha1.ScaleDownCondition {
+	return v1alpha1.ScaleDownCondition{
+		Status:             string(condition.Status),
+		Candidates:         int32(condition.Candidates),
+		LastProbeTime:      condition.LastProbeTime.Rfc3339Copy(),
+		LastTransitionTime: condition.LastTransitionTime.Rfc3339Copy(),
+	}
+}
+
+func truncateUnremovableNodes(nodes []v1alpha1.UnremovableNode) []v1alpha1.UnremovableNode {
+	if len(nodes) > maxUnremovableNodesInStatus {
+		return nodes[:maxUnremovableNodesInStatus]
+	}
+	return nodes
+}
+
+// withoutProbeTimes returns a copy of the state with fields which change in
+// every iteration cleared.
+func withoutProbeTimes(state v1alpha1.NodeGroupState) v1alpha1.NodeGroupState {
+	state.LastUpdateTime = metav1.Time{}
+	state.Health.LastProbeTime = metav1.Time{}
+	state.ScaleUp.LastProbeTime = metav1.Time{}
+	state.ScaleDown.LastProbeTime = metav1.Time{}
+	return state
+}
+
+// StatusCRDAutoscalingStatusProcessor writes status CRDs after calling the wrapped processor.
+type StatusCRDAutoscalingStatusProcessor struct {
+	writer  *StatusCRDWriter
+	wrapped AutoscalingStatusProcessor
+}
+
+// NewStatusCRDAutoscalingStatusProcessor creates a StatusCRDAutoscalingStatusProcessor.
+func NewStatusCRDAutoscalingStatusProcessor(writer *StatusCRDWriter, wrapped AutoscalingStatusProcessor) *StatusCRDAutoscalingStatusProcessor {
+	return &StatusCRDAutoscalingStatusProcessor{writer: writer, wrapped: wrapped}
+}
+
+// Process calls the wrapped processor and writes status CRDs.
+func (p *StatusCRDAutoscalingStatusProcessor) Process(context *context.AutoscalingContext, csr *clusterstate.ClusterStateRegistry, now time.Time) error {
+	err := p.wrapped.Process(context, csr, now)
+	if writeErr := p.writer.Write(csr, now); writeErr != nil {
+		klog.Errorf("Failed to write status CRDs: %v", writeErr)
+	}
+	return err
+}
+
+// CleanUp cleans up the wrapped processor.
+func (p *StatusCRDAutoscalingStatusProcessor) CleanUp() {
+	p.wrapped.CleanUp()
+}
+
+// StatusCRDScaleUpStatusProcessor records scale-ups in a StatusCRDWriter
+// before passing the status on to the wrapped processor.
+type StatusCRDScaleUpStatusProcessor struct {
+	writer  *StatusCRDWriter
+	wrapped ScaleUpStatusProcessor
+}
+
+// NewStatusCRDScaleUpStatusProcessor creates a StatusCRDScaleUpStatusProcessor.
+func NewStatusCRDScaleUpStatusProcessor(writer *StatusCRDWriter, wrapped ScaleUpStatusProcessor) *StatusCRDScaleUpStatusProcessor {
+	return &StatusCRDScaleUpStatusProcessor{writer: writer, wrapped: wrapped}
+}
+
+// Process records the scale-up status and calls the wrapped processor.
+func (p *StatusCRDScaleUpStatusProcessor) Process(context *context.AutoscalingContext, status *ScaleUpStatus) {
+	p.writer.RecordScaleUp(status)
+	p.wrapped.Process(context, status)
+}
+
+// CleanUp cleans up the wrapped processor.
+func (p *StatusCRDScaleUpStatusProcessor) CleanUp() {
+	p.wrapped.CleanUp()
+}
+
+// StatusCRDScaleDownStatusProcessor records scale-downs and unremovable nodes in
+// a StatusCRDWriter before passing the status on to the wrapped processor.
+type StatusCRDScaleDownStatusProcessor struct {
+	writer  *StatusCRDWriter
+	wrapped ScaleDownStatusProcessor
+}
+
+// NewStatusCRDScaleDownStatusProcessor creates a StatusCRDScaleDownStatusProcessor.
+func NewStatusCRDScaleDownStatusProcessor(writer *StatusCRDWriter, wrapped ScaleDownStatusProcessor) *StatusCRDScaleDownStatusProcessor {
+	return &StatusCRDScaleDownStatusProcessor{writer: writer, wrapped: wrapped}
+}
+
+// Process records the scale-down status and calls the wrapped processor.
+func (p *StatusCRDScaleDownStatusProcessor) Process(context *context.AutoscalingContext, status *scaledownstatus.ScaleDownStatus) {
+	p.writer.RecordScaleDown(status)
+	p.wrapped.Process(context, status)
+}
+
+// CleanUp cleans up the wrapped processor.
+func (p *StatusCRDScaleDownStatusProcessor) CleanUp() {
+	p.wrapped.CleanUp()
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	ctx "context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/fake"
+	kube_record "k8s.io/client-go/tools/record"
+
+	"k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/autoscaling.x-k8s.io/v1alpha1"
+	statusfake "k8s.io/autoscaler/cluster-autoscaler/apis/clusterautoscalerstatus/client/clientset/versioned/fake"
+	cp_test "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestStatusCRDWriter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, now.Add(-time.Minute))
+	n2 := BuildTestNode("n2", 1000, 1000)
+	SetNodeReadyState(n2, true, now.Add(-time.Minute))
+
+	provider := cp_test.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNodeGroup("ng2", 1, 10, 1)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng2", n2)
+
+	logRecorder, _ := utils.NewStatusMapRecorder(&fake.Clientset{}, "kube-system", kube_record.NewFakeRecorder(5), false, "my-cool-configmap")
+	csr := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{
+		MaxTotalUnreadyPercentage: 10,
+		OkTotalUnreadyCount:       1,
+	}, logRecorder, backoff.NewIdBasedExponentialBackoff(5*time.Minute, 30*time.Minute, 3*time.Hour),
+		nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: time.Minute}))
+	assert.NoError(t, csr.UpdateNodes([]*apiv1.Node{n1, n2}, nil, now))
+
+	client := statusfake.NewSimpleClientset()
+	writer := NewStatusCRDWriter(client, "ca")
+	writer.RecordScaleUp(&ScaleUpStatus{
+		Result:       ScaleUpSuccessful,
+		ScaleUpInfos: []nodegroupset.ScaleUpInfo{{Group: provider.GetNodeGroup("ng1"), CurrentSize: 1, NewSize: 2}},
+	})
+	writer.RecordScaleDown(&scaledownstatus.ScaleDownStatus{
+		UnremovableNodes: []*scaledownstatus.UnremovableNode{
+			{Node: n2, NodeGroup: provider.GetNodeGroup("ng2"), Reason: simulator.NotUnderutilized},
+		},
+	})
+	assert.NoError(t, writer.Write(csr, now))
+
+	wantTime := metav1.NewTime(now)
+	clusterStatus, err := client.AutoscalingV1alpha1().ClusterAutoscalerStatuses().Get(ctx.TODO(), "ca", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Running", clusterStatus.Status.AutoscalerStatus)
+	assert.Equal(t, "Healthy", clusterStatus.Status.ClusterWide.Health.Status)
+	assert.Equal(t, int32(2), clusterStatus.Status.ClusterWide.Health.NodeCounts.Ready)
+	assert.Equal(t, int32(2), clusterStatus.Status.NodeGroups)
+	assert.Equal(t, &wantTime, clusterStatus.Status.LastScaleUpTime)
+	assert.Nil(t, clusterStatus.Status.LastScaleDownTime)
+	assert.Equal(t, []v1alpha1.UnremovableNode{{Name: "n2", NodeGroup: "ng2", Reason: "NotUnderutilized"}}, clusterStatus.Status.UnremovableNodes)
+
+	ng1Status, err := client.AutoscalingV1alpha1().NodeGroupStatuses().Get(ctx.TODO(), "ca-ng1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "ng1", ng1Status.Status.NodeGroup)
+	assert.Equal(t, map[string]string{v1alpha1.ClusterAutoscalerStatusLabelKey: "ca"}, ng1Status.Labels)
+	assert.Equal(t, "ca", ng1Status.OwnerReferences[0].Name)
+	assert.Equal(t, int32(1), ng1Status.Status.Health.CloudProviderTarget)
+	assert.Equal(t, int32(10), ng1Status.Status.Health.MaxSize)
+	assert.Equal(t, &wantTime, ng1Status.Status.LastScaleUpTime)
+	assert.Empty(t, ng1Status.Status.UnremovableNodes)
+
+	ng2Status, err := client.AutoscalingV1alpha1().NodeGroupStatuses().Get(ctx.TODO(), "ca-ng2", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Nil(t, ng2Status.Status.LastScaleUpTime)
+	assert.Equal(t, []v1alpha1.UnremovableNode{{Name: "n2", NodeGroup: "ng2", Reason: "NotUnderutilized"}}, ng2Status.Status.UnremovableNodes)
+
+	// Nothing changed, only the cluster-wide object should be updated.
+	client.ClearActions()
+	later := now.Add(10 * time.Second)
+	assert.NoError(t, csr.UpdateNodes([]*apiv1.Node{n1, n2}, nil, later))
+	assert.NoError(t, writer.Write(csr, later))
+	for _, action := range client.Actions() {
+		assert.Equal(t, "clusterautoscalerstatuses", action.GetResource().Resource, "unexpected action %v", action)
+	}
+
+	// Objects of removed node groups are deleted.
+	provider.DeleteNodeGroup("ng2")
+	assert.NoError(t, writer.Write(csr, later.Add(10*time.Second)))
+	list, err := client.AutoscalingV1alpha1().NodeGroupStatuses().List(ctx.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+	assert.Equal(t, "ca-ng1", list.Items[0].Name)
+}
+
+func TestNodeGroupStatusName(t *testing.T) {
+	for tn, tc := range map[string]struct {
+		nodeGroupId string
+		want        string
+	}{
+		"valid id is used as is": {
+			nodeGroupId: "pool-1",
+			want:        "ca-pool-1",
+		},
+		"invalid characters are replaced and hash is appended": {
+			nodeGroupId: "https://www.googleapis.com/compute/v1/projects/p/zones/z/instanceGroups/Pool_1",
+			want:        "ca-https---www.googleapis.com-compute-v1-projects-p-zones-z-instancegroups-pool-1-",
+		},
+		"long id is truncated": {
+			nodeGroupId: strings.Repeat("a", 300),
+			want:        "ca-" + strings.Repeat("a", 241) + "-",
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			got := NodeGroupStatusName("ca", tc.nodeGroupId)
+			assert.True(t, strings.HasPrefix(got, tc.want), "got %q, want prefix %q", got, tc.want)
+			assert.Empty(t, validation.IsDNS1123Subdomain(got))
+		})
+	}
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package orchestrator
 
 import (
+	"time"
+
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/apis/provisioningrequest/autoscaling.x-k8s.io/v1beta1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
@@ -106,3 +109,18 @@ func (o *WrapperOrchestrator) ScaleUpToNodeGroupMinSize(
 ) (*status.ScaleUpStatus, errors.AutoscalerError) {
 	return o.podsOrchestrator.ScaleUpToNodeGroupMinSize(nodes, nodeInfos)
 }
+
+// ScaleUpNodeGroup scales up the node group using the orchestrator of regular pods.
+func (o *WrapperOrchestrator) ScaleUpNodeGroup(
+	nodeGroup cloudprovider.NodeGroup,
+	delta int,
+	nodes []*apiv1.Node,
+	nodeInfos map[string]*schedulerframework.NodeInfo,
+	now time.Time,
+) (int, errors.AutoscalerError) {
+	scaler, ok := o.podsOrchestrator.(scaleup.NodeGroupScaler)
+	if !ok {
+		return 0, errors.NewAutoscalerError(errors.InternalError, "orchestrator of regular pods can't scale up node groups")
+	}
+	return scaler.ScaleUpNodeGroup(nodeGroup, delta, nodes, nodeInfos, now)
+}
@@ -23,6 +23,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/draincost"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/predicatechecker"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/scheduling"
@@ -45,6 +46,8 @@ type NodeToBeRemoved struct {
 	// PodsToReschedule contains pods on the node that should be rescheduled elsewhere.
 	PodsToReschedule []*apiv1.Pod
 	DaemonSetPods    []*apiv1.Pod
+	// DrainCost is the cost of moving PodsToReschedule. It is zero unless the simulator calculates drain costs.
+	DrainCost float64
 }
 
 // UnremovableNode represents a node that can't be removed by CA.
@@ -89,6 +92,10 @@ const (
 	BlockedByPod
 	// UnexpectedError - node can't be removed because of an unexpected error.
 	UnexpectedError
+	// NodeGroupSetMinimalResourceLimitExceeded - node can't be removed because it would violate minimal resource limits of a set of node groups.
+	NodeGroupSetMinimalResourceLimitExceeded
+	// ScaleDownProtected - node can't be removed because it is protected from scale down by a node annotation which hasn't expired yet or by a pod annotation.
+	ScaleDownProtected
 )
 
 // RemovalSimulator is a helper object for simulating node removal scenarios.
@@ -100,6 +107,7 @@ type RemovalSimulator struct {
 	deleteOptions       options.NodeDeleteOptions
 	drainabilityRules   rules.Rules
 	schedulingSimulator *scheduling.HintingSimulator
+	drainCost           *draincost.Calculator
 }
 
 // NewRemovalSimulator returns a new RemovalSimulator.
@@ -116,6 +124,12 @@ func NewRemovalSimulator(listers kube_util.ListerRegistry, clusterSnapshot clust
 	}
 }
 
+// WithDrainCost makes the simulator calculate the cost of moving pods of removable nodes.
+func (r *RemovalSimulator) WithDrainCost(calculator *draincost.Calculator) *RemovalSimulator {
+	r.drainCost = calculator
+	return r
+}
+
 // FindNodesToRemove finds nodes that can be removed.
 func (r *RemovalSimulator) FindNodesToRemove(
 	candidates []string,
@@ -172,10 +186,15 @@ func (r *RemovalSimulator) SimulateNodeRemoval(
 		return nil, &UnremovableNode{Node: nodeInfo.Node(), Reason: NoPlaceToMovePods}
 	}
 	klog.V(2).Infof("node %s may be removed", nodeName)
+	drainCost := 0.0
+	if r.drainCost != nil {
+		drainCost = r.drainCost.PodsCost(podsToRemove)
+	}
 	return &NodeToBeRemoved{
 		Node:             nodeInfo.Node(),
 		PodsToReschedule: podsToRemove,
 		DaemonSetPods:    daemonSetPods,
+		DrainCost:        drainCost,
 	}, nil
 }
 
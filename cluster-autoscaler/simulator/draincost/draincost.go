@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package draincost
+
+import (
+	"strconv"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// DrainCostAnnotationKey is the annotation on a pod overriding the cost of moving it during scale-down.
+	DrainCostAnnotationKey = "cluster-autoscaler.kubernetes.io/drain-cost"
+	// DefaultPodCost is the cost of moving a pod of a controller kind without a configured cost.
+	DefaultPodCost = 1.0
+	// DefaultSingletonCost is the default cost of moving a pod which is the only replica of its controller.
+	DefaultSingletonCost = 10.0
+)
+
+// DefaultControllerKindCosts returns default costs of moving pods of controller kinds.
+// Pods of StatefulSets have a stable identity and often storage, so moving them is more disruptive.
+func DefaultControllerKindCosts() map[string]float64 {
+	return map[string]float64{
+		"StatefulSet": 2,
+	}
+}
+
+// Calculator calculates how expensive it is to move pods during scale-down.
+type Calculator struct {
+	listers       kube_util.ListerRegistry
+	kindCosts     map[string]float64
+	singletonCost float64
+}
+
+// NewCalculator creates a Calculator using costs of controller kinds and the cost of singleton pods,
+// i.e. pods without a controller or with a controller of a single replica. Listers are used to check
+// the number of replicas of controllers and may be nil.
+func NewCalculator(listers kube_util.ListerRegistry, kindCosts map[string]float64, singletonCost float64) *Calculator {
+	return &Calculator{
+		listers:       listers,
+		kindCosts:     kindCosts,
+		singletonCost: singletonCost,
+	}
+}
+
+// PodsCost returns the total cost of moving the pods.
+func (c *Calculator) PodsCost(pods []*apiv1.Pod) float64 {
+	cost := 0.0
+	for _, pod := range pods {
+		cost += c.PodCost(pod)
+	}
+	return cost
+}
+
+// PodCost returns the cost of moving the pod. The cost from the drain cost annotation takes
+// precedence, otherwise the cost of the controller kind is used, raised to the singleton cost
+// for singleton pods.
+func (c *Calculator) PodCost(pod *apiv1.Pod) float64 {
+	if value, found := pod.Annotations[DrainCostAnnotationKey]; found {
+		cost, err := strconv.ParseFloat(value, 64)
+		if err == nil && cost >= 0 {
+			return cost
+		}
+		klog.Warningf("Ignoring invalid %s annotation %q of pod %s/%s", DrainCostAnnotationKey, value, pod.Namespace, pod.Name)
+	}
+	cost := DefaultPodCost
+	controllerRef := drain.ControllerRef(pod)
+	if controllerRef != nil {
+		if kindCost, found := c.kindCosts[controllerRef.Kind]; found {
+			cost = kindCost
+		}
+	}
+	if c.isSingleton(pod) && c.singletonCost > cost {
+		cost = c.singletonCost
+	}
+	return cost
+}
+
+// isSingleton checks if the pod has no controller or is the only replica of its controller,
+// so that moving it makes the workload unavailable.
+func (c *Calculator) isSingleton(pod *apiv1.Pod) bool {
+	if pod_util.IsMirrorPod(pod) || pod_util.IsDaemonSetPod(pod) {
+		return false
+	}
+	controllerRef := drain.ControllerRef(pod)
+	if controllerRef == nil {
+		return true
+	}
+	if c.listers == nil {
+		return false
+	}
+	var replicas *int32
+	switch controllerRef.Kind {
+	case "ReplicaSet":
+		rs, err := c.listers.ReplicaSetLister().ReplicaSets(pod.Namespace).Get(controllerRef.Name)
+		if err != nil {
+			return false
+		}
+		replicas = rs.Spec.Replicas
+	case "StatefulSet":
+		ss, err := c.listers.StatefulSetLister().StatefulSets(pod.Namespace).Get(controllerRef.Name)
+		if err != nil {
+			return false
+		}
+		replicas = ss.Spec.Replicas
+	case "ReplicationController":
+		rc, err := c.listers.ReplicationControllerLister().ReplicationControllers(pod.Namespace).Get(controllerRef.Name)
+		if err != nil {
+			return false
+		}
+		replicas = rc.Spec.Replicas
+	default:
+		return false
+	}
+	// Replicas default to 1 when not set.
+	return replicas == nil || *replicas <= 1
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package draincost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodCost(t *testing.T) {
+	rss := []*appsv1.ReplicaSet{
+		{ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "default"}, Spec: appsv1.ReplicaSetSpec{Replicas: ptr.To[int32](3)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "rs-single", Namespace: "default"}, Spec: appsv1.ReplicaSetSpec{Replicas: ptr.To[int32](1)}},
+	}
+	sss := []*appsv1.StatefulSet{
+		{ObjectMeta: metav1.ObjectMeta{Name: "ss", Namespace: "default"}, Spec: appsv1.StatefulSetSpec{Replicas: ptr.To[int32](3)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "ss-single", Namespace: "default"}},
+	}
+	rsLister, err := kube_util.NewTestReplicaSetLister(rss)
+	assert.NoError(t, err)
+	ssLister, err := kube_util.NewTestStatefulSetLister(sss)
+	assert.NoError(t, err)
+	rcLister, err := kube_util.NewTestReplicationControllerLister(nil)
+	assert.NoError(t, err)
+	listers := kube_util.NewListerRegistry(nil, nil, nil, nil, nil, rcLister, nil, rsLister, ssLister)
+
+	withOwner := func(name, kind string) func(*apiv1.Pod) {
+		return func(pod *apiv1.Pod) {
+			pod.OwnerReferences = GenerateOwnerReferences(name, kind, "apps/v1", "")
+		}
+	}
+	withCost := func(cost string) func(*apiv1.Pod) {
+		return func(pod *apiv1.Pod) {
+			pod.Annotations[DrainCostAnnotationKey] = cost
+		}
+	}
+
+	for tn, tc := range map[string]struct {
+		pod      *apiv1.Pod
+		listers  kube_util.ListerRegistry
+		wantCost float64
+	}{
+		"replicated pod": {
+			pod:      BuildTestPod("p", 100, 0, withOwner("rs", "ReplicaSet")),
+			listers:  listers,
+			wantCost: 1,
+		},
+		"replicated stateful set pod": {
+			pod:      BuildTestPod("p", 100, 0, withOwner("ss", "StatefulSet")),
+			listers:  listers,
+			wantCost: 2,
+		},
+		"single replica replica set pod": {
+			pod:      BuildTestPod("p", 100, 0, withOwner("rs-single", "ReplicaSet")),
+			listers:  listers,
+			wantCost: 10,
+		},
+		"stateful set pod with default replicas": {
+			pod:      BuildTestPod("p", 100, 0, withOwner("ss-single", "StatefulSet")),
+			listers:  listers,
+			wantCost: 10,
+		},
+		"pod of unknown controller": {
+			pod:      BuildTestPod("p", 100, 0, withOwner("missing", "ReplicaSet")),
+			listers:  listers,
+			wantCost: 1,
+		},
+		"bare pod": {
+			pod:      BuildTestPod("p", 100, 0),
+			listers:  listers,
+			wantCost: 10,
+		},
+		"stateful set pod without listers": {
+			pod:      BuildTestPod("p", 100, 0, withOwner("ss-single", "StatefulSet")),
+			wantCost: 2,
+		},
+		"annotated pod": {
+			pod:      BuildTestPod("p", 100, 0, withCost("0.5")),
+			listers:  listers,
+			wantCost: 0.5,
+		},
+		"invalid annotation": {
+			pod:      BuildTestPod("p", 100, 0, withOwner("rs", "ReplicaSet"), withCost("cheap")),
+			listers:  listers,
+			wantCost: 1,
+		},
+		"negative annotation": {
+			pod:      BuildTestPod("p", 100, 0, withOwner("rs", "ReplicaSet"), withCost("-1")),
+			listers:  listers,
+			wantCost: 1,
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			c := NewCalculator(tc.listers, DefaultControllerKindCosts(), DefaultSingletonCost)
+			assert.Equal(t, tc.wantCost, c.PodCost(tc.pod))
+		})
+	}
+}
+
+func TestPodsCost(t *testing.T) {
+	c := NewCalculator(nil, map[string]float64{"Job": 0.5}, DefaultSingletonCost)
+	pods := []*apiv1.Pod{
+		BuildTestPod("p1", 100, 0, func(pod *apiv1.Pod) {
+			pod.OwnerReferences = GenerateOwnerReferences("job", "Job", "batch/v1", "")
+		}),
+		BuildTestPod("p2", 100, 0),
+	}
+	assert.Equal(t, 10.5, c.PodsCost(pods))
+	assert.Equal(t, 0.0, c.PodsCost(nil))
+}
@@ -22,6 +22,7 @@ import (
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
 
 	apiv1 "k8s.io/api/core/v1"
@@ -49,7 +50,7 @@ type Info struct {
 // returns the individual cpu, memory and gpu utilization.
 func Calculate(nodeInfo *schedulerframework.NodeInfo, skipDaemonSetPods, skipMirrorPods bool, gpuConfig *cloudprovider.GpuConfig, currentTime time.Time) (utilInfo Info, err error) {
 	if gpuConfig != nil {
-		gpuUtil, err := CalculateUtilizationOfResource(nodeInfo, gpuConfig.ResourceName, skipDaemonSetPods, skipMirrorPods, currentTime)
+		gpuUtil, err := calculateGpuUtilization(nodeInfo, gpuConfig, skipDaemonSetPods, skipMirrorPods, currentTime)
 		if err != nil {
 			klog.V(3).Infof("node %s has unready GPU resource: %s", nodeInfo.Node().Name, gpuConfig.ResourceName)
 			// Return 0 if GPU is unready. This will guarantee we can still scale down a node with unready GPU.
@@ -81,14 +82,51 @@ func Calculate(nodeInfo *schedulerframework.NodeInfo, skipDaemonSetPods, skipMir
 	return utilization, nil
 }
 
+// calculateGpuUtilization calculates utilization of GPUs of a node. If the GPUs are partitioned, requests and
+// allocatable of all partition resources are summed, weighted by the number of GPU compute slices of each
+// partition, and the higher of partitions and whole GPUs utilization is returned.
+func calculateGpuUtilization(nodeInfo *schedulerframework.NodeInfo, gpuConfig *cloudprovider.GpuConfig, skipDaemonSetPods, skipMirrorPods bool, currentTime time.Time) (float64, error) {
+	if len(gpuConfig.PartitionResources) == 0 {
+		return CalculateUtilizationOfResource(nodeInfo, gpuConfig.ResourceName, skipDaemonSetPods, skipMirrorPods, currentTime)
+	}
+	var requested, allocatable int64
+	for _, resourceName := range gpuConfig.PartitionResources {
+		resourceRequested, resourceAllocatable, err := calculateUsageOfResource(nodeInfo, resourceName, skipDaemonSetPods, skipMirrorPods, currentTime)
+		if err != nil {
+			continue
+		}
+		weight := gpu.GpuPartitionWeight(resourceName)
+		requested += resourceRequested * weight
+		allocatable += resourceAllocatable * weight
+	}
+	if allocatable <= 0 {
+		return 0, fmt.Errorf("GPU partitions are not allocatable at %s", nodeInfo.Node().Name)
+	}
+	utilization := float64(requested) / float64(allocatable)
+	if wholeGpuUtil, err := CalculateUtilizationOfResource(nodeInfo, gpuConfig.ResourceName, skipDaemonSetPods, skipMirrorPods, currentTime); err == nil && wholeGpuUtil > utilization {
+		utilization = wholeGpuUtil
+	}
+	return utilization, nil
+}
+
 // CalculateUtilizationOfResource calculates utilization of a given resource for a node.
 func CalculateUtilizationOfResource(nodeInfo *schedulerframework.NodeInfo, resourceName apiv1.ResourceName, skipDaemonSetPods, skipMirrorPods bool, currentTime time.Time) (float64, error) {
+	requested, allocatable, err := calculateUsageOfResource(nodeInfo, resourceName, skipDaemonSetPods, skipMirrorPods, currentTime)
+	if err != nil {
+		return 0, err
+	}
+	return float64(requested) / float64(allocatable), nil
+}
+
+// calculateUsageOfResource returns milli values of requests of a given resource on a node and of its allocatable
+// amount, excluding requests of DaemonSet and mirror pods from both if they are skipped.
+func calculateUsageOfResource(nodeInfo *schedulerframework.NodeInfo, resourceName apiv1.ResourceName, skipDaemonSetPods, skipMirrorPods bool, currentTime time.Time) (int64, int64, error) {
 	nodeAllocatable, found := nodeInfo.Node().Status.Allocatable[resourceName]
 	if !found {
-		return 0, fmt.Errorf("failed to get %v from %s", resourceName, nodeInfo.Node().Name)
+		return 0, 0, fmt.Errorf("failed to get %v from %s", resourceName, nodeInfo.Node().Name)
 	}
 	if nodeAllocatable.MilliValue() == 0 {
-		return 0, fmt.Errorf("%v is 0 at %s", resourceName, nodeInfo.Node().Name)
+		return 0, 0, fmt.Errorf("%v is 0 at %s", resourceName, nodeInfo.Node().Name)
 	}
 
 	opts := resourcehelper.PodResourcesOptions{}
@@ -122,5 +160,5 @@ func CalculateUtilizationOfResource(nodeInfo *schedulerframework.NodeInfo, resou
 		podsRequest.Add(resourceValue)
 	}
 
-	return float64(podsRequest.MilliValue()) / float64(nodeAllocatable.MilliValue()-daemonSetAndMirrorPodsUtilization.MilliValue()), nil
+	return podsRequest.MilliValue(), nodeAllocatable.MilliValue() - daemonSetAndMirrorPodsUtilization.MilliValue(), nil
 }
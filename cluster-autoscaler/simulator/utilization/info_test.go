@@ -23,6 +23,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/kubernetes/pkg/kubelet/types"
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
@@ -170,6 +171,37 @@ func TestCalculate(t *testing.T) {
 	assert.Zero(t, utilInfo.Utilization)
 }
 
+func TestCalculateGpuPartitions(t *testing.T) {
+	testTime := time.Date(2020, time.December, 18, 17, 0, 0, 0, time.UTC)
+	node := BuildTestNode("mig-node", 2000, 2000000)
+	node.Status.Allocatable["nvidia.com/mig-1g.10gb"] = *resource.NewQuantity(4, resource.DecimalSI)
+	node.Status.Allocatable["nvidia.com/mig-3g.40gb"] = *resource.NewQuantity(1, resource.DecimalSI)
+	gpuConfig := &cloudprovider.GpuConfig{
+		ResourceName:       "nvidia.com/gpu",
+		PartitionResources: []apiv1.ResourceName{"nvidia.com/mig-1g.10gb", "nvidia.com/mig-3g.40gb"},
+	}
+	smallPod := BuildTestPod("small", 100, 1000)
+	smallPod.Spec.Containers[0].Resources.Requests["nvidia.com/mig-1g.10gb"] = *resource.NewQuantity(1, resource.DecimalSI)
+	largePod := BuildTestPod("large", 100, 1000)
+	largePod.Spec.Containers[0].Resources.Requests["nvidia.com/mig-3g.40gb"] = *resource.NewQuantity(1, resource.DecimalSI)
+
+	// 1 of 7 compute slices is used.
+	utilInfo, err := Calculate(newNodeInfo(node, smallPod), false, false, gpuConfig, testTime)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 1.0/7, utilInfo.Utilization, 0.01)
+	assert.Equal(t, apiv1.ResourceName("nvidia.com/gpu"), utilInfo.ResourceName)
+
+	// 4 of 7 compute slices are used.
+	utilInfo, err = Calculate(newNodeInfo(node, smallPod, largePod), false, false, gpuConfig, testTime)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 4.0/7, utilInfo.Utilization, 0.01)
+
+	// Partitions aren't allocatable yet.
+	utilInfo, err = Calculate(newNodeInfo(BuildTestNode("starting", 2000, 2000000)), false, false, gpuConfig, testTime)
+	assert.NoError(t, err)
+	assert.Zero(t, utilInfo.Utilization)
+}
+
 func nodeInfos(nodes []*apiv1.Node) []*schedulerframework.NodeInfo {
 	result := make([]*schedulerframework.NodeInfo, len(nodes))
 	for i, node := range nodes {
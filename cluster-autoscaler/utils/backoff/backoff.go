@@ -40,3 +40,20 @@ type Backoff interface {
 	// RemoveStaleBackoffData removes stale backoff data.
 	RemoveStaleBackoffData(currentTime time.Time)
 }
+
+// Entry is the backoff state of a single node group.
+type Entry struct {
+	Duration            time.Duration                   `json:"duration"`
+	BackoffUntil        time.Time                       `json:"backoffUntil"`
+	LastFailedExecution time.Time                       `json:"lastFailedExecution"`
+	ErrorInfo           cloudprovider.InstanceErrorInfo `json:"errorInfo"`
+}
+
+// Persistent is implemented by Backoff implementations whose state can be saved and
+// restored, e.g. to survive a restart of Cluster Autoscaler.
+type Persistent interface {
+	// Entries returns the current backoff state, keyed by node group key.
+	Entries() map[string]Entry
+	// RestoreEntries replaces the backoff state with the given entries.
+	RestoreEntries(entries map[string]Entry)
+}
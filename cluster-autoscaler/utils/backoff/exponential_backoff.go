@@ -118,3 +118,30 @@ func (b *exponentialBackoff) RemoveStaleBackoffData(currentTime time.Time) {
 		}
 	}
 }
+
+// Entries returns the current backoff state, keyed by node group key.
+func (b *exponentialBackoff) Entries() map[string]Entry {
+	entries := make(map[string]Entry, len(b.backoffInfo))
+	for key, backoffInfo := range b.backoffInfo {
+		entries[key] = Entry{
+			Duration:            backoffInfo.duration,
+			BackoffUntil:        backoffInfo.backoffUntil,
+			LastFailedExecution: backoffInfo.lastFailedExecution,
+			ErrorInfo:           backoffInfo.errorInfo,
+		}
+	}
+	return entries
+}
+
+// RestoreEntries replaces the backoff state with the given entries.
+func (b *exponentialBackoff) RestoreEntries(entries map[string]Entry) {
+	b.backoffInfo = make(map[string]exponentialBackoffInfo, len(entries))
+	for key, entry := range entries {
+		b.backoffInfo[key] = exponentialBackoffInfo{
+			duration:            entry.Duration,
+			backoffUntil:        entry.BackoffUntil,
+			lastFailedExecution: entry.LastFailedExecution,
+			errorInfo:           entry.ErrorInfo,
+		}
+	}
+}
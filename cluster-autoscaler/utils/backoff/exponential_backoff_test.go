@@ -116,3 +116,19 @@ func TestIncreaseExistingBackoff(t *testing.T) {
 	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, currentTime))
 	// Result: existing backoff duration was scaled up beyond initial duration
 }
+
+func TestBackoffRestoreEntries(t *testing.T) {
+	backoff := NewIdBasedExponentialBackoff(10*time.Minute, time.Hour, 3*time.Hour)
+	startTime := time.Now()
+	backoff.Backoff(nodeGroup1, nil, quotaError, startTime)
+	backoff.Backoff(nodeGroup1, nil, quotaError, startTime.Add(20*time.Minute))
+	entries := backoff.(Persistent).Entries()
+
+	restored := NewIdBasedExponentialBackoff(10*time.Minute, time.Hour, 3*time.Hour)
+	restored.(Persistent).RestoreEntries(entries)
+	assert.Equal(t, entries, restored.(Persistent).Entries())
+	assert.Equal(t, backoffWithQuotaError, restored.BackoffStatus(nodeGroup1, nil, startTime.Add(35*time.Minute)))
+	assert.Equal(t, noBackOff, restored.BackoffStatus(nodeGroup2, nil, startTime.Add(35*time.Minute)))
+	// Backoff duration keeps growing exponentially after restore.
+	assert.Equal(t, startTime.Add(100*time.Minute), restored.Backoff(nodeGroup1, nil, quotaError, startTime.Add(60*time.Minute)))
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package gpu
 
 import (
+	"sort"
+	"strconv"
+	"strings"
+
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/klog/v2"
@@ -27,6 +31,12 @@ const (
 	ResourceNvidiaGPU = "nvidia.com/gpu"
 	// ResourceDirectX is the name of the DirectX resource on windows.
 	ResourceDirectX = "microsoft.com/directx"
+	// ResourceNvidiaMigPrefix is the prefix of names of resources of NVIDIA MIG partitions
+	// exposed with the mixed strategy, e.g. nvidia.com/mig-1g.10gb.
+	ResourceNvidiaMigPrefix = "nvidia.com/mig-"
+	// ResourceNvidiaSharedGPU is the name of the resource of time-sliced NVIDIA GPUs
+	// when the device plugin renames shared resources.
+	ResourceNvidiaSharedGPU = "nvidia.com/gpu.shared"
 	// DefaultGPUType is the type of GPU used in NAP if the user
 	// don't specify what type of GPU his pod wants.
 	DefaultGPUType = "nvidia-tesla-k80"
@@ -100,15 +110,14 @@ func validateGpuType(availableGPUTypes map[string]struct{}, gpu string) string {
 func NodeHasGpu(GPULabel string, node *apiv1.Node) bool {
 	_, hasGpuLabel := node.Labels[GPULabel]
 	gpuAllocatable, hasGpuAllocatable := node.Status.Allocatable[ResourceNvidiaGPU]
-	return hasGpuLabel || (hasGpuAllocatable && !gpuAllocatable.IsZero())
+	return hasGpuLabel || (hasGpuAllocatable && !gpuAllocatable.IsZero()) || len(NodeGpuPartitions(node)) > 0
 }
 
-// PodRequestsGpu returns true if a given pod has GPU request.
+// PodRequestsGpu returns true if a given pod has GPU request, including requests of GPU partitions.
 func PodRequestsGpu(pod *apiv1.Pod) bool {
 	for _, container := range pod.Spec.Containers {
-		if container.Resources.Requests != nil {
-			_, gpuFound := container.Resources.Requests[ResourceNvidiaGPU]
-			if gpuFound {
+		for resourceName := range container.Resources.Requests {
+			if resourceName == ResourceNvidiaGPU || IsGpuPartitionResource(resourceName) {
 				return true
 			}
 		}
@@ -116,12 +125,49 @@ func PodRequestsGpu(pod *apiv1.Pod) bool {
 	return false
 }
 
+// IsGpuPartitionResource returns true if the resource is a partition of a GPU, i.e. a MIG profile
+// or a time-sliced GPU.
+func IsGpuPartitionResource(resourceName apiv1.ResourceName) bool {
+	return strings.HasPrefix(string(resourceName), ResourceNvidiaMigPrefix) || resourceName == ResourceNvidiaSharedGPU
+}
+
+// GpuPartitionWeight returns the number of compute slices of a GPU used by a single partition, which
+// allows comparing usage of different MIG profiles, e.g. 3 for nvidia.com/mig-3g.40gb. Partitions of
+// time-sliced GPUs and unknown profiles have weight 1.
+func GpuPartitionWeight(resourceName apiv1.ResourceName) int64 {
+	profile := strings.TrimPrefix(string(resourceName), ResourceNvidiaMigPrefix)
+	if profile == string(resourceName) {
+		return 1
+	}
+	slices, _, found := strings.Cut(profile, "g.")
+	if !found {
+		return 1
+	}
+	weight, err := strconv.ParseInt(slices, 10, 64)
+	if err != nil || weight < 1 {
+		return 1
+	}
+	return weight
+}
+
+// NodeGpuPartitions returns sorted names of GPU partition resources allocatable on the node.
+func NodeGpuPartitions(node *apiv1.Node) []apiv1.ResourceName {
+	var partitions []apiv1.ResourceName
+	for resourceName, quantity := range node.Status.Allocatable {
+		if IsGpuPartitionResource(resourceName) && !quantity.IsZero() {
+			partitions = append(partitions, resourceName)
+		}
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+	return partitions
+}
+
 // GetNodeGPUFromCloudProvider returns the GPU the node has. Returned GPU has the GPU label of the
 // passed in cloud provider. If the node doesn't have a GPU, returns nil.
 func GetNodeGPUFromCloudProvider(provider cloudprovider.CloudProvider, node *apiv1.Node) *cloudprovider.GpuConfig {
 	gpuLabel := provider.GPULabel()
 	if NodeHasGpu(gpuLabel, node) {
-		return &cloudprovider.GpuConfig{Label: gpuLabel, Type: node.Labels[gpuLabel], ResourceName: ResourceNvidiaGPU}
+		return &cloudprovider.GpuConfig{Label: gpuLabel, Type: node.Labels[gpuLabel], ResourceName: ResourceNvidiaGPU, PartitionResources: NodeGpuPartitions(node)}
 	}
 	return nil
 }
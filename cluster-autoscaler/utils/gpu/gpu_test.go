@@ -79,6 +79,36 @@ func TestPodRequestsGpu(t *testing.T) {
 	podWithGpu := test.BuildTestPod("pod1AnyGpu", 0, 1000)
 	podWithGpu.Spec.Containers[0].Resources.Requests[ResourceNvidiaGPU] = *resource.NewQuantity(1, resource.DecimalSI)
 
+	podWithMig := test.BuildTestPod("podMig", 0, 1000)
+	podWithMig.Spec.Containers[0].Resources.Requests["nvidia.com/mig-1g.10gb"] = *resource.NewQuantity(1, resource.DecimalSI)
+
 	assert.False(t, PodRequestsGpu(podNoGpu))
 	assert.True(t, PodRequestsGpu(podWithGpu))
+	assert.True(t, PodRequestsGpu(podWithMig))
+}
+
+func TestGpuPartitionWeight(t *testing.T) {
+	testCases := map[apiv1.ResourceName]int64{
+		"nvidia.com/mig-1g.10gb": 1,
+		"nvidia.com/mig-3g.40gb": 3,
+		"nvidia.com/mig-7g.80gb": 7,
+		"nvidia.com/mig-weird":   1,
+		ResourceNvidiaSharedGPU:  1,
+		ResourceNvidiaGPU:        1,
+	}
+	for resourceName, want := range testCases {
+		assert.Equal(t, want, GpuPartitionWeight(resourceName), string(resourceName))
+	}
+}
+
+func TestNodeGpuPartitions(t *testing.T) {
+	node := test.BuildTestNode("node", 1000, 1000)
+	node.Status.Allocatable["nvidia.com/mig-3g.40gb"] = *resource.NewQuantity(2, resource.DecimalSI)
+	node.Status.Allocatable["nvidia.com/mig-1g.10gb"] = *resource.NewQuantity(1, resource.DecimalSI)
+	node.Status.Allocatable["nvidia.com/mig-2g.20gb"] = *resource.NewQuantity(0, resource.DecimalSI)
+	node.Status.Allocatable[ResourceNvidiaGPU] = *resource.NewQuantity(1, resource.DecimalSI)
+
+	assert.Equal(t, []apiv1.ResourceName{"nvidia.com/mig-1g.10gb", "nvidia.com/mig-3g.40gb"}, NodeGpuPartitions(node))
+	assert.True(t, NodeHasGpu("", node))
+	assert.Empty(t, NodeGpuPartitions(test.BuildTestNode("plain", 1000, 1000)))
 }
@@ -25,6 +25,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 	kube_record "k8s.io/client-go/tools/record"
+	klog "k8s.io/klog/v2"
 )
 
 const (
@@ -59,6 +60,14 @@ func CreateEventRecorder(kubeClient clientset.Interface, recordDuplicatedEvents
 	return eventBroadcaster.NewRecorder(scheme.Scheme, clientv1.EventSource{Component: "cluster-autoscaler"})
 }
 
+// CreateLoggingEventRecorder creates an event recorder which only logs events instead of sending
+// them to Kubernetes, e.g. in dry-run mode.
+func CreateLoggingEventRecorder() kube_record.EventRecorder {
+	eventBroadcaster := kube_record.NewBroadcaster()
+	eventBroadcaster.StartLogging(klog.V(4).Infof)
+	return eventBroadcaster.NewRecorder(scheme.Scheme, clientv1.EventSource{Component: "cluster-autoscaler"})
+}
+
 func getCorrelationOptions() kube_record.CorrelatorOptions {
 	return kube_record.CorrelatorOptions{
 		QPS:          defaultQPS,
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// FileExporter writes finished spans as JSON lines, for offline analysis.
+type FileExporter struct {
+	mutex   sync.Mutex
+	writer  io.WriteCloser
+	encoder *json.Encoder
+}
+
+// spanRecord is a single line written by FileExporter.
+type spanRecord struct {
+	TraceID      string                 `json:"traceId"`
+	SpanID       string                 `json:"spanId"`
+	ParentSpanID string                 `json:"parentSpanId,omitempty"`
+	Name         string                 `json:"name"`
+	StartTime    time.Time              `json:"startTime"`
+	EndTime      time.Time              `json:"endTime"`
+	DurationMs   float64                `json:"durationMs"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Status       string                 `json:"status,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+// NewFileExporter creates a FileExporter appending to the file at path.
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return newFileExporter(file), nil
+}
+
+func newFileExporter(writer io.WriteCloser) *FileExporter {
+	return &FileExporter{writer: writer, encoder: json.NewEncoder(writer)}
+}
+
+// ExportSpans writes spans to the file.
+func (e *FileExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for _, span := range spans {
+		record := spanRecord{
+			TraceID:    span.SpanContext().TraceID().String(),
+			SpanID:     span.SpanContext().SpanID().String(),
+			Name:       span.Name(),
+			StartTime:  span.StartTime(),
+			EndTime:    span.EndTime(),
+			DurationMs: float64(span.EndTime().Sub(span.StartTime())) / float64(time.Millisecond),
+		}
+		if span.Parent().IsValid() {
+			record.ParentSpanID = span.Parent().SpanID().String()
+		}
+		if attrs := span.Attributes(); len(attrs) > 0 {
+			record.Attributes = make(map[string]interface{}, len(attrs))
+			for _, attr := range attrs {
+				record.Attributes[string(attr.Key)] = attr.Value.AsInterface()
+			}
+		}
+		if status := span.Status(); status.Code != 0 {
+			record.Status = status.Code.String()
+			record.Error = status.Description
+		}
+		if err := e.encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown closes the file.
+func (e *FileExporter) Shutdown(context.Context) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.writer.Close()
+}
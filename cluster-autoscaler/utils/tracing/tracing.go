@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing provides OpenTelemetry tracing of the autoscaling loop.
+//
+// Spans are always started with an explicit parent context. Every loop
+// iteration is a separate trace whose root span context is available to the
+// code running within the iteration through AutoscalingContext.TraceContext.
+// Code which outlives the iteration or runs in separate goroutines captures
+// its parent context when it is scheduled. Objects whose methods don't accept
+// a context.Context, e.g. cloud provider node groups or expanders, may
+// implement Contextual so that their outgoing calls propagate the trace.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+const (
+	// ExporterNone disables tracing.
+	ExporterNone = "none"
+	// ExporterOTLP exports spans to an OTLP gRPC endpoint.
+	ExporterOTLP = "otlp"
+	// ExporterFile appends spans to a local file as JSON lines.
+	ExporterFile = "file"
+
+	instrumentationName = "k8s.io/autoscaler/cluster-autoscaler"
+	serviceName         = "cluster-autoscaler"
+)
+
+// Options configure tracing.
+type Options struct {
+	// Exporter is one of ExporterNone, ExporterOTLP or ExporterFile.
+	Exporter string
+	// OTLPEndpoint is the host:port of the OTLP gRPC collector. If empty,
+	// the standard OTEL_EXPORTER_OTLP_* environment variables are used.
+	OTLPEndpoint string
+	// OTLPInsecure disables TLS for the OTLP connection.
+	OTLPInsecure bool
+	// FilePath is the path of the file used by ExporterFile.
+	FilePath string
+	// SamplingRatio is the fraction of autoscaling loop iterations which are traced.
+	SamplingRatio float64
+}
+
+// Setup configures the global tracer provider according to options. The
+// returned function flushes and shuts down the exporter.
+func Setup(options Options) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch options.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporterOptions := []otlptracegrpc.Option{}
+		if options.OTLPEndpoint != "" {
+			exporterOptions = append(exporterOptions, otlptracegrpc.WithEndpoint(options.OTLPEndpoint))
+		}
+		if options.OTLPInsecure {
+			exporterOptions = append(exporterOptions, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(context.Background(), exporterOptions...)
+	case ExporterFile:
+		exporter, err = NewFileExporter(options.FilePath)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", options.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s tracing exporter: %v", options.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SamplingRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Span is an active span of the autoscaling loop trace.
+type Span struct {
+	trace.Span
+	ctx context.Context
+}
+
+// StartSpan starts a span as a child of the span carried by parent. If parent
+// carries no span, the span starts a new trace.
+func StartSpan(parent context.Context, name string, attrs ...attribute.KeyValue) *Span {
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, span := otel.Tracer(instrumentationName).Start(parent, name, trace.WithAttributes(attrs...))
+	return &Span{Span: span, ctx: ctx}
+}
+
+// StartRootSpan starts a new trace, e.g. for an autoscaling loop iteration.
+func StartRootSpan(name string, attrs ...attribute.KeyValue) *Span {
+	return StartSpan(context.Background(), name, attrs...)
+}
+
+// Context returns a context carrying the span, to be used as the parent of
+// its child spans and for outgoing calls so that the trace is propagated.
+func (s *Span) Context() context.Context {
+	return s.ctx
+}
+
+// EndWithError records err, if not nil, and ends the span.
+func (s *Span) EndWithError(err error) {
+	if err != nil {
+		s.RecordError(err)
+		s.SetStatus(codes.Error, err.Error())
+	}
+	s.End()
+}
+
+// Contextual is implemented by objects whose methods don't accept a context,
+// but can make outgoing calls with one, e.g. to propagate the trace.
+type Contextual[T any] interface {
+	// WithContext returns a copy of the object making outgoing calls with ctx.
+	WithContext(ctx context.Context) T
+}
+
+// Bind returns v making outgoing calls with ctx if it implements Contextual,
+// v itself otherwise.
+func Bind[T any](v T, ctx context.Context) T {
+	if c, ok := any(v).(Contextual[T]); ok {
+		return c.WithContext(ctx)
+	}
+	return v
+}
+
+// GRPCDialOption instruments a gRPC client connection, creating a span for
+// every RPC and propagating the trace to the server.
+func GRPCDialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler())
+}
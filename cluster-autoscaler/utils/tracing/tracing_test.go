@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func recordedParents(recorder *tracetest.SpanRecorder) map[string]string {
+	parents := map[string]string{}
+	names := map[trace.SpanID]string{}
+	for _, span := range recorder.Ended() {
+		names[span.SpanContext().SpanID()] = span.Name()
+	}
+	for _, span := range recorder.Ended() {
+		parents[span.Name()] = names[span.Parent().SpanID()]
+	}
+	return parents
+}
+
+func TestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	previous := StartRootSpan("previous")
+	root := StartRootSpan("RunOnce")
+	child := StartSpan(root.Context(), "child", attribute.Int("pods", 3))
+	assert.Equal(t, child.SpanContext(), trace.SpanContextFromContext(child.Context()))
+	grandchild := StartSpan(child.Context(), "grandchild")
+	// A span started before the grandchild ended still has the right parent.
+	async := StartSpan(previous.Context(), "async")
+	grandchild.EndWithError(fmt.Errorf("boom"))
+	child.End()
+	sibling := StartSpan(root.Context(), "sibling")
+	sibling.End()
+	root.End()
+	async.End()
+	previous.End()
+	orphan := StartSpan(nil, "orphan")
+	orphan.End()
+
+	assert.Equal(t, map[string]string{
+		"previous":   "",
+		"RunOnce":    "",
+		"child":      "RunOnce",
+		"grandchild": "child",
+		"async":      "previous",
+		"sibling":    "RunOnce",
+		"orphan":     "",
+	}, recordedParents(recorder))
+}
+
+func TestConcurrentSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	root := StartRootSpan("RunOnce")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			parent := StartSpan(root.Context(), fmt.Sprintf("parent-%d", i))
+			StartSpan(parent.Context(), fmt.Sprintf("child-%d", i)).End()
+			parent.End()
+		}(i)
+	}
+	wg.Wait()
+	root.End()
+
+	parents := recordedParents(recorder)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "RunOnce", parents[fmt.Sprintf("parent-%d", i)])
+		assert.Equal(t, fmt.Sprintf("parent-%d", i), parents[fmt.Sprintf("child-%d", i)])
+	}
+}
+
+type contextualClient struct {
+	ctx context.Context
+}
+
+func (c *contextualClient) WithContext(ctx context.Context) *contextualClient {
+	return &contextualClient{ctx: ctx}
+}
+
+func TestBind(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextualClient{}, "value")
+	client := &contextualClient{}
+	bound := Bind(client, ctx)
+	assert.Equal(t, ctx, bound.ctx)
+	assert.Nil(t, client.ctx)
+
+	var plain io.Writer = &bytes.Buffer{}
+	assert.Same(t, plain, Bind(plain, ctx))
+}
+
+func TestFileExporter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	exporter := newFileExporter(nopCloser{buf})
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := provider.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child", trace.WithAttributes(attribute.String("nodeGroup", "ng1")))
+	child.End()
+	parent.End()
+	assert.NoError(t, provider.Shutdown(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		var childRecord, parentRecord spanRecord
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &childRecord))
+		assert.NoError(t, json.Unmarshal([]byte(lines[1]), &parentRecord))
+		assert.Equal(t, "child", childRecord.Name)
+		assert.Equal(t, parentRecord.SpanID, childRecord.ParentSpanID)
+		assert.Equal(t, parentRecord.TraceID, childRecord.TraceID)
+		assert.Equal(t, map[string]interface{}{"nodeGroup": "ng1"}, childRecord.Attributes)
+		assert.Empty(t, parentRecord.ParentSpanID)
+	}
+}
+
+func TestSetupUnknownExporter(t *testing.T) {
+	_, err := Setup(Options{Exporter: "carrier-pigeon"})
+	assert.Error(t, err)
+}